@@ -43,6 +43,11 @@ func main() {
 		}
 	}()
 
+	// Make auto-tips as matches lock, until shutdown
+	autoTipCtx, stopAutoTips := context.WithCancel(context.Background())
+	defer stopAutoTips()
+	srv.StartAutoTips(autoTipCtx)
+
 	// Create HTTP server
 	httpServer := &http.Server{
 		Addr:         ":8080",
@@ -65,6 +70,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	log.Println("Shutting down server...")
+	stopAutoTips()
 
 	// Create shutdown context with 10 second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
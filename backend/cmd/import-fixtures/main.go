@@ -11,6 +11,7 @@ import (
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/parkertr2/footy-tipping/internal/domain"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository/postgres"
@@ -24,12 +25,42 @@ type MatchFixture struct {
 	AwayTeam    string    `json:"awayTeam"`
 	Date        time.Time `json:"date"`
 	Competition string    `json:"competition"`
+	TimeZone    string    `json:"timeZone,omitempty"` // venue, if not the home team's ground
+}
+
+// CompetitionFixture represents a competition from the competitions JSON file
+type CompetitionFixture struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Sport       string   `json:"sport"`
+	Aliases     []string `json:"aliases"`
+	ResultBasis string   `json:"resultBasis,omitempty"`
+	TippingMode string   `json:"tippingMode,omitempty"`
+}
+
+// TeamFixture represents a team from the teams JSON file
+type TeamFixture struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	ShortCode string   `json:"shortCode"`
+	Aliases   []string `json:"aliases"`
+	TimeZone  string   `json:"timeZone,omitempty"`
+}
+
+// resolvedFixture is a fixture whose competition and teams have been looked up
+type resolvedFixture struct {
+	Competition *domain.Competition
+	HomeTeam    *domain.Team
+	AwayTeam    *domain.Team
+	TimeZone    string
 }
 
 func main() {
 	var (
 		dbURL        = flag.String("db", "", "Database connection URL")
 		fixturesFile = flag.String("fixtures", "fixtures/matches.json", "Path to fixtures JSON file")
+		compsFile    = flag.String("competitions", "fixtures/competitions.json", "Path to competitions JSON file")
+		teamsFile    = flag.String("teams", "fixtures/teams.json", "Path to teams JSON file")
 		dryRun       = flag.Bool("dry-run", false, "Print what would be imported without actually importing")
 	)
 	flag.Parse()
@@ -44,13 +75,39 @@ func main() {
 		log.Fatalf("Failed to read fixtures: %v", err)
 	}
 
+	competitionFixtures, err := readCompetitions(*compsFile)
+	if err != nil {
+		log.Fatalf("Failed to read competitions: %v", err)
+	}
+
+	teamFixtures, err := readTeams(*teamsFile)
+	if err != nil {
+		log.Fatalf("Failed to read teams: %v", err)
+	}
+
 	fmt.Printf("Found %d fixtures to import\n", len(fixtures))
 
 	if *dryRun {
+		// Resolve against the competitions and teams files alone; anything
+		// created through the API is only known once connected to the database
+		competitions := make([]*domain.Competition, 0, len(competitionFixtures))
+		for _, comp := range competitionFixtures {
+			competitions = append(competitions, comp.toDomain())
+		}
+		teams := make([]*domain.Team, 0, len(teamFixtures))
+		for _, team := range teamFixtures {
+			teams = append(teams, team.toDomain())
+		}
+
 		fmt.Println("\nDry run mode - showing what would be imported:")
 		for _, fixture := range fixtures {
+			resolved, err := resolveFixture(fixture, competitions, teams)
+			if err != nil {
+				fmt.Printf("- REJECTED %s vs %s: %v\n", fixture.HomeTeam, fixture.AwayTeam, err)
+				continue
+			}
 			fmt.Printf("- %s vs %s (%s) on %s\n",
-				fixture.HomeTeam, fixture.AwayTeam, fixture.Competition, fixture.Date.Format("2006-01-02 15:04"))
+				resolved.HomeTeam.Name, resolved.AwayTeam.Name, resolved.Competition.Name, fixture.Date.Format("2006-01-02 15:04"))
 		}
 		return
 	}
@@ -81,12 +138,33 @@ func main() {
 	matchRepo := postgres.NewMatchRepository(db)
 	eventHandler := eventhandlers.NewMatchEventHandler(matchRepo)
 
-	// Import fixtures
 	ctx := context.Background()
+
+	// Make sure every competition in the competitions file exists
+	catalog, err := importCompetitions(ctx, eventStore, competitionFixtures)
+	if err != nil {
+		log.Fatalf("Failed to import competitions: %v", err)
+	}
+
+	// Make sure every team in the teams file exists
+	registry, err := importTeams(ctx, eventStore, teamFixtures)
+	if err != nil {
+		log.Fatalf("Failed to import teams: %v", err)
+	}
+
+	// Import fixtures
 	imported := 0
 	skipped := 0
+	rejected := 0
 
 	for _, fixture := range fixtures {
+		resolved, err := resolveFixture(fixture, catalog.Competitions, registry.Teams)
+		if err != nil {
+			log.Printf("Rejecting %s vs %s: %v", fixture.HomeTeam, fixture.AwayTeam, err)
+			rejected++
+			continue
+		}
+
 		// Check if match already exists
 		existingEvents, err := eventStore.GetEvents(ctx, fixture.ID)
 		if err != nil {
@@ -102,11 +180,15 @@ func main() {
 
 		// Create MatchCreated event
 		matchCreated := events.MatchCreated{
-			ID:          fixture.ID,
-			HomeTeam:    fixture.HomeTeam,
-			AwayTeam:    fixture.AwayTeam,
-			Date:        fixture.Date,
-			Competition: fixture.Competition,
+			ID:            fixture.ID,
+			HomeTeam:      resolved.HomeTeam.Name,
+			AwayTeam:      resolved.AwayTeam.Name,
+			HomeTeamID:    resolved.HomeTeam.ID,
+			AwayTeamID:    resolved.AwayTeam.ID,
+			Date:          fixture.Date,
+			Competition:   resolved.Competition.Name,
+			CompetitionID: resolved.Competition.ID,
+			TimeZone:      resolved.TimeZone,
 		}
 
 		event := events.NewEvent("MatchCreated", matchCreated)
@@ -124,11 +206,122 @@ func main() {
 		}
 
 		fmt.Printf("Imported: %s vs %s (%s) on %s\n",
-			fixture.HomeTeam, fixture.AwayTeam, fixture.Competition, fixture.Date.Format("2006-01-02 15:04"))
+			resolved.HomeTeam.Name, resolved.AwayTeam.Name, resolved.Competition.Name, fixture.Date.Format("2006-01-02 15:04"))
 		imported++
 	}
 
-	fmt.Printf("\nImport complete: %d imported, %d skipped\n", imported, skipped)
+	fmt.Printf("\nImport complete: %d imported, %d skipped, %d rejected\n", imported, skipped, rejected)
+}
+
+// importCompetitions creates any competitions from the file that don't exist
+// yet and returns the resulting catalog
+func importCompetitions(ctx context.Context, eventStore eventstore.EventStore, fixtures []CompetitionFixture) (*eventhandlers.CompetitionCatalog, error) {
+	catalog, err := eventhandlers.LoadCompetitionCatalog(ctx, eventStore)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fixture := range fixtures {
+		if catalog.Competition(fixture.ID) != nil || catalog.Competition(fixture.Name) != nil {
+			continue
+		}
+
+		competition := fixture.toDomain()
+		if err := competition.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid competition %s: %w", fixture.Name, err)
+		}
+
+		event := events.NewEvent("CompetitionCreated", events.CompetitionCreated{
+			ID:          competition.ID,
+			Name:        competition.Name,
+			Sport:       string(competition.Sport),
+			Aliases:     competition.Aliases,
+			ResultBasis: string(competition.ResultBasis),
+			TippingMode: string(competition.TippingMode),
+		})
+		if err := eventStore.SaveEvent(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to create competition %s: %w", fixture.Name, err)
+		}
+
+		catalog.Competitions = append(catalog.Competitions, competition)
+		fmt.Printf("Created competition: %s (%s)\n", competition.Name, competition.ID)
+	}
+
+	return catalog, nil
+}
+
+// importTeams creates any teams from the file that don't exist yet and
+// returns the resulting registry
+func importTeams(ctx context.Context, eventStore eventstore.EventStore, fixtures []TeamFixture) (*eventhandlers.TeamRegistry, error) {
+	registry, err := eventhandlers.LoadTeamRegistry(ctx, eventStore)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fixture := range fixtures {
+		if registry.Team(fixture.ID) != nil || registry.Team(fixture.Name) != nil {
+			continue
+		}
+
+		team := fixture.toDomain()
+		if err := team.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid team %s: %w", fixture.Name, err)
+		}
+
+		event := events.NewEvent("TeamCreated", events.TeamCreated{
+			ID:        team.ID,
+			Name:      team.Name,
+			ShortCode: team.ShortCode,
+			Aliases:   team.Aliases,
+			TimeZone:  team.TimeZone,
+		})
+		if err := eventStore.SaveEvent(ctx, event); err != nil {
+			return nil, fmt.Errorf("failed to create team %s: %w", fixture.Name, err)
+		}
+
+		registry.Teams = append(registry.Teams, team)
+		fmt.Printf("Created team: %s (%s)\n", team.Name, team.ID)
+	}
+
+	return registry, nil
+}
+
+// resolveFixture looks up the fixture's competition and teams, failing if any
+// of them is not recognised
+func resolveFixture(fixture MatchFixture, competitions []*domain.Competition, teams []*domain.Team) (*resolvedFixture, error) {
+	competition := domain.ResolveCompetition(competitions, fixture.Competition)
+	if competition == nil {
+		return nil, fmt.Errorf("unknown competition %q", fixture.Competition)
+	}
+
+	homeTeam := domain.ResolveTeam(teams, fixture.HomeTeam)
+	if homeTeam == nil {
+		return nil, fmt.Errorf("unknown team %q", fixture.HomeTeam)
+	}
+
+	awayTeam := domain.ResolveTeam(teams, fixture.AwayTeam)
+	if awayTeam == nil {
+		return nil, fmt.Errorf("unknown team %q", fixture.AwayTeam)
+	}
+
+	if homeTeam.ID == awayTeam.ID {
+		return nil, domain.ErrTeamPlaysItself
+	}
+
+	timeZone := fixture.TimeZone
+	if timeZone == "" {
+		timeZone = homeTeam.TimeZone
+	}
+	if _, err := domain.LoadTimeZone(timeZone); err != nil {
+		return nil, err
+	}
+
+	return &resolvedFixture{
+		Competition: competition,
+		HomeTeam:    homeTeam,
+		AwayTeam:    awayTeam,
+		TimeZone:    timeZone,
+	}, nil
 }
 
 // readFixtures reads and parses the fixtures JSON file
@@ -145,3 +338,52 @@ func readFixtures(filename string) ([]MatchFixture, error) {
 
 	return fixtures, nil
 }
+
+// readCompetitions reads and parses the competitions JSON file
+func readCompetitions(filename string) ([]CompetitionFixture, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var competitions []CompetitionFixture
+	if err := json.Unmarshal(data, &competitions); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return competitions, nil
+}
+
+// toDomain converts the fixture into a domain competition
+func (c CompetitionFixture) toDomain() *domain.Competition {
+	competition := domain.NewCompetition(c.ID, c.Name, domain.Sport(c.Sport), c.Aliases)
+	if c.ResultBasis != "" {
+		competition.ResultBasis = domain.ResultBasis(c.ResultBasis)
+	}
+	if c.TippingMode != "" {
+		competition.TippingMode = domain.TippingMode(c.TippingMode)
+	}
+	return competition
+}
+
+// readTeams reads and parses the teams JSON file
+func readTeams(filename string) ([]TeamFixture, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+	}
+
+	var teams []TeamFixture
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return teams, nil
+}
+
+// toDomain converts the fixture into a domain team
+func (t TeamFixture) toDomain() *domain.Team {
+	team := domain.NewTeam(t.ID, t.Name, t.ShortCode, t.Aliases)
+	team.TimeZone = t.TimeZone
+	return team
+}
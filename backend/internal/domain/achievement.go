@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// AchievementMetric is the statistic an achievement is measured on
+type AchievementMetric string
+
+const (
+	// MetricTipsMade counts the match tips a user has made
+	MetricTipsMade AchievementMetric = "TIPS_MADE"
+	// MetricBestStreak is a user's longest run of correct tips
+	MetricBestStreak AchievementMetric = "BEST_STREAK"
+	// MetricExactScores counts a user's exact-score tips
+	MetricExactScores AchievementMetric = "EXACT_SCORES"
+	// MetricPerfectRounds counts the rounds in which every one of a user's
+	// tips was correct
+	MetricPerfectRounds AchievementMetric = "PERFECT_ROUNDS"
+)
+
+// Achievement is an entry in the badge catalogue. Its badge is unlocked once
+// a user's metric reaches the threshold.
+type Achievement struct {
+	BadgeID     string            `json:"badgeId"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Metric      AchievementMetric `json:"metric"`
+	Threshold   int               `json:"threshold"`
+}
+
+// achievementCatalogue lists every badge that can be unlocked, in the order
+// they are shown
+var achievementCatalogue = []Achievement{
+	{BadgeID: "FIRST_TIP", Name: "Off the Mark", Description: "Made your first tip", Metric: MetricTipsMade, Threshold: 1},
+	{BadgeID: "TIPS_50", Name: "Regular", Description: "Made 50 tips", Metric: MetricTipsMade, Threshold: 50},
+	{BadgeID: "TIPS_250", Name: "Season Ticket", Description: "Made 250 tips", Metric: MetricTipsMade, Threshold: 250},
+	{BadgeID: "STREAK_3", Name: "Hat-Trick", Description: "Tipped 3 in a row correctly", Metric: MetricBestStreak, Threshold: 3},
+	{BadgeID: "STREAK_5", Name: "On Fire", Description: "Tipped 5 in a row correctly", Metric: MetricBestStreak, Threshold: 5},
+	{BadgeID: "STREAK_10", Name: "Unstoppable", Description: "Tipped 10 in a row correctly", Metric: MetricBestStreak, Threshold: 10},
+	{BadgeID: "EXACT_1", Name: "Bullseye", Description: "Tipped an exact score", Metric: MetricExactScores, Threshold: 1},
+	{BadgeID: "EXACT_10", Name: "Sharpshooter", Description: "Tipped 10 exact scores", Metric: MetricExactScores, Threshold: 10},
+	{BadgeID: "EXACT_25", Name: "Oracle", Description: "Tipped 25 exact scores", Metric: MetricExactScores, Threshold: 25},
+	{BadgeID: "PERFECT_ROUND", Name: "Perfect Round", Description: "Tipped every match of a round correctly", Metric: MetricPerfectRounds, Threshold: 1},
+	{BadgeID: "PERFECT_ROUNDS_5", Name: "Flawless", Description: "Tipped every match of 5 rounds correctly", Metric: MetricPerfectRounds, Threshold: 5},
+}
+
+// AchievementCatalogue returns every badge that can be unlocked
+func AchievementCatalogue() []Achievement {
+	return append([]Achievement(nil), achievementCatalogue...)
+}
+
+// FindAchievement returns the catalogue entry for a badge, or nil if there is
+// none
+func FindAchievement(badgeID string) *Achievement {
+	for i := range achievementCatalogue {
+		if achievementCatalogue[i].BadgeID == badgeID {
+			achievement := achievementCatalogue[i]
+			return &achievement
+		}
+	}
+	return nil
+}
+
+// AchievementStats are the statistics a user's badges are unlocked by. A tip
+// is correct if it scored points; voided tips neither extend nor break a
+// streak.
+type AchievementStats struct {
+	UserID        string `json:"userId"`
+	TipsMade      int    `json:"tipsMade"`
+	CorrectTips   int    `json:"correctTips"`
+	CurrentStreak int    `json:"currentStreak"`
+	BestStreak    int    `json:"bestStreak"`
+	ExactScores   int    `json:"exactScores"`
+	PerfectRounds int    `json:"perfectRounds"`
+}
+
+// Value returns the statistic an achievement metric measures
+func (s *AchievementStats) Value(metric AchievementMetric) int {
+	switch metric {
+	case MetricTipsMade:
+		return s.TipsMade
+	case MetricBestStreak:
+		return s.BestStreak
+	case MetricExactScores:
+		return s.ExactScores
+	case MetricPerfectRounds:
+		return s.PerfectRounds
+	}
+	return 0
+}
+
+// Unlocked returns the achievements in the catalogue the statistics reach
+func (s *AchievementStats) Unlocked() []Achievement {
+	unlocked := make([]Achievement, 0)
+	for _, achievement := range achievementCatalogue {
+		if s.Value(achievement.Metric) >= achievement.Threshold {
+			unlocked = append(unlocked, achievement)
+		}
+	}
+	return unlocked
+}
+
+// BuildAchievementStats works out each user's statistics from the tips they
+// made and the points awarded for them, in the order they were awarded. The
+// latest award for a tip replaces an earlier one, so a score correction can
+// extend or break a streak. A round is perfect for a user when they tipped
+// every match in it correctly, leaving out abandoned matches. Only tips still
+// standing count as made, and auto-tips are not the user's own and count
+// towards none of the statistics. Users are listed by ID.
+func BuildAchievementStats(tips []*Prediction, awards []PointsAward, matches []*Match) []*AchievementStats {
+	byUser := make(map[string]*AchievementStats)
+	userStats := func(userID string) *AchievementStats {
+		stats, ok := byUser[userID]
+		if !ok {
+			stats = &AchievementStats{UserID: userID}
+			byUser[userID] = stats
+		}
+		return stats
+	}
+
+	for _, tip := range tips {
+		if !tip.Auto && !tip.IsWithdrawn() {
+			userStats(tip.UserID).TipsMade++
+		}
+	}
+
+	abandoned := make(map[string]bool)
+	correct := make(map[string]map[string]bool)
+	for _, award := range LatestAwards(awards) {
+		if award.Source != PointsSourceMatch {
+			continue
+		}
+		stats := userStats(award.UserID)
+		if award.Void {
+			abandoned[award.SourceID] = true
+			continue
+		}
+		if award.Auto {
+			continue
+		}
+		if award.Points <= 0 {
+			stats.CurrentStreak = 0
+			continue
+		}
+
+		stats.CorrectTips++
+		stats.CurrentStreak++
+		if stats.CurrentStreak > stats.BestStreak {
+			stats.BestStreak = stats.CurrentStreak
+		}
+		if award.ExactScore {
+			stats.ExactScores++
+		}
+		if correct[award.UserID] == nil {
+			correct[award.UserID] = make(map[string]bool)
+		}
+		correct[award.UserID][award.SourceID] = true
+	}
+
+	rounds := make(map[string][]string)
+	for _, match := range matches {
+		if !abandoned[match.ID] {
+			rounds[match.RoundKey()] = append(rounds[match.RoundKey()], match.ID)
+		}
+	}
+	for userID, correctMatches := range correct {
+		for _, roundMatches := range rounds {
+			if isPerfectRound(roundMatches, correctMatches) {
+				byUser[userID].PerfectRounds++
+			}
+		}
+	}
+
+	users := make([]*AchievementStats, 0, len(byUser))
+	for _, stats := range byUser {
+		users = append(users, stats)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserID < users[j].UserID
+	})
+	return users
+}
+
+// isPerfectRound returns true if every match in the round is among the
+// matches tipped correctly
+func isPerfectRound(roundMatches []string, correctMatches map[string]bool) bool {
+	if len(roundMatches) == 0 {
+		return false
+	}
+	for _, matchID := range roundMatches {
+		if !correctMatches[matchID] {
+			return false
+		}
+	}
+	return true
+}
+
+// Badge is an achievement a user has unlocked. Badges are kept once awarded,
+// even if a later score correction takes the user back below the threshold.
+type Badge struct {
+	UserID      string    `json:"userId"`
+	BadgeID     string    `json:"badgeId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	AwardedAt   time.Time `json:"awardedAt"`
+}
+
+// UserAchievements is a user's statistics with the badges they have unlocked
+type UserAchievements struct {
+	Stats  *AchievementStats `json:"stats"`
+	Badges []*Badge          `json:"badges"`
+}
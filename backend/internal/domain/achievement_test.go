@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAchievementCatalogue(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, achievement := range AchievementCatalogue() {
+		if seen[achievement.BadgeID] {
+			t.Errorf("badge %s listed more than once", achievement.BadgeID)
+		}
+		seen[achievement.BadgeID] = true
+		if achievement.Threshold < 1 {
+			t.Errorf("badge %s has threshold %d", achievement.BadgeID, achievement.Threshold)
+		}
+		if found := FindAchievement(achievement.BadgeID); found == nil || *found != achievement {
+			t.Errorf("expected to find badge %s, got %v", achievement.BadgeID, found)
+		}
+	}
+	if FindAchievement("UNKNOWN") != nil {
+		t.Errorf("expected no achievement for an unknown badge")
+	}
+}
+
+func TestBuildAchievementStats(t *testing.T) {
+	kickoff := time.Date(2025, 8, 16, 15, 0, 0, 0, time.UTC)
+	match := func(id, roundID string) *Match {
+		m := NewMatch(id, "Home "+id, "Away "+id, kickoff, "EPL")
+		m.RoundID = roundID
+		return m
+	}
+	matches := []*Match{match("m1", "r1"), match("m2", "r1"), match("m3", "r1"), match("m4", "r2"), match("m5", "r2")}
+	award := func(key, userID, matchID string, points int) PointsAward {
+		return PointsAward{UserID: userID, Source: PointsSourceMatch, SourceID: matchID, Key: key, Points: points}
+	}
+	exact := func(a PointsAward) PointsAward {
+		a.ExactScore = true
+		return a
+	}
+	void := func(a PointsAward) PointsAward {
+		a.Void = true
+		return a
+	}
+
+	tips := []*Prediction{
+		{ID: "a1", UserID: "alice"}, {ID: "a2", UserID: "alice"}, {ID: "a3", UserID: "alice"},
+		{ID: "a4", UserID: "alice"}, {ID: "a5", UserID: "alice"}, {ID: "b1", UserID: "bob"},
+	}
+	// A tip made and withdrawn does not count towards tips made
+	withdrawn := NewPrediction("b2", "bob", "m2", 1, 1)
+	_ = withdrawn.Withdraw(kickoff.Add(-time.Hour))
+	tips = append(tips, withdrawn)
+	awards := []PointsAward{
+		exact(award("a1", "alice", "m1", 3)),
+		award("a2", "alice", "m2", 1),
+		void(award("a3", "alice", "m3", 0)),
+		award("b1", "bob", "m1", 0),
+		award("a4", "alice", "m4", 0),
+		award("a5", "alice", "m5", 1),
+		// A correction turns alice's miss on m4 into a correct tip
+		award("a4", "alice", "m4", 1),
+		{UserID: "bob", Source: PointsSourceOutright, SourceID: "market1", Key: "tip1", Points: 5},
+	}
+
+	users := BuildAchievementStats(tips, awards, matches)
+
+	if len(users) != 2 || users[0].UserID != "alice" || users[1].UserID != "bob" {
+		t.Fatalf("expected alice then bob, got %+v", users)
+	}
+	// m3 was abandoned, so round 1 is perfect on m1 and m2 alone, and the
+	// corrected m4 makes round 2 perfect and the streak unbroken
+	want := AchievementStats{UserID: "alice", TipsMade: 5, CorrectTips: 4, CurrentStreak: 4, BestStreak: 4, ExactScores: 1, PerfectRounds: 2}
+	if *users[0] != want {
+		t.Errorf("expected %+v, got %+v", want, *users[0])
+	}
+	want = AchievementStats{UserID: "bob", TipsMade: 1}
+	if *users[1] != want {
+		t.Errorf("expected %+v, got %+v", want, *users[1])
+	}
+}
+
+func TestStreakBrokenByMiss(t *testing.T) {
+	awards := make([]PointsAward, 0)
+	for i, points := range []int{1, 1, 1, 0, 1} {
+		key := string(rune('a' + i))
+		awards = append(awards, PointsAward{UserID: "alice", Source: PointsSourceMatch, SourceID: "m" + key, Key: key, Points: points})
+	}
+
+	stats := BuildAchievementStats(nil, awards, nil)[0]
+
+	if stats.CurrentStreak != 1 || stats.BestStreak != 3 {
+		t.Errorf("expected current streak 1 and best 3, got %d and %d", stats.CurrentStreak, stats.BestStreak)
+	}
+	if stats.PerfectRounds != 0 {
+		t.Errorf("expected no perfect rounds without matches, got %d", stats.PerfectRounds)
+	}
+}
+
+func TestAchievementStatsUnlocked(t *testing.T) {
+	stats := &AchievementStats{UserID: "alice", TipsMade: 50, BestStreak: 4, ExactScores: 1}
+
+	unlocked := make(map[string]bool)
+	for _, achievement := range stats.Unlocked() {
+		unlocked[achievement.BadgeID] = true
+	}
+
+	for _, badgeID := range []string{"FIRST_TIP", "TIPS_50", "STREAK_3", "EXACT_1"} {
+		if !unlocked[badgeID] {
+			t.Errorf("expected %s to be unlocked", badgeID)
+		}
+	}
+	for _, badgeID := range []string{"TIPS_250", "STREAK_5", "EXACT_10", "PERFECT_ROUND"} {
+		if unlocked[badgeID] {
+			t.Errorf("expected %s to be locked", badgeID)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"time"
+)
+
+// ErrInvalidAutoTipPolicy is returned for an auto-tip policy that is not one
+// of the supported policies
+var ErrInvalidAutoTipPolicy = newFieldError("autoTip", "INVALID_AUTO_TIP_POLICY", "auto-tip policy must be HOME_TEAM, FAVOURITE_ELO, FAVOURITE_ODDS or PREVIOUS_TIP")
+
+// AutoTipPolicy decides the tip made for a league member who has not tipped a
+// match by the time it locks
+type AutoTipPolicy string
+
+const (
+	// AutoTipHomeTeam tips the home team to win
+	AutoTipHomeTeam AutoTipPolicy = "HOME_TEAM"
+	// AutoTipFavouriteElo tips the team with the better Elo rating, counting
+	// home advantage
+	AutoTipFavouriteElo AutoTipPolicy = "FAVOURITE_ELO"
+	// AutoTipFavouriteOdds tips the team with the shorter odds, or the home
+	// team if the match has no odds
+	AutoTipFavouriteOdds AutoTipPolicy = "FAVOURITE_ODDS"
+	// AutoTipPreviousTip repeats the player's own tip on the last meeting of
+	// the same two teams, or tips the home team if they never tipped one
+	AutoTipPreviousTip AutoTipPolicy = "PREVIOUS_TIP"
+)
+
+// IsValid returns true if the policy is one of the supported policies
+func (p AutoTipPolicy) IsValid() bool {
+	switch p {
+	case AutoTipHomeTeam, AutoTipFavouriteElo, AutoTipFavouriteOdds, AutoTipPreviousTip:
+		return true
+	}
+	return false
+}
+
+// AutoTipInput is what the auto-tip policies draw on for a match that has
+// just locked
+type AutoTipInput struct {
+	Match       *Match
+	Mode        TippingMode   // the tipping mode of the match's competition
+	Leagues     []*League     // in the order they were created
+	Users       []*User       // registered users
+	Predictions []*Prediction // every tip, including withdrawn ones
+	Matches     []*Match      // every match, for Elo ratings and earlier meetings
+	At          time.Time     // when the tips are made
+}
+
+// PlanAutoTips returns the tips to make for the members of leagues with an
+// auto-tip policy who have no tip of their own on the match. Each tip is made
+// for one league, by its policy, and counts only there, so a member of several
+// such leagues gets a tip in each. Members already auto-tipped in a league are
+// skipped, as are unregistered and deactivated users, and auto-tips are never
+// jokers.
+func PlanAutoTips(input AutoTipInput, newID func() string) []*Prediction {
+	tipped := make(map[string]bool)
+	autoTipped := make(map[string]bool)
+	for _, prediction := range input.Predictions {
+		if prediction.MatchID != input.Match.ID || prediction.IsWithdrawn() {
+			continue
+		}
+		if prediction.Auto {
+			autoTipped[prediction.LeagueID+"/"+prediction.UserID] = true
+		} else {
+			tipped[prediction.UserID] = true
+		}
+	}
+	active := make(map[string]bool)
+	for _, user := range input.Users {
+		active[user.ID] = user.IsActive()
+	}
+
+	var ratings EloRatings
+	tips := make([]*Prediction, 0)
+	for _, league := range input.Leagues {
+		policy := league.Rules.AutoTip
+		if policy == "" || !league.Covers(input.Match) {
+			continue
+		}
+
+		for _, userID := range league.MemberList() {
+			if tipped[userID] || autoTipped[league.ID+"/"+userID] || !active[userID] {
+				continue
+			}
+
+			var tip *Prediction
+			switch policy {
+			case AutoTipPreviousTip:
+				if previous, reversed := previousTip(input, userID); previous != nil {
+					tip = repeatTip(newID(), userID, input.Match.ID, input.Mode, previous, reversed)
+				}
+			case AutoTipFavouriteElo:
+				if ratings == nil {
+					ratings = BuildEloRatings(input.Matches, input.Match.Date)
+				}
+				tip = sideTip(newID(), userID, input.Match.ID, input.Mode, ratings.Favourite(input.Match))
+			case AutoTipFavouriteOdds:
+				if input.Match.Odds != nil {
+					tip = sideTip(newID(), userID, input.Match.ID, input.Mode, input.Match.Odds.Favourite())
+				}
+			}
+			if tip == nil {
+				tip = sideTip(newID(), userID, input.Match.ID, input.Mode, MatchSideHome)
+			}
+
+			tip.Auto = true
+			tip.LeagueID = league.ID
+			tip.CreatedAt = input.At
+			tip.UpdatedAt = input.At
+			tips = append(tips, tip)
+			autoTipped[league.ID+"/"+userID] = true
+		}
+	}
+	return tips
+}
+
+// previousTip returns the user's own current tip on the latest earlier
+// meeting of the match's two teams, and whether the teams were the other way
+// around then. It returns nil if the user never tipped one.
+func previousTip(input AutoTipInput, userID string) (*Prediction, bool) {
+	home, away := input.Match.teamKey(MatchSideHome), input.Match.teamKey(MatchSideAway)
+	meetings := make(map[string]*Match)
+	for _, match := range input.Matches {
+		if match.ID == input.Match.ID || !match.Date.Before(input.Match.Date) {
+			continue
+		}
+		matchHome, matchAway := match.teamKey(MatchSideHome), match.teamKey(MatchSideAway)
+		if (matchHome == home && matchAway == away) || (matchHome == away && matchAway == home) {
+			meetings[match.ID] = match
+		}
+	}
+
+	var previous *Prediction
+	var previousMatch *Match
+	for _, prediction := range input.Predictions {
+		match, ok := meetings[prediction.MatchID]
+		if !ok || prediction.UserID != userID || prediction.Auto || prediction.IsWithdrawn() {
+			continue
+		}
+		if previousMatch == nil || match.Date.After(previousMatch.Date) {
+			previous, previousMatch = prediction, match
+		}
+	}
+	if previous == nil {
+		return nil, false
+	}
+	return previous, previousMatch.teamKey(MatchSideHome) != home
+}
+
+// sideTip tips a side to win: by 1-0 in exact-score tipping, or by a margin
+// of 1 in winner tipping
+func sideTip(id, userID, matchID string, mode TippingMode, side MatchSide) *Prediction {
+	margin := 1
+	if mode == TippingModeWinnerMargin {
+		return NewWinnerPrediction(id, userID, matchID, side, &margin)
+	}
+	if side == MatchSideAway {
+		return NewPrediction(id, userID, matchID, 0, margin)
+	}
+	return NewPrediction(id, userID, matchID, margin, 0)
+}
+
+// repeatTip copies an earlier tip, swapping sides if the teams were the other
+// way around. A tip of the other kind than the tipping mode takes is
+// converted: a score tip picks the side it had winning, by its goal
+// difference, and a winner tip becomes a score of its margin to nil.
+func repeatTip(id, userID, matchID string, mode TippingMode, previous *Prediction, reversed bool) *Prediction {
+	homeGoals, awayGoals, winner := previous.HomeGoals, previous.AwayGoals, previous.Winner
+	if reversed {
+		homeGoals, awayGoals = awayGoals, homeGoals
+		winner = winner.Opposite()
+	}
+
+	if !previous.IsWinnerTip() {
+		if mode != TippingModeWinnerMargin {
+			return NewPrediction(id, userID, matchID, homeGoals, awayGoals)
+		}
+		side, margin := MatchSideHome, homeGoals-awayGoals
+		if margin < 0 {
+			side, margin = MatchSideAway, -margin
+		}
+		if margin == 0 {
+			margin = 1
+		}
+		return NewWinnerPrediction(id, userID, matchID, side, &margin)
+	}
+
+	margin := 1
+	if previous.Margin != nil {
+		margin = *previous.Margin
+	}
+	if mode == TippingModeWinnerMargin {
+		return NewWinnerPrediction(id, userID, matchID, winner, &margin)
+	}
+	if winner == MatchSideAway {
+		return NewPrediction(id, userID, matchID, 0, margin)
+	}
+	return NewPrediction(id, userID, matchID, margin, 0)
+}
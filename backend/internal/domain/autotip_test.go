@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// autoTipFixture returns a league with the given policy whose members are
+// user1, user2 and user3, all registered, and a match between team_a and
+// team_b that has just locked
+func autoTipFixture(policy AutoTipPolicy) AutoTipInput {
+	kickoff := time.Date(2026, 4, 4, 15, 0, 0, 0, time.UTC)
+	league := NewLeague("league1", "Office", "user1", "ABCD2345", kickoff.Add(-30*24*time.Hour))
+	league.Rules.AutoTip = policy
+	users := []*User{NewUser("user1", "one", "one@example.com")}
+	for _, userID := range []string{"user2", "user3"} {
+		_ = league.Join(userID, kickoff.Add(-24*time.Hour))
+		users = append(users, NewUser(userID, userID, userID+"@example.com"))
+	}
+
+	match := NewMatch("m9", "Team A", "Team B", kickoff, "EPL")
+	match.HomeTeamID, match.AwayTeamID = "team_a", "team_b"
+	return AutoTipInput{
+		Match:   match,
+		Mode:    TippingModeExactScore,
+		Leagues: []*League{league},
+		Users:   users,
+		Matches: []*Match{match},
+		At:      kickoff,
+	}
+}
+
+// sequentialIDs returns an ID generator counting up from auto1
+func sequentialIDs() func() string {
+	next := 0
+	return func() string {
+		next++
+		return fmt.Sprintf("auto%d", next)
+	}
+}
+
+// tipsByUser indexes planned tips by user ID
+func tipsByUser(tips []*Prediction) map[string]*Prediction {
+	byUser := make(map[string]*Prediction)
+	for _, tip := range tips {
+		byUser[tip.UserID] = tip
+	}
+	return byUser
+}
+
+func TestAutoTipPolicyIsValid(t *testing.T) {
+	for _, policy := range []AutoTipPolicy{AutoTipHomeTeam, AutoTipFavouriteElo, AutoTipFavouriteOdds, AutoTipPreviousTip} {
+		if !policy.IsValid() {
+			t.Errorf("expected %s to be valid", policy)
+		}
+	}
+	if AutoTipPolicy("RANDOM").IsValid() {
+		t.Errorf("expected RANDOM to be invalid")
+	}
+
+	rules := LeagueRules{AutoTip: "RANDOM"}
+	if err := rules.Validate(); err != ErrInvalidAutoTipPolicy {
+		t.Errorf("expected ErrInvalidAutoTipPolicy, got %v", err)
+	}
+	if !(LeagueRules{AutoTip: AutoTipHomeTeam}).IsSet() {
+		t.Errorf("expected an auto-tip policy to have the league score its own tips")
+	}
+}
+
+func TestPlanAutoTipsHomeTeam(t *testing.T) {
+	input := autoTipFixture(AutoTipHomeTeam)
+	_ = input.Users[2].Deactivate(input.At.Add(-time.Hour))
+	input.Predictions = []*Prediction{NewPrediction("p1", "user1", "m9", 2, 2)}
+
+	tips := PlanAutoTips(input, sequentialIDs())
+
+	if len(tips) != 1 {
+		t.Fatalf("expected only user2 to be auto-tipped, got %d tips", len(tips))
+	}
+	tip := tips[0]
+	if tip.UserID != "user2" || tip.HomeGoals != 1 || tip.AwayGoals != 0 {
+		t.Errorf("expected user2 tipped 1-0 to the home side, got %s %d-%d", tip.UserID, tip.HomeGoals, tip.AwayGoals)
+	}
+	if !tip.Auto || tip.Joker || tip.ID != "auto1" || !tip.CreatedAt.Equal(input.At) {
+		t.Errorf("expected a non-joker auto-tip made at the lock, got %+v", tip)
+	}
+}
+
+func TestPlanAutoTipsSkips(t *testing.T) {
+	// Withdrawn tips leave the user to be auto-tipped
+	input := autoTipFixture(AutoTipHomeTeam)
+	withdrawn := NewPrediction("p1", "user2", "m9", 0, 1)
+	_ = withdrawn.Withdraw(input.At.Add(-time.Hour))
+	input.Predictions = []*Prediction{withdrawn}
+	if tips := tipsByUser(PlanAutoTips(input, sequentialIDs())); tips["user2"] == nil {
+		t.Errorf("expected a user who withdrew their tip to be auto-tipped")
+	}
+
+	// Users with a tip of their own are not tipped
+	input = autoTipFixture(AutoTipHomeTeam)
+	input.Predictions = []*Prediction{NewPrediction("p1", "user2", "m9", 0, 1)}
+	if tips := tipsByUser(PlanAutoTips(input, sequentialIDs())); len(tips) != 2 || tips["user2"] != nil {
+		t.Errorf("expected only the members without a tip to be auto-tipped, got %d tips", len(tips))
+	}
+
+	// Members who never registered are not tipped
+	input = autoTipFixture(AutoTipHomeTeam)
+	input.Users = input.Users[:1]
+	if tips := PlanAutoTips(input, sequentialIDs()); len(tips) != 1 || tips[0].UserID != "user1" {
+		t.Errorf("expected only the registered member to be tipped, got %d tips", len(tips))
+	}
+
+	// Leagues without a policy or not covering the match make no tips
+	input = autoTipFixture("")
+	if tips := PlanAutoTips(input, sequentialIDs()); len(tips) != 0 {
+		t.Errorf("expected no tips without a policy, got %d", len(tips))
+	}
+	input = autoTipFixture(AutoTipHomeTeam)
+	input.Leagues[0].Rules.MatchIDs = []string{"m1"}
+	if tips := PlanAutoTips(input, sequentialIDs()); len(tips) != 0 {
+		t.Errorf("expected no tips for a match outside the league, got %d", len(tips))
+	}
+}
+
+func TestPlanAutoTipsPerLeague(t *testing.T) {
+	input := autoTipFixture(AutoTipHomeTeam)
+	input.Match.Odds = &MatchOdds{Home: 3.5, Away: 1.9}
+	other := NewLeague("league2", "Pub", "user2", "WXYZ2345", input.At)
+	other.Rules.AutoTip = AutoTipFavouriteOdds
+	input.Leagues = []*League{other, input.Leagues[0]}
+
+	tips := PlanAutoTips(input, sequentialIDs())
+
+	// user2 is in both leagues and gets each league's tip
+	if len(tips) != 4 {
+		t.Fatalf("expected a tip per member of each league, got %d tips", len(tips))
+	}
+	byLeague := make(map[string]*Prediction)
+	for _, tip := range tips {
+		if tip.UserID == "user2" {
+			byLeague[tip.LeagueID] = tip
+		}
+	}
+	if tip := byLeague["league2"]; tip == nil || tip.AwayGoals != 1 {
+		t.Errorf("expected user2 tipped by the pub league's odds policy, got %+v", tip)
+	}
+	if tip := byLeague["league1"]; tip == nil || tip.HomeGoals != 1 {
+		t.Errorf("expected user2 tipped by the office league's home policy, got %+v", tip)
+	}
+
+	// A member already auto-tipped in a league is not tipped there again
+	input.Predictions = tips[:1]
+	if again := PlanAutoTips(input, sequentialIDs()); len(again) != 3 {
+		t.Errorf("expected the remaining 3 tips, got %d", len(again))
+	}
+}
+
+func TestPlanAutoTipsFavouriteOdds(t *testing.T) {
+	// Without odds the home side is tipped
+	input := autoTipFixture(AutoTipFavouriteOdds)
+	input.Mode = TippingModeWinnerMargin
+	if tip := PlanAutoTips(input, sequentialIDs())[0]; tip.Winner != MatchSideHome {
+		t.Errorf("expected the home side without odds, got %s", tip.Winner)
+	}
+
+	input.Match.Odds = &MatchOdds{Home: 2.8, Draw: 3.1, Away: 2.4}
+	tip := PlanAutoTips(input, sequentialIDs())[0]
+	if tip.Winner != MatchSideAway || tip.Margin == nil || *tip.Margin != 1 {
+		t.Errorf("expected the away favourite by 1 in winner tipping, got %s by %v", tip.Winner, tip.Margin)
+	}
+}
+
+func TestPlanAutoTipsFavouriteElo(t *testing.T) {
+	input := autoTipFixture(AutoTipFavouriteElo)
+	for week := 1; week <= 4; week++ {
+		date := input.Match.Date.Add(-time.Duration(week) * 7 * 24 * time.Hour)
+		input.Matches = append(input.Matches,
+			finishedMatch(fmt.Sprintf("b%d", week), "team_c", "team_b", date, 0, 3),
+			finishedMatch(fmt.Sprintf("a%d", week), "team_a", "team_c", date.Add(time.Hour), 0, 1),
+		)
+	}
+
+	tip := PlanAutoTips(input, sequentialIDs())[0]
+
+	if tip.HomeGoals != 0 || tip.AwayGoals != 1 {
+		t.Errorf("expected the higher rated away side tipped 0-1, got %d-%d", tip.HomeGoals, tip.AwayGoals)
+	}
+}
+
+func TestPlanAutoTipsPreviousTip(t *testing.T) {
+	input := autoTipFixture(AutoTipPreviousTip)
+	first := finishedMatch("m1", "team_a", "team_b", input.At.Add(-60*24*time.Hour), 1, 1)
+	reverse := finishedMatch("m2", "team_b", "team_a", input.At.Add(-30*24*time.Hour), 2, 0)
+	input.Matches = append(input.Matches, first, reverse)
+
+	autoTip := NewPrediction("p4", "user3", "m2", 0, 4)
+	autoTip.Auto = true
+	input.Predictions = []*Prediction{
+		NewPrediction("p1", "user1", "m1", 3, 1),
+		NewPrediction("p2", "user1", "m2", 2, 1),
+		NewPrediction("p3", "user3", "m1", 0, 2),
+		autoTip,
+	}
+	margin := 3
+	input.Predictions = append(input.Predictions, NewWinnerPrediction("p5", "user2", "m2", MatchSideHome, &margin))
+
+	tips := tipsByUser(PlanAutoTips(input, sequentialIDs()))
+
+	if tip := tips["user1"]; tip.HomeGoals != 1 || tip.AwayGoals != 2 {
+		t.Errorf("expected user1's latest tip repeated with the sides swapped as 1-2, got %d-%d", tip.HomeGoals, tip.AwayGoals)
+	}
+	if tip := tips["user2"]; tip.HomeGoals != 0 || tip.AwayGoals != 3 {
+		t.Errorf("expected user2's winner tip repeated as a 0-3 score, got %d-%d", tip.HomeGoals, tip.AwayGoals)
+	}
+	if tip := tips["user3"]; tip.HomeGoals != 0 || tip.AwayGoals != 2 {
+		t.Errorf("expected user3's own tip repeated over their auto-tip, got %d-%d", tip.HomeGoals, tip.AwayGoals)
+	}
+
+	// A user who never tipped the teams is tipped the home side
+	input.Predictions = nil
+	input.Mode = TippingModeWinnerMargin
+	if tip := tipsByUser(PlanAutoTips(input, sequentialIDs()))["user1"]; tip.Winner != MatchSideHome {
+		t.Errorf("expected the home side without an earlier tip, got %s", tip.Winner)
+	}
+}
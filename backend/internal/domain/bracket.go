@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bracket errors
+var (
+	ErrBracketNameRequired = newFieldError("name", "BRACKET_NAME_REQUIRED", "bracket name is required")
+	ErrBracketLockRequired = newFieldError("lockAt", "BRACKET_LOCK_REQUIRED", "bracket lock time is required")
+	ErrEmptyBracket        = newFieldError("ties", "EMPTY_BRACKET", "bracket must have at least one tie")
+	ErrDuplicateTie        = newFieldError("ties", "DUPLICATE_TIE", "tie IDs must be unique and non-empty")
+	ErrInvalidTieRound     = newFieldError("ties", "INVALID_TIE_ROUND", "tie round must be at least 1")
+	ErrInvalidTieSlot      = newFieldError("ties", "INVALID_TIE_SLOT", "each side of a tie must be a team or the winner of an earlier tie")
+	ErrInvalidTieSource    = newFieldError("ties", "INVALID_TIE_SOURCE", "a tie can only feed one later tie")
+	ErrBracketNotConnected = newFieldError("ties", "BRACKET_NOT_CONNECTED", "bracket must lead to a single final")
+	ErrInvalidRoundWeight  = newFieldError("roundWeights", "INVALID_ROUND_WEIGHT", "round weights cannot be negative")
+	ErrBracketLocked       = newError("BRACKET_LOCKED", "bracket is locked")
+	ErrTieDecided          = newError("TIE_DECIDED", "tie has already been decided")
+	ErrIncompleteBracket   = newFieldError("picks", "INCOMPLETE_BRACKET", "bracket entry must pick a winner for every tie")
+	ErrInvalidBracketPick  = newFieldError("picks", "INVALID_BRACKET_PICK", "bracket pick must be one of the teams in the tie")
+	ErrMatchDoesNotFitTie  = newFieldError("matchId", "MATCH_DOES_NOT_FIT_TIE", "match teams do not match the tie")
+)
+
+// TieSlot is one side of a knockout tie: either a known team, or the winner
+// of an earlier tie in the same bracket
+type TieSlot struct {
+	TeamID   string `json:"teamId,omitempty"`
+	WinnerOf string `json:"winnerOf,omitempty"`
+}
+
+// IsValid returns true if exactly one of TeamID and WinnerOf is set
+func (s TieSlot) IsValid() bool {
+	return (s.TeamID == "") != (s.WinnerOf == "")
+}
+
+// BracketTie is a single knockout tie, e.g. a quarter-final. Round 1 is the
+// first knockout round. The tie is decided by the linked match once it has
+// been played.
+type BracketTie struct {
+	ID      string  `json:"id"`
+	Round   int     `json:"round"`
+	Home    TieSlot `json:"home"`
+	Away    TieSlot `json:"away"`
+	MatchID string  `json:"matchId,omitempty"`
+}
+
+// Bracket links the knockout ties of a tournament so users can pick who
+// advances from each tie, all the way to the champion, before it locks.
+// Correct picks earn the weight of the tie's round.
+type Bracket struct {
+	ID            string        `json:"id"`
+	CompetitionID string        `json:"competitionId"`
+	SeasonID      string        `json:"seasonId,omitempty"`
+	Name          string        `json:"name"`
+	LockAt        time.Time     `json:"lockAt"`
+	RoundWeights  []int         `json:"roundWeights"`
+	Ties          []*BracketTie `json:"ties"`
+}
+
+// NewBracket creates a new bracket instance
+func NewBracket(id, competitionID, name string, lockAt time.Time, roundWeights []int, ties []*BracketTie) *Bracket {
+	if roundWeights == nil {
+		roundWeights = make([]int, 0)
+	}
+	if ties == nil {
+		ties = make([]*BracketTie, 0)
+	}
+	return &Bracket{
+		ID:            id,
+		CompetitionID: competitionID,
+		Name:          name,
+		LockAt:        lockAt,
+		RoundWeights:  roundWeights,
+		Ties:          ties,
+	}
+}
+
+// Validate checks the bracket is a single knockout tree: every tie has two
+// valid sides, each tie feeds at most one later tie, and all ties lead to
+// one final
+func (b *Bracket) Validate() error {
+	if strings.TrimSpace(b.Name) == "" {
+		return ErrBracketNameRequired
+	}
+	if b.LockAt.IsZero() {
+		return ErrBracketLockRequired
+	}
+	if len(b.Ties) == 0 {
+		return ErrEmptyBracket
+	}
+	for _, weight := range b.RoundWeights {
+		if weight < 0 {
+			return ErrInvalidRoundWeight
+		}
+	}
+
+	ties := make(map[string]*BracketTie, len(b.Ties))
+	for _, tie := range b.Ties {
+		if tie.ID == "" || ties[tie.ID] != nil {
+			return ErrDuplicateTie
+		}
+		if tie.Round < 1 {
+			return ErrInvalidTieRound
+		}
+		ties[tie.ID] = tie
+	}
+
+	fed := make(map[string]bool, len(b.Ties))
+	for _, tie := range b.Ties {
+		for _, slot := range []TieSlot{tie.Home, tie.Away} {
+			if !slot.IsValid() {
+				return ErrInvalidTieSlot
+			}
+			if slot.WinnerOf == "" {
+				continue
+			}
+			source := ties[slot.WinnerOf]
+			if source == nil || source.Round >= tie.Round {
+				return ErrInvalidTieSlot
+			}
+			if fed[source.ID] {
+				return ErrInvalidTieSource
+			}
+			fed[source.ID] = true
+		}
+	}
+
+	if len(b.Ties)-len(fed) != 1 {
+		return ErrBracketNotConnected
+	}
+	return nil
+}
+
+// Tie returns the tie with the given ID, or nil if there is none
+func (b *Bracket) Tie(tieID string) *BracketTie {
+	for _, tie := range b.Ties {
+		if tie.ID == tieID {
+			return tie
+		}
+	}
+	return nil
+}
+
+// TieForMatch returns the tie decided by the given match, or nil if the match
+// is not part of the bracket
+func (b *Bracket) TieForMatch(matchID string) *BracketTie {
+	for _, tie := range b.Ties {
+		if tie.MatchID != "" && tie.MatchID == matchID {
+			return tie
+		}
+	}
+	return nil
+}
+
+// Final returns the tie whose winner is the champion
+func (b *Bracket) Final() *BracketTie {
+	fed := make(map[string]bool, len(b.Ties))
+	for _, tie := range b.Ties {
+		fed[tie.Home.WinnerOf] = true
+		fed[tie.Away.WinnerOf] = true
+	}
+	for _, tie := range b.Ties {
+		if !fed[tie.ID] {
+			return tie
+		}
+	}
+	return nil
+}
+
+// Weight returns the points a correct pick in the given round is worth.
+// Rounds without a configured weight are worth one point.
+func (b *Bracket) Weight(round int) int {
+	if round < 1 || round > len(b.RoundWeights) {
+		return 1
+	}
+	return b.RoundWeights[round-1]
+}
+
+// IsLocked returns true once entries can no longer be submitted
+func (b *Bracket) IsLocked(now time.Time) bool {
+	return !now.Before(b.LockAt)
+}
+
+// ValidatePicks checks an entry picks a winner for every tie, and that each
+// pick is one of the two teams the entry itself has reaching that tie
+func (b *Bracket) ValidatePicks(picks map[string]string) error {
+	for tieID := range picks {
+		if b.Tie(tieID) == nil {
+			return fmt.Errorf("%w: unknown tie %q", ErrInvalidBracketPick, tieID)
+		}
+	}
+
+	for _, tie := range b.Ties {
+		pick := picks[tie.ID]
+		if pick == "" {
+			return fmt.Errorf("%w: no pick for tie %q", ErrIncompleteBracket, tie.ID)
+		}
+		if pick != slotTeam(tie.Home, picks) && pick != slotTeam(tie.Away, picks) {
+			return fmt.Errorf("%w: %q cannot win tie %q", ErrInvalidBracketPick, pick, tie.ID)
+		}
+	}
+	return nil
+}
+
+// ScoreTie returns the points an entry earns for a decided tie: the round's
+// weight if it picked the team that advanced, otherwise zero
+func (b *Bracket) ScoreTie(entry *BracketEntry, tie *BracketTie, winnerID string) int {
+	if winnerID == "" || entry.Picks[tie.ID] != winnerID {
+		return 0
+	}
+	return b.Weight(tie.Round)
+}
+
+// TeamsFor returns the teams known to be playing in a tie given the winners
+// of the ties decided so far. A side is empty until its feeding tie is decided.
+func (b *Bracket) TeamsFor(tie *BracketTie, winners map[string]string) (string, string) {
+	return slotTeam(tie.Home, winners), slotTeam(tie.Away, winners)
+}
+
+// CheckMatch returns ErrMatchDoesNotFitTie unless the match is played
+// between the teams known to be in the tie. Sides still to be decided accept
+// any team.
+func (b *Bracket) CheckMatch(tie *BracketTie, match *Match, winners map[string]string) error {
+	home, away := b.TeamsFor(tie, winners)
+	for _, teamID := range []string{home, away} {
+		if teamID != "" && teamID != match.HomeTeamID && teamID != match.AwayTeamID {
+			return ErrMatchDoesNotFitTie
+		}
+	}
+	return nil
+}
+
+// slotTeam returns the team filling a slot, looking up the winner of the
+// feeding tie in winners
+func slotTeam(slot TieSlot, winners map[string]string) string {
+	if slot.TeamID != "" {
+		return slot.TeamID
+	}
+	return winners[slot.WinnerOf]
+}
+
+// BracketEntry is a user's full set of picks for a bracket. Picks maps each
+// tie ID to the team ID the user expects to advance. Awards records the
+// points earned for each tie scored so far.
+type BracketEntry struct {
+	ID          string            `json:"id"`
+	BracketID   string            `json:"bracketId"`
+	UserID      string            `json:"userId"`
+	Picks       map[string]string `json:"picks"`
+	SubmittedAt time.Time         `json:"submittedAt"`
+	Awards      map[string]int    `json:"awards"`
+	Points      int               `json:"points"`
+}
+
+// NewBracketEntry creates a new bracket entry instance
+func NewBracketEntry(id, bracketID, userID string, picks map[string]string, submittedAt time.Time) *BracketEntry {
+	return &BracketEntry{
+		ID:          id,
+		BracketID:   bracketID,
+		UserID:      userID,
+		Picks:       picks,
+		SubmittedAt: submittedAt,
+		Awards:      make(map[string]int),
+	}
+}
+
+// IsScored returns true if the entry has already been awarded points for a tie
+func (e *BracketEntry) IsScored(tieID string) bool {
+	_, ok := e.Awards[tieID]
+	return ok
+}
+
+// Award records the points earned for a tie, replacing any earlier award
+// for it
+func (e *BracketEntry) Award(tieID string, points int) {
+	e.Points += points - e.Awards[tieID]
+	e.Awards[tieID] = points
+}
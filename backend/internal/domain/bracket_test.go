@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// testBracket returns a four-team bracket: two semi-finals feeding a final,
+// with semi-final picks worth 1 point and the final worth 3
+func testBracket() *Bracket {
+	lockAt := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	return NewBracket("b1", "comp_wc", "Finals", lockAt, []int{1, 3}, []*BracketTie{
+		{ID: "SF1", Round: 1, Home: TieSlot{TeamID: "team_a"}, Away: TieSlot{TeamID: "team_b"}, MatchID: "m1"},
+		{ID: "SF2", Round: 1, Home: TieSlot{TeamID: "team_c"}, Away: TieSlot{TeamID: "team_d"}},
+		{ID: "F", Round: 2, Home: TieSlot{WinnerOf: "SF1"}, Away: TieSlot{WinnerOf: "SF2"}},
+	})
+}
+
+func TestBracketValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(b *Bracket)
+		expected error
+	}{
+		{"Valid bracket", func(b *Bracket) {}, nil},
+		{"Missing name", func(b *Bracket) { b.Name = "" }, ErrBracketNameRequired},
+		{"Missing lock time", func(b *Bracket) { b.LockAt = time.Time{} }, ErrBracketLockRequired},
+		{"No ties", func(b *Bracket) { b.Ties = nil }, ErrEmptyBracket},
+		{"Negative weight", func(b *Bracket) { b.RoundWeights = []int{1, -3} }, ErrInvalidRoundWeight},
+		{"Duplicate tie", func(b *Bracket) { b.Ties[1].ID = "SF1" }, ErrDuplicateTie},
+		{"Zero round", func(b *Bracket) { b.Ties[0].Round = 0 }, ErrInvalidTieRound},
+		{"Side with team and source", func(b *Bracket) { b.Ties[2].Home.TeamID = "team_a" }, ErrInvalidTieSlot},
+		{"Empty side", func(b *Bracket) { b.Ties[0].Away = TieSlot{} }, ErrInvalidTieSlot},
+		{"Unknown source", func(b *Bracket) { b.Ties[2].Away.WinnerOf = "QF1" }, ErrInvalidTieSlot},
+		{"Source in same round", func(b *Bracket) { b.Ties[2].Round = 1 }, ErrInvalidTieSlot},
+		{"Tie feeds twice", func(b *Bracket) { b.Ties[2].Away.WinnerOf = "SF1" }, ErrInvalidTieSource},
+		{"Two finals", func(b *Bracket) { b.Ties = b.Ties[:2] }, ErrBracketNotConnected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bracket := testBracket()
+			tt.modify(bracket)
+			if err := bracket.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestBracketFinalAndWeight(t *testing.T) {
+	bracket := testBracket()
+
+	if final := bracket.Final(); final == nil || final.ID != "F" {
+		t.Errorf("expected final F, got %v", final)
+	}
+	if weight := bracket.Weight(2); weight != 3 {
+		t.Errorf("expected final weight 3, got %d", weight)
+	}
+	if weight := bracket.Weight(3); weight != 1 {
+		t.Errorf("expected unconfigured round weight 1, got %d", weight)
+	}
+	if tie := bracket.TieForMatch("m1"); tie == nil || tie.ID != "SF1" {
+		t.Errorf("expected m1 to decide SF1, got %v", tie)
+	}
+	if tie := bracket.TieForMatch(""); tie != nil {
+		t.Errorf("expected no tie for an empty match ID, got %v", tie.ID)
+	}
+}
+
+func TestBracketValidatePicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		picks    map[string]string
+		expected error
+	}{
+		{"Full bracket", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_d"}, nil},
+		{"Missing final pick", map[string]string{"SF1": "team_a", "SF2": "team_d"}, ErrIncompleteBracket},
+		{"Team not in tie", map[string]string{"SF1": "team_c", "SF2": "team_d", "F": "team_d"}, ErrInvalidBracketPick},
+		{"Champion knocked out earlier", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_c"}, ErrInvalidBracketPick},
+		{"Unknown tie", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_d", "QF1": "team_a"}, ErrInvalidBracketPick},
+	}
+
+	bracket := testBracket()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := bracket.ValidatePicks(tt.picks); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestBracketScoreTie(t *testing.T) {
+	bracket := testBracket()
+	entry := NewBracketEntry("e1", "b1", "user1", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_a"}, time.Now())
+
+	if points := bracket.ScoreTie(entry, bracket.Tie("SF1"), "team_a"); points != 1 {
+		t.Errorf("expected 1 point for a correct semi-final pick, got %d", points)
+	}
+	if points := bracket.ScoreTie(entry, bracket.Tie("SF2"), "team_c"); points != 0 {
+		t.Errorf("expected 0 points for a wrong pick, got %d", points)
+	}
+	if points := bracket.ScoreTie(entry, bracket.Tie("F"), "team_a"); points != 3 {
+		t.Errorf("expected 3 points for the champion, got %d", points)
+	}
+
+	entry.Award("SF1", 1)
+	entry.Award("F", 3)
+	if !entry.IsScored("SF1") || entry.IsScored("SF2") {
+		t.Errorf("expected only SF1 and F to be scored, got %v", entry.Awards)
+	}
+	if entry.Points != 4 {
+		t.Errorf("expected 4 points, got %d", entry.Points)
+	}
+
+	// A corrected winner replaces the earlier award for the tie
+	entry.Award("SF1", 0)
+	if entry.Points != 3 || entry.Awards["SF1"] != 0 {
+		t.Errorf("expected 3 points after the SF1 correction, got %d (%v)", entry.Points, entry.Awards)
+	}
+}
+
+func TestBracketCheckMatch(t *testing.T) {
+	bracket := testBracket()
+	final := bracket.Tie("F")
+	match := &Match{ID: "m3", HomeTeamID: "team_b", AwayTeamID: "team_c"}
+
+	if err := bracket.CheckMatch(final, match, map[string]string{}); err != nil {
+		t.Errorf("expected an undecided final to accept any match, got %v", err)
+	}
+	if err := bracket.CheckMatch(final, match, map[string]string{"SF1": "team_a"}); err != ErrMatchDoesNotFitTie {
+		t.Errorf("expected %v, got %v", ErrMatchDoesNotFitTie, err)
+	}
+	if err := bracket.CheckMatch(bracket.Tie("SF2"), &Match{HomeTeamID: "team_d", AwayTeamID: "team_c"}, nil); err != nil {
+		t.Errorf("expected home and away to be interchangeable, got %v", err)
+	}
+}
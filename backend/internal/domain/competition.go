@@ -0,0 +1,205 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Competition errors
+var (
+	ErrInvalidSport             = newFieldError("sport", "INVALID_SPORT", "invalid sport")
+	ErrCompetitionNameRequired  = newFieldError("name", "COMPETITION_NAME_REQUIRED", "competition name is required")
+	ErrInvalidSeasonWindow      = newFieldError("endDate", "INVALID_SEASON_WINDOW", "season must end after it starts")
+	ErrInvalidSeasonStatus      = newFieldError("status", "INVALID_SEASON_STATUS", "invalid season status")
+	ErrInvalidSeasonTransition  = newError("INVALID_SEASON_TRANSITION", "invalid season status transition")
+	ErrSeasonNotInCompetition   = newFieldError("seasonId", "SEASON_NOT_IN_COMPETITION", "season does not belong to the competition")
+	ErrUnknownSeason            = newFieldError("seasonId", "UNKNOWN_SEASON", "unknown season")
+	ErrUnknownCompetition       = newFieldError("competitionId", "UNKNOWN_COMPETITION", "unknown competition")
+	ErrCompetitionAlreadyExists = newFieldError("name", "COMPETITION_ALREADY_EXISTS", "competition already exists")
+	ErrInvalidTippingMode       = newFieldError("tippingMode", "INVALID_TIPPING_MODE", "invalid tipping mode")
+)
+
+// Sport identifies the code a competition is played under
+type Sport string
+
+const (
+	SportFootball Sport = "FOOTBALL"
+	SportAFL      Sport = "AFL"
+)
+
+// IsValid returns true if the sport is one of the supported sports
+func (s Sport) IsValid() bool {
+	return s == SportFootball || s == SportAFL
+}
+
+// TippingMode says what users tip for each match in a competition
+type TippingMode string
+
+const (
+	// TippingModeExactScore tips the final score of every match
+	TippingModeExactScore TippingMode = "EXACT_SCORE"
+	// TippingModeWinnerMargin tips the winner of every match, plus the
+	// winning margin of the first match of each round as a tiebreaker
+	TippingModeWinnerMargin TippingMode = "WINNER_MARGIN"
+)
+
+// IsValid returns true if the mode is one of the supported tipping modes
+func (m TippingMode) IsValid() bool {
+	return m == TippingModeExactScore || m == TippingModeWinnerMargin
+}
+
+// DefaultTippingMode returns the mode a competition in the sport uses unless
+// another is chosen: winner and margin for Aussie rules, otherwise exact score
+func DefaultTippingMode(sport Sport) TippingMode {
+	if sport == SportAFL {
+		return TippingModeWinnerMargin
+	}
+	return TippingModeExactScore
+}
+
+// Competition represents a league or tournament that matches are played in
+type Competition struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Sport       Sport        `json:"sport"`
+	Aliases     []string     `json:"aliases"`
+	ResultBasis ResultBasis  `json:"resultBasis"`
+	TippingMode TippingMode  `json:"tippingMode"`
+	Tiebreakers []Tiebreaker `json:"tiebreakers"`
+}
+
+// NewCompetition creates a new competition instance. Tips are scored on the
+// regulation-time result unless the result basis is changed, and use the
+// sport's default tipping mode and the default leaderboard tiebreakers.
+func NewCompetition(id, name string, sport Sport, aliases []string) *Competition {
+	if aliases == nil {
+		aliases = make([]string, 0)
+	}
+	return &Competition{
+		ID:          id,
+		Name:        name,
+		Sport:       sport,
+		Aliases:     aliases,
+		ResultBasis: ResultBasisRegulation,
+		TippingMode: DefaultTippingMode(sport),
+		Tiebreakers: DefaultTiebreakers(),
+	}
+}
+
+// Validate checks the competition's name, sport, result basis, tipping mode
+// and tiebreakers
+func (c *Competition) Validate() error {
+	if strings.TrimSpace(c.Name) == "" {
+		return ErrCompetitionNameRequired
+	}
+	if !c.Sport.IsValid() {
+		return ErrInvalidSport
+	}
+	if !c.ResultBasis.IsValid() {
+		return ErrInvalidResultBasis
+	}
+	if !c.TippingMode.IsValid() {
+		return ErrInvalidTippingMode
+	}
+	return ValidateTiebreakers(c.Tiebreakers)
+}
+
+// HasName returns true if the name matches the competition's name or one of
+// its aliases, ignoring case and surrounding whitespace
+func (c *Competition) HasName(name string) bool {
+	name = strings.TrimSpace(name)
+	if strings.EqualFold(c.Name, name) {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(alias, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveCompetition finds a competition by ID, name or alias. It returns nil
+// if no competition matches.
+func ResolveCompetition(competitions []*Competition, ref string) *Competition {
+	for _, competition := range competitions {
+		if competition.ID == ref {
+			return competition
+		}
+	}
+	for _, competition := range competitions {
+		if competition.HasName(ref) {
+			return competition
+		}
+	}
+	return nil
+}
+
+// SeasonStatus represents where a season is in its lifecycle
+type SeasonStatus string
+
+const (
+	SeasonStatusUpcoming  SeasonStatus = "UPCOMING"
+	SeasonStatusActive    SeasonStatus = "ACTIVE"
+	SeasonStatusCompleted SeasonStatus = "COMPLETED"
+)
+
+// IsValid returns true if the status is one of the known season statuses
+func (s SeasonStatus) IsValid() bool {
+	switch s {
+	case SeasonStatusUpcoming, SeasonStatusActive, SeasonStatusCompleted:
+		return true
+	}
+	return false
+}
+
+// seasonTransitions lists the statuses a season may move to from each status.
+// Completed seasons are terminal.
+var seasonTransitions = map[SeasonStatus][]SeasonStatus{
+	SeasonStatusUpcoming: {SeasonStatusActive},
+	SeasonStatusActive:   {SeasonStatusCompleted},
+}
+
+// Season represents one edition of a competition, such as 2025/26
+type Season struct {
+	ID            string       `json:"id"`
+	CompetitionID string       `json:"competitionId"`
+	Name          string       `json:"name"`
+	StartDate     time.Time    `json:"startDate"`
+	EndDate       time.Time    `json:"endDate"`
+	Status        SeasonStatus `json:"status"`
+}
+
+// NewSeason creates a new season instance. Seasons start out upcoming.
+func NewSeason(id, competitionID, name string, startDate, endDate time.Time) *Season {
+	return &Season{
+		ID:            id,
+		CompetitionID: competitionID,
+		Name:          name,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Status:        SeasonStatusUpcoming,
+	}
+}
+
+// Validate checks the season's date range
+func (s *Season) Validate() error {
+	if !s.EndDate.After(s.StartDate) {
+		return ErrInvalidSeasonWindow
+	}
+	return nil
+}
+
+// ChangeStatus moves the season to a new status, enforcing the lifecycle
+func (s *Season) ChangeStatus(status SeasonStatus) error {
+	if !status.IsValid() {
+		return ErrInvalidSeasonStatus
+	}
+	for _, allowed := range seasonTransitions[s.Status] {
+		if allowed == status {
+			s.Status = status
+			return nil
+		}
+	}
+	return ErrInvalidSeasonTransition
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCompetitionValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		competition *Competition
+		expected    error
+	}{
+		{"Valid competition", NewCompetition("c", "Premier League", SportFootball, nil), nil},
+		{"AFL competition", NewCompetition("c", "AFL Premiership", SportAFL, nil), nil},
+		{"Missing name", NewCompetition("c", "  ", SportFootball, nil), ErrCompetitionNameRequired},
+		{"Unknown sport", NewCompetition("c", "Super Rugby", Sport("RUGBY"), nil), ErrInvalidSport},
+		{"Unknown result basis", &Competition{ID: "c", Name: "FA Cup", Sport: SportFootball, ResultBasis: ResultBasis("GOLDEN_GOAL")}, ErrInvalidResultBasis},
+		{"Unknown tipping mode", &Competition{ID: "c", Name: "FA Cup", Sport: SportFootball, ResultBasis: ResultBasisRegulation, TippingMode: TippingMode("LAST_GOAL")}, ErrInvalidTippingMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.competition.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestDefaultTippingMode(t *testing.T) {
+	if mode := NewCompetition("c", "Premier League", SportFootball, nil).TippingMode; mode != TippingModeExactScore {
+		t.Errorf("expected football to tip exact scores, got %v", mode)
+	}
+	if mode := NewCompetition("c", "AFL Premiership", SportAFL, nil).TippingMode; mode != TippingModeWinnerMargin {
+		t.Errorf("expected AFL to tip winners and margins, got %v", mode)
+	}
+}
+
+func TestResolveCompetition(t *testing.T) {
+	epl := NewCompetition("comp_epl", "Premier League", SportFootball, []string{"EPL", "English Premier League"})
+	laliga := NewCompetition("comp_laliga", "La Liga", SportFootball, nil)
+	competitions := []*Competition{epl, laliga}
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected *Competition
+	}{
+		{"By ID", "comp_laliga", laliga},
+		{"By name", "Premier League", epl},
+		{"By alias ignoring case", "epl", epl},
+		{"By name with whitespace", " La Liga ", laliga},
+		{"Unknown", "Serie A", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveCompetition(competitions, tt.ref); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSeasonValidate(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 5, 24, 0, 0, 0, 0, time.UTC)
+
+	if err := NewSeason("s", "comp_epl", "2025/26", start, end).Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := NewSeason("s", "comp_epl", "2025/26", end, start).Validate(); err != ErrInvalidSeasonWindow {
+		t.Errorf("expected %v, got %v", ErrInvalidSeasonWindow, err)
+	}
+}
+
+func TestSeasonChangeStatus(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 5, 24, 0, 0, 0, 0, time.UTC)
+	season := NewSeason("s", "comp_epl", "2025/26", start, end)
+
+	if season.Status != SeasonStatusUpcoming {
+		t.Fatalf("expected new season to be UPCOMING, got %v", season.Status)
+	}
+	if err := season.ChangeStatus(SeasonStatusCompleted); err != ErrInvalidSeasonTransition {
+		t.Errorf("expected %v, got %v", ErrInvalidSeasonTransition, err)
+	}
+	if err := season.ChangeStatus(SeasonStatus("PAUSED")); err != ErrInvalidSeasonStatus {
+		t.Errorf("expected %v, got %v", ErrInvalidSeasonStatus, err)
+	}
+	if err := season.ChangeStatus(SeasonStatusActive); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := season.ChangeStatus(SeasonStatusCompleted); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := season.ChangeStatus(SeasonStatusActive); err != ErrInvalidSeasonTransition {
+		t.Errorf("expected %v, got %v", ErrInvalidSeasonTransition, err)
+	}
+	if season.Status != SeasonStatusCompleted {
+		t.Errorf("expected COMPLETED, got %v", season.Status)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Elo rating parameters. Every team starts on the initial rating, each result
+// moves the two teams' ratings by up to EloK points, and the home side is
+// treated as EloHomeAdvantage points stronger than its rating.
+const (
+	EloInitialRating = 1500.0
+	EloK             = 20.0
+	EloHomeAdvantage = 65.0
+)
+
+// EloRatings are team strength ratings built from finished results, keyed by
+// team ID, or by team name for teams that are not registered
+type EloRatings map[string]float64
+
+// BuildEloRatings rates teams on the overall results of the finished matches
+// that kicked off before the given time, oldest first
+func BuildEloRatings(matches []*Match, before time.Time) EloRatings {
+	results := make([]*Match, 0)
+	for _, match := range matches {
+		if match.IsFinished() && match.Score != nil && match.Date.Before(before) {
+			results = append(results, match)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].Date.Equal(results[j].Date) {
+			return results[i].Date.Before(results[j].Date)
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	ratings := make(EloRatings)
+	for _, match := range results {
+		home, away := match.teamKey(MatchSideHome), match.teamKey(MatchSideAway)
+		expected := ratings.expectedHomeScore(home, away)
+
+		actual := 0.5
+		switch match.Score.Winner(ResultBasisOverall) {
+		case MatchSideHome:
+			actual = 1
+		case MatchSideAway:
+			actual = 0
+		}
+
+		change := EloK * (actual - expected)
+		ratings[home] = ratings.Rating(home) + change
+		ratings[away] = ratings.Rating(away) - change
+	}
+	return ratings
+}
+
+// Rating returns the team's rating, or the initial rating for a team without
+// results
+func (r EloRatings) Rating(team string) float64 {
+	if rating, ok := r[team]; ok {
+		return rating
+	}
+	return EloInitialRating
+}
+
+// Favourite returns the side the ratings expect to win the match, counting
+// home advantage. Level teams favour the home side.
+func (r EloRatings) Favourite(match *Match) MatchSide {
+	if r.expectedHomeScore(match.teamKey(MatchSideHome), match.teamKey(MatchSideAway)) < 0.5 {
+		return MatchSideAway
+	}
+	return MatchSideHome
+}
+
+// expectedHomeScore returns the home side's expected result, from 0 for a
+// certain away win to 1 for a certain home win
+func (r EloRatings) expectedHomeScore(home, away string) float64 {
+	difference := r.Rating(away) - (r.Rating(home) + EloHomeAdvantage)
+	return 1 / (1 + math.Pow(10, difference/400))
+}
+
+// teamKey identifies one of the match's teams: its team ID, or its name if
+// the team is not registered
+func (m *Match) teamKey(side MatchSide) string {
+	if side == MatchSideAway {
+		if m.AwayTeamID != "" {
+			return m.AwayTeamID
+		}
+		return m.AwayTeam
+	}
+	if m.HomeTeamID != "" {
+		return m.HomeTeamID
+	}
+	return m.HomeTeam
+}
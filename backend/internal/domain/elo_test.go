@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// finishedMatch returns a finished match between two registered teams
+func finishedMatch(id, homeTeamID, awayTeamID string, date time.Time, homeGoals, awayGoals int) *Match {
+	match := NewMatch(id, homeTeamID, awayTeamID, date, "EPL")
+	match.HomeTeamID = homeTeamID
+	match.AwayTeamID = awayTeamID
+	match.Status = MatchStatusFinished
+	match.Score = &Score{HomeGoals: homeGoals, AwayGoals: awayGoals}
+	return match
+}
+
+func TestBuildEloRatings(t *testing.T) {
+	start := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	matches := []*Match{
+		finishedMatch("m1", "team_a", "team_b", start, 0, 2),
+		finishedMatch("m2", "team_b", "team_c", start.Add(7*24*time.Hour), 1, 1),
+		NewMatch("m3", "team_a", "team_c", start.Add(14*24*time.Hour), "EPL"),
+		finishedMatch("m4", "team_c", "team_a", start.Add(21*24*time.Hour), 5, 0),
+	}
+
+	ratings := BuildEloRatings(matches, start.Add(14*24*time.Hour))
+
+	if ratings.Rating("team_b") <= EloInitialRating || ratings.Rating("team_a") >= EloInitialRating {
+		t.Errorf("expected the away win to move team_b up and team_a down, got %.1f and %.1f", ratings.Rating("team_b"), ratings.Rating("team_a"))
+	}
+	if ratings.Rating("team_c") <= EloInitialRating {
+		t.Errorf("expected team_c to gain from an away draw against a stronger side, got %.1f", ratings.Rating("team_c"))
+	}
+	if ratings.Rating("team_d") != EloInitialRating {
+		t.Errorf("expected a team without results on the initial rating, got %.1f", ratings.Rating("team_d"))
+	}
+
+	total := ratings.Rating("team_a") + ratings.Rating("team_b") + ratings.Rating("team_c")
+	if total < 3*EloInitialRating-0.001 || total > 3*EloInitialRating+0.001 {
+		t.Errorf("expected results to move points between teams only, got a total of %.3f", total)
+	}
+}
+
+func TestEloFavourite(t *testing.T) {
+	start := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	upcoming := NewMatch("m9", "team_a", "team_b", start.Add(30*24*time.Hour), "EPL")
+	upcoming.HomeTeamID, upcoming.AwayTeamID = "team_a", "team_b"
+
+	// Level teams favour the home side
+	if side := BuildEloRatings(nil, upcoming.Date).Favourite(upcoming); side != MatchSideHome {
+		t.Errorf("expected level teams to favour the home side, got %s", side)
+	}
+
+	// team_b winning every week while team_a loses outweighs home advantage
+	var matches []*Match
+	for week := 0; week < 4; week++ {
+		date := start.Add(time.Duration(week) * 7 * 24 * time.Hour)
+		matches = append(matches,
+			finishedMatch(fmt.Sprintf("b%d", week), "team_c", "team_b", date, 0, 2),
+			finishedMatch(fmt.Sprintf("a%d", week), "team_a", "team_d", date, 0, 1),
+		)
+	}
+	if side := BuildEloRatings(matches, upcoming.Date).Favourite(upcoming); side != MatchSideAway {
+		t.Errorf("expected the in-form away side to be favourite, got %s", side)
+	}
+}
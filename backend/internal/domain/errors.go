@@ -0,0 +1,44 @@
+package domain
+
+import "errors"
+
+// Error is a broken domain rule. Its code is stable, so clients can rely on
+// it where the wording of the message may change. Field names the JSON field
+// at fault when the rule is about a single field.
+type Error struct {
+	Code    string
+	Field   string
+	Message string
+}
+
+// Error returns the rule's message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// newError creates a domain error that is not about any one field
+func newError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// newFieldError creates a domain error about a single field
+func newFieldError(field, code, message string) *Error {
+	return &Error{Code: code, Field: field, Message: message}
+}
+
+// OnField returns a copy of the rule about the given field, for rules that
+// requests can break through more than one field
+func (e *Error) OnField(field string) *Error {
+	fieldErr := *e
+	fieldErr.Field = field
+	return &fieldErr
+}
+
+// AsError returns the domain error in err's chain, or nil if there is none
+func AsError(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAsError(t *testing.T) {
+	wrapped := fmt.Errorf("invalid team: %w", ErrTeamNameRequired)
+
+	domainErr := AsError(wrapped)
+	if domainErr == nil {
+		t.Fatal("expected the wrapped domain error to be found")
+	}
+	if domainErr.Code != "TEAM_NAME_REQUIRED" || domainErr.Field != "name" {
+		t.Errorf("expected TEAM_NAME_REQUIRED on name, got %s on %q", domainErr.Code, domainErr.Field)
+	}
+
+	if AsError(fmt.Errorf("connection refused")) != nil {
+		t.Error("expected no domain error in a plain error")
+	}
+}
@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"sort"
+)
+
+// Head-to-head errors
+var (
+	ErrInvalidLeagueFormat = newFieldError("format", "INVALID_LEAGUE_FORMAT", "league format must be CLASSIC or HEAD_TO_HEAD")
+	ErrNotHeadToHead       = newError("NOT_HEAD_TO_HEAD", "league does not play head-to-head")
+	ErrNoFixtureRounds     = newFieldError("roundIds", "NO_FIXTURE_ROUNDS", "fixtures need at least one round")
+	ErrTooFewMembers       = newError("TOO_FEW_MEMBERS", "head-to-head needs at least two members")
+)
+
+// LeagueFormat decides how a league ranks its members
+type LeagueFormat string
+
+const (
+	LeagueFormatClassic    LeagueFormat = "CLASSIC"      // one cumulative points table
+	LeagueFormatHeadToHead LeagueFormat = "HEAD_TO_HEAD" // weekly matchups and a W/D/L ladder
+)
+
+// IsValid returns true if the format is a supported league format
+func (f LeagueFormat) IsValid() bool {
+	return f == LeagueFormatClassic || f == LeagueFormatHeadToHead
+}
+
+// Ladder points for a head-to-head result
+const (
+	LadderPointsWin  = 3
+	LadderPointsDraw = 1
+)
+
+// MatchupStatus represents whether a head-to-head matchup has been decided
+type MatchupStatus string
+
+const (
+	MatchupStatusScheduled MatchupStatus = "SCHEDULED"
+	MatchupStatusDecided   MatchupStatus = "DECIDED"
+)
+
+// Matchup pairs two league members for a round. Each scores the points their
+// tips earned in the round, and the higher score wins once every match in the
+// round has a final result.
+type Matchup struct {
+	RoundID    string        `json:"roundId"`
+	HomeUserID string        `json:"homeUserId"`
+	AwayUserID string        `json:"awayUserId"`
+	HomeScore  int           `json:"homeScore"`
+	AwayScore  int           `json:"awayScore"`
+	Status     MatchupStatus `json:"status"`
+	WinnerID   string        `json:"winnerId,omitempty"` // empty for a draw or until decided
+}
+
+// NewMatchup creates a scheduled matchup between two members
+func NewMatchup(roundID, homeUserID, awayUserID string) *Matchup {
+	return &Matchup{
+		RoundID:    roundID,
+		HomeUserID: homeUserID,
+		AwayUserID: awayUserID,
+		Status:     MatchupStatusScheduled,
+	}
+}
+
+// Involves returns true if the user plays in the matchup
+func (m *Matchup) Involves(userID string) bool {
+	return m.HomeUserID == userID || m.AwayUserID == userID
+}
+
+// Record sets both members' round scores. A decided matchup goes to the
+// higher score, or is drawn if they are level.
+func (m *Matchup) Record(homeScore, awayScore int, decided bool) {
+	m.HomeScore = homeScore
+	m.AwayScore = awayScore
+	m.Status = MatchupStatusScheduled
+	m.WinnerID = ""
+	if !decided {
+		return
+	}
+
+	m.Status = MatchupStatusDecided
+	switch {
+	case homeScore > awayScore:
+		m.WinnerID = m.HomeUserID
+	case awayScore > homeScore:
+		m.WinnerID = m.AwayUserID
+	}
+}
+
+// IsDecided returns true once the matchup has a result
+func (m *Matchup) IsDecided() bool {
+	return m.Status == MatchupStatusDecided
+}
+
+// GenerateFixtures pairs the members round-robin across the rounds, so that
+// everyone plays everyone once before any pairing repeats. With an odd number
+// of members one member has a bye each round. Home and away swap each time
+// the cycle repeats.
+func GenerateFixtures(memberIDs, roundIDs []string) ([]*Matchup, error) {
+	if len(roundIDs) == 0 {
+		return nil, ErrNoFixtureRounds
+	}
+	if len(memberIDs) < 2 {
+		return nil, ErrTooFewMembers
+	}
+
+	// The circle method: the first slot stays put while the others rotate
+	slots := append([]string(nil), memberIDs...)
+	sort.Strings(slots)
+	if len(slots)%2 == 1 {
+		slots = append(slots, "") // bye
+	}
+	n := len(slots)
+
+	fixtures := make([]*Matchup, 0, len(roundIDs)*n/2)
+	for r, roundID := range roundIDs {
+		swap := (r/(n-1))%2 == 1
+		for i := 0; i < n/2; i++ {
+			home, away := slots[i], slots[n-1-i]
+			if home == "" || away == "" {
+				continue
+			}
+			if swap {
+				home, away = away, home
+			}
+			fixtures = append(fixtures, NewMatchup(roundID, home, away))
+		}
+		slots = append([]string{slots[0], slots[n-1]}, slots[1:n-1]...)
+	}
+	return fixtures, nil
+}
+
+// LadderEntry is a member's row in a head-to-head ladder
+type LadderEntry struct {
+	Rank          int    `json:"rank"`
+	UserID        string `json:"userId"`
+	Played        int    `json:"played"`
+	Won           int    `json:"won"`
+	Drawn         int    `json:"drawn"`
+	Lost          int    `json:"lost"`
+	PointsFor     int    `json:"pointsFor"`
+	PointsAgainst int    `json:"pointsAgainst"`
+	Points        int    `json:"points"` // ladder points
+}
+
+// BuildLadder tallies the decided matchups into a W/D/L ladder of the
+// members, ranked by ladder points and then tip points scored. Members level
+// on both share a rank.
+func BuildLadder(memberIDs []string, matchups []*Matchup) []LadderEntry {
+	byUser := make(map[string]*LadderEntry)
+	entry := func(userID string) *LadderEntry {
+		if _, ok := byUser[userID]; !ok {
+			byUser[userID] = &LadderEntry{UserID: userID}
+		}
+		return byUser[userID]
+	}
+	for _, userID := range memberIDs {
+		entry(userID)
+	}
+
+	for _, matchup := range matchups {
+		if !matchup.IsDecided() {
+			continue
+		}
+		home, away := entry(matchup.HomeUserID), entry(matchup.AwayUserID)
+		home.record(matchup.HomeScore, matchup.AwayScore)
+		away.record(matchup.AwayScore, matchup.HomeScore)
+	}
+
+	ladder := make([]LadderEntry, 0, len(byUser))
+	for _, e := range byUser {
+		ladder = append(ladder, *e)
+	}
+	sort.Slice(ladder, func(i, j int) bool {
+		if ladder[i].Points != ladder[j].Points {
+			return ladder[i].Points > ladder[j].Points
+		}
+		if ladder[i].PointsFor != ladder[j].PointsFor {
+			return ladder[i].PointsFor > ladder[j].PointsFor
+		}
+		return ladder[i].UserID < ladder[j].UserID
+	})
+
+	for i := range ladder {
+		if i > 0 && ladder[i].Points == ladder[i-1].Points && ladder[i].PointsFor == ladder[i-1].PointsFor {
+			ladder[i].Rank = ladder[i-1].Rank
+		} else {
+			ladder[i].Rank = i + 1
+		}
+	}
+	return ladder
+}
+
+// record adds one decided matchup to the entry
+func (e *LadderEntry) record(scored, conceded int) {
+	e.Played++
+	e.PointsFor += scored
+	e.PointsAgainst += conceded
+	switch {
+	case scored > conceded:
+		e.Won++
+		e.Points += LadderPointsWin
+	case scored == conceded:
+		e.Drawn++
+		e.Points += LadderPointsDraw
+	default:
+		e.Lost++
+	}
+}
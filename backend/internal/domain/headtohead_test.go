@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestGenerateFixtures(t *testing.T) {
+	t.Run("Everyone plays everyone once", func(t *testing.T) {
+		fixtures, err := GenerateFixtures([]string{"d", "c", "b", "a"}, []string{"r1", "r2", "r3"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(fixtures) != 6 {
+			t.Fatalf("expected 6 matchups, got %d", len(fixtures))
+		}
+
+		pairs := make(map[string]bool)
+		perRound := make(map[string]map[string]bool)
+		for _, matchup := range fixtures {
+			home, away := matchup.HomeUserID, matchup.AwayUserID
+			if home > away {
+				home, away = away, home
+			}
+			if pairs[home+away] {
+				t.Errorf("expected %s and %s to meet once", home, away)
+			}
+			pairs[home+away] = true
+
+			if perRound[matchup.RoundID] == nil {
+				perRound[matchup.RoundID] = make(map[string]bool)
+			}
+			for _, userID := range []string{home, away} {
+				if perRound[matchup.RoundID][userID] {
+					t.Errorf("expected %s to play once in %s", userID, matchup.RoundID)
+				}
+				perRound[matchup.RoundID][userID] = true
+			}
+		}
+	})
+
+	t.Run("Odd number of members has a bye", func(t *testing.T) {
+		fixtures, err := GenerateFixtures([]string{"a", "b", "c"}, []string{"r1", "r2", "r3"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(fixtures) != 3 {
+			t.Fatalf("expected one matchup per round, got %d", len(fixtures))
+		}
+	})
+
+	t.Run("Second cycle swaps home and away", func(t *testing.T) {
+		fixtures, err := GenerateFixtures([]string{"a", "b"}, []string{"r1", "r2"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if fixtures[0].HomeUserID != fixtures[1].AwayUserID {
+			t.Errorf("expected home and away to swap, got %+v and %+v", fixtures[0], fixtures[1])
+		}
+	})
+
+	t.Run("Too few members", func(t *testing.T) {
+		if _, err := GenerateFixtures([]string{"a"}, []string{"r1"}); err != ErrTooFewMembers {
+			t.Errorf("expected %v, got %v", ErrTooFewMembers, err)
+		}
+	})
+
+	t.Run("No rounds", func(t *testing.T) {
+		if _, err := GenerateFixtures([]string{"a", "b"}, nil); err != ErrNoFixtureRounds {
+			t.Errorf("expected %v, got %v", ErrNoFixtureRounds, err)
+		}
+	})
+}
+
+func TestMatchupRecord(t *testing.T) {
+	matchup := NewMatchup("r1", "a", "b")
+
+	matchup.Record(5, 3, false)
+	if matchup.IsDecided() || matchup.WinnerID != "" {
+		t.Errorf("expected an undecided matchup, got %+v", matchup)
+	}
+
+	matchup.Record(5, 7, true)
+	if !matchup.IsDecided() || matchup.WinnerID != "b" {
+		t.Errorf("expected b to win, got %+v", matchup)
+	}
+
+	matchup.Record(4, 4, true)
+	if !matchup.IsDecided() || matchup.WinnerID != "" {
+		t.Errorf("expected a draw, got %+v", matchup)
+	}
+}
+
+func TestBuildLadder(t *testing.T) {
+	decided := func(home, away string, homeScore, awayScore int) *Matchup {
+		matchup := NewMatchup("r", home, away)
+		matchup.Record(homeScore, awayScore, true)
+		return matchup
+	}
+	undecided := NewMatchup("r3", "a", "c")
+	undecided.Record(10, 0, false)
+
+	ladder := BuildLadder([]string{"a", "b", "c", "d"}, []*Matchup{
+		decided("a", "b", 6, 4),
+		decided("c", "d", 5, 5),
+		decided("b", "c", 7, 2),
+		decided("d", "a", 3, 3),
+		undecided,
+	})
+
+	expected := []struct {
+		userID               string
+		rank, points, played int
+	}{
+		{"a", 1, 4, 2},
+		{"b", 2, 3, 2},
+		{"d", 3, 2, 2},
+		{"c", 4, 1, 2},
+	}
+	if len(ladder) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(ladder))
+	}
+	for i, e := range expected {
+		entry := ladder[i]
+		if entry.UserID != e.userID || entry.Rank != e.rank || entry.Points != e.points || entry.Played != e.played {
+			t.Errorf("entry %d: expected %+v, got %+v", i, e, entry)
+		}
+	}
+}
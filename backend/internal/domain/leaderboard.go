@@ -0,0 +1,281 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Leaderboard errors
+var (
+	ErrInvalidTiebreaker   = newFieldError("tiebreakers", "INVALID_TIEBREAKER", "invalid tiebreaker")
+	ErrDuplicateTiebreaker = newFieldError("tiebreakers", "DUPLICATE_TIEBREAKER", "tiebreaker listed more than once")
+)
+
+// PointsSource identifies what a user was awarded points for
+type PointsSource string
+
+const (
+	PointsSourceMatch    PointsSource = "MATCH"
+	PointsSourceBracket  PointsSource = "BRACKET"
+	PointsSourceOutright PointsSource = "OUTRIGHT"
+)
+
+// Tiebreaker orders users who are level on points
+type Tiebreaker string
+
+const (
+	// TiebreakerExactScores ranks the user with more exact-score tips higher
+	TiebreakerExactScores Tiebreaker = "EXACT_SCORES"
+	// TiebreakerMarginError ranks the user with the lower total margin error higher
+	TiebreakerMarginError Tiebreaker = "MARGIN_ERROR"
+	// TiebreakerEarliestTip ranks the user who submitted a tip first higher
+	TiebreakerEarliestTip Tiebreaker = "EARLIEST_TIP"
+	// TiebreakerHeadToHead ranks the user who outscored more of the users
+	// still level with them, on the matches they both tipped, higher
+	TiebreakerHeadToHead Tiebreaker = "HEAD_TO_HEAD"
+)
+
+// Besides a tiebreaker, a leaderboard entry may be split from the one above
+// it on points, or not at all
+const (
+	DecidedByPoints = "POINTS"
+	DecidedByTie    = "TIED"
+)
+
+// IsValid returns true if the tiebreaker is one of the supported tiebreakers
+func (t Tiebreaker) IsValid() bool {
+	switch t {
+	case TiebreakerExactScores, TiebreakerMarginError, TiebreakerEarliestTip, TiebreakerHeadToHead:
+		return true
+	}
+	return false
+}
+
+// DefaultTiebreakers returns the tiebreakers used unless a competition
+// configures its own: exact scores, then margin error
+func DefaultTiebreakers() []Tiebreaker {
+	return []Tiebreaker{TiebreakerExactScores, TiebreakerMarginError}
+}
+
+// ValidateTiebreakers checks every tiebreaker is known and listed once. An
+// empty list is valid and leaves users on the same points sharing a rank.
+func ValidateTiebreakers(tiebreakers []Tiebreaker) error {
+	seen := make(map[Tiebreaker]bool)
+	for _, tiebreaker := range tiebreakers {
+		if !tiebreaker.IsValid() {
+			return ErrInvalidTiebreaker
+		}
+		if seen[tiebreaker] {
+			return ErrDuplicateTiebreaker
+		}
+		seen[tiebreaker] = true
+	}
+	return nil
+}
+
+// PointsAward is a single award of points to a user for a match tip, a
+// bracket tie or an outright market. Key identifies what was scored within
+// the source, e.g. the prediction ID; a later award with the same key
+// replaces an earlier one.
+type PointsAward struct {
+	UserID      string
+	Source      PointsSource
+	SourceID    string // the match, bracket or outright market
+	Key         string
+	Points      int
+	ExactScore  bool      // the match tip had the exact score
+	MarginError int       // how far a round's tiebreaker margin tip was out
+	SubmittedAt time.Time // when the match tip was last submitted, if known
+	Void        bool      // the match was abandoned and the tip voided
+	Auto        bool      // the match tip was made by an auto-tip policy
+}
+
+// LatestAwards returns the latest award for each source and key, in the
+// order each was first awarded, dropping the awards a correction replaced
+func LatestAwards(awards []PointsAward) []PointsAward {
+	latest := make(map[string]int)
+	kept := make([]PointsAward, 0, len(awards))
+	for _, award := range awards {
+		key := string(award.Source) + "/" + award.Key
+		if i, ok := latest[key]; ok {
+			kept[i] = award
+			continue
+		}
+		latest[key] = len(kept)
+		kept = append(kept, award)
+	}
+	return kept
+}
+
+// LeaderboardEntry is a user's standing across every kind of tip. Correct and
+// total predictions count match tips only, and AutoTips how many of those
+// were made by an auto-tip policy. ExactScores, MarginError and FirstTipAt
+// feed the tiebreakers, with auto-tips never counting as the first tip, and
+// DecidedBy says what ranks the entry below the one above it: POINTS, a
+// tiebreaker, or TIED when they share a rank.
+type LeaderboardEntry struct {
+	Rank               int        `json:"rank"`
+	UserID             string     `json:"userId"`
+	Username           string     `json:"username"`
+	Points             int        `json:"points"`
+	MatchPoints        int        `json:"matchPoints"`
+	BracketPoints      int        `json:"bracketPoints"`
+	OutrightPoints     int        `json:"outrightPoints"`
+	CorrectPredictions int        `json:"correctPredictions"`
+	TotalPredictions   int        `json:"totalPredictions"`
+	AutoTips           int        `json:"autoTips"`
+	ExactScores        int        `json:"exactScores"`
+	MarginError        int        `json:"marginError"`
+	FirstTipAt         *time.Time `json:"firstTipAt,omitempty"`
+	DecidedBy          string     `json:"decidedBy,omitempty"`
+}
+
+// BuildLeaderboard totals each user's awards and ranks them by points,
+// highest first. Users on the same points are split by the tiebreakers in
+// order; users level on every tiebreaker share a rank and are listed by user
+// ID, so the ranking is the same every time it is built.
+func BuildLeaderboard(awards []PointsAward, tiebreakers []Tiebreaker) []*LeaderboardEntry {
+	byUser := make(map[string]*LeaderboardEntry)
+	entries := make([]*LeaderboardEntry, 0)
+	matchPoints := make(map[string]map[string]int)
+	for _, award := range LatestAwards(awards) {
+		entry, ok := byUser[award.UserID]
+		if !ok {
+			entry = &LeaderboardEntry{UserID: award.UserID, Username: award.UserID}
+			byUser[award.UserID] = entry
+			entries = append(entries, entry)
+		}
+
+		entry.Points += award.Points
+		entry.MarginError += award.MarginError
+		switch award.Source {
+		case PointsSourceMatch:
+			entry.MatchPoints += award.Points
+			if !award.Void {
+				entry.TotalPredictions++
+				if award.Auto {
+					entry.AutoTips++
+				}
+				if award.Points > 0 {
+					entry.CorrectPredictions++
+				}
+				if award.ExactScore {
+					entry.ExactScores++
+				}
+				if matchPoints[award.UserID] == nil {
+					matchPoints[award.UserID] = make(map[string]int)
+				}
+				matchPoints[award.UserID][award.SourceID] += award.Points
+			}
+			if !award.Auto && !award.SubmittedAt.IsZero() && (entry.FirstTipAt == nil || award.SubmittedAt.Before(*entry.FirstTipAt)) {
+				submittedAt := award.SubmittedAt
+				entry.FirstTipAt = &submittedAt
+			}
+		case PointsSourceBracket:
+			entry.BracketPoints += award.Points
+		case PointsSourceOutright:
+			entry.OutrightPoints += award.Points
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Points != entries[j].Points {
+			return entries[i].Points > entries[j].Points
+		}
+		return entries[i].UserID < entries[j].UserID
+	})
+
+	start := 0
+	for i := 1; i <= len(entries); i++ {
+		if i < len(entries) && entries[i].Points == entries[start].Points {
+			continue
+		}
+		breakTies(entries[start:i], tiebreakers, matchPoints)
+		if i < len(entries) {
+			entries[i].DecidedBy = DecidedByPoints
+		}
+		start = i
+	}
+
+	for i, entry := range entries {
+		entry.Rank = i + 1
+		if entry.DecidedBy == DecidedByTie {
+			entry.Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
+
+// breakTies orders a group of users level on points by applying the first
+// tiebreaker, then the rest to any users it leaves level. Each entry after
+// the first records what split it from the entry above.
+func breakTies(group []*LeaderboardEntry, tiebreakers []Tiebreaker, matchPoints map[string]map[string]int) {
+	if len(group) < 2 {
+		return
+	}
+	if len(tiebreakers) == 0 {
+		for _, entry := range group[1:] {
+			entry.DecidedBy = DecidedByTie
+		}
+		return
+	}
+
+	tiebreaker := tiebreakers[0]
+	keys := tiebreakKeys(tiebreaker, group, matchPoints)
+	sort.SliceStable(group, func(i, j int) bool {
+		return keys[group[i].UserID] < keys[group[j].UserID]
+	})
+
+	start := 0
+	for i := 1; i <= len(group); i++ {
+		if i < len(group) && keys[group[i].UserID] == keys[group[start].UserID] {
+			continue
+		}
+		breakTies(group[start:i], tiebreakers[1:], matchPoints)
+		if i < len(group) {
+			group[i].DecidedBy = string(tiebreaker)
+		}
+		start = i
+	}
+}
+
+// tiebreakKeys returns each user's standing on the tiebreaker within the
+// group, lowest first
+func tiebreakKeys(tiebreaker Tiebreaker, group []*LeaderboardEntry, matchPoints map[string]map[string]int) map[string]int64 {
+	keys := make(map[string]int64, len(group))
+	for _, entry := range group {
+		switch tiebreaker {
+		case TiebreakerExactScores:
+			keys[entry.UserID] = -int64(entry.ExactScores)
+		case TiebreakerMarginError:
+			keys[entry.UserID] = int64(entry.MarginError)
+		case TiebreakerEarliestTip:
+			keys[entry.UserID] = math.MaxInt64
+			if entry.FirstTipAt != nil {
+				keys[entry.UserID] = entry.FirstTipAt.UnixNano()
+			}
+		case TiebreakerHeadToHead:
+			wins := 0
+			for _, other := range group {
+				if other.UserID != entry.UserID && headToHead(matchPoints[entry.UserID], matchPoints[other.UserID]) > 0 {
+					wins++
+				}
+			}
+			keys[entry.UserID] = -int64(wins)
+		}
+	}
+	return keys
+}
+
+// headToHead compares two users' points on the matches they both tipped. It
+// is positive if the first user scored more.
+func headToHead(points, otherPoints map[string]int) int {
+	diff := 0
+	for matchID, userPoints := range points {
+		if otherUserPoints, ok := otherPoints[matchID]; ok {
+			diff += userPoints - otherUserPoints
+		}
+	}
+	return diff
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildLeaderboard(t *testing.T) {
+	awards := []PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 3},
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m2", Key: "p2", Points: 0},
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m3", Key: "p3", Points: 0, Void: true},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p4", Points: 1},
+		{UserID: "user2", Source: PointsSourceOutright, SourceID: "o1", Key: "t1", Points: 5},
+		{UserID: "user3", Source: PointsSourceBracket, SourceID: "b1", Key: "e1/F", Points: 3},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p4", Points: 2},
+	}
+
+	leaderboard := BuildLeaderboard(awards, DefaultTiebreakers())
+
+	if len(leaderboard) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(leaderboard))
+	}
+
+	first := leaderboard[0]
+	if first.UserID != "user2" || first.Rank != 1 || first.Points != 7 {
+		t.Errorf("expected user2 first on 7 points, got %s rank %d on %d", first.UserID, first.Rank, first.Points)
+	}
+	if first.MatchPoints != 2 || first.OutrightPoints != 5 {
+		t.Errorf("expected a later award to replace the earlier one, got %d match points", first.MatchPoints)
+	}
+
+	if leaderboard[1].UserID != "user1" || leaderboard[2].UserID != "user3" {
+		t.Errorf("expected users on the same points ordered by ID, got %s then %s", leaderboard[1].UserID, leaderboard[2].UserID)
+	}
+	if leaderboard[1].Rank != 2 || leaderboard[2].Rank != 2 {
+		t.Errorf("expected users on the same points to share a rank, got %d and %d", leaderboard[1].Rank, leaderboard[2].Rank)
+	}
+
+	user1 := leaderboard[1]
+	if user1.TotalPredictions != 2 || user1.CorrectPredictions != 1 {
+		t.Errorf("expected 1 of 2 predictions correct with voided tips excluded, got %d of %d", user1.CorrectPredictions, user1.TotalPredictions)
+	}
+	if leaderboard[2].BracketPoints != 3 {
+		t.Errorf("expected 3 bracket points, got %d", leaderboard[2].BracketPoints)
+	}
+}
+
+func TestBuildLeaderboardMarginError(t *testing.T) {
+	awards := []PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 1, MarginError: 12},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p2", Points: 1, MarginError: 4},
+		{UserID: "user3", Source: PointsSourceMatch, SourceID: "m1", Key: "p3", Points: 1, MarginError: 4},
+		{UserID: "user4", Source: PointsSourceMatch, SourceID: "m1", Key: "p4", Points: 0, MarginError: 1},
+	}
+
+	leaderboard := BuildLeaderboard(awards, []Tiebreaker{TiebreakerMarginError})
+
+	order := []string{"user2", "user3", "user1", "user4"}
+	ranks := []int{1, 1, 3, 4}
+	for i, entry := range leaderboard {
+		if entry.UserID != order[i] || entry.Rank != ranks[i] {
+			t.Errorf("expected %s ranked %d at position %d, got %s ranked %d", order[i], ranks[i], i, entry.UserID, entry.Rank)
+		}
+	}
+	if leaderboard[2].MarginError != 12 {
+		t.Errorf("expected a margin error of 12, got %d", leaderboard[2].MarginError)
+	}
+}
+
+func TestBuildLeaderboardAutoTips(t *testing.T) {
+	early := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	awards := []PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 1, SubmittedAt: early, Auto: true},
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m2", Key: "p2", Points: 1, SubmittedAt: early.Add(time.Hour)},
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m3", Key: "p3", Points: 0, Void: true, Auto: true},
+	}
+
+	leaderboard := BuildLeaderboard(awards, DefaultTiebreakers())
+
+	entry := leaderboard[0]
+	if entry.AutoTips != 1 || entry.TotalPredictions != 2 {
+		t.Errorf("expected 1 auto-tip of 2 predictions with voided tips excluded, got %d of %d", entry.AutoTips, entry.TotalPredictions)
+	}
+	if entry.Points != 2 {
+		t.Errorf("expected auto-tips to score, got %d points", entry.Points)
+	}
+	if entry.FirstTipAt == nil || !entry.FirstTipAt.Equal(early.Add(time.Hour)) {
+		t.Errorf("expected the first tip to be the user's own at %v, got %v", early.Add(time.Hour), entry.FirstTipAt)
+	}
+}
+
+func TestBuildLeaderboardTiebreakers(t *testing.T) {
+	first := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+
+	// user1, user2 and user3 are level on 4 points. user1 and user2 have one
+	// exact score each; user2 beat user1 on the match they both tipped.
+	awards := []PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 1, SubmittedAt: first},
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m2", Key: "p2", Points: 3, ExactScore: true, SubmittedAt: first},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p3", Points: 3, ExactScore: true, SubmittedAt: first.Add(time.Hour)},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m3", Key: "p4", Points: 1, SubmittedAt: first.Add(time.Hour)},
+		{UserID: "user3", Source: PointsSourceMatch, SourceID: "m3", Key: "p5", Points: 4},
+		{UserID: "user4", Source: PointsSourceMatch, SourceID: "m3", Key: "p6", Points: 1},
+	}
+
+	tests := []struct {
+		name        string
+		tiebreakers []Tiebreaker
+		order       []string
+		decidedBy   []string
+		ranks       []int
+	}{
+		{
+			"Exact scores then head-to-head",
+			[]Tiebreaker{TiebreakerExactScores, TiebreakerHeadToHead},
+			[]string{"user2", "user1", "user3", "user4"},
+			[]string{"", string(TiebreakerHeadToHead), string(TiebreakerExactScores), DecidedByPoints},
+			[]int{1, 2, 3, 4},
+		},
+		{
+			"Earliest tip",
+			[]Tiebreaker{TiebreakerEarliestTip},
+			[]string{"user1", "user2", "user3", "user4"},
+			[]string{"", string(TiebreakerEarliestTip), string(TiebreakerEarliestTip), DecidedByPoints},
+			[]int{1, 2, 3, 4},
+		},
+		{
+			"Exact scores alone",
+			[]Tiebreaker{TiebreakerExactScores},
+			[]string{"user1", "user2", "user3", "user4"},
+			[]string{"", DecidedByTie, string(TiebreakerExactScores), DecidedByPoints},
+			[]int{1, 1, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaderboard := BuildLeaderboard(awards, tt.tiebreakers)
+			for i, entry := range leaderboard {
+				if entry.UserID != tt.order[i] || entry.DecidedBy != tt.decidedBy[i] || entry.Rank != tt.ranks[i] {
+					t.Errorf("expected %s ranked %d (%q) at position %d, got %s ranked %d (%q)",
+						tt.order[i], tt.ranks[i], tt.decidedBy[i], i, entry.UserID, entry.Rank, entry.DecidedBy)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTiebreakers(t *testing.T) {
+	tests := []struct {
+		name        string
+		tiebreakers []Tiebreaker
+		expected    error
+	}{
+		{"Defaults", DefaultTiebreakers(), nil},
+		{"None", nil, nil},
+		{"Unknown tiebreaker", []Tiebreaker{"COIN_TOSS"}, ErrInvalidTiebreaker},
+		{"Listed twice", []Tiebreaker{TiebreakerHeadToHead, TiebreakerHeadToHead}, ErrDuplicateTiebreaker},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTiebreakers(tt.tiebreakers); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
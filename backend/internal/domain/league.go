@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// League errors
+var (
+	ErrLeagueNameRequired  = newFieldError("name", "LEAGUE_NAME_REQUIRED", "league name is required")
+	ErrLeagueOwnerRequired = newFieldError("ownerId", "LEAGUE_OWNER_REQUIRED", "league owner is required")
+	ErrAlreadyLeagueMember = newError("ALREADY_LEAGUE_MEMBER", "user is already a member of the league")
+	ErrNotLeagueMember     = newError("NOT_LEAGUE_MEMBER", "user is not a member of the league")
+	ErrNotLeagueOwner      = newError("NOT_LEAGUE_OWNER", "only the league owner can do this")
+	ErrUnknownInviteCode   = newFieldError("inviteCode", "UNKNOWN_INVITE_CODE", "no league has the invite code")
+	ErrLeagueOwnerLeaving  = newError("LEAGUE_OWNER_LEAVING", "the league owner cannot leave the league")
+	ErrInvalidLockCutoff   = newFieldError("lockCutoff", "INVALID_LOCK_CUTOFF", "lock cutoff must be a non-negative duration such as 15m")
+)
+
+// LeagueMember is a user who has joined a league
+type LeagueMember struct {
+	UserID   string    `json:"userId"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+// LeagueRules override how a league scores the tips everyone shares. Unset
+// rules fall back to the competition's: its scoring, locking at the global
+// cutoff, jokers counted and every match in the league's scope included.
+type LeagueRules struct {
+	Scoring    *ScoringSpec `json:"scoring,omitempty"`
+	LockCutoff string       `json:"lockCutoff,omitempty"` // e.g. "1h"; tips changed later don't count in the league
+	Jokers     *bool        `json:"jokers,omitempty"`     // false scores jokers as ordinary tips
+	RoundIDs   []string     `json:"roundIds,omitempty"`   // only matches in these rounds or listed in MatchIDs count
+	MatchIDs   []string     `json:"matchIds,omitempty"`
+
+	// AutoTip tips for members who miss a match's lock; empty leaves them
+	// without a tip. The tips count only in this league.
+	AutoTip AutoTipPolicy `json:"autoTip,omitempty"`
+}
+
+// IsSet returns true if any rule overrides how the competition scores tips.
+// An auto-tip policy does, as the league scores its auto-tips on its own.
+func (r LeagueRules) IsSet() bool {
+	return r.Scoring != nil || r.LockCutoff != "" || r.Jokers != nil ||
+		len(r.RoundIDs) > 0 || len(r.MatchIDs) > 0 || r.AutoTip != ""
+}
+
+// Validate checks that the scoring spec builds, the lock cutoff parses and
+// any auto-tip policy is supported
+func (r LeagueRules) Validate() error {
+	if r.Scoring != nil {
+		if _, err := r.Scoring.Build(); err != nil {
+			return err
+		}
+	}
+	if r.LockCutoff != "" {
+		cutoff, err := time.ParseDuration(r.LockCutoff)
+		if err != nil || cutoff < 0 {
+			return ErrInvalidLockCutoff
+		}
+	}
+	if r.AutoTip != "" && !r.AutoTip.IsValid() {
+		return ErrInvalidAutoTipPolicy
+	}
+	return nil
+}
+
+// Cutoff returns how long before kickoff tips lock for the league, or zero if
+// the league uses the global lock
+func (r LeagueRules) Cutoff() time.Duration {
+	cutoff, err := time.ParseDuration(r.LockCutoff)
+	if err != nil || cutoff < 0 {
+		return 0
+	}
+	return cutoff
+}
+
+// JokersAllowed returns true if jokers multiply points in the league
+func (r LeagueRules) JokersAllowed() bool {
+	return r.Jokers == nil || *r.Jokers
+}
+
+// Includes returns true if the match is one of the rounds or matches the
+// rules select, or if the rules don't select any
+func (r LeagueRules) Includes(match *Match) bool {
+	if len(r.RoundIDs) == 0 && len(r.MatchIDs) == 0 {
+		return true
+	}
+	for _, roundID := range r.RoundIDs {
+		if match.RoundID == roundID {
+			return true
+		}
+	}
+	for _, matchID := range r.MatchIDs {
+		if match.ID == matchID {
+			return true
+		}
+	}
+	return false
+}
+
+// League is a private tipping pool. Users join with its invite code and its
+// leaderboard counts only its members, optionally limited to one competition
+// or season.
+type League struct {
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	OwnerID       string          `json:"ownerId"`
+	InviteCode    string          `json:"inviteCode"`
+	CompetitionID string          `json:"competitionId,omitempty"`
+	SeasonID      string          `json:"seasonId,omitempty"`
+	Format        LeagueFormat    `json:"format"`
+	Members       []*LeagueMember `json:"members"`
+	Rules         LeagueRules     `json:"rules"`
+	Fixtures      []*Matchup      `json:"-"` // head-to-head pairings, served with their results
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+// NewLeague creates a new league instance with its owner as the first member
+func NewLeague(id, name, ownerID, inviteCode string, createdAt time.Time) *League {
+	return &League{
+		ID:         id,
+		Name:       name,
+		OwnerID:    ownerID,
+		InviteCode: inviteCode,
+		Format:     LeagueFormatClassic,
+		Members:    []*LeagueMember{{UserID: ownerID, JoinedAt: createdAt}},
+		CreatedAt:  createdAt,
+	}
+}
+
+// Validate checks the league's name, owner and format
+func (l *League) Validate() error {
+	if strings.TrimSpace(l.Name) == "" {
+		return ErrLeagueNameRequired
+	}
+	if l.OwnerID == "" {
+		return ErrLeagueOwnerRequired
+	}
+	if !l.Format.IsValid() {
+		return ErrInvalidLeagueFormat
+	}
+	return nil
+}
+
+// IsMember returns true if the user belongs to the league
+func (l *League) IsMember(userID string) bool {
+	for _, member := range l.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// MemberIDs returns the set of user IDs in the league
+func (l *League) MemberIDs() map[string]bool {
+	ids := make(map[string]bool, len(l.Members))
+	for _, member := range l.Members {
+		ids[member.UserID] = true
+	}
+	return ids
+}
+
+// Join adds the user to the league
+func (l *League) Join(userID string, at time.Time) error {
+	if l.IsMember(userID) {
+		return ErrAlreadyLeagueMember
+	}
+	l.Members = append(l.Members, &LeagueMember{UserID: userID, JoinedAt: at})
+	return nil
+}
+
+// Leave removes the user from the league. The owner must stay.
+func (l *League) Leave(userID string) error {
+	if userID == l.OwnerID {
+		return ErrLeagueOwnerLeaving
+	}
+	for i, member := range l.Members {
+		if member.UserID == userID {
+			l.Members = append(l.Members[:i], l.Members[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotLeagueMember
+}
+
+// Covers returns true if the match counts towards the league: it is in the
+// league's competition and season, and its rules include it
+func (l *League) Covers(match *Match) bool {
+	if l.CompetitionID != "" && match.CompetitionID != l.CompetitionID {
+		return false
+	}
+	if l.SeasonID != "" && match.SeasonID != l.SeasonID {
+		return false
+	}
+	return l.Rules.Includes(match)
+}
+
+// MemberList returns the IDs of the league's members in the order they joined
+func (l *League) MemberList() []string {
+	ids := make([]string, 0, len(l.Members))
+	for _, member := range l.Members {
+		ids = append(ids, member.UserID)
+	}
+	return ids
+}
+
+// HasInviteCode returns true if the code opens the league, ignoring case and
+// surrounding whitespace
+func (l *League) HasInviteCode(code string) bool {
+	return strings.EqualFold(l.InviteCode, strings.TrimSpace(code))
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLeagueValidate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		league   *League
+		expected error
+	}{
+		{"Valid league", NewLeague("l", "Office", "user1", "ABCD2345", now), nil},
+		{"Missing name", NewLeague("l", " ", "user1", "ABCD2345", now), ErrLeagueNameRequired},
+		{"Missing owner", NewLeague("l", "Office", "", "ABCD2345", now), ErrLeagueOwnerRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.league.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestLeagueMembership(t *testing.T) {
+	now := time.Now()
+	league := NewLeague("l", "Office", "user1", "ABCD2345", now)
+
+	if !league.IsMember("user1") {
+		t.Fatalf("expected the owner to be a member")
+	}
+	if err := league.Join("user2", now); err != nil {
+		t.Fatalf("expected user2 to join, got %v", err)
+	}
+	if err := league.Join("user2", now); err != ErrAlreadyLeagueMember {
+		t.Errorf("expected %v, got %v", ErrAlreadyLeagueMember, err)
+	}
+	if members := league.MemberIDs(); len(members) != 2 || !members["user2"] {
+		t.Errorf("expected user1 and user2, got %v", members)
+	}
+
+	if err := league.Leave("user1"); err != ErrLeagueOwnerLeaving {
+		t.Errorf("expected %v, got %v", ErrLeagueOwnerLeaving, err)
+	}
+	if err := league.Leave("user2"); err != nil {
+		t.Fatalf("expected user2 to leave, got %v", err)
+	}
+	if err := league.Leave("user2"); err != ErrNotLeagueMember {
+		t.Errorf("expected %v, got %v", ErrNotLeagueMember, err)
+	}
+	if league.IsMember("user2") {
+		t.Errorf("expected user2 to have left")
+	}
+}
+
+func TestLeagueHasInviteCode(t *testing.T) {
+	league := NewLeague("l", "Office", "user1", "ABCD2345", time.Now())
+
+	if !league.HasInviteCode(" abcd2345 ") {
+		t.Errorf("expected the code to match regardless of case and whitespace")
+	}
+	if league.HasInviteCode("ABCD2346") {
+		t.Errorf("expected a different code not to match")
+	}
+}
+
+func TestLeagueRulesValidate(t *testing.T) {
+	emptySpec := ScoringSpec{}
+
+	tests := []struct {
+		name     string
+		rules    LeagueRules
+		expected error
+	}{
+		{"No rules", LeagueRules{}, nil},
+		{"Lock cutoff", LeagueRules{LockCutoff: "90m"}, nil},
+		{"Negative lock cutoff", LeagueRules{LockCutoff: "-1h"}, ErrInvalidLockCutoff},
+		{"Unparseable lock cutoff", LeagueRules{LockCutoff: "soon"}, ErrInvalidLockCutoff},
+		{"Empty scoring", LeagueRules{Scoring: &emptySpec}, ErrEmptyScoringSpec},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestLeagueCovers(t *testing.T) {
+	league := NewLeague("l", "Office", "user1", "ABCD2345", time.Now())
+	league.CompetitionID = "comp_epl"
+
+	inRound := &Match{ID: "m1", CompetitionID: "comp_epl", RoundID: "r1"}
+	otherRound := &Match{ID: "m2", CompetitionID: "comp_epl", RoundID: "r2"}
+	otherCompetition := &Match{ID: "m3", CompetitionID: "comp_laliga", RoundID: "r1"}
+
+	if !league.Covers(inRound) || !league.Covers(otherRound) {
+		t.Errorf("expected every match in the competition to be covered without rules")
+	}
+	if league.Covers(otherCompetition) {
+		t.Errorf("expected matches from other competitions not to be covered")
+	}
+
+	league.Rules = LeagueRules{RoundIDs: []string{"r1"}, MatchIDs: []string{"m2"}}
+	if !league.Covers(inRound) || !league.Covers(otherRound) {
+		t.Errorf("expected selected rounds and matches to be covered")
+	}
+
+	league.Rules = LeagueRules{RoundIDs: []string{"r1"}}
+	if league.Covers(otherRound) {
+		t.Errorf("expected matches outside the selected rounds not to be covered")
+	}
+}
@@ -1,9 +1,31 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 )
 
+// Match errors
+var (
+	ErrInvalidMatchStatus      = newFieldError("status", "INVALID_MATCH_STATUS", "invalid match status")
+	ErrInvalidStatusTransition = newError("INVALID_STATUS_TRANSITION", "invalid match status transition")
+	ErrMatchHasNoScore         = newError("MATCH_HAS_NO_SCORE", "match cannot finish without a score")
+	ErrPredictionsLocked       = newError("PREDICTIONS_LOCKED", "predictions are locked for this match")
+	ErrNegativeGoals           = newError("NEGATIVE_GOALS", "goals cannot be negative")
+	ErrInvalidExtraTime        = newFieldError("extraTime", "INVALID_EXTRA_TIME", "extra time is only played after a draw and cannot reduce either side's goals")
+	ErrInvalidPenalties        = newFieldError("penalties", "INVALID_PENALTIES", "a penalty shootout is only held after a draw and must have a winner")
+	ErrInvalidResultBasis      = newFieldError("resultBasis", "INVALID_RESULT_BASIS", "invalid result basis")
+	ErrMatchNotFinished        = newError("MATCH_NOT_FINISHED", "only a finished match's score can be corrected")
+	ErrScoreUnchanged          = newError("SCORE_UNCHANGED", "corrected score is the same as the recorded score")
+	ErrCannotReschedule        = newError("CANNOT_RESCHEDULE", "only a scheduled or postponed match can be rescheduled")
+	ErrKickoffRequired         = newFieldError("date", "KICKOFF_REQUIRED", "new kickoff is required")
+	ErrKickoffUnchanged        = newError("KICKOFF_UNCHANGED", "new kickoff is the same as the current kickoff")
+	ErrMatchAlreadyFinished    = newError("MATCH_ALREADY_FINISHED", "match has finished, correct its score instead")
+	ErrOddsClosed              = newError("ODDS_CLOSED", "odds can only be updated while the match accepts predictions")
+	ErrUnknownMatch            = newFieldError("matchId", "UNKNOWN_MATCH", "unknown match")
+	ErrInvalidOdds             = newFieldError("odds", "INVALID_ODDS", "home and away odds, and any draw odds, must be decimal prices greater than 1")
+)
+
 // Match represents a football match in the system
 type Match struct {
 	ID          string      `json:"id"`
@@ -13,14 +35,195 @@ type Match struct {
 	Competition string      `json:"competition"`
 	Status      MatchStatus `json:"status"`
 	Score       *Score      `json:"score"`
+
+	HomeTeamID    string `json:"homeTeamId,omitempty"`
+	AwayTeamID    string `json:"awayTeamId,omitempty"`
+	CompetitionID string `json:"competitionId,omitempty"`
+	SeasonID      string `json:"seasonId,omitempty"`
+	RoundID       string `json:"roundId,omitempty"`
+
+	// TimeZone is the IANA time zone of the venue. Date is always UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Odds are the latest bookmaker prices, if any were recorded
+	Odds *MatchOdds `json:"odds,omitempty"`
+}
+
+// MatchOdds are decimal prices for each result of a match. The side with the
+// shorter price is the favourite.
+type MatchOdds struct {
+	Home      float64   `json:"home"`
+	Draw      float64   `json:"draw,omitempty"` // zero for sports without draws
+	Away      float64   `json:"away"`
+	Source    string    `json:"source,omitempty"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate checks that every decimal price is greater than 1. The draw price
+// may be left out.
+func (o MatchOdds) Validate() error {
+	if o.Home <= 1 || o.Away <= 1 || (o.Draw != 0 && o.Draw <= 1) {
+		return ErrInvalidOdds
+	}
+	return nil
+}
+
+// Favourite returns the side with the shorter price, or the home side if the
+// prices are level
+func (o MatchOdds) Favourite() MatchSide {
+	if o.Away < o.Home {
+		return MatchSideAway
+	}
+	return MatchSideHome
+}
+
+// ScoreRevision is one entry in a match's score history: a score as it was
+// recorded, or a correction made after the match finished
+type ScoreRevision struct {
+	MatchID    string    `json:"matchId"`
+	Revision   int       `json:"revision"`
+	Score      Score     `json:"score"`
+	Correction bool      `json:"correction"`
+	Reason     string    `json:"reason,omitempty"`
+	RecordedAt time.Time `json:"recordedAt"`
 }
 
-// Score represents the match score
+// Score represents the match score. HomeGoals and AwayGoals are the score
+// after regulation time. If the match went to extra time, ExtraTime holds the
+// score at the end of it, including the regulation goals; if it was decided
+// on penalties, Penalties holds the shootout tally.
 type Score struct {
+	HomeGoals int        `json:"homeGoals"`
+	AwayGoals int        `json:"awayGoals"`
+	ExtraTime *Scoreline `json:"extraTime,omitempty"`
+	Penalties *Scoreline `json:"penalties,omitempty"`
+}
+
+// Scoreline is a pair of goal tallies for one phase of a match
+type Scoreline struct {
 	HomeGoals int `json:"homeGoals"`
 	AwayGoals int `json:"awayGoals"`
 }
 
+// ResultBasis says which part of a match's score counts for tipping
+type ResultBasis string
+
+const (
+	// ResultBasisRegulation counts the score after 90 minutes
+	ResultBasisRegulation ResultBasis = "REGULATION"
+	// ResultBasisExtraTime counts the score after extra time, if played
+	ResultBasisExtraTime ResultBasis = "EXTRA_TIME"
+	// ResultBasisOverall counts the score after extra time, with any
+	// shootout deciding the winner of a match level after extra time
+	ResultBasisOverall ResultBasis = "OVERALL"
+)
+
+// IsValid returns true if the basis is one of the known result bases
+func (b ResultBasis) IsValid() bool {
+	switch b {
+	case ResultBasisRegulation, ResultBasisExtraTime, ResultBasisOverall:
+		return true
+	}
+	return false
+}
+
+// Validate checks the score's phases are consistent: extra time only follows
+// a draw and never takes goals away, and a shootout only follows a draw and
+// always has a winner
+func (s Score) Validate() error {
+	lines := []Scoreline{{HomeGoals: s.HomeGoals, AwayGoals: s.AwayGoals}}
+	if s.ExtraTime != nil {
+		lines = append(lines, *s.ExtraTime)
+	}
+	if s.Penalties != nil {
+		lines = append(lines, *s.Penalties)
+	}
+	for _, line := range lines {
+		if line.HomeGoals < 0 || line.AwayGoals < 0 {
+			return ErrNegativeGoals
+		}
+	}
+
+	if s.ExtraTime != nil {
+		if s.HomeGoals != s.AwayGoals ||
+			s.ExtraTime.HomeGoals < s.HomeGoals || s.ExtraTime.AwayGoals < s.AwayGoals {
+			return ErrInvalidExtraTime
+		}
+	}
+
+	if s.Penalties != nil {
+		beforeShootout := s.afterExtraTime()
+		if beforeShootout.HomeGoals != beforeShootout.AwayGoals || s.Penalties.HomeGoals == s.Penalties.AwayGoals {
+			return ErrInvalidPenalties
+		}
+	}
+	return nil
+}
+
+// Equal returns true if both scores have the same goals in every phase
+func (s Score) Equal(other Score) bool {
+	return s.HomeGoals == other.HomeGoals && s.AwayGoals == other.AwayGoals &&
+		scorelinesEqual(s.ExtraTime, other.ExtraTime) && scorelinesEqual(s.Penalties, other.Penalties)
+}
+
+// scorelinesEqual returns true if both phases were played with the same
+// goals, or neither was played
+func scorelinesEqual(a, b *Scoreline) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Counted returns the score that counts for tipping under the given basis.
+// The overall basis counts the goals after extra time and keeps the
+// shootout, which only decides the winner. Unknown bases count the
+// regulation score.
+func (s Score) Counted(basis ResultBasis) Score {
+	switch basis {
+	case ResultBasisExtraTime:
+		line := s.afterExtraTime()
+		return Score{HomeGoals: line.HomeGoals, AwayGoals: line.AwayGoals}
+	case ResultBasisOverall:
+		line := s.afterExtraTime()
+		return Score{HomeGoals: line.HomeGoals, AwayGoals: line.AwayGoals, Penalties: s.Penalties}
+	default:
+		return Score{HomeGoals: s.HomeGoals, AwayGoals: s.AwayGoals}
+	}
+}
+
+// Winner returns the side that won on the result the basis counts, or an
+// empty side for a draw
+func (s Score) Winner(basis ResultBasis) MatchSide {
+	return s.Counted(basis).winner()
+}
+
+// winner returns the side with more goals, or the shootout winner if the
+// goals are level and a shootout was played
+func (s Score) winner() MatchSide {
+	switch {
+	case s.HomeGoals > s.AwayGoals:
+		return MatchSideHome
+	case s.AwayGoals > s.HomeGoals:
+		return MatchSideAway
+	case s.Penalties != nil && s.Penalties.HomeGoals > s.Penalties.AwayGoals:
+		return MatchSideHome
+	case s.Penalties != nil && s.Penalties.AwayGoals > s.Penalties.HomeGoals:
+		return MatchSideAway
+	default:
+		return ""
+	}
+}
+
+// afterExtraTime returns the score after extra time, or after regulation if
+// no extra time was played
+func (s Score) afterExtraTime() Scoreline {
+	if s.ExtraTime != nil {
+		return *s.ExtraTime
+	}
+	return Scoreline{HomeGoals: s.HomeGoals, AwayGoals: s.AwayGoals}
+}
+
 // MatchStatus represents the current status of a match
 type MatchStatus string
 
@@ -29,26 +232,152 @@ const (
 	MatchStatusLive      MatchStatus = "LIVE"
 	MatchStatusFinished  MatchStatus = "FINISHED"
 	MatchStatusCancelled MatchStatus = "CANCELLED"
+	MatchStatusPostponed MatchStatus = "POSTPONED"
+	MatchStatusAbandoned MatchStatus = "ABANDONED"
 )
 
+// statusTransitions lists the statuses a match may move to from each status.
+// Finished, cancelled and abandoned matches are terminal.
+var statusTransitions = map[MatchStatus][]MatchStatus{
+	MatchStatusScheduled: {MatchStatusLive, MatchStatusFinished, MatchStatusPostponed, MatchStatusCancelled, MatchStatusAbandoned},
+	MatchStatusLive:      {MatchStatusFinished, MatchStatusPostponed, MatchStatusAbandoned},
+	MatchStatusPostponed: {MatchStatusScheduled, MatchStatusLive, MatchStatusCancelled},
+}
+
+// IsValid returns true if the status is one of the known match statuses
+func (s MatchStatus) IsValid() bool {
+	switch s {
+	case MatchStatusScheduled, MatchStatusLive, MatchStatusFinished,
+		MatchStatusCancelled, MatchStatusPostponed, MatchStatusAbandoned:
+		return true
+	}
+	return false
+}
+
 // NewMatch creates a new match instance
 func NewMatch(id, homeTeam, awayTeam string, date time.Time, competition string) *Match {
 	return &Match{
 		ID:          id,
 		HomeTeam:    homeTeam,
 		AwayTeam:    awayTeam,
-		Date:        date,
+		Date:        date.UTC(),
 		Competition: competition,
 		Status:      MatchStatusScheduled,
 	}
 }
 
-// UpdateScore updates the match score
+// UpdateScore updates the match score after regulation time
 func (m *Match) UpdateScore(homeGoals, awayGoals int) {
-	m.Score = &Score{
+	m.SetScore(Score{
 		HomeGoals: homeGoals,
 		AwayGoals: awayGoals,
+	})
+}
+
+// SetScore replaces the match score, including any extra time and shootout
+func (m *Match) SetScore(score Score) {
+	m.Score = &score
+}
+
+// CorrectScore fixes the score of a finished match that was recorded wrongly
+func (m *Match) CorrectScore(score Score) error {
+	if !m.IsFinished() {
+		return ErrMatchNotFinished
+	}
+	if err := score.Validate(); err != nil {
+		return err
 	}
+	if m.Score != nil && m.Score.Equal(score) {
+		return ErrScoreUnchanged
+	}
+	m.SetScore(score)
+	return nil
+}
+
+// Reschedule moves the match's kickoff. Only matches that have not started
+// can move, and their tips lock relative to the new kickoff.
+func (m *Match) Reschedule(date time.Time) error {
+	if !m.AcceptsPredictions() {
+		return ErrCannotReschedule
+	}
+	if date.IsZero() {
+		return ErrKickoffRequired
+	}
+	if date.Equal(m.Date) {
+		return ErrKickoffUnchanged
+	}
+	m.Date = date.UTC()
+	return nil
+}
+
+// CanTransitionTo returns true if the match may move to the given status
+func (m *Match) CanTransitionTo(status MatchStatus) bool {
+	for _, allowed := range statusTransitions[m.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus moves the match to a new status, enforcing the allowed transitions
+func (m *Match) ChangeStatus(status MatchStatus) error {
+	if !status.IsValid() {
+		return ErrInvalidMatchStatus
+	}
+	if !m.CanTransitionTo(status) {
+		return ErrInvalidStatusTransition
+	}
+	if status == MatchStatusFinished && m.Score == nil {
+		return ErrMatchHasNoScore
+	}
+	m.Status = status
+	return nil
+}
+
+// LockTime returns when tipping closes for the match: kickoff less the cutoff
+func (m *Match) LockTime(cutoff time.Duration) time.Time {
+	return m.Date.Add(-cutoff)
+}
+
+// IsLocked returns true if tips can no longer be submitted or changed at the
+// given time, either because of the match status or because the lock has passed
+func (m *Match) IsLocked(now time.Time, cutoff time.Duration) bool {
+	return !m.AcceptsPredictions() || !now.Before(m.LockTime(cutoff))
+}
+
+// RoundKey identifies the tipping round the match belongs to. Matches that
+// have not been assigned a round fall back to their competition's ISO week.
+func (m *Match) RoundKey() string {
+	if m.RoundID != "" {
+		return m.RoundID
+	}
+	year, week := m.Date.UTC().ISOWeek()
+	return fmt.Sprintf("%s/%d-W%02d", m.CompetitionKey(), year, week)
+}
+
+// MarginMatch returns the match whose margin breaks ties in winner tipping:
+// the first to kick off among the matches of a round, with the lowest ID
+// breaking a shared kickoff. It returns nil if there are no matches.
+func MarginMatch(roundMatches []*Match) *Match {
+	var first *Match
+	for _, match := range roundMatches {
+		if first == nil || match.Date.Before(first.Date) ||
+			(match.Date.Equal(first.Date) && match.ID < first.ID) {
+			first = match
+		}
+	}
+	return first
+}
+
+// CompetitionKey identifies the competition the match is played in: its
+// competition ID, or its competition name for matches created before
+// competitions were managed entities
+func (m *Match) CompetitionKey() string {
+	if m.CompetitionID != "" {
+		return m.CompetitionID
+	}
+	return m.Competition
 }
 
 // IsFinished returns true if the match is finished
@@ -60,3 +389,32 @@ func (m *Match) IsFinished() bool {
 func (m *Match) IsLive() bool {
 	return m.Status == MatchStatusLive
 }
+
+// IsAbandoned returns true if the match was abandoned. Predictions for an
+// abandoned match are void and earn no points.
+func (m *Match) IsAbandoned() bool {
+	return m.Status == MatchStatusAbandoned
+}
+
+// AcceptsPredictions returns true if tips can still be submitted for the match.
+// Postponed matches reopen tipping until their new kickoff.
+func (m *Match) AcceptsPredictions() bool {
+	return m.Status == MatchStatusScheduled || m.Status == MatchStatusPostponed
+}
+
+// WinnerID returns the ID of the team that won the match overall, counting
+// extra time and any penalty shootout. It is empty if the match has no
+// score, ended level, or its teams are not registered.
+func (m *Match) WinnerID() string {
+	if m.Score == nil {
+		return ""
+	}
+	switch m.Score.Winner(ResultBasisOverall) {
+	case MatchSideHome:
+		return m.HomeTeamID
+	case MatchSideAway:
+		return m.AwayTeamID
+	default:
+		return ""
+	}
+}
@@ -57,6 +57,76 @@ func TestUpdateScore(t *testing.T) {
 	}
 }
 
+func TestScoreValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    Score
+		expected error
+	}{
+		{"Regulation only", Score{HomeGoals: 2, AwayGoals: 1}, nil},
+		{"Extra time after a draw", Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 2, AwayGoals: 1}}, nil},
+		{"Penalties after extra time", Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 1}, Penalties: &Scoreline{HomeGoals: 4, AwayGoals: 3}}, nil},
+		{"Penalties straight after regulation", Score{HomeGoals: 0, AwayGoals: 0, Penalties: &Scoreline{HomeGoals: 5, AwayGoals: 6}}, nil},
+		{"Negative goals", Score{HomeGoals: -1, AwayGoals: 0}, ErrNegativeGoals},
+		{"Extra time after a win", Score{HomeGoals: 2, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 2, AwayGoals: 1}}, ErrInvalidExtraTime},
+		{"Extra time loses goals", Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 0, AwayGoals: 1}}, ErrInvalidExtraTime},
+		{"Penalties after extra-time winner", Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 2, AwayGoals: 1}, Penalties: &Scoreline{HomeGoals: 4, AwayGoals: 3}}, ErrInvalidPenalties},
+		{"Level shootout", Score{HomeGoals: 1, AwayGoals: 1, Penalties: &Scoreline{HomeGoals: 4, AwayGoals: 4}}, ErrInvalidPenalties},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.score.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestScoreCounted(t *testing.T) {
+	// 1-1 after 90 minutes, 2-2 after extra time, home side wins 5-4 on penalties
+	score := Score{
+		HomeGoals: 1,
+		AwayGoals: 1,
+		ExtraTime: &Scoreline{HomeGoals: 2, AwayGoals: 2},
+		Penalties: &Scoreline{HomeGoals: 5, AwayGoals: 4},
+	}
+
+	tests := []struct {
+		basis    ResultBasis
+		expected Score
+	}{
+		{ResultBasisRegulation, Score{HomeGoals: 1, AwayGoals: 1}},
+		{ResultBasisExtraTime, Score{HomeGoals: 2, AwayGoals: 2}},
+		{ResultBasisOverall, Score{HomeGoals: 2, AwayGoals: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.basis), func(t *testing.T) {
+			got := score.Counted(tt.basis)
+			if got.HomeGoals != tt.expected.HomeGoals || got.AwayGoals != tt.expected.AwayGoals {
+				t.Errorf("expected %d-%d, got %d-%d", tt.expected.HomeGoals, tt.expected.AwayGoals, got.HomeGoals, got.AwayGoals)
+			}
+		})
+	}
+
+	// The shootout decides only the overall winner, never the goals
+	winners := map[ResultBasis]MatchSide{ResultBasisRegulation: "", ResultBasisExtraTime: "", ResultBasisOverall: MatchSideHome}
+	for basis, expected := range winners {
+		if got := score.Winner(basis); got != expected {
+			t.Errorf("expected winner %q under %s, got %q", expected, basis, got)
+		}
+	}
+
+	// A match settled in regulation counts the same under every basis
+	settled := Score{HomeGoals: 2, AwayGoals: 0}
+	for _, basis := range []ResultBasis{ResultBasisRegulation, ResultBasisExtraTime, ResultBasisOverall} {
+		if got := settled.Counted(basis); got.HomeGoals != 2 || got.AwayGoals != 0 {
+			t.Errorf("expected 2-0 under %s, got %d-%d", basis, got.HomeGoals, got.AwayGoals)
+		}
+	}
+}
+
 func TestIsFinished(t *testing.T) {
 	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
 
@@ -86,3 +156,205 @@ func TestIsLive(t *testing.T) {
 		t.Errorf("expected match to be live")
 	}
 }
+
+func TestChangeStatus(t *testing.T) {
+	// Test case 1: Scheduled match goes live
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	if err := match.ChangeStatus(MatchStatusLive); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if match.Status != MatchStatusLive {
+		t.Errorf("expected Status %v, got %v", MatchStatusLive, match.Status)
+	}
+
+	// Test case 2: Match cannot finish without a score
+	if err := match.ChangeStatus(MatchStatusFinished); err != ErrMatchHasNoScore {
+		t.Errorf("expected error %v, got %v", ErrMatchHasNoScore, err)
+	}
+
+	// Test case 3: Match finishes once a score is recorded
+	match.UpdateScore(1, 0)
+	if err := match.ChangeStatus(MatchStatusFinished); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	// Test case 4: Finished match is terminal
+	if err := match.ChangeStatus(MatchStatusLive); err != ErrInvalidStatusTransition {
+		t.Errorf("expected error %v, got %v", ErrInvalidStatusTransition, err)
+	}
+
+	// Test case 5: Unknown status
+	if err := match.ChangeStatus(MatchStatus("HALF_TIME")); err != ErrInvalidMatchStatus {
+		t.Errorf("expected error %v, got %v", ErrInvalidMatchStatus, err)
+	}
+}
+
+func TestAcceptsPredictions(t *testing.T) {
+	testCases := []struct {
+		status   MatchStatus
+		expected bool
+	}{
+		{MatchStatusScheduled, true},
+		{MatchStatusPostponed, true},
+		{MatchStatusLive, false},
+		{MatchStatusFinished, false},
+		{MatchStatusAbandoned, false},
+		{MatchStatusCancelled, false},
+	}
+
+	for _, tc := range testCases {
+		match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+		match.Status = tc.status
+		if match.AcceptsPredictions() != tc.expected {
+			t.Errorf("expected AcceptsPredictions %v for status %v", tc.expected, tc.status)
+		}
+	}
+}
+
+func TestRoundKey(t *testing.T) {
+	saturday := time.Date(2025, 6, 21, 15, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 6, 22, 15, 0, 0, 0, time.UTC)
+	nextWeek := time.Date(2025, 6, 28, 15, 0, 0, 0, time.UTC)
+
+	first := NewMatch("match1", "Team A", "Team B", saturday, "Premier League")
+	second := NewMatch("match2", "Team C", "Team D", sunday, "Premier League")
+	later := NewMatch("match3", "Team A", "Team C", nextWeek, "Premier League")
+	otherComp := NewMatch("match4", "Team E", "Team F", saturday, "La Liga")
+
+	if first.RoundKey() != second.RoundKey() {
+		t.Errorf("expected matches in the same week to share a round, got %v and %v", first.RoundKey(), second.RoundKey())
+	}
+	if first.RoundKey() == later.RoundKey() {
+		t.Errorf("expected matches a week apart to be in different rounds")
+	}
+	if first.RoundKey() == otherComp.RoundKey() {
+		t.Errorf("expected matches in different competitions to be in different rounds")
+	}
+
+	// An assigned round takes precedence over the week
+	later.RoundID = "round1"
+	first.RoundID = "round1"
+	if first.RoundKey() != "round1" || later.RoundKey() != first.RoundKey() {
+		t.Errorf("expected matches in the same round to share a key, got %v and %v", first.RoundKey(), later.RoundKey())
+	}
+}
+
+func TestIsLocked(t *testing.T) {
+	kickoff := time.Date(2025, 6, 21, 15, 0, 0, 0, time.UTC)
+	match := NewMatch("match123", "Team A", "Team B", kickoff, "Premier League")
+
+	// Test case 1: Lock time is kickoff less the cutoff
+	if !match.LockTime(15 * time.Minute).Equal(kickoff.Add(-15 * time.Minute)) {
+		t.Errorf("expected lock time 15 minutes before kickoff, got %v", match.LockTime(15*time.Minute))
+	}
+
+	// Test case 2: Open before kickoff
+	if match.IsLocked(kickoff.Add(-time.Minute), 0) {
+		t.Errorf("expected match to be open a minute before kickoff")
+	}
+
+	// Test case 3: Locked at kickoff
+	if !match.IsLocked(kickoff, 0) {
+		t.Errorf("expected match to be locked at kickoff")
+	}
+
+	// Test case 4: Locked inside the cutoff window
+	if !match.IsLocked(kickoff.Add(-time.Minute), 15*time.Minute) {
+		t.Errorf("expected match to be locked inside the cutoff")
+	}
+
+	// Test case 5: Locked by status regardless of time
+	match.Status = MatchStatusLive
+	if !match.IsLocked(kickoff.Add(-time.Hour), 0) {
+		t.Errorf("expected live match to be locked")
+	}
+}
+
+func TestWinnerID(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    *Score
+		expected string
+	}{
+		{"No score", nil, ""},
+		{"Home win", &Score{HomeGoals: 2, AwayGoals: 1}, "team_a"},
+		{"Draw", &Score{HomeGoals: 1, AwayGoals: 1}, ""},
+		{"Won in extra time", &Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 2}}, "team_b"},
+		{"Won on penalties", &Score{HomeGoals: 0, AwayGoals: 0, Penalties: &Scoreline{HomeGoals: 4, AwayGoals: 3}}, "team_a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := &Match{ID: "m", HomeTeamID: "team_a", AwayTeamID: "team_b", Score: tt.score}
+			if winner := match.WinnerID(); winner != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, winner)
+			}
+		})
+	}
+}
+
+func TestMarginMatch(t *testing.T) {
+	friday := time.Date(2026, 3, 13, 8, 40, 0, 0, time.UTC)
+	saturday := friday.Add(24 * time.Hour)
+
+	if MarginMatch(nil) != nil {
+		t.Errorf("expected no margin match for an empty round")
+	}
+
+	roundMatches := []*Match{
+		{ID: "m3", Date: saturday},
+		{ID: "m2", Date: friday},
+		{ID: "m1", Date: friday},
+	}
+	if marginMatch := MarginMatch(roundMatches); marginMatch.ID != "m1" {
+		t.Errorf("expected the first kickoff, lowest ID first, got %s", marginMatch.ID)
+	}
+}
+
+func TestCorrectScore(t *testing.T) {
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	match.UpdateScore(2, 1)
+
+	if err := match.CorrectScore(Score{HomeGoals: 2, AwayGoals: 2}); err != ErrMatchNotFinished {
+		t.Errorf("expected ErrMatchNotFinished, got %v", err)
+	}
+
+	match.Status = MatchStatusFinished
+	if err := match.CorrectScore(Score{HomeGoals: 2, AwayGoals: 1}); err != ErrScoreUnchanged {
+		t.Errorf("expected ErrScoreUnchanged, got %v", err)
+	}
+	if err := match.CorrectScore(Score{HomeGoals: -1, AwayGoals: 1}); err == nil {
+		t.Errorf("expected an invalid score to be rejected")
+	}
+	if err := match.CorrectScore(Score{HomeGoals: 2, AwayGoals: 2}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if match.Score.AwayGoals != 2 {
+		t.Errorf("expected corrected AwayGoals 2, got %d", match.Score.AwayGoals)
+	}
+}
+
+func TestReschedule(t *testing.T) {
+	kickoff := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	match := NewMatch("match123", "Team A", "Team B", kickoff, "Premier League")
+
+	if err := match.Reschedule(time.Time{}); err != ErrKickoffRequired {
+		t.Errorf("expected ErrKickoffRequired, got %v", err)
+	}
+	if err := match.Reschedule(kickoff); err != ErrKickoffUnchanged {
+		t.Errorf("expected ErrKickoffUnchanged, got %v", err)
+	}
+
+	moved := kickoff.Add(48 * time.Hour)
+	if err := match.Reschedule(moved); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !match.LockTime(time.Hour).Equal(moved.Add(-time.Hour)) {
+		t.Errorf("expected tips to lock an hour before the new kickoff, got %v", match.LockTime(time.Hour))
+	}
+
+	match.Status = MatchStatusLive
+	if err := match.Reschedule(moved.Add(time.Hour)); err != ErrCannotReschedule {
+		t.Errorf("expected ErrCannotReschedule, got %v", err)
+	}
+}
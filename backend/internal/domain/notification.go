@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// NotificationType says what a notification is about
+type NotificationType string
+
+const (
+	// NotificationMatchRescheduled tells a tipper a match they tipped has moved
+	NotificationMatchRescheduled NotificationType = "MATCH_RESCHEDULED"
+)
+
+// Notification is a message to a user about something that affects their tips
+type Notification struct {
+	ID      string           `json:"id"`
+	UserID  string           `json:"userId"`
+	Type    NotificationType `json:"type"`
+	MatchID string           `json:"matchId,omitempty"`
+	Message string           `json:"message"`
+	SentAt  time.Time        `json:"sentAt"`
+}
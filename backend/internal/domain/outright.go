@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Outright errors
+var (
+	ErrMarketNameRequired     = newFieldError("name", "MARKET_NAME_REQUIRED", "market name is required")
+	ErrMarketDeadlineRequired = newFieldError("deadline", "MARKET_DEADLINE_REQUIRED", "market deadline is required")
+	ErrInvalidSelections      = newFieldError("selections", "INVALID_SELECTIONS", "a market must ask for at least one team")
+	ErrInvalidPointsPerPick   = newFieldError("pointsPerPick", "INVALID_POINTS_PER_PICK", "points per correct pick must be positive")
+	ErrMarketSettled          = newError("MARKET_SETTLED", "market has already been settled")
+	ErrOutrightTipsLocked     = newError("OUTRIGHT_TIPS_LOCKED", "outright tips are locked for this market")
+	ErrMarketNotClosed        = newError("MARKET_NOT_CLOSED", "market cannot be settled before its deadline")
+	ErrInvalidOutrightPicks   = newFieldError("picks", "INVALID_OUTRIGHT_PICKS", "picks must name the number of different teams the market asks for")
+)
+
+// MarketStatus represents where an outright market is in its lifecycle
+type MarketStatus string
+
+const (
+	MarketStatusOpen    MarketStatus = "OPEN"
+	MarketStatusSettled MarketStatus = "SETTLED"
+)
+
+// OutrightMarket is a season-long question about a competition, such as the
+// league winner, the relegated teams or the top four. Users pick as many
+// teams as the market has Selections, in any order, before the deadline.
+// Each pick that is in the settled result earns PointsPerPick.
+type OutrightMarket struct {
+	ID            string       `json:"id"`
+	CompetitionID string       `json:"competitionId"`
+	SeasonID      string       `json:"seasonId,omitempty"`
+	Name          string       `json:"name"`
+	Selections    int          `json:"selections"`
+	PointsPerPick int          `json:"pointsPerPick"`
+	Deadline      time.Time    `json:"deadline"`
+	Status        MarketStatus `json:"status"`
+	Result        []string     `json:"result,omitempty"`
+	SettledAt     *time.Time   `json:"settledAt,omitempty"`
+}
+
+// NewOutrightMarket creates a new open outright market
+func NewOutrightMarket(id, competitionID, name string, selections, pointsPerPick int, deadline time.Time) *OutrightMarket {
+	return &OutrightMarket{
+		ID:            id,
+		CompetitionID: competitionID,
+		Name:          name,
+		Selections:    selections,
+		PointsPerPick: pointsPerPick,
+		Deadline:      deadline,
+		Status:        MarketStatusOpen,
+	}
+}
+
+// Validate checks the market's name, deadline, selections and points
+func (m *OutrightMarket) Validate() error {
+	if strings.TrimSpace(m.Name) == "" {
+		return ErrMarketNameRequired
+	}
+	if m.Deadline.IsZero() {
+		return ErrMarketDeadlineRequired
+	}
+	if m.Selections < 1 {
+		return ErrInvalidSelections
+	}
+	if m.PointsPerPick < 1 {
+		return ErrInvalidPointsPerPick
+	}
+	return nil
+}
+
+// IsLocked returns true once tips can no longer be submitted
+func (m *OutrightMarket) IsLocked(now time.Time) bool {
+	return m.Status != MarketStatusOpen || !now.Before(m.Deadline)
+}
+
+// IsSettled returns true once the market's result is known
+func (m *OutrightMarket) IsSettled() bool {
+	return m.Status == MarketStatusSettled
+}
+
+// ValidatePicks checks the picks name exactly the number of different teams
+// the market asks for
+func (m *OutrightMarket) ValidatePicks(picks []string) error {
+	if len(picks) != m.Selections {
+		return ErrInvalidOutrightPicks
+	}
+	seen := make(map[string]bool, len(picks))
+	for _, pick := range picks {
+		if pick == "" || seen[pick] {
+			return ErrInvalidOutrightPicks
+		}
+		seen[pick] = true
+	}
+	return nil
+}
+
+// Settle records the market's result once its deadline has passed
+func (m *OutrightMarket) Settle(result []string, now time.Time) error {
+	if m.IsSettled() {
+		return ErrMarketSettled
+	}
+	if now.Before(m.Deadline) {
+		return ErrMarketNotClosed
+	}
+	if err := m.ValidatePicks(result); err != nil {
+		return err
+	}
+	m.Status = MarketStatusSettled
+	m.Result = result
+	m.SettledAt = &now
+	return nil
+}
+
+// CorrectPicks returns how many of the picks are in the settled result
+func (m *OutrightMarket) CorrectPicks(picks []string) int {
+	correct := 0
+	for _, pick := range picks {
+		for _, team := range m.Result {
+			if pick == team {
+				correct++
+				break
+			}
+		}
+	}
+	return correct
+}
+
+// OutrightTip is a user's picks for an outright market. Points is set once
+// the market is settled.
+type OutrightTip struct {
+	ID          string    `json:"id"`
+	MarketID    string    `json:"marketId"`
+	UserID      string    `json:"userId"`
+	Picks       []string  `json:"picks"`
+	SubmittedAt time.Time `json:"submittedAt"`
+	Points      int       `json:"points"`
+	Settled     bool      `json:"settled"`
+}
+
+// NewOutrightTip creates a new outright tip instance
+func NewOutrightTip(id, marketID, userID string, picks []string, submittedAt time.Time) *OutrightTip {
+	return &OutrightTip{
+		ID:          id,
+		MarketID:    marketID,
+		UserID:      userID,
+		Picks:       picks,
+		SubmittedAt: submittedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutrightMarketValidate(t *testing.T) {
+	deadline := time.Date(2025, 8, 15, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		market   *OutrightMarket
+		expected error
+	}{
+		{"Valid market", NewOutrightMarket("m", "comp_epl", "Relegated", 3, 5, deadline), nil},
+		{"Missing name", NewOutrightMarket("m", "comp_epl", " ", 1, 5, deadline), ErrMarketNameRequired},
+		{"Missing deadline", NewOutrightMarket("m", "comp_epl", "Winner", 1, 5, time.Time{}), ErrMarketDeadlineRequired},
+		{"No selections", NewOutrightMarket("m", "comp_epl", "Winner", 0, 5, deadline), ErrInvalidSelections},
+		{"No points", NewOutrightMarket("m", "comp_epl", "Winner", 1, 0, deadline), ErrInvalidPointsPerPick},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.market.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestOutrightMarketValidatePicks(t *testing.T) {
+	market := NewOutrightMarket("m", "comp_epl", "Top four", 2, 5, time.Now())
+
+	tests := []struct {
+		name     string
+		picks    []string
+		expected error
+	}{
+		{"Right number of teams", []string{"team_a", "team_b"}, nil},
+		{"Too few teams", []string{"team_a"}, ErrInvalidOutrightPicks},
+		{"Same team twice", []string{"team_a", "team_a"}, ErrInvalidOutrightPicks},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := market.ValidatePicks(tt.picks); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestOutrightMarketSettle(t *testing.T) {
+	deadline := time.Date(2026, 5, 24, 0, 0, 0, 0, time.UTC)
+	market := NewOutrightMarket("m", "comp_epl", "Relegated", 3, 5, deadline)
+
+	if !market.IsLocked(deadline) {
+		t.Errorf("expected market to be locked at the deadline")
+	}
+	if err := market.Settle([]string{"team_a", "team_b", "team_c"}, deadline.Add(-time.Hour)); err != ErrMarketNotClosed {
+		t.Errorf("expected %v, got %v", ErrMarketNotClosed, err)
+	}
+	if err := market.Settle([]string{"team_a", "team_b"}, deadline); err != ErrInvalidOutrightPicks {
+		t.Errorf("expected %v, got %v", ErrInvalidOutrightPicks, err)
+	}
+	if err := market.Settle([]string{"team_a", "team_b", "team_c"}, deadline); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !market.IsSettled() || market.SettledAt == nil {
+		t.Errorf("expected market to be settled")
+	}
+	if err := market.Settle([]string{"team_a", "team_b", "team_c"}, deadline); err != ErrMarketSettled {
+		t.Errorf("expected %v, got %v", ErrMarketSettled, err)
+	}
+
+	if correct := market.CorrectPicks([]string{"team_c", "team_d", "team_a"}); correct != 2 {
+		t.Errorf("expected 2 correct picks in any order, got %d", correct)
+	}
+}
@@ -4,48 +4,227 @@ import (
 	"time"
 )
 
-// Prediction represents a user's prediction for a match
+// JokerMultiplier is applied to the points of a user's joker tip
+const JokerMultiplier = 2
+
+// Prediction errors
+var (
+	ErrJokerAlreadyPlayed  = newError("JOKER_ALREADY_PLAYED", "joker already played this round")
+	ErrPredictionExists    = newError("PREDICTION_EXISTS", "prediction already exists for this match")
+	ErrPredictionWithdrawn = newError("PREDICTION_WITHDRAWN", "prediction has been withdrawn")
+	ErrWrongPredictionType = newError("WRONG_PREDICTION_TYPE", "prediction does not suit the competition's tipping mode")
+	ErrInvalidWinnerPick   = newFieldError("winner", "INVALID_WINNER_PICK", "winner must be HOME or AWAY")
+	ErrInvalidMargin       = newFieldError("margin", "INVALID_MARGIN", "margin must be at least 1")
+	ErrMarginRequired      = newFieldError("margin", "MARGIN_REQUIRED", "margin is required for the first match of the round")
+)
+
+// MatchSide identifies one of the two teams in a match
+type MatchSide string
+
+const (
+	MatchSideHome MatchSide = "HOME"
+	MatchSideAway MatchSide = "AWAY"
+)
+
+// IsValid returns true if the side is home or away
+func (s MatchSide) IsValid() bool {
+	return s == MatchSideHome || s == MatchSideAway
+}
+
+// Opposite returns the other side of the match
+func (s MatchSide) Opposite() MatchSide {
+	if s == MatchSideAway {
+		return MatchSideHome
+	}
+	return MatchSideAway
+}
+
+// Prediction represents a user's prediction for a match: either the exact
+// score, or a winner plus an optional winning margin
 type Prediction struct {
 	ID        string    `json:"id"`
 	UserID    string    `json:"userId"`
 	MatchID   string    `json:"matchId"`
 	HomeGoals int       `json:"homeGoals"`
 	AwayGoals int       `json:"awayGoals"`
+	Winner    MatchSide `json:"winner,omitempty"`
+	Margin    *int      `json:"margin,omitempty"`
+	Joker     bool      `json:"joker"`
+	Auto      bool      `json:"auto,omitempty"`     // made by the league's auto-tip policy when the user missed the lock
+	LeagueID  string    `json:"leagueId,omitempty"` // the league an auto-tip was made for, the only one it counts in
+	Revision  int       `json:"revision"`
 	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
 	Points    int       `json:"points"`
+
+	WithdrawnAt *time.Time `json:"withdrawnAt,omitempty"`
+}
+
+// PredictionRevision is one version of a prediction in its history
+type PredictionRevision struct {
+	PredictionID string    `json:"predictionId"`
+	Revision     int       `json:"revision"`
+	HomeGoals    int       `json:"homeGoals"`
+	AwayGoals    int       `json:"awayGoals"`
+	Winner       MatchSide `json:"winner,omitempty"`
+	Margin       *int      `json:"margin,omitempty"`
+	Joker        bool      `json:"joker"`
+	Withdrawn    bool      `json:"withdrawn"`
+	RecordedAt   time.Time `json:"recordedAt"`
 }
 
 // NewPrediction creates a new prediction instance
 func NewPrediction(id, userID, matchID string, homeGoals, awayGoals int) *Prediction {
+	now := time.Now()
 	return &Prediction{
 		ID:        id,
 		UserID:    userID,
 		MatchID:   matchID,
 		HomeGoals: homeGoals,
 		AwayGoals: awayGoals,
-		CreatedAt: time.Now(),
+		Revision:  1,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// NewWinnerPrediction creates a prediction of the winner, with an optional
+// winning margin
+func NewWinnerPrediction(id, userID, matchID string, winner MatchSide, margin *int) *Prediction {
+	prediction := NewPrediction(id, userID, matchID, 0, 0)
+	prediction.Winner = winner
+	prediction.Margin = margin
+	return prediction
+}
+
+// IsWinnerTip returns true if the prediction picks a winner rather than a score
+func (p *Prediction) IsWinnerTip() bool {
+	return p.Winner != ""
+}
+
+// ValidateFor checks the prediction is the kind the tipping mode takes. In
+// winner mode the winner must be home or away and any margin at least 1.
+func (p *Prediction) ValidateFor(mode TippingMode) error {
+	if mode != TippingModeWinnerMargin {
+		if p.IsWinnerTip() || p.Margin != nil {
+			return ErrWrongPredictionType
+		}
+		return nil
+	}
+	if !p.Winner.IsValid() {
+		return ErrInvalidWinnerPick
+	}
+	if p.Margin != nil && *p.Margin < 1 {
+		return ErrInvalidMargin
 	}
+	return nil
 }
 
-// CalculatePoints calculates the points earned for this prediction
-func (p *Prediction) CalculatePoints(match *Match) int {
-	if match.Score == nil {
-		return 0
+// Amend changes the predicted score and joker flag, starting a new revision.
+// An amended auto-tip becomes the user's own, counting everywhere.
+func (p *Prediction) Amend(homeGoals, awayGoals int, joker bool, at time.Time) {
+	p.HomeGoals = homeGoals
+	p.AwayGoals = awayGoals
+	p.Winner = ""
+	p.Margin = nil
+	p.Joker = joker
+	p.Auto = false
+	p.LeagueID = ""
+	p.Revision++
+	p.UpdatedAt = at
+}
+
+// AmendWinner changes the predicted winner, margin and joker flag, starting
+// a new revision
+func (p *Prediction) AmendWinner(winner MatchSide, margin *int, joker bool, at time.Time) {
+	p.Amend(0, 0, joker, at)
+	p.Winner = winner
+	p.Margin = margin
+}
+
+// Withdraw pulls the prediction, starting a final revision. A withdrawn
+// prediction is kept for history but no longer scored.
+func (p *Prediction) Withdraw(at time.Time) error {
+	if p.IsWithdrawn() {
+		return ErrPredictionWithdrawn
 	}
+	p.Revision++
+	p.UpdatedAt = at
+	p.WithdrawnAt = &at
+	return nil
+}
+
+// IsWithdrawn reports whether the prediction has been withdrawn
+func (p *Prediction) IsWithdrawn() bool {
+	return p.WithdrawnAt != nil
+}
 
-	// Exact score prediction
-	if p.HomeGoals == match.Score.HomeGoals && p.AwayGoals == match.Score.AwayGoals {
-		return 3
+// CurrentRevision returns the prediction's current state as a history entry
+func (p *Prediction) CurrentRevision() PredictionRevision {
+	return PredictionRevision{
+		PredictionID: p.ID,
+		Revision:     p.Revision,
+		HomeGoals:    p.HomeGoals,
+		AwayGoals:    p.AwayGoals,
+		Winner:       p.Winner,
+		Margin:       p.Margin,
+		Joker:        p.Joker,
+		Withdrawn:    p.IsWithdrawn(),
+		RecordedAt:   p.UpdatedAt,
 	}
+}
 
-	// Correct result (win/draw/loss)
-	predictionResult := getResult(p.HomeGoals, p.AwayGoals)
-	actualResult := getResult(match.Score.HomeGoals, match.Score.AwayGoals)
-	if predictionResult == actualResult {
-		return 1
+// Multiplier returns the factor applied to the points this prediction earns
+func (p *Prediction) Multiplier() int {
+	if p.Joker {
+		return JokerMultiplier
 	}
+	return 1
+}
 
-	return 0
+// CalculatePoints calculates the points earned for this prediction using the
+// default scoring scheme, against the part of the score the basis counts
+func (p *Prediction) CalculatePoints(match *Match, basis ResultBasis) int {
+	return DefaultScoringScheme().WithBasis(basis).Evaluate(p, match).Points
+}
+
+// PredictedResult returns the result the prediction calls: HOME_WIN,
+// AWAY_WIN or DRAW
+func (p *Prediction) PredictedResult() string {
+	if p.Winner != "" {
+		return sideResult(p.Winner)
+	}
+	return getResult(p.HomeGoals, p.AwayGoals)
+}
+
+// MarginError returns how far a winner tip's margin was from the actual
+// margin, seen from the picked team's side: a wrong pick adds the margin it
+// lost by. A tip without a margin counts as a margin of 0.
+func (p *Prediction) MarginError(score Score) int {
+	margin := 0
+	if p.Margin != nil {
+		margin = *p.Margin
+	}
+	actual := score.HomeGoals - score.AwayGoals
+	if p.Winner == MatchSideAway {
+		actual = -actual
+	}
+	if margin > actual {
+		return margin - actual
+	}
+	return actual - margin
+}
+
+// sideResult returns the result of a match won by the side, or a draw if no
+// side won
+func sideResult(side MatchSide) string {
+	switch side {
+	case MatchSideHome:
+		return "HOME_WIN"
+	case MatchSideAway:
+		return "AWAY_WIN"
+	}
+	return "DRAW"
 }
 
 // getResult determines the result of a match based on goals
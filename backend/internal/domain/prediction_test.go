@@ -43,31 +43,58 @@ func TestCalculatePoints(t *testing.T) {
 
 	// Test case 1: Match has no score
 	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
-	points := prediction.CalculatePoints(match)
+	points := prediction.CalculatePoints(match, ResultBasisRegulation)
 	if points != 0 {
 		t.Errorf("expected points 0, got %v", points)
 	}
 
 	// Test case 2: Exact score prediction
 	match.UpdateScore(2, 1)
-	points = prediction.CalculatePoints(match)
+	points = prediction.CalculatePoints(match, ResultBasisRegulation)
 	if points != 3 {
 		t.Errorf("expected points 3, got %v", points)
 	}
 
 	// Test case 3: Correct result (home win) but wrong score
 	prediction = NewPrediction("pred123", "user123", "match123", 3, 1)
-	points = prediction.CalculatePoints(match)
+	points = prediction.CalculatePoints(match, ResultBasisRegulation)
 	if points != 1 {
 		t.Errorf("expected points 1, got %v", points)
 	}
 
 	// Test case 4: Wrong result
 	prediction = NewPrediction("pred123", "user123", "match123", 1, 2)
-	points = prediction.CalculatePoints(match)
+	points = prediction.CalculatePoints(match, ResultBasisRegulation)
 	if points != 0 {
 		t.Errorf("expected points 0, got %v", points)
 	}
+
+	// Test case 5: Abandoned match voids the prediction
+	prediction = NewPrediction("pred123", "user123", "match123", 2, 1)
+	match.Status = MatchStatusAbandoned
+	points = prediction.CalculatePoints(match, ResultBasisRegulation)
+	if points != 0 {
+		t.Errorf("expected points 0, got %v", points)
+	}
+}
+
+func TestCalculatePointsResultBasis(t *testing.T) {
+	// A cup final drawn 1-1, won 2-1 by the away side in extra time
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "FA Cup")
+	match.SetScore(Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 2}})
+
+	draw := NewPrediction("pred1", "user123", "match123", 1, 1)
+	awayWin := NewPrediction("pred2", "user123", "match123", 1, 2)
+
+	if points := draw.CalculatePoints(match, ResultBasisRegulation); points != 3 {
+		t.Errorf("expected 3 points for 1-1 after 90 minutes, got %v", points)
+	}
+	if points := draw.CalculatePoints(match, ResultBasisExtraTime); points != 0 {
+		t.Errorf("expected 0 points for 1-1 after extra time, got %v", points)
+	}
+	if points := awayWin.CalculatePoints(match, ResultBasisExtraTime); points != 3 {
+		t.Errorf("expected 3 points for 1-2 after extra time, got %v", points)
+	}
 }
 
 func TestGetResult(t *testing.T) {
@@ -89,3 +116,188 @@ func TestGetResult(t *testing.T) {
 		t.Errorf("expected result DRAW, got %v", result)
 	}
 }
+
+func TestJokerPrediction(t *testing.T) {
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	match.UpdateScore(2, 1)
+
+	// Test case 1: Plain tip has no multiplier
+	prediction := NewPrediction("pred123", "user123", "match123", 2, 1)
+	if prediction.Multiplier() != 1 {
+		t.Errorf("expected multiplier 1, got %v", prediction.Multiplier())
+	}
+
+	// Test case 2: Joker doubles an exact score
+	prediction.Joker = true
+	if prediction.Multiplier() != JokerMultiplier {
+		t.Errorf("expected multiplier %v, got %v", JokerMultiplier, prediction.Multiplier())
+	}
+	result := DefaultScoringScheme().Evaluate(prediction, match)
+	if result.Points != 6 {
+		t.Errorf("expected points 6, got %v", result.Points)
+	}
+	last := result.Breakdown[len(result.Breakdown)-1]
+	if last.Rule != RuleJoker || last.Points != 3 {
+		t.Errorf("expected joker bonus of 3, got %+v", last)
+	}
+
+	// Test case 3: Joker on a wrong tip still earns nothing
+	prediction = NewPrediction("pred123", "user123", "match123", 0, 2)
+	prediction.Joker = true
+	if points := prediction.CalculatePoints(match, ResultBasisRegulation); points != 0 {
+		t.Errorf("expected points 0, got %v", points)
+	}
+}
+
+func TestAmendPrediction(t *testing.T) {
+	prediction := NewPrediction("pred123", "user123", "match123", 2, 1)
+	prediction.Auto, prediction.LeagueID = true, "league1"
+	if prediction.Revision != 1 {
+		t.Errorf("expected Revision 1, got %v", prediction.Revision)
+	}
+
+	amendedAt := prediction.CreatedAt.Add(time.Hour)
+	prediction.Amend(0, 0, true, amendedAt)
+
+	if prediction.HomeGoals != 0 || prediction.AwayGoals != 0 {
+		t.Errorf("expected score 0-0, got %v-%v", prediction.HomeGoals, prediction.AwayGoals)
+	}
+	if !prediction.Joker {
+		t.Errorf("expected prediction to be marked as joker")
+	}
+	if prediction.Auto || prediction.LeagueID != "" {
+		t.Errorf("expected an amended auto-tip to become the user's own")
+	}
+	if prediction.Revision != 2 {
+		t.Errorf("expected Revision 2, got %v", prediction.Revision)
+	}
+	if !prediction.UpdatedAt.Equal(amendedAt) {
+		t.Errorf("expected UpdatedAt %v, got %v", amendedAt, prediction.UpdatedAt)
+	}
+
+	revision := prediction.CurrentRevision()
+	if revision.PredictionID != prediction.ID || revision.Revision != 2 || !revision.RecordedAt.Equal(amendedAt) {
+		t.Errorf("unexpected revision %+v", revision)
+	}
+}
+
+func TestWithdrawPrediction(t *testing.T) {
+	prediction := NewPrediction("pred123", "user123", "match123", 2, 1)
+	if prediction.IsWithdrawn() {
+		t.Errorf("expected new prediction not to be withdrawn")
+	}
+
+	withdrawnAt := prediction.CreatedAt.Add(time.Hour)
+	if err := prediction.Withdraw(withdrawnAt); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !prediction.IsWithdrawn() {
+		t.Errorf("expected prediction to be withdrawn")
+	}
+	if prediction.Revision != 2 {
+		t.Errorf("expected Revision 2, got %v", prediction.Revision)
+	}
+	if !prediction.CurrentRevision().Withdrawn {
+		t.Errorf("expected current revision to record the withdrawal")
+	}
+
+	// Withdrawing twice is rejected
+	if err := prediction.Withdraw(withdrawnAt); err != ErrPredictionWithdrawn {
+		t.Errorf("expected ErrPredictionWithdrawn, got %v", err)
+	}
+
+	// Withdrawn predictions earn nothing
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	match.UpdateScore(2, 1)
+	if points := prediction.CalculatePoints(match, ResultBasisRegulation); points != 0 {
+		t.Errorf("expected 0 points for withdrawn prediction, got %v", points)
+	}
+}
+
+func TestWinnerPredictionValidateFor(t *testing.T) {
+	margin := 12
+	zero := 0
+
+	tests := []struct {
+		name       string
+		prediction *Prediction
+		mode       TippingMode
+		expected   error
+	}{
+		{"Score tip in exact score mode", NewPrediction("p", "u", "m", 2, 1), TippingModeExactScore, nil},
+		{"Winner tip in exact score mode", NewWinnerPrediction("p", "u", "m", MatchSideHome, nil), TippingModeExactScore, ErrWrongPredictionType},
+		{"Winner with margin", NewWinnerPrediction("p", "u", "m", MatchSideAway, &margin), TippingModeWinnerMargin, nil},
+		{"Winner without margin", NewWinnerPrediction("p", "u", "m", MatchSideHome, nil), TippingModeWinnerMargin, nil},
+		{"Score tip in winner mode", NewPrediction("p", "u", "m", 2, 1), TippingModeWinnerMargin, ErrInvalidWinnerPick},
+		{"Unknown side", NewWinnerPrediction("p", "u", "m", MatchSide("DRAW"), nil), TippingModeWinnerMargin, ErrInvalidWinnerPick},
+		{"Zero margin", NewWinnerPrediction("p", "u", "m", MatchSideHome, &zero), TippingModeWinnerMargin, ErrInvalidMargin},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.prediction.ValidateFor(tt.mode); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestWinnerPredictionPoints(t *testing.T) {
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "AFL")
+	match.UpdateScore(95, 80)
+
+	if points := NewWinnerPrediction("p", "u", "match123", MatchSideHome, nil).CalculatePoints(match, ResultBasisRegulation); points != 1 {
+		t.Errorf("expected 1 point for the correct winner, got %v", points)
+	}
+	if points := NewWinnerPrediction("p", "u", "match123", MatchSideAway, nil).CalculatePoints(match, ResultBasisRegulation); points != 0 {
+		t.Errorf("expected no points for the wrong winner, got %v", points)
+	}
+
+	match.UpdateScore(0, 0)
+	if points := NewWinnerPrediction("p", "u", "match123", MatchSideHome, nil).CalculatePoints(match, ResultBasisRegulation); points != 0 {
+		t.Errorf("expected no points for a 0-0 draw, got %v", points)
+	}
+}
+
+func TestMarginError(t *testing.T) {
+	margin := 20
+	score := Score{HomeGoals: 95, AwayGoals: 80}
+
+	tests := []struct {
+		name       string
+		prediction *Prediction
+		expected   int
+	}{
+		{"Right winner, margin too big", NewWinnerPrediction("p", "u", "m", MatchSideHome, &margin), 5},
+		{"Wrong winner", NewWinnerPrediction("p", "u", "m", MatchSideAway, &margin), 35},
+		{"No margin", NewWinnerPrediction("p", "u", "m", MatchSideHome, nil), 15},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if marginError := tt.prediction.MarginError(score); marginError != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, marginError)
+			}
+		})
+	}
+}
+
+func TestAmendWinnerPrediction(t *testing.T) {
+	margin := 8
+	prediction := NewPrediction("pred123", "user123", "match123", 2, 1)
+
+	prediction.AmendWinner(MatchSideAway, &margin, false, prediction.CreatedAt.Add(time.Hour))
+	if !prediction.IsWinnerTip() || prediction.Margin == nil || *prediction.Margin != 8 || prediction.HomeGoals != 0 {
+		t.Errorf("expected an away tip by 8, got %+v", prediction)
+	}
+
+	revision := prediction.CurrentRevision()
+	if revision.Winner != MatchSideAway || revision.Margin == nil || revision.Revision != 2 {
+		t.Errorf("unexpected revision %+v", revision)
+	}
+
+	prediction.Amend(1, 1, false, prediction.UpdatedAt.Add(time.Hour))
+	if prediction.IsWinnerTip() || prediction.Margin != nil {
+		t.Errorf("expected a score amendment to clear the winner tip")
+	}
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+)
+
+// Round errors
+var (
+	ErrInvalidRoundNumber    = newFieldError("number", "INVALID_ROUND_NUMBER", "round number must be positive")
+	ErrInvalidRoundWindow    = newFieldError("endDate", "INVALID_ROUND_WINDOW", "round must end after it starts")
+	ErrInvalidRoundDeadline  = newFieldError("deadline", "INVALID_ROUND_DEADLINE", "round deadline must fall before the round ends")
+	ErrUnknownRound          = newFieldError("roundId", "UNKNOWN_ROUND", "unknown round")
+	ErrRoundNotInCompetition = newFieldError("roundId", "ROUND_NOT_IN_COMPETITION", "round does not belong to the competition")
+)
+
+// Round represents a round (or gameweek) of matches within a competition
+type Round struct {
+	ID            string    `json:"id"`
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId,omitempty"`
+	Number        int       `json:"number"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"startDate"`
+	EndDate       time.Time `json:"endDate"`
+	Deadline      time.Time `json:"deadline"`
+}
+
+// NewRound creates a new round instance. Its dates are held in UTC.
+func NewRound(id, competitionID string, number int, name string, startDate, endDate, deadline time.Time) *Round {
+	round := &Round{
+		ID:            id,
+		CompetitionID: competitionID,
+		Number:        number,
+		Name:          name,
+	}
+	round.SetDates(startDate, endDate, deadline)
+	return round
+}
+
+// SetDates sets the round's window and tipping deadline, in UTC
+func (r *Round) SetDates(startDate, endDate, deadline time.Time) {
+	r.StartDate = startDate.UTC()
+	r.EndDate = endDate.UTC()
+	r.Deadline = deadline.UTC()
+}
+
+// Validate checks the round's number, window and tipping deadline
+func (r *Round) Validate() error {
+	if r.Number <= 0 {
+		return ErrInvalidRoundNumber
+	}
+	if !r.EndDate.After(r.StartDate) {
+		return ErrInvalidRoundWindow
+	}
+	if r.Deadline.After(r.EndDate) {
+		return ErrInvalidRoundDeadline
+	}
+	return nil
+}
+
+// IsLocked returns true once the round's tipping deadline has passed. The
+// deadline is compared in UTC, whatever zone now is in.
+func (r *Round) IsLocked(now time.Time) bool {
+	return !now.UTC().Before(r.Deadline)
+}
+
+// Contains returns true if the time falls within the round's window
+func (r *Round) Contains(t time.Time) bool {
+	return !t.Before(r.StartDate) && !t.After(r.EndDate)
+}
+
+// CurrentRound picks the round in play at the given time: the round whose
+// window contains it, otherwise the next round to start, otherwise the most
+// recent round to finish. It returns nil if there are no rounds.
+func CurrentRound(rounds []*Round, now time.Time) *Round {
+	var next, previous *Round
+	for _, round := range rounds {
+		switch {
+		case round.Contains(now):
+			return round
+		case round.StartDate.After(now):
+			if next == nil || round.StartDate.Before(next.StartDate) {
+				next = round
+			}
+		default:
+			if previous == nil || round.EndDate.After(previous.EndDate) {
+				previous = round
+			}
+		}
+	}
+	if next != nil {
+		return next
+	}
+	return previous
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRound(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+	deadline := start.Add(19 * time.Hour)
+
+	round := NewRound("round1", "comp_epl", 1, "Gameweek 1", start, end, deadline)
+
+	if round.ID != "round1" {
+		t.Errorf("expected ID round1, got %v", round.ID)
+	}
+	if round.CompetitionID != "comp_epl" {
+		t.Errorf("expected CompetitionID comp_epl, got %v", round.CompetitionID)
+	}
+	if round.Number != 1 {
+		t.Errorf("expected Number 1, got %v", round.Number)
+	}
+	if !round.Deadline.Equal(deadline) {
+		t.Errorf("expected Deadline %v, got %v", deadline, round.Deadline)
+	}
+}
+
+func TestRoundValidate(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	end := start.Add(72 * time.Hour)
+
+	tests := []struct {
+		name     string
+		round    *Round
+		expected error
+	}{
+		{"Valid round", NewRound("r", "EPL", 1, "Round 1", start, end, start), nil},
+		{"Zero number", NewRound("r", "EPL", 0, "Round 0", start, end, start), ErrInvalidRoundNumber},
+		{"Ends before it starts", NewRound("r", "EPL", 1, "Round 1", end, start, start), ErrInvalidRoundWindow},
+		{"Deadline after end", NewRound("r", "EPL", 1, "Round 1", start, end, end.Add(time.Hour)), ErrInvalidRoundDeadline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.round.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestRoundIsLocked(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	round := NewRound("r", "EPL", 1, "Round 1", start, start.Add(72*time.Hour), start.Add(19*time.Hour))
+
+	if round.IsLocked(start) {
+		t.Errorf("expected round to be open before the deadline")
+	}
+	if !round.IsLocked(round.Deadline) {
+		t.Errorf("expected round to be locked at the deadline")
+	}
+}
+
+func TestRoundDeadlineInUTC(t *testing.T) {
+	perth, _ := time.LoadLocation("Australia/Perth")
+	deadline := time.Date(2025, 8, 15, 19, 0, 0, 0, perth)
+	round := NewRound("r", "AFL", 1, "Round 1", deadline.Add(-24*time.Hour), deadline.Add(72*time.Hour), deadline)
+
+	if round.Deadline.Location() != time.UTC || round.Deadline.Hour() != 11 {
+		t.Errorf("expected the deadline stored as 11:00 UTC, got %v", round.Deadline)
+	}
+
+	// The same instant is the same deadline wherever the clock is read
+	london, _ := time.LoadLocation("Europe/London")
+	if round.IsLocked(deadline.Add(-time.Minute).In(london)) {
+		t.Errorf("expected round to be open a minute before the deadline")
+	}
+	if !round.IsLocked(deadline.In(london)) {
+		t.Errorf("expected round to be locked at the deadline")
+	}
+}
+
+func TestCurrentRound(t *testing.T) {
+	start := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+	round1 := NewRound("r1", "EPL", 1, "Round 1", start, start.Add(3*24*time.Hour), start)
+	round2 := NewRound("r2", "EPL", 2, "Round 2", start.Add(week), start.Add(week+3*24*time.Hour), start.Add(week))
+	rounds := []*Round{round2, round1}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected *Round
+	}{
+		{"Before the season", start.Add(-week), round1},
+		{"During a round", start.Add(24 * time.Hour), round1},
+		{"Between rounds", start.Add(5 * 24 * time.Hour), round2},
+		{"After the season", start.Add(3 * week), round2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if current := CurrentRound(rounds, tt.now); current != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected.ID, current)
+			}
+		})
+	}
+
+	if CurrentRound(nil, start) != nil {
+		t.Errorf("expected no current round without rounds")
+	}
+}
@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Scoring errors
+var (
+	ErrUnknownScoringRule = newError("UNKNOWN_SCORING_RULE", "unknown scoring rule")
+	ErrEmptyScoringSpec   = newError("EMPTY_SCORING_SPEC", "scoring spec has no rules")
+)
+
+// Built-in scoring rule types
+const (
+	RuleExactScore     = "exact_score"
+	RuleCorrectResult  = "correct_result"
+	RuleGoalDifference = "goal_difference"
+	RuleTeamGoals      = "team_goals"
+)
+
+// RuleJoker labels the bonus a joker tip adds in a points breakdown
+const RuleJoker = "joker"
+
+// ScoringRule awards points for one aspect of a prediction
+type ScoringRule interface {
+	// Name returns the rule type used in specs and points breakdowns
+	Name() string
+
+	// Evaluate returns the points the prediction earns against the final score
+	Evaluate(prediction *Prediction, score Score) int
+}
+
+// ExactScoreRule awards points when both teams' goals are predicted exactly
+type ExactScoreRule struct {
+	Points int
+}
+
+func (r ExactScoreRule) Name() string { return RuleExactScore }
+
+func (r ExactScoreRule) Evaluate(prediction *Prediction, score Score) int {
+	if prediction.IsWinnerTip() {
+		return 0
+	}
+	if prediction.HomeGoals == score.HomeGoals && prediction.AwayGoals == score.AwayGoals {
+		return r.Points
+	}
+	return 0
+}
+
+// CorrectResultRule awards points when the win, draw or loss is predicted
+// correctly, with a shootout deciding the result when the basis counts it.
+// For a winner tip this is one award per correct winner.
+type CorrectResultRule struct {
+	Points int
+}
+
+func (r CorrectResultRule) Name() string { return RuleCorrectResult }
+
+func (r CorrectResultRule) Evaluate(prediction *Prediction, score Score) int {
+	if prediction.PredictedResult() == sideResult(score.winner()) {
+		return r.Points
+	}
+	return 0
+}
+
+// GoalDifferenceRule awards points when the winning margin is predicted correctly
+type GoalDifferenceRule struct {
+	Points int
+}
+
+func (r GoalDifferenceRule) Name() string { return RuleGoalDifference }
+
+func (r GoalDifferenceRule) Evaluate(prediction *Prediction, score Score) int {
+	if prediction.IsWinnerTip() {
+		return 0
+	}
+	if prediction.HomeGoals-prediction.AwayGoals == score.HomeGoals-score.AwayGoals {
+		return r.Points
+	}
+	return 0
+}
+
+// TeamGoalsRule awards points for each team whose goals are predicted exactly
+type TeamGoalsRule struct {
+	Points int
+}
+
+func (r TeamGoalsRule) Name() string { return RuleTeamGoals }
+
+func (r TeamGoalsRule) Evaluate(prediction *Prediction, score Score) int {
+	if prediction.IsWinnerTip() {
+		return 0
+	}
+	points := 0
+	if prediction.HomeGoals == score.HomeGoals {
+		points += r.Points
+	}
+	if prediction.AwayGoals == score.AwayGoals {
+		points += r.Points
+	}
+	return points
+}
+
+// RuleAward records the points a single rule contributed to a prediction
+type RuleAward struct {
+	Rule   string `json:"rule"`
+	Points int    `json:"points"`
+}
+
+// ScoringResult is the total points for a prediction and the rules that
+// fired. MarginError is set only for the tiebreaker tip of a round.
+type ScoringResult struct {
+	Points      int         `json:"points"`
+	Breakdown   []RuleAward `json:"breakdown"`
+	MarginError *int        `json:"marginError,omitempty"`
+}
+
+// ScoringScheme is an ordered list of rules. Points from every rule that fires
+// are summed, except that a final rule stops evaluation once it fires. Rules
+// are evaluated against the part of the score its result basis counts.
+type ScoringScheme struct {
+	rules []schemeRule
+	basis ResultBasis
+}
+
+type schemeRule struct {
+	rule  ScoringRule
+	final bool
+}
+
+// WithBasis returns a copy of the scheme that scores tips against the given
+// result basis
+func (s *ScoringScheme) WithBasis(basis ResultBasis) *ScoringScheme {
+	return &ScoringScheme{rules: s.rules, basis: basis}
+}
+
+// Evaluate scores a prediction against a match, applying the prediction's
+// multiplier. Matches without a score, abandoned matches and withdrawn
+// predictions earn nothing.
+func (s *ScoringScheme) Evaluate(prediction *Prediction, match *Match) ScoringResult {
+	result := ScoringResult{Breakdown: make([]RuleAward, 0)}
+	if match.Score == nil || match.IsAbandoned() || prediction.IsWithdrawn() {
+		return result
+	}
+
+	score := match.Score.Counted(s.basis)
+	for _, r := range s.rules {
+		points := r.rule.Evaluate(prediction, score)
+		if points == 0 {
+			continue
+		}
+		result.Points += points
+		result.Breakdown = append(result.Breakdown, RuleAward{Rule: r.rule.Name(), Points: points})
+		if r.final {
+			break
+		}
+	}
+
+	if multiplier := prediction.Multiplier(); multiplier > 1 && result.Points > 0 {
+		bonus := result.Points * (multiplier - 1)
+		result.Points += bonus
+		result.Breakdown = append(result.Breakdown, RuleAward{Rule: RuleJoker, Points: bonus})
+	}
+	return result
+}
+
+// ScoringSpec is the declarative form of a scoring scheme, stored per competition
+type ScoringSpec struct {
+	Rules []RuleSpec `json:"rules" yaml:"rules"`
+}
+
+// RuleSpec configures one rule within a scoring spec
+type RuleSpec struct {
+	Type   string `json:"type" yaml:"type"`
+	Points int    `json:"points" yaml:"points"`
+	Final  bool   `json:"final,omitempty" yaml:"final,omitempty"`
+}
+
+// DefaultScoringSpec returns the classic scheme: 3 points for an exact score,
+// otherwise 1 point for the correct result
+func DefaultScoringSpec() ScoringSpec {
+	return ScoringSpec{
+		Rules: []RuleSpec{
+			{Type: RuleExactScore, Points: 3, Final: true},
+			{Type: RuleCorrectResult, Points: 1},
+		},
+	}
+}
+
+// ParseScoringSpec parses a scoring spec written in either YAML or JSON
+func ParseScoringSpec(data []byte) (ScoringSpec, error) {
+	var spec ScoringSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return ScoringSpec{}, fmt.Errorf("failed to parse scoring spec: %w", err)
+	}
+	if _, err := spec.Build(); err != nil {
+		return ScoringSpec{}, err
+	}
+	return spec, nil
+}
+
+// Build turns the spec into a scoring scheme, rejecting unknown rule types
+func (s ScoringSpec) Build() (*ScoringScheme, error) {
+	if len(s.Rules) == 0 {
+		return nil, ErrEmptyScoringSpec
+	}
+
+	scheme := &ScoringScheme{basis: ResultBasisRegulation}
+	for _, spec := range s.Rules {
+		var rule ScoringRule
+		switch spec.Type {
+		case RuleExactScore:
+			rule = ExactScoreRule{Points: spec.Points}
+		case RuleCorrectResult:
+			rule = CorrectResultRule{Points: spec.Points}
+		case RuleGoalDifference:
+			rule = GoalDifferenceRule{Points: spec.Points}
+		case RuleTeamGoals:
+			rule = TeamGoalsRule{Points: spec.Points}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownScoringRule, spec.Type)
+		}
+		scheme.rules = append(scheme.rules, schemeRule{rule: rule, final: spec.Final})
+	}
+	return scheme, nil
+}
+
+// DefaultScoringScheme returns the scheme built from DefaultScoringSpec
+func DefaultScoringScheme() *ScoringScheme {
+	scheme, _ := DefaultScoringSpec().Build()
+	return scheme
+}
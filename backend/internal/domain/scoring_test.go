@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScoringSchemeEvaluate(t *testing.T) {
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	match.UpdateScore(2, 1)
+
+	spec := ScoringSpec{
+		Rules: []RuleSpec{
+			{Type: RuleExactScore, Points: 5, Final: true},
+			{Type: RuleCorrectResult, Points: 2},
+			{Type: RuleGoalDifference, Points: 1},
+			{Type: RuleTeamGoals, Points: 1},
+		},
+	}
+	scheme, err := spec.Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		homeGoals int
+		awayGoals int
+		points    int
+		rules     []string
+	}{
+		{"Exact score stops evaluation", 2, 1, 5, []string{RuleExactScore}},
+		{"Correct result and goal difference", 3, 2, 3, []string{RuleCorrectResult, RuleGoalDifference}},
+		{"Correct result and one team's goals", 2, 0, 3, []string{RuleCorrectResult, RuleTeamGoals}},
+		{"Wrong result with one team's goals", 1, 1, 1, []string{RuleTeamGoals}},
+		{"Nothing right", 0, 3, 0, []string{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prediction := NewPrediction("pred123", "user123", "match123", tc.homeGoals, tc.awayGoals)
+			result := scheme.Evaluate(prediction, match)
+
+			if result.Points != tc.points {
+				t.Errorf("expected points %v, got %v", tc.points, result.Points)
+			}
+			if len(result.Breakdown) != len(tc.rules) {
+				t.Fatalf("expected %d rules to fire, got %v", len(tc.rules), result.Breakdown)
+			}
+			for i, rule := range tc.rules {
+				if result.Breakdown[i].Rule != rule {
+					t.Errorf("expected rule %v at position %d, got %v", rule, i, result.Breakdown[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestScoringSchemeShootout(t *testing.T) {
+	// 1-1 after extra time, home side wins 4-3 on penalties
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "FA Cup")
+	match.Score = &Score{
+		HomeGoals: 1,
+		AwayGoals: 1,
+		ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 1},
+		Penalties: &Scoreline{HomeGoals: 4, AwayGoals: 3},
+	}
+	match.Status = MatchStatusFinished
+
+	spec := ScoringSpec{
+		Rules: []RuleSpec{
+			{Type: RuleExactScore, Points: 5, Final: true},
+			{Type: RuleCorrectResult, Points: 2},
+			{Type: RuleGoalDifference, Points: 1},
+		},
+	}
+	scheme, err := spec.Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	scheme = scheme.WithBasis(ResultBasisOverall)
+
+	testCases := []struct {
+		name      string
+		homeGoals int
+		awayGoals int
+		points    int
+	}{
+		{"Score after extra time is exact", 1, 1, 5},
+		{"Shootout winner earns the result only", 2, 1, 2},
+		{"Shootout loser earns nothing", 1, 2, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prediction := NewPrediction("pred123", "user123", "match123", tc.homeGoals, tc.awayGoals)
+			if result := scheme.Evaluate(prediction, match); result.Points != tc.points {
+				t.Errorf("expected points %v, got %v (%v)", tc.points, result.Points, result.Breakdown)
+			}
+		})
+	}
+
+	// A winner tip on the shootout winner is correct
+	winner := NewWinnerPrediction("pred124", "user123", "match123", MatchSideHome, nil)
+	if result := scheme.Evaluate(winner, match); result.Points != 2 {
+		t.Errorf("expected the shootout winner tip to earn 2 points, got %v", result.Points)
+	}
+}
+
+func TestScoringSchemeAbandonedMatch(t *testing.T) {
+	match := NewMatch("match123", "Team A", "Team B", time.Now(), "Premier League")
+	match.UpdateScore(2, 1)
+	match.Status = MatchStatusAbandoned
+
+	prediction := NewPrediction("pred123", "user123", "match123", 2, 1)
+	result := DefaultScoringScheme().Evaluate(prediction, match)
+	if result.Points != 0 {
+		t.Errorf("expected points 0, got %v", result.Points)
+	}
+	if len(result.Breakdown) != 0 {
+		t.Errorf("expected empty breakdown, got %v", result.Breakdown)
+	}
+}
+
+func TestParseScoringSpec(t *testing.T) {
+	// Test case 1: YAML spec
+	spec, err := ParseScoringSpec([]byte(`
+rules:
+  - type: exact_score
+    points: 5
+    final: true
+  - type: correct_result
+    points: 2
+`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(spec.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(spec.Rules))
+	}
+	if spec.Rules[0].Type != RuleExactScore || spec.Rules[0].Points != 5 || !spec.Rules[0].Final {
+		t.Errorf("unexpected first rule %+v", spec.Rules[0])
+	}
+
+	// Test case 2: JSON spec
+	spec, err = ParseScoringSpec([]byte(`{"rules": [{"type": "team_goals", "points": 1}]}`))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(spec.Rules) != 1 || spec.Rules[0].Type != RuleTeamGoals {
+		t.Errorf("unexpected rules %+v", spec.Rules)
+	}
+
+	// Test case 3: Unknown rule type
+	_, err = ParseScoringSpec([]byte(`{"rules": [{"type": "first_scorer", "points": 1}]}`))
+	if !errors.Is(err, ErrUnknownScoringRule) {
+		t.Errorf("expected error %v, got %v", ErrUnknownScoringRule, err)
+	}
+
+	// Test case 4: No rules
+	_, err = ParseScoringSpec([]byte(`rules: []`))
+	if !errors.Is(err, ErrEmptyScoringSpec) {
+		t.Errorf("expected error %v, got %v", ErrEmptyScoringSpec, err)
+	}
+}
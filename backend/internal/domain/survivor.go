@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Survivor errors
+var (
+	ErrSurvivorNameRequired        = newFieldError("name", "SURVIVOR_NAME_REQUIRED", "survivor pool name is required")
+	ErrSurvivorCompetitionRequired = newFieldError("competitionId", "SURVIVOR_COMPETITION_REQUIRED", "survivor pool competition is required")
+	ErrSurvivorEliminated          = newError("SURVIVOR_ELIMINATED", "user has been eliminated from the survivor pool")
+	ErrTeamAlreadyPicked           = newFieldError("team", "TEAM_ALREADY_PICKED", "team has already been picked in an earlier round")
+	ErrTeamNotInMatch              = newFieldError("team", "TEAM_NOT_IN_MATCH", "picked team does not play in the match")
+	ErrSurvivorPickLocked          = newError("SURVIVOR_PICK_LOCKED", "the round's pick is locked")
+	ErrMatchNotInPool              = newFieldError("matchId", "MATCH_NOT_IN_POOL", "match is not part of the survivor pool")
+)
+
+// SurvivorPool is a last-man-standing game over a competition's matches.
+// Each round a player picks one team to win, and can never pick the same
+// team twice. A loss or a draw knocks them out.
+type SurvivorPool struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// NewSurvivorPool creates a new survivor pool instance
+func NewSurvivorPool(id, name, competitionID string, createdAt time.Time) *SurvivorPool {
+	return &SurvivorPool{
+		ID:            id,
+		Name:          name,
+		CompetitionID: competitionID,
+		CreatedAt:     createdAt,
+	}
+}
+
+// Validate checks the pool's name and competition
+func (p *SurvivorPool) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return ErrSurvivorNameRequired
+	}
+	if p.CompetitionID == "" {
+		return ErrSurvivorCompetitionRequired
+	}
+	return nil
+}
+
+// Covers returns true if the match is in the pool's competition and season
+func (p *SurvivorPool) Covers(match *Match) bool {
+	if match.CompetitionID != p.CompetitionID {
+		return false
+	}
+	return p.SeasonID == "" || match.SeasonID == p.SeasonID
+}
+
+// SurvivorOutcome is how a survivor pick turned out
+type SurvivorOutcome string
+
+const (
+	SurvivorOutcomePending SurvivorOutcome = "PENDING"
+	SurvivorOutcomeWon     SurvivorOutcome = "WON"
+	SurvivorOutcomeLost    SurvivorOutcome = "LOST"
+	SurvivorOutcomeDrawn   SurvivorOutcome = "DRAWN"
+	SurvivorOutcomeVoid    SurvivorOutcome = "VOID" // the match was abandoned
+)
+
+// SurvivorOutcomeFor returns how a pick of the team fared in the match's
+// final score, on the result the competition counts. Picks on an abandoned
+// match are void, and stay pending while the match has no score.
+func SurvivorOutcomeFor(match *Match, teamID string, basis ResultBasis) SurvivorOutcome {
+	if match.IsAbandoned() {
+		return SurvivorOutcomeVoid
+	}
+	if match.Score == nil {
+		return SurvivorOutcomePending
+	}
+
+	pickedSide := MatchSideHome
+	if teamID == match.AwayTeamID {
+		pickedSide = MatchSideAway
+	}
+	switch match.Score.Winner(basis) {
+	case pickedSide:
+		return SurvivorOutcomeWon
+	case pickedSide.Opposite():
+		return SurvivorOutcomeLost
+	default:
+		return SurvivorOutcomeDrawn
+	}
+}
+
+// SurvivorPick is a player's team for one round
+type SurvivorPick struct {
+	ID       string          `json:"id"`
+	RoundID  string          `json:"roundId"` // the match's round key
+	MatchID  string          `json:"matchId"`
+	TeamID   string          `json:"teamId"`
+	PickedAt time.Time       `json:"pickedAt"`
+	Outcome  SurvivorOutcome `json:"outcome"`
+}
+
+// IsSettled returns true once the pick's match has a final result
+func (p *SurvivorPick) IsSettled() bool {
+	return p.Outcome != SurvivorOutcomePending
+}
+
+// Eliminates returns true if the pick knocks its player out
+func (p *SurvivorPick) Eliminates() bool {
+	return p.Outcome == SurvivorOutcomeLost || p.Outcome == SurvivorOutcomeDrawn
+}
+
+// SurvivorEntry is a player's picks in a survivor pool
+type SurvivorEntry struct {
+	PoolID       string          `json:"poolId"`
+	UserID       string          `json:"userId"`
+	Picks        []*SurvivorPick `json:"picks"`
+	Alive        bool            `json:"alive"`
+	EliminatedIn string          `json:"eliminatedIn,omitempty"` // round key of the losing pick
+}
+
+// NewSurvivorEntry creates an entry for a player who has not picked yet
+func NewSurvivorEntry(poolID, userID string) *SurvivorEntry {
+	return &SurvivorEntry{
+		PoolID: poolID,
+		UserID: userID,
+		Picks:  []*SurvivorPick{},
+		Alive:  true,
+	}
+}
+
+// PickFor returns the player's pick for the round, or nil if they have none
+func (e *SurvivorEntry) PickFor(roundID string) *SurvivorPick {
+	for _, pick := range e.Picks {
+		if pick.RoundID == roundID {
+			return pick
+		}
+	}
+	return nil
+}
+
+// ValidatePick checks the player is still alive, the team plays in the match
+// and the player has not used the team in another round. Teams from void
+// picks may be picked again.
+func (e *SurvivorEntry) ValidatePick(match *Match, teamID string) error {
+	if !e.Alive {
+		return ErrSurvivorEliminated
+	}
+	if teamID == "" || (teamID != match.HomeTeamID && teamID != match.AwayTeamID) {
+		return ErrTeamNotInMatch
+	}
+	for _, pick := range e.Picks {
+		if pick.RoundID != match.RoundKey() && pick.TeamID == teamID && pick.Outcome != SurvivorOutcomeVoid {
+			return ErrTeamAlreadyPicked
+		}
+	}
+	return nil
+}
+
+// Pick records the player's team for the pick's round, replacing any
+// earlier pick for the round
+func (e *SurvivorEntry) Pick(pick *SurvivorPick) {
+	for i, existing := range e.Picks {
+		if existing.RoundID == pick.RoundID {
+			e.Picks[i] = pick
+			return
+		}
+	}
+	e.Picks = append(e.Picks, pick)
+}
+
+// Settle records a pick's outcome, knocking the player out on a loss or
+// draw. Settling a pick again, after a score correction, may bring the
+// player back: they go out on their first pick that eliminates.
+func (e *SurvivorEntry) Settle(pickID string, outcome SurvivorOutcome) {
+	for _, pick := range e.Picks {
+		if pick.ID == pickID {
+			pick.Outcome = outcome
+		}
+	}
+
+	e.Alive, e.EliminatedIn = true, ""
+	for _, pick := range e.Picks {
+		if pick.Eliminates() {
+			e.Alive = false
+			e.EliminatedIn = pick.RoundID
+			return
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSurvivorOutcomeFor(t *testing.T) {
+	finished := func(score Score) *Match {
+		match := &Match{ID: "m1", HomeTeamID: "team_a", AwayTeamID: "team_b", Status: MatchStatusFinished}
+		match.SetScore(score)
+		return match
+	}
+
+	tests := []struct {
+		name     string
+		match    *Match
+		teamID   string
+		basis    ResultBasis
+		expected SurvivorOutcome
+	}{
+		{"Home win for the home team", finished(Score{HomeGoals: 2, AwayGoals: 0}), "team_a", ResultBasisRegulation, SurvivorOutcomeWon},
+		{"Home win for the away team", finished(Score{HomeGoals: 2, AwayGoals: 0}), "team_b", ResultBasisRegulation, SurvivorOutcomeLost},
+		{"Draw", finished(Score{HomeGoals: 1, AwayGoals: 1}), "team_b", ResultBasisRegulation, SurvivorOutcomeDrawn},
+		{
+			"Extra time winner on the overall result",
+			finished(Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 2}}),
+			"team_b", ResultBasisOverall, SurvivorOutcomeWon,
+		},
+		{
+			"Extra time winner on the regulation result",
+			finished(Score{HomeGoals: 1, AwayGoals: 1, ExtraTime: &Scoreline{HomeGoals: 1, AwayGoals: 2}}),
+			"team_b", ResultBasisRegulation, SurvivorOutcomeDrawn,
+		},
+		{"Abandoned", &Match{ID: "m1", HomeTeamID: "team_a", AwayTeamID: "team_b", Status: MatchStatusAbandoned}, "team_a", ResultBasisRegulation, SurvivorOutcomeVoid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if outcome := SurvivorOutcomeFor(tt.match, tt.teamID, tt.basis); outcome != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, outcome)
+			}
+		})
+	}
+}
+
+func TestSurvivorEntryValidatePick(t *testing.T) {
+	match := &Match{ID: "m2", HomeTeamID: "team_a", AwayTeamID: "team_c", RoundID: "r2"}
+	entry := NewSurvivorEntry("pool1", "user1")
+	entry.Pick(&SurvivorPick{ID: "p1", RoundID: "r1", MatchID: "m1", TeamID: "team_a", Outcome: SurvivorOutcomePending})
+	entry.Settle("p1", SurvivorOutcomeWon)
+	entry.Pick(&SurvivorPick{ID: "p2", RoundID: "r2", MatchID: "m2", TeamID: "team_c", Outcome: SurvivorOutcomePending})
+
+	tests := []struct {
+		name     string
+		teamID   string
+		expected error
+	}{
+		{"Team not used before", "team_c", nil},
+		{"Team used in an earlier round", "team_a", ErrTeamAlreadyPicked},
+		{"Team not in the match", "team_b", ErrTeamNotInMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := entry.ValidatePick(match, tt.teamID); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestSurvivorEntrySettle(t *testing.T) {
+	entry := NewSurvivorEntry("pool1", "user1")
+	entry.Pick(&SurvivorPick{ID: "p1", RoundID: "r1", TeamID: "team_a", PickedAt: time.Now(), Outcome: SurvivorOutcomePending})
+	entry.Pick(&SurvivorPick{ID: "p2", RoundID: "r2", TeamID: "team_b", PickedAt: time.Now(), Outcome: SurvivorOutcomePending})
+
+	entry.Settle("p1", SurvivorOutcomeVoid)
+	if !entry.Alive {
+		t.Fatal("expected a void pick not to eliminate")
+	}
+
+	entry.Settle("p2", SurvivorOutcomeDrawn)
+	if entry.Alive || entry.EliminatedIn != "r2" {
+		t.Errorf("expected elimination in r2 after a draw, got alive=%v eliminatedIn=%q", entry.Alive, entry.EliminatedIn)
+	}
+
+	if err := entry.ValidatePick(&Match{HomeTeamID: "team_c", AwayTeamID: "team_d", RoundID: "r3"}, "team_c"); err != ErrSurvivorEliminated {
+		t.Errorf("expected %v, got %v", ErrSurvivorEliminated, err)
+	}
+}
+
+func TestSurvivorEntryResettle(t *testing.T) {
+	entry := NewSurvivorEntry("pool1", "user1")
+	entry.Pick(&SurvivorPick{ID: "p1", RoundID: "r1", TeamID: "team_a", PickedAt: time.Now(), Outcome: SurvivorOutcomePending})
+	entry.Pick(&SurvivorPick{ID: "p2", RoundID: "r2", TeamID: "team_b", PickedAt: time.Now(), Outcome: SurvivorOutcomePending})
+	entry.Settle("p1", SurvivorOutcomeWon)
+	entry.Settle("p2", SurvivorOutcomeLost)
+
+	// A correction turning the loss into a win brings the player back
+	entry.Settle("p2", SurvivorOutcomeWon)
+	if !entry.Alive || entry.EliminatedIn != "" {
+		t.Errorf("expected the player back in, got alive=%v eliminatedIn=%q", entry.Alive, entry.EliminatedIn)
+	}
+
+	// One turning an earlier win into a loss knocks them out in that round
+	entry.Settle("p1", SurvivorOutcomeLost)
+	if entry.Alive || entry.EliminatedIn != "r1" {
+		t.Errorf("expected elimination in r1, got alive=%v eliminatedIn=%q", entry.Alive, entry.EliminatedIn)
+	}
+}
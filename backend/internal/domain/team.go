@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"strings"
+)
+
+// Team errors
+var (
+	ErrTeamNameRequired  = newFieldError("name", "TEAM_NAME_REQUIRED", "team name is required")
+	ErrInvalidShortCode  = newFieldError("shortCode", "INVALID_SHORT_CODE", "team short code must be 2 to 5 letters or digits")
+	ErrTeamPlaysItself   = newFieldError("awayTeam", "TEAM_PLAYS_ITSELF", "a team cannot play itself")
+	ErrUnknownTeam       = newFieldError("team", "UNKNOWN_TEAM", "unknown team")
+	ErrTeamAlreadyExists = newFieldError("name", "TEAM_ALREADY_EXISTS", "team already exists")
+)
+
+// Team represents a club or national side that plays in matches
+type Team struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	ShortCode string   `json:"shortCode"`
+	Aliases   []string `json:"aliases"`
+	TimeZone  string   `json:"timeZone,omitempty"` // IANA time zone of the home ground
+}
+
+// NewTeam creates a new team instance. The short code is stored upper case.
+func NewTeam(id, name, shortCode string, aliases []string) *Team {
+	if aliases == nil {
+		aliases = make([]string, 0)
+	}
+	return &Team{
+		ID:        id,
+		Name:      name,
+		ShortCode: strings.ToUpper(strings.TrimSpace(shortCode)),
+		Aliases:   aliases,
+	}
+}
+
+// Validate checks the team's name, short code and time zone
+func (t *Team) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ErrTeamNameRequired
+	}
+	if len(t.ShortCode) < 2 || len(t.ShortCode) > 5 {
+		return ErrInvalidShortCode
+	}
+	for _, r := range t.ShortCode {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return ErrInvalidShortCode
+		}
+	}
+	if _, err := LoadTimeZone(t.TimeZone); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Names returns every name the team is known by: its name, short code and aliases
+func (t *Team) Names() []string {
+	return append([]string{t.Name, t.ShortCode}, t.Aliases...)
+}
+
+// HasName returns true if the name matches the team's name, short code or one
+// of its aliases, ignoring case and surrounding whitespace
+func (t *Team) HasName(name string) bool {
+	name = strings.TrimSpace(name)
+	for _, known := range t.Names() {
+		if strings.EqualFold(known, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTeam finds a team by ID, name, short code or alias. It returns nil if
+// no team matches.
+func ResolveTeam(teams []*Team, ref string) *Team {
+	for _, team := range teams {
+		if team.ID == ref {
+			return team
+		}
+	}
+	for _, team := range teams {
+		if team.HasName(ref) {
+			return team
+		}
+	}
+	return nil
+}
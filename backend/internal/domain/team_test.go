@@ -0,0 +1,63 @@
+package domain
+
+import "testing"
+
+func TestNewTeam(t *testing.T) {
+	team := NewTeam("team_mun", "Manchester United", " mun ", nil)
+
+	if team.ShortCode != "MUN" {
+		t.Errorf("expected ShortCode MUN, got %v", team.ShortCode)
+	}
+	if team.Aliases == nil {
+		t.Error("expected Aliases to be an empty slice, got nil")
+	}
+}
+
+func TestTeamValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		team     *Team
+		expected error
+	}{
+		{"Valid team", NewTeam("t", "Manchester United", "MUN", nil), nil},
+		{"Digits in short code", NewTeam("t", "FC Schalke 04", "S04", nil), nil},
+		{"Missing name", NewTeam("t", "", "MUN", nil), ErrTeamNameRequired},
+		{"Short code too short", NewTeam("t", "Manchester United", "M", nil), ErrInvalidShortCode},
+		{"Short code too long", NewTeam("t", "Manchester United", "MANUTD", nil), ErrInvalidShortCode},
+		{"Short code with spaces", NewTeam("t", "Manchester United", "MA U", nil), ErrInvalidShortCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.team.Validate(); err != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestResolveTeam(t *testing.T) {
+	united := NewTeam("team_mun", "Manchester United", "MUN", []string{"Man United", "Man Utd"})
+	city := NewTeam("team_mci", "Manchester City", "MCI", []string{"Man City"})
+	teams := []*Team{united, city}
+
+	tests := []struct {
+		name     string
+		ref      string
+		expected *Team
+	}{
+		{"By ID", "team_mci", city},
+		{"By name", "Manchester United", united},
+		{"By alias ignoring case", "man united", united},
+		{"By short code", "mci", city},
+		{"Unknown", "Manchester", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveTeam(teams, tt.ref); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Timeline errors
+var (
+	ErrMatchNotLive             = newError("MATCH_NOT_LIVE", "in-play events can only be recorded while the match is live")
+	ErrInvalidSide              = newFieldError("side", "INVALID_SIDE", "side must be HOME or AWAY")
+	ErrInvalidMinute            = newFieldError("minute", "INVALID_MINUTE", "minute must be between 0 and 120, and added time cannot be negative")
+	ErrPlayerRequired           = newFieldError("player", "PLAYER_REQUIRED", "player is required")
+	ErrInvalidCard              = newFieldError("card", "INVALID_CARD", "card must be YELLOW, SECOND_YELLOW or RED")
+	ErrInvalidPeriod            = newFieldError("period", "INVALID_PERIOD", "invalid match period")
+	ErrInvalidPeriodTransition  = newError("INVALID_PERIOD_TRANSITION", "invalid match period transition")
+	ErrBallNotInPlay            = newError("BALL_NOT_IN_PLAY", "goals can only be scored while a period is being played")
+	ErrGoalNotFound             = newError("GOAL_NOT_FOUND", "goal not found in the match timeline")
+	ErrGoalAlreadyDisallowed    = newError("GOAL_ALREADY_DISALLOWED", "goal has already been disallowed")
+	ErrOwnGoalAndPenalty        = newError("OWN_GOAL_AND_PENALTY", "a goal cannot be both an own goal and a penalty")
+	ErrPenaltyShootoutOwnGoal   = newError("PENALTY_SHOOTOUT_OWN_GOAL", "shootout goals cannot be own goals")
+	ErrMinuteBeforeLatestPeriod = newError("MINUTE_BEFORE_LATEST_PERIOD", "minute is before the start of the current period")
+)
+
+// MaxMatchMinute is the last minute of extra time. Stoppage time is recorded
+// as added time on top of it.
+const MaxMatchMinute = 120
+
+// MatchPeriod is a phase of a live match
+type MatchPeriod string
+
+const (
+	MatchPeriodFirstHalf  MatchPeriod = "FIRST_HALF"
+	MatchPeriodHalfTime   MatchPeriod = "HALF_TIME"
+	MatchPeriodSecondHalf MatchPeriod = "SECOND_HALF"
+	MatchPeriodExtraTime  MatchPeriod = "EXTRA_TIME"
+	MatchPeriodPenalties  MatchPeriod = "PENALTIES"
+	MatchPeriodFullTime   MatchPeriod = "FULL_TIME"
+)
+
+// periodTransitions lists the periods a match may move to from each period.
+// A match that has not kicked off has no period.
+var periodTransitions = map[MatchPeriod][]MatchPeriod{
+	"":                    {MatchPeriodFirstHalf},
+	MatchPeriodFirstHalf:  {MatchPeriodHalfTime},
+	MatchPeriodHalfTime:   {MatchPeriodSecondHalf},
+	MatchPeriodSecondHalf: {MatchPeriodExtraTime, MatchPeriodPenalties, MatchPeriodFullTime},
+	MatchPeriodExtraTime:  {MatchPeriodPenalties, MatchPeriodFullTime},
+	MatchPeriodPenalties:  {MatchPeriodFullTime},
+}
+
+// IsValid returns true if the period is one of the known match periods
+func (p MatchPeriod) IsValid() bool {
+	switch p {
+	case MatchPeriodFirstHalf, MatchPeriodHalfTime, MatchPeriodSecondHalf,
+		MatchPeriodExtraTime, MatchPeriodPenalties, MatchPeriodFullTime:
+		return true
+	}
+	return false
+}
+
+// InPlay returns true if goals can be scored during the period
+func (p MatchPeriod) InPlay() bool {
+	switch p {
+	case MatchPeriodFirstHalf, MatchPeriodSecondHalf, MatchPeriodExtraTime, MatchPeriodPenalties:
+		return true
+	}
+	return false
+}
+
+// CardType is the colour of a card shown to a player
+type CardType string
+
+const (
+	CardYellow       CardType = "YELLOW"
+	CardSecondYellow CardType = "SECOND_YELLOW" // a second booking, which sends the player off
+	CardRed          CardType = "RED"
+)
+
+// IsValid returns true if the card is one of the known card types
+func (c CardType) IsValid() bool {
+	return c == CardYellow || c == CardSecondYellow || c == CardRed
+}
+
+// TimelineEntryType identifies what happened at a point in a match
+type TimelineEntryType string
+
+const (
+	TimelineGoal           TimelineEntryType = "GOAL"
+	TimelineGoalDisallowed TimelineEntryType = "GOAL_DISALLOWED"
+	TimelineCard           TimelineEntryType = "CARD"
+	TimelinePeriod         TimelineEntryType = "PERIOD"
+)
+
+// TimelineEntry is one in-play event of a match. Which fields are set
+// depends on its type.
+type TimelineEntry struct {
+	ID         string            `json:"id"`
+	Type       TimelineEntryType `json:"type"`
+	Minute     int               `json:"minute"`
+	AddedTime  int               `json:"addedTime,omitempty"`
+	Period     MatchPeriod       `json:"period"`
+	Side       MatchSide         `json:"side,omitempty"` // for goals, the team credited with the goal
+	Player     string            `json:"player,omitempty"`
+	OwnGoal    bool              `json:"ownGoal,omitempty"`
+	Penalty    bool              `json:"penalty,omitempty"`
+	Disallowed bool              `json:"disallowed,omitempty"` // a goal later ruled out
+	Card       CardType          `json:"card,omitempty"`
+	GoalID     string            `json:"goalId,omitempty"` // the goal a disallowance rules out
+	Reason     string            `json:"reason,omitempty"`
+	RecordedAt time.Time         `json:"recordedAt"`
+}
+
+// ValidateMinute checks the minute is within the match and the added time
+// is not negative
+func ValidateMinute(minute, addedTime int) error {
+	if minute < 0 || minute > MaxMatchMinute || addedTime < 0 {
+		return ErrInvalidMinute
+	}
+	return nil
+}
+
+// MatchTimeline is a match's in-play events in the order they were recorded,
+// with the live score and period they leave the match in
+type MatchTimeline struct {
+	MatchID string           `json:"matchId"`
+	Status  MatchStatus      `json:"status"`
+	Period  MatchPeriod      `json:"period,omitempty"` // empty until kickoff
+	Score   *Score           `json:"score"`
+	Entries []*TimelineEntry `json:"entries"`
+}
+
+// NewMatchTimeline creates the timeline of a match with no in-play events
+func NewMatchTimeline(match *Match) *MatchTimeline {
+	return &MatchTimeline{
+		MatchID: match.ID,
+		Status:  match.Status,
+		Score:   match.Score,
+		Entries: []*TimelineEntry{},
+	}
+}
+
+// periodStart returns the minute the current period started at
+func (t *MatchTimeline) periodStart() int {
+	for i := len(t.Entries) - 1; i >= 0; i-- {
+		if t.Entries[i].Type == TimelinePeriod {
+			return t.Entries[i].Minute
+		}
+	}
+	return 0
+}
+
+// validateInPlay checks the match is live and the minute is within the
+// match and not before the current period started
+func (t *MatchTimeline) validateInPlay(minute, addedTime int) error {
+	if t.Status != MatchStatusLive {
+		return ErrMatchNotLive
+	}
+	if err := ValidateMinute(minute, addedTime); err != nil {
+		return err
+	}
+	if minute < t.periodStart() {
+		return ErrMinuteBeforeLatestPeriod
+	}
+	return nil
+}
+
+// ValidateGoal checks a goal can be scored now: the match is live, a period
+// is being played, and the goal's side, minute and kind are sensible. The
+// goal is credited to the current period.
+func (t *MatchTimeline) ValidateGoal(goal *TimelineEntry) error {
+	if err := t.validateInPlay(goal.Minute, goal.AddedTime); err != nil {
+		return err
+	}
+	if !t.Period.InPlay() {
+		return ErrBallNotInPlay
+	}
+	if !goal.Side.IsValid() {
+		return ErrInvalidSide
+	}
+	if goal.OwnGoal && goal.Penalty {
+		return ErrOwnGoalAndPenalty
+	}
+	if goal.OwnGoal && t.Period == MatchPeriodPenalties {
+		return ErrPenaltyShootoutOwnGoal
+	}
+	return nil
+}
+
+// ValidateCard checks a card can be shown now: the match is live and the
+// card names a side, a player and a known colour
+func (t *MatchTimeline) ValidateCard(card *TimelineEntry) error {
+	if err := t.validateInPlay(card.Minute, card.AddedTime); err != nil {
+		return err
+	}
+	if !card.Side.IsValid() {
+		return ErrInvalidSide
+	}
+	if strings.TrimSpace(card.Player) == "" {
+		return ErrPlayerRequired
+	}
+	if !card.Card.IsValid() {
+		return ErrInvalidCard
+	}
+	return nil
+}
+
+// ValidatePeriod checks the match can move into the period at the minute
+func (t *MatchTimeline) ValidatePeriod(period MatchPeriod, minute int) error {
+	if !period.IsValid() {
+		return ErrInvalidPeriod
+	}
+	if err := t.validateInPlay(minute, 0); err != nil {
+		return err
+	}
+	for _, allowed := range periodTransitions[t.Period] {
+		if allowed == period {
+			return nil
+		}
+	}
+	return ErrInvalidPeriodTransition
+}
+
+// Goal returns the goal with the given ID, or nil if there is none
+func (t *MatchTimeline) Goal(goalID string) *TimelineEntry {
+	for _, entry := range t.Entries {
+		if entry.Type == TimelineGoal && entry.ID == goalID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// ValidateDisallow checks the goal can be ruled out and returns it
+func (t *MatchTimeline) ValidateDisallow(goalID string, minute, addedTime int) (*TimelineEntry, error) {
+	if t.Status != MatchStatusLive {
+		return nil, ErrMatchNotLive
+	}
+	if err := ValidateMinute(minute, addedTime); err != nil {
+		return nil, err
+	}
+	goal := t.Goal(goalID)
+	if goal == nil {
+		return nil, ErrGoalNotFound
+	}
+	if goal.Disallowed {
+		return nil, ErrGoalAlreadyDisallowed
+	}
+	return goal, nil
+}
+
+// Record adds an entry to the timeline, moving the period on or ruling out
+// a goal as the entry says. The score is left to the match.
+func (t *MatchTimeline) Record(entry *TimelineEntry) {
+	switch entry.Type {
+	case TimelinePeriod:
+		t.Period = entry.Period
+	case TimelineGoalDisallowed:
+		if goal := t.Goal(entry.GoalID); goal != nil {
+			goal.Disallowed = true
+		}
+	}
+	t.Entries = append(t.Entries, entry)
+}
+
+// AddGoal puts a goal for the side on the score for the period. Extra-time
+// goals add to the extra-time score, which starts from the regulation score,
+// and shootout goals add to the penalty tally.
+func (s *Score) AddGoal(side MatchSide, period MatchPeriod) {
+	s.addGoals(side, period, 1)
+}
+
+// RemoveGoal takes a goal for the side in the period back off the score
+func (s *Score) RemoveGoal(side MatchSide, period MatchPeriod) {
+	s.addGoals(side, period, -1)
+}
+
+// EnterPeriod starts the extra-time score or the shootout tally when the
+// match moves into extra time or penalties
+func (s *Score) EnterPeriod(period MatchPeriod) {
+	switch period {
+	case MatchPeriodExtraTime:
+		if s.ExtraTime == nil {
+			s.ExtraTime = &Scoreline{HomeGoals: s.HomeGoals, AwayGoals: s.AwayGoals}
+		}
+	case MatchPeriodPenalties:
+		if s.Penalties == nil {
+			s.Penalties = &Scoreline{}
+		}
+	}
+}
+
+func (s *Score) addGoals(side MatchSide, period MatchPeriod, goals int) {
+	switch period {
+	case MatchPeriodPenalties:
+		s.EnterPeriod(period)
+		s.Penalties.add(side, goals)
+	case MatchPeriodExtraTime:
+		s.EnterPeriod(period)
+		s.ExtraTime.add(side, goals)
+	default:
+		line := Scoreline{HomeGoals: s.HomeGoals, AwayGoals: s.AwayGoals}
+		line.add(side, goals)
+		s.HomeGoals, s.AwayGoals = line.HomeGoals, line.AwayGoals
+		if s.ExtraTime != nil {
+			s.ExtraTime.add(side, goals)
+		}
+	}
+}
+
+// add changes the side's tally, never taking it below zero
+func (l *Scoreline) add(side MatchSide, goals int) {
+	tally := &l.HomeGoals
+	if side == MatchSideAway {
+		tally = &l.AwayGoals
+	}
+	*tally += goals
+	if *tally < 0 {
+		*tally = 0
+	}
+}
+
+// StartPeriod moves the match's live score into a new period. The score
+// starts at 0-0 when the match kicks off.
+func (m *Match) StartPeriod(period MatchPeriod) {
+	if m.Score == nil {
+		m.Score = &Score{}
+	}
+	m.Score.EnterPeriod(period)
+}
+
+// AddGoal puts a goal on the match's live score
+func (m *Match) AddGoal(side MatchSide, period MatchPeriod) {
+	if m.Score == nil {
+		m.Score = &Score{}
+	}
+	m.Score.AddGoal(side, period)
+}
+
+// RemoveGoal takes a disallowed goal off the match's live score
+func (m *Match) RemoveGoal(side MatchSide, period MatchPeriod) {
+	if m.Score == nil {
+		return
+	}
+	m.Score.RemoveGoal(side, period)
+}
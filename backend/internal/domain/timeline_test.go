@@ -0,0 +1,135 @@
+package domain
+
+import "testing"
+
+func TestLiveScore(t *testing.T) {
+	match := &Match{ID: "m1", Status: MatchStatusLive}
+
+	match.StartPeriod(MatchPeriodFirstHalf)
+	if match.Score == nil || match.Score.HomeGoals != 0 || match.Score.AwayGoals != 0 {
+		t.Fatalf("expected 0-0 at kickoff, got %+v", match.Score)
+	}
+
+	match.AddGoal(MatchSideHome, MatchPeriodFirstHalf)
+	match.AddGoal(MatchSideAway, MatchPeriodSecondHalf)
+	match.StartPeriod(MatchPeriodExtraTime)
+	match.AddGoal(MatchSideAway, MatchPeriodExtraTime)
+	match.AddGoal(MatchSideHome, MatchPeriodExtraTime)
+	match.StartPeriod(MatchPeriodPenalties)
+	match.AddGoal(MatchSideHome, MatchPeriodPenalties)
+
+	expected := Score{
+		HomeGoals: 1,
+		AwayGoals: 1,
+		ExtraTime: &Scoreline{HomeGoals: 2, AwayGoals: 2},
+		Penalties: &Scoreline{HomeGoals: 1, AwayGoals: 0},
+	}
+	if !match.Score.Equal(expected) {
+		t.Errorf("expected %+v, got %+v", expected, match.Score)
+	}
+
+	// Ruling out a regulation goal also takes it off the extra-time score
+	match.RemoveGoal(MatchSideHome, MatchPeriodFirstHalf)
+	if match.Score.HomeGoals != 0 || match.Score.ExtraTime.HomeGoals != 1 {
+		t.Errorf("expected the disallowed goal off both scores, got %+v", match.Score)
+	}
+}
+
+func TestTimelineValidation(t *testing.T) {
+	live := func(periods ...MatchPeriod) *MatchTimeline {
+		timeline := NewMatchTimeline(&Match{ID: "m1", Status: MatchStatusLive})
+		for i, period := range periods {
+			timeline.Record(&TimelineEntry{ID: string(period), Type: TimelinePeriod, Period: period, Minute: i * 45})
+		}
+		return timeline
+	}
+
+	t.Run("Goals need a period in play", func(t *testing.T) {
+		goal := &TimelineEntry{Type: TimelineGoal, Side: MatchSideHome, Minute: 10}
+		if err := live().ValidateGoal(goal); err != ErrBallNotInPlay {
+			t.Errorf("expected ErrBallNotInPlay before kickoff, got %v", err)
+		}
+		if err := live(MatchPeriodFirstHalf).ValidateGoal(goal); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := live(MatchPeriodFirstHalf, MatchPeriodHalfTime).ValidateGoal(&TimelineEntry{Side: MatchSideHome, Minute: 45}); err != ErrBallNotInPlay {
+			t.Errorf("expected ErrBallNotInPlay at half time, got %v", err)
+		}
+	})
+
+	t.Run("Match must be live", func(t *testing.T) {
+		timeline := NewMatchTimeline(&Match{ID: "m1", Status: MatchStatusScheduled})
+		if err := timeline.ValidatePeriod(MatchPeriodFirstHalf, 0); err != ErrMatchNotLive {
+			t.Errorf("expected ErrMatchNotLive, got %v", err)
+		}
+	})
+
+	t.Run("Goal details", func(t *testing.T) {
+		timeline := live(MatchPeriodFirstHalf)
+		tests := []struct {
+			name     string
+			goal     *TimelineEntry
+			expected error
+		}{
+			{"Unknown side", &TimelineEntry{Side: "NEITHER", Minute: 10}, ErrInvalidSide},
+			{"Minute past extra time", &TimelineEntry{Side: MatchSideAway, Minute: 121}, ErrInvalidMinute},
+			{"Negative added time", &TimelineEntry{Side: MatchSideAway, Minute: 45, AddedTime: -1}, ErrInvalidMinute},
+			{"Own goal penalty", &TimelineEntry{Side: MatchSideAway, Minute: 10, OwnGoal: true, Penalty: true}, ErrOwnGoalAndPenalty},
+		}
+		for _, tt := range tests {
+			if err := timeline.ValidateGoal(tt.goal); err != tt.expected {
+				t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, err)
+			}
+		}
+	})
+
+	t.Run("Cards", func(t *testing.T) {
+		timeline := live(MatchPeriodFirstHalf)
+		if err := timeline.ValidateCard(&TimelineEntry{Side: MatchSideHome, Card: CardYellow, Minute: 20}); err != ErrPlayerRequired {
+			t.Errorf("expected ErrPlayerRequired, got %v", err)
+		}
+		if err := timeline.ValidateCard(&TimelineEntry{Side: MatchSideHome, Player: "Smith", Card: "GREEN", Minute: 20}); err != ErrInvalidCard {
+			t.Errorf("expected ErrInvalidCard, got %v", err)
+		}
+		if err := timeline.ValidateCard(&TimelineEntry{Side: MatchSideHome, Player: "Smith", Card: CardRed, Minute: 20}); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("Period transitions", func(t *testing.T) {
+		timeline := live(MatchPeriodFirstHalf)
+		if err := timeline.ValidatePeriod(MatchPeriodSecondHalf, 45); err != ErrInvalidPeriodTransition {
+			t.Errorf("expected ErrInvalidPeriodTransition, got %v", err)
+		}
+		if err := timeline.ValidatePeriod(MatchPeriodHalfTime, 30); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if err := live(MatchPeriodFirstHalf, MatchPeriodHalfTime).ValidatePeriod(MatchPeriodSecondHalf, 30); err != ErrMinuteBeforeLatestPeriod {
+			t.Errorf("expected ErrMinuteBeforeLatestPeriod, got %v", err)
+		}
+		if err := timeline.ValidatePeriod("OVERTIME", 45); err != ErrInvalidPeriod {
+			t.Errorf("expected ErrInvalidPeriod, got %v", err)
+		}
+	})
+
+	t.Run("Disallowing a goal", func(t *testing.T) {
+		timeline := live(MatchPeriodFirstHalf)
+		timeline.Record(&TimelineEntry{ID: "g1", Type: TimelineGoal, Side: MatchSideHome, Period: MatchPeriodFirstHalf, Minute: 12})
+
+		if _, err := timeline.ValidateDisallow("g2", 14, 0); err != ErrGoalNotFound {
+			t.Errorf("expected ErrGoalNotFound, got %v", err)
+		}
+		goal, err := timeline.ValidateDisallow("g1", 14, 0)
+		if err != nil || goal.ID != "g1" {
+			t.Fatalf("expected goal g1, got %v, %v", goal, err)
+		}
+
+		timeline.Record(&TimelineEntry{ID: "d1", Type: TimelineGoalDisallowed, GoalID: "g1", Minute: 14})
+		if !timeline.Goal("g1").Disallowed {
+			t.Errorf("expected goal g1 to be marked disallowed")
+		}
+		if _, err := timeline.ValidateDisallow("g1", 15, 0); err != ErrGoalAlreadyDisallowed {
+			t.Errorf("expected ErrGoalAlreadyDisallowed, got %v", err)
+		}
+	})
+}
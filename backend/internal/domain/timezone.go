@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	// Embed the time zone database so zones load on hosts without one
+	_ "time/tzdata"
+)
+
+// ErrInvalidTimeZone is returned for a time zone that is not an IANA name
+var ErrInvalidTimeZone = newFieldError("timeZone", "INVALID_TIME_ZONE", "time zone must be an IANA name such as Australia/Perth")
+
+// LoadTimeZone returns the location with the given IANA name. An empty name
+// is UTC. The server's own zone ("Local") is not accepted.
+func LoadTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, ErrInvalidTimeZone
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimeZone
+	}
+	return location, nil
+}
+
+// Kickoff is a match's kickoff in UTC, at the venue and in the viewer's own
+// time zone. Each time carries its zone's offset when encoded.
+type Kickoff struct {
+	UTC           time.Time `json:"utc"`
+	Venue         time.Time `json:"venue"`
+	VenueTimeZone string    `json:"venueTimeZone"`
+	Local         time.Time `json:"local"`
+	LocalTimeZone string    `json:"localTimeZone"`
+}
+
+// VenueLocation returns the time zone of the match's venue, or UTC if it was
+// not recorded or is no longer known
+func (m *Match) VenueLocation() *time.Location {
+	location, err := LoadTimeZone(m.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Kickoff returns the match's kickoff in UTC, at its venue and in the local
+// time zone
+func (m *Match) Kickoff(local *time.Location) Kickoff {
+	venue := m.VenueLocation()
+	return Kickoff{
+		UTC:           m.Date.UTC(),
+		Venue:         m.Date.In(venue),
+		VenueTimeZone: venue.String(),
+		Local:         m.Date.In(local),
+		LocalTimeZone: local.String(),
+	}
+}
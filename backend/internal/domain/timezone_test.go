@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadTimeZone(t *testing.T) {
+	tests := []struct {
+		name     string
+		zone     string
+		expected string
+		err      error
+	}{
+		{"Blank is UTC", "", "UTC", nil},
+		{"IANA name", "Australia/Perth", "Australia/Perth", nil},
+		{"Server zone", "Local", "", ErrInvalidTimeZone},
+		{"Unknown name", "Perth", "", ErrInvalidTimeZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, err := LoadTimeZone(tt.zone)
+			if err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if err == nil && location.String() != tt.expected {
+				t.Errorf("expected location %s, got %s", tt.expected, location)
+			}
+		})
+	}
+}
+
+func TestMatchKickoff(t *testing.T) {
+	perth, _ := time.LoadLocation("Australia/Perth")
+	london, _ := time.LoadLocation("Europe/London")
+
+	// 7:30pm in Perth is 12:30pm in London during the British summer
+	match := NewMatch("m1", "West Coast", "Fremantle", time.Date(2025, 8, 16, 19, 30, 0, 0, perth), "AFL")
+	match.TimeZone = "Australia/Perth"
+
+	kickoff := match.Kickoff(london)
+
+	if kickoff.UTC.Location() != time.UTC || kickoff.UTC.Hour() != 11 {
+		t.Errorf("expected 11:30 UTC, got %v", kickoff.UTC)
+	}
+	if kickoff.VenueTimeZone != "Australia/Perth" || kickoff.Venue.Hour() != 19 {
+		t.Errorf("expected 19:30 at the venue, got %v in %s", kickoff.Venue, kickoff.VenueTimeZone)
+	}
+	if kickoff.LocalTimeZone != "Europe/London" || kickoff.Local.Hour() != 12 {
+		t.Errorf("expected 12:30 in London, got %v in %s", kickoff.Local, kickoff.LocalTimeZone)
+	}
+	if !kickoff.Local.Equal(kickoff.UTC) || !kickoff.Venue.Equal(kickoff.UTC) {
+		t.Errorf("expected every kickoff time to be the same instant")
+	}
+}
+
+func TestMatchVenueLocation(t *testing.T) {
+	match := NewMatch("m1", "Team A", "Team B", time.Now(), "EPL")
+	if match.VenueLocation() != time.UTC {
+		t.Errorf("expected a venue without a time zone to be UTC, got %v", match.VenueLocation())
+	}
+
+	match.TimeZone = "Nowhere/Special"
+	if match.VenueLocation() != time.UTC {
+		t.Errorf("expected an unknown venue time zone to be UTC, got %v", match.VenueLocation())
+	}
+}
@@ -1,32 +1,74 @@
 package domain
 
 import (
+	"net/mail"
+	"strings"
 	"time"
 )
 
+// User errors
+var (
+	ErrInvalidUsername = newFieldError("username", "INVALID_USERNAME", "username must be 3 to 30 letters, digits, dots, dashes or underscores")
+	ErrInvalidEmail    = newFieldError("email", "INVALID_EMAIL", "email must be a valid address")
+	ErrUsernameTaken   = newFieldError("username", "USERNAME_TAKEN", "username is already taken")
+	ErrEmailTaken      = newFieldError("email", "EMAIL_TAKEN", "email is already registered")
+	ErrUnknownUser     = newFieldError("userId", "UNKNOWN_USER", "user is not registered")
+	ErrUserDeactivated = newFieldError("userId", "USER_DEACTIVATED", "user has been deactivated")
+)
+
 // User represents a user in the system
 type User struct {
-	ID       string
-	Username string
-	Email    string
-	JoinDate time.Time
-	Stats    UserStats
+	ID            string     `json:"id"`
+	Username      string     `json:"username"`
+	Email         string     `json:"email"`
+	TimeZone      string     `json:"timeZone"`
+	JoinDate      time.Time  `json:"joinDate"`
+	DeactivatedAt *time.Time `json:"deactivatedAt,omitempty"`
+	Stats         UserStats  `json:"stats"`
 }
 
 // UserStats represents a user's statistics
 type UserStats struct {
-	TotalPoints        int
-	CorrectPredictions int
-	TotalPredictions   int
-	CurrentRank        int
+	TotalPoints        int `json:"totalPoints"`
+	CorrectPredictions int `json:"correctPredictions"`
+	TotalPredictions   int `json:"totalPredictions"`
+	CurrentRank        int `json:"currentRank"`
+}
+
+// UserPreferences are a user's display settings
+type UserPreferences struct {
+	UserID    string     `json:"userId"`
+	TimeZone  string     `json:"timeZone"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// NewUserPreferences returns the preferences of a user who has not set any,
+// showing times in UTC
+func NewUserPreferences(userID string) *UserPreferences {
+	return &UserPreferences{
+		UserID:   userID,
+		TimeZone: "UTC",
+	}
+}
+
+// Location returns the user's preferred time zone, or UTC if it is no longer
+// known
+func (p *UserPreferences) Location() *time.Location {
+	location, err := LoadTimeZone(p.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
 }
 
-// NewUser creates a new user instance
+// NewUser creates a new user instance. The username and email are trimmed,
+// and the email is stored lower case.
 func NewUser(id, username, email string) *User {
 	return &User{
 		ID:       id,
-		Username: username,
-		Email:    email,
+		Username: strings.TrimSpace(username),
+		Email:    strings.ToLower(strings.TrimSpace(email)),
+		TimeZone: "UTC",
 		JoinDate: time.Now(),
 		Stats: UserStats{
 			TotalPoints:        0,
@@ -37,6 +79,63 @@ func NewUser(id, username, email string) *User {
 	}
 }
 
+// Validate checks the user's username and email
+func (u *User) Validate() error {
+	if len(u.Username) < 3 || len(u.Username) > 30 {
+		return ErrInvalidUsername
+	}
+	for _, r := range u.Username {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '.' && r != '-' && r != '_' {
+			return ErrInvalidUsername
+		}
+	}
+	address, err := mail.ParseAddress(u.Email)
+	if err != nil || address.Address != u.Email {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// IsActive returns true until the user is deactivated
+func (u *User) IsActive() bool {
+	return u.DeactivatedAt == nil
+}
+
+// Deactivate closes the user's account. Deactivated users keep their
+// history but can no longer tip.
+func (u *User) Deactivate(at time.Time) error {
+	if !u.IsActive() {
+		return ErrUserDeactivated
+	}
+	u.DeactivatedAt = &at
+	return nil
+}
+
+// CanTip returns nil if the user may make or change tips, or why not
+func (u *User) CanTip() error {
+	if !u.IsActive() {
+		return ErrUserDeactivated
+	}
+	return nil
+}
+
+// CheckUserUnique returns an error if another of the users already has the
+// user's username or email, ignoring case. Deactivated users keep theirs.
+func CheckUserUnique(users []*User, user *User) error {
+	for _, other := range users {
+		if other.ID == user.ID {
+			continue
+		}
+		if strings.EqualFold(other.Username, user.Username) {
+			return ErrUsernameTaken
+		}
+		if strings.EqualFold(other.Email, user.Email) {
+			return ErrEmailTaken
+		}
+	}
+	return nil
+}
+
 // UpdateStats updates the user's statistics
 func (u *User) UpdateStats(points int, isCorrect bool) {
 	u.Stats.TotalPoints += points
@@ -53,3 +152,30 @@ func (u *User) GetSuccessRate() float64 {
 	}
 	return float64(u.Stats.CorrectPredictions) / float64(u.Stats.TotalPredictions) * 100
 }
+
+// UpdateFromLeaderboard sets the user's points, tip counts and current rank
+// from a leaderboard built by BuildLeaderboard. A user not on it has none.
+func (u *User) UpdateFromLeaderboard(leaderboard []*LeaderboardEntry) {
+	u.Stats.TotalPoints, u.Stats.CorrectPredictions, u.Stats.TotalPredictions = 0, 0, 0
+	for _, entry := range leaderboard {
+		if entry.UserID == u.ID {
+			u.Stats.TotalPoints = entry.Points
+			u.Stats.CorrectPredictions = entry.CorrectPredictions
+			u.Stats.TotalPredictions = entry.TotalPredictions
+		}
+	}
+	u.UpdateRank(leaderboard)
+}
+
+// UpdateRank sets the user's current rank from a leaderboard built by
+// BuildLeaderboard, so it always agrees with the leaderboard's ordering and
+// tiebreakers. A user not on the leaderboard is unranked (0).
+func (u *User) UpdateRank(leaderboard []*LeaderboardEntry) {
+	u.Stats.CurrentRank = 0
+	for _, entry := range leaderboard {
+		if entry.UserID == u.ID {
+			u.Stats.CurrentRank = entry.Rank
+			return
+		}
+	}
+}
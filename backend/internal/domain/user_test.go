@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestNewUser(t *testing.T) {
@@ -66,6 +68,44 @@ func TestUpdateStats(t *testing.T) {
 	}
 }
 
+func TestUpdateRank(t *testing.T) {
+	user := NewUser("user2", "testuser", "test@example.com")
+	leaderboard := BuildLeaderboard([]PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 3, ExactScore: true},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p2", Points: 3},
+	}, DefaultTiebreakers())
+
+	// Test case 1: Rank taken from the leaderboard, tiebreakers included
+	user.UpdateRank(leaderboard)
+	if user.Stats.CurrentRank != 2 {
+		t.Errorf("expected CurrentRank 2, got %v", user.Stats.CurrentRank)
+	}
+
+	// Test case 2: Fully level users share a rank
+	level := BuildLeaderboard([]PointsAward{
+		{UserID: "user1", Source: PointsSourceMatch, SourceID: "m1", Key: "p1", Points: 3},
+		{UserID: "user2", Source: PointsSourceMatch, SourceID: "m1", Key: "p2", Points: 3},
+		{UserID: "user3", Source: PointsSourceMatch, SourceID: "m1", Key: "p3", Points: 1},
+	}, DefaultTiebreakers())
+	user.UpdateFromLeaderboard(level)
+	if user.Stats.CurrentRank != 1 || user.Stats.TotalPoints != 3 || user.Stats.TotalPredictions != 1 {
+		t.Errorf("expected a shared first place on 3 points, got %+v", user.Stats)
+	}
+	for _, entry := range level {
+		other := NewUser(entry.UserID, entry.UserID, entry.UserID+"@example.com")
+		other.UpdateFromLeaderboard(level)
+		if other.Stats.CurrentRank != entry.Rank {
+			t.Errorf("expected %s ranked %d as on the leaderboard, got %d", entry.UserID, entry.Rank, other.Stats.CurrentRank)
+		}
+	}
+
+	// Test case 3: Not on the leaderboard
+	user.UpdateRank(nil)
+	if user.Stats.CurrentRank != 0 {
+		t.Errorf("expected CurrentRank 0, got %v", user.Stats.CurrentRank)
+	}
+}
+
 func TestGetSuccessRate(t *testing.T) {
 	user := NewUser("user123", "testuser", "test@example.com")
 
@@ -86,3 +126,75 @@ func TestGetSuccessRate(t *testing.T) {
 		t.Errorf("expected success rate 50, got %v", user.GetSuccessRate())
 	}
 }
+
+func TestValidateUser(t *testing.T) {
+	testCases := []struct {
+		name     string
+		username string
+		email    string
+		expected error
+	}{
+		{"Valid user", "alice_99", "Alice@Example.com", nil},
+		{"Username too short", "al", "alice@example.com", ErrInvalidUsername},
+		{"Username with spaces", "alice smith", "alice@example.com", ErrInvalidUsername},
+		{"Email without domain", "alice", "alice", ErrInvalidEmail},
+		{"Email with display name", "alice", "Alice <alice@example.com>", ErrInvalidEmail},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewUser("user1", tc.username, tc.email).Validate()
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("expected error %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	user := NewUser("user1", "  alice ", " Alice@Example.com ")
+	if user.Username != "alice" || user.Email != "alice@example.com" {
+		t.Errorf("expected trimmed username and lower case email, got %q and %q", user.Username, user.Email)
+	}
+}
+
+func TestCheckUserUnique(t *testing.T) {
+	users := []*User{
+		NewUser("user1", "alice", "alice@example.com"),
+		NewUser("user2", "bob", "bob@example.com"),
+	}
+
+	// Test case 1: Username taken, ignoring case
+	if err := CheckUserUnique(users, NewUser("user3", "Alice", "carol@example.com")); !errors.Is(err, ErrUsernameTaken) {
+		t.Errorf("expected ErrUsernameTaken, got %v", err)
+	}
+
+	// Test case 2: Email taken
+	if err := CheckUserUnique(users, NewUser("user3", "carol", "BOB@example.com")); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("expected ErrEmailTaken, got %v", err)
+	}
+
+	// Test case 3: A user keeping their own username and email
+	if err := CheckUserUnique(users, NewUser("user1", "alice", "alice@example.com")); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestDeactivateUser(t *testing.T) {
+	user := NewUser("user1", "alice", "alice@example.com")
+	if !user.IsActive() || user.CanTip() != nil {
+		t.Fatalf("expected a new user to be active and able to tip")
+	}
+
+	at := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+	if err := user.Deactivate(at); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if user.IsActive() || !user.DeactivatedAt.Equal(at) {
+		t.Errorf("expected user deactivated at %v, got %v", at, user.DeactivatedAt)
+	}
+	if err := user.CanTip(); !errors.Is(err, ErrUserDeactivated) {
+		t.Errorf("expected ErrUserDeactivated, got %v", err)
+	}
+	if err := user.Deactivate(at); !errors.Is(err, ErrUserDeactivated) {
+		t.Errorf("expected ErrUserDeactivated deactivating twice, got %v", err)
+	}
+}
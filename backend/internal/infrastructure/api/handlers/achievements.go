@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+)
+
+type AchievementHandler struct {
+	eventStore  EventStore
+	leaderboard *LeaderboardHandler
+}
+
+func NewAchievementHandler(eventStore EventStore, matchRepo repository.MatchRepository) *AchievementHandler {
+	return &AchievementHandler{
+		eventStore:  eventStore,
+		leaderboard: NewLeaderboardHandler(eventStore, matchRepo),
+	}
+}
+
+// ListAchievements retrieves the catalogue of badges that can be unlocked
+func (h *AchievementHandler) ListAchievements(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(domain.AchievementCatalogue()); err != nil {
+		fmt.Printf("error encoding achievements: %v\n", err)
+	}
+}
+
+// GetUserBadges retrieves a user's streaks, perfect rounds and exact-score
+// count with the badges they have unlocked, oldest first. Use ?leagueId= for
+// their statistics within a private league.
+func (h *AchievementHandler) GetUserBadges(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	league, ok := requestLeague(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	var stats *domain.AchievementStats
+	if league == nil {
+		users, err := eventhandlers.LoadAchievementStats(r.Context(), h.eventStore)
+		if err != nil {
+			writeError(w, "Failed to retrieve achievements", http.StatusInternalServerError)
+			return
+		}
+		stats = eventhandlers.FindAchievementStats(users, userID)
+	} else if stats, ok = h.leagueStats(w, r, league, userID); !ok {
+		return
+	}
+
+	badges, err := eventhandlers.LoadBadges(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve badges", http.StatusInternalServerError)
+		return
+	}
+
+	achievements := domain.UserAchievements{
+		Stats:  stats,
+		Badges: make([]*domain.Badge, 0),
+	}
+	for _, badge := range badges {
+		if badge.UserID == userID {
+			achievements.Badges = append(achievements.Badges, badge)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(achievements); err != nil {
+		fmt.Printf("error encoding badges: %v\n", err)
+	}
+}
+
+// leagueStats works out the user's statistics within the league: its
+// members' tips on the matches in the league's scope, scored by the league's
+// own rules if it has them. It writes an error response and returns false if
+// they cannot be loaded.
+func (h *AchievementHandler) leagueStats(w http.ResponseWriter, r *http.Request, league *domain.League, userID string) (*domain.AchievementStats, bool) {
+	scope, ok := h.leaderboard.rankedScope(w, r, league)
+	if !ok {
+		return nil, false
+	}
+	inScope := func(matchID string) bool {
+		return scope.matches == nil || scope.matches[matchID]
+	}
+
+	tipEvents, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		return nil, false
+	}
+	tips, err := eventhandlers.ReplayPredictions(tipEvents, "")
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return nil, false
+	}
+	members := league.MemberIDs()
+	leagueTips := make([]*domain.Prediction, 0, len(tips))
+	for _, tip := range tips {
+		if members[tip.UserID] && inScope(tip.MatchID) {
+			leagueTips = append(leagueTips, tip)
+		}
+	}
+
+	matches, err := eventhandlers.LoadScheduledMatches(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve matches", http.StatusInternalServerError)
+		return nil, false
+	}
+	leagueMatches := make([]*domain.Match, 0, len(matches))
+	for _, match := range matches {
+		if inScope(match.ID) {
+			leagueMatches = append(leagueMatches, match)
+		}
+	}
+
+	users := domain.BuildAchievementStats(leagueTips, scope.awards, leagueMatches)
+	return eventhandlers.FindAchievementStats(users, userID), true
+}
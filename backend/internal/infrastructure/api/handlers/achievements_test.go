@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectBadges sets up the event store for badges to be worked out after a
+// tip is made or scored, returning nothing for the event types the test does
+// not set up itself, and accepts any badge awarded. Call it after the test's
+// own expectations so they take precedence.
+func expectBadges(mockStore *mocks.MockEventStore, ctx context.Context) {
+	for _, eventType := range []string{"PredictionMade", "PredictionAmended", "PredictionWithdrawn", "PointsAwarded", "MatchCreated", "MatchRescheduled", "BadgeAwarded"} {
+		mockStore.On("GetEventsByType", ctx, eventType).Return([]*events.Event{}, nil).Maybe()
+	}
+	mockStore.On("SaveEvent", ctx, mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "BadgeAwarded"
+	})).Return(nil).Maybe()
+}
+
+func TestListAchievements(t *testing.T) {
+	handler := NewAchievementHandler(new(mocks.MockEventStore), new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("GET", "/api/badges", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ListAchievements(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var catalogue []domain.Achievement
+	if err := json.NewDecoder(rr.Body).Decode(&catalogue); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(catalogue) != len(domain.AchievementCatalogue()) {
+		t.Errorf("expected %d achievements, got %d", len(domain.AchievementCatalogue()), len(catalogue))
+	}
+}
+
+func TestGetUserBadges(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewAchievementHandler(mockStore, new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("GET", "/api/users/user1/badges", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+	event := func(eventType string, data interface{}) *events.Event {
+		return &events.Event{ID: "event-" + eventType, Type: eventType, Data: data, Timestamp: time.Now(), Version: 1}
+	}
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+		event("PredictionMade", events.PredictionMade{ID: "p1", UserID: "user1", MatchID: "m1", HomeGoals: 2, AwayGoals: 1}),
+		event("PredictionMade", events.PredictionMade{ID: "p2", UserID: "user1", MatchID: "m2", HomeGoals: 0, AwayGoals: 0}),
+		event("PredictionMade", events.PredictionMade{ID: "p3", UserID: "user1", MatchID: "m3", HomeGoals: 1, AwayGoals: 1}),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	// A withdrawn tip does not count towards the tips made
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{
+		event("PredictionWithdrawn", events.PredictionWithdrawn{PredictionID: "p3", UserID: "user1", MatchID: "m3"}),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{
+		event("PointsAwarded", events.PointsAwarded{PredictionID: "p1", UserID: "user1", MatchID: "m1", Points: 3,
+			Breakdown: []events.RuleAward{{Rule: domain.RuleExactScore, Points: 3}}}),
+		event("PointsAwarded", events.PointsAwarded{PredictionID: "p2", UserID: "user1", MatchID: "m2", Points: 1}),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "MatchCreated").Return([]*events.Event{
+		event("MatchCreated", events.MatchCreated{ID: "m1", HomeTeam: "Team A", AwayTeam: "Team B", RoundID: "round1"}),
+		event("MatchCreated", events.MatchCreated{ID: "m2", HomeTeam: "Team C", AwayTeam: "Team D", RoundID: "round1"}),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "MatchRescheduled").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "BadgeAwarded").Return([]*events.Event{
+		event("BadgeAwarded", events.BadgeAwarded{UserID: "user1", BadgeID: "FIRST_TIP", Name: "Off the Mark"}),
+		event("BadgeAwarded", events.BadgeAwarded{UserID: "user2", BadgeID: "FIRST_TIP", Name: "Off the Mark"}),
+		event("BadgeAwarded", events.BadgeAwarded{UserID: "user1", BadgeID: "EXACT_1", Name: "Bullseye"}),
+	}, nil)
+
+	handler.GetUserBadges(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var achievements domain.UserAchievements
+	if err := json.NewDecoder(rr.Body).Decode(&achievements); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := domain.AchievementStats{UserID: "user1", TipsMade: 2, CorrectTips: 2, CurrentStreak: 2, BestStreak: 2, ExactScores: 1, PerfectRounds: 1}
+	if *achievements.Stats != want {
+		t.Errorf("expected stats %+v, got %+v", want, *achievements.Stats)
+	}
+	if len(achievements.Badges) != 2 || achievements.Badges[0].BadgeID != "FIRST_TIP" || achievements.Badges[1].BadgeID != "EXACT_1" {
+		t.Fatalf("expected user1's two badges oldest first, got %+v", achievements.Badges)
+	}
+	if achievements.Badges[1].Description != "Tipped an exact score" {
+		t.Errorf("expected the catalogue description, got %q", achievements.Badges[1].Description)
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestGetUserBadgesInLeague(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewAchievementHandler(mockStore, new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("GET", "/api/users/user1/badges?leagueId=league1", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+	jokers := false
+	expectLeagues(mockStore, req.Context(), append(testLeagueEvents(), &events.Event{
+		ID:        "event-l3",
+		Type:      "LeagueRulesChanged",
+		Data:      events.LeagueRulesChanged{LeagueID: "league1", Jokers: &jokers},
+		Timestamp: time.Now().Add(2 * time.Second),
+		Version:   1,
+	}))
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+		events.NewEvent("PredictionMade", events.PredictionMade{ID: "p1", UserID: "user1", MatchID: "m1", HomeGoals: 2, AwayGoals: 1}),
+		events.NewEvent("PredictionMade", events.PredictionMade{ID: "p2", UserID: "user3", MatchID: "m1", HomeGoals: 2, AwayGoals: 1}),
+	}, nil)
+	// The overall scoring gives p1 a point for the result; the league's own
+	// rules count it as an exact score
+	mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{
+		events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p1", UserID: "user1", MatchID: "m1", Points: 1}),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "LeaguePointsAwarded").Return([]*events.Event{
+		events.NewEvent("LeaguePointsAwarded", events.LeaguePointsAwarded{
+			LeagueID: "league1",
+			PointsAwarded: events.PointsAwarded{PredictionID: "p1", UserID: "user1", MatchID: "m1", Points: 5,
+				Breakdown: []events.RuleAward{{Rule: domain.RuleExactScore, Points: 5}}},
+		}),
+	}, nil)
+	expectBadges(mockStore, req.Context())
+
+	handler.GetUserBadges(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	var achievements domain.UserAchievements
+	if err := json.NewDecoder(rr.Body).Decode(&achievements); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := domain.AchievementStats{UserID: "user1", TipsMade: 1, CorrectTips: 1, CurrentStreak: 1, BestStreak: 1, ExactScores: 1}
+	if *achievements.Stats != want {
+		t.Errorf("expected league stats %+v, got %+v", want, *achievements.Stats)
+	}
+}
+
+func TestFirstTipUnlocksBadge(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockPredictionRepository)
+	handler := NewPredictionHandler(mockStore, mockRepo)
+
+	body := `{"userId": "user1", "matchId": "m2", "homeGoals": 1, "awayGoals": 0}`
+	req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	matchCreated := &events.Event{
+		ID:        "event-m2",
+		Type:      "MatchCreated",
+		Data:      events.MatchCreated{ID: "m2", HomeTeam: "Team A", AwayTeam: "Team B", Date: time.Now().Add(24 * time.Hour), Competition: "Premier League"},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+	// By the time badges are worked out the event store holds the new tip
+	// alongside user2's, whose first-tip badge is already awarded
+	tipEvents := []*events.Event{
+		{ID: "event-p1", Type: "PredictionMade", Data: events.PredictionMade{ID: "p1", UserID: "user2", MatchID: "m1"}, Timestamp: time.Now(), Version: 1},
+		{ID: "event-p2", Type: "PredictionMade", Data: events.PredictionMade{ID: "p2", UserID: "user1", MatchID: "m2"}, Timestamp: time.Now(), Version: 1},
+	}
+	badgeEvents := []*events.Event{
+		{ID: "event-b1", Type: "BadgeAwarded", Data: events.BadgeAwarded{UserID: "user2", BadgeID: "FIRST_TIP"}, Timestamp: time.Now(), Version: 1},
+	}
+
+	mockStore.On("GetEvents", req.Context(), "m2").Return([]*events.Event{matchCreated}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return(tipEvents[:1], nil).Once()
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return(tipEvents, nil).Once()
+	mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "MatchCreated").Return([]*events.Event{matchCreated}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "MatchRescheduled").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "BadgeAwarded").Return(badgeEvents, nil)
+	mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+	mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "PredictionMade"
+	})).Return(nil)
+
+	var awarded []events.BadgeAwarded
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "BadgeAwarded"
+	})).Run(func(args mock.Arguments) {
+		awarded = append(awarded, args.Get(1).(*events.Event).Data.(events.BadgeAwarded))
+	}).Return(nil)
+	expectUsers(mockStore, req.Context(), "user1")
+
+	handler.CreatePrediction(rr, req)
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+	}
+	if len(awarded) != 1 || awarded[0].UserID != "user1" || awarded[0].BadgeID != "FIRST_TIP" || awarded[0].Value != 1 {
+		t.Errorf("expected only user1 to be awarded FIRST_TIP, got %+v", awarded)
+	}
+	mockStore.AssertExpectations(t)
+}
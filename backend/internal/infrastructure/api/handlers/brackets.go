@@ -0,0 +1,527 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type BracketHandler struct {
+	eventStore     EventStore
+	scoringHandler *eventhandlers.BracketScoringHandler
+	now            func() time.Time
+}
+
+func NewBracketHandler(eventStore EventStore) *BracketHandler {
+	return &BracketHandler{
+		eventStore:     eventStore,
+		scoringHandler: eventhandlers.NewBracketScoringHandler(eventStore),
+		now:            time.Now,
+	}
+}
+
+// bracketView is a bracket together with the winners of the ties decided so far
+type bracketView struct {
+	*domain.Bracket
+	Winners map[string]string `json:"winners"`
+}
+
+// createBracketRequest is the body accepted when setting up a bracket
+type createBracketRequest struct {
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId"`
+	Name          string    `json:"name"`
+	LockAt        time.Time `json:"lockAt"`
+	RoundWeights  []int     `json:"roundWeights"`
+	Ties          []struct {
+		ID           string `json:"id"`
+		Round        int    `json:"round"`
+		HomeTeam     string `json:"homeTeam"`
+		HomeWinnerOf string `json:"homeWinnerOf"`
+		AwayTeam     string `json:"awayTeam"`
+		AwayWinnerOf string `json:"awayWinnerOf"`
+		MatchID      string `json:"matchId"`
+	} `json:"ties"`
+}
+
+func (r createBracketRequest) validate(errs *fieldErrors) {
+	errs.required("competitionId", r.CompetitionID)
+	errs.required("name", r.Name)
+	errs.requiredTime("lockAt", r.LockAt)
+}
+
+// CreateBracket handles setting up a knockout bracket for a competition.
+// Each side of a tie is either a team, given by ID, name, short code or
+// alias, or the winner of an earlier tie. Ties may be linked to their
+// matches now or once the fixtures are known.
+func (h *BracketHandler) CreateBracket(w http.ResponseWriter, r *http.Request) {
+	var request createBracketRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(request.CompetitionID)
+	if competition == nil {
+		writeDomainError(w, "Invalid bracket", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, request.CompetitionID), http.StatusBadRequest)
+		return
+	}
+
+	if request.SeasonID != "" {
+		season := catalog.Season(request.SeasonID)
+		if season == nil || season.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid bracket", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, request.SeasonID, competition.Name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	ties := make([]*domain.BracketTie, 0, len(request.Ties))
+	for _, tieRequest := range request.Ties {
+		tie := &domain.BracketTie{
+			ID:      tieRequest.ID,
+			Round:   tieRequest.Round,
+			Home:    domain.TieSlot{WinnerOf: tieRequest.HomeWinnerOf},
+			Away:    domain.TieSlot{WinnerOf: tieRequest.AwayWinnerOf},
+			MatchID: tieRequest.MatchID,
+		}
+		if tieRequest.HomeTeam != "" {
+			team, ok := resolveTeam(w, registry, "ties", "", tieRequest.HomeTeam)
+			if !ok {
+				return
+			}
+			tie.Home.TeamID = team.ID
+		}
+		if tieRequest.AwayTeam != "" {
+			team, ok := resolveTeam(w, registry, "ties", "", tieRequest.AwayTeam)
+			if !ok {
+				return
+			}
+			tie.Away.TeamID = team.ID
+		}
+		ties = append(ties, tie)
+	}
+
+	bracket := domain.NewBracket(
+		utils.GenerateID(),
+		competition.ID,
+		request.Name,
+		request.LockAt,
+		request.RoundWeights,
+		ties,
+	)
+	bracket.SeasonID = request.SeasonID
+
+	if err := bracket.Validate(); err != nil {
+		writeDomainError(w, "Invalid bracket", err, http.StatusBadRequest)
+		return
+	}
+
+	for _, tie := range bracket.Ties {
+		if tie.MatchID == "" {
+			continue
+		}
+		if _, ok := h.loadTieMatch(w, r, bracket, tie, tie.MatchID, map[string]string{}); !ok {
+			return
+		}
+	}
+
+	tieEvents := make([]events.BracketTie, 0, len(bracket.Ties))
+	for _, tie := range bracket.Ties {
+		tieEvents = append(tieEvents, events.BracketTie{
+			ID:           tie.ID,
+			Round:        tie.Round,
+			HomeTeamID:   tie.Home.TeamID,
+			HomeWinnerOf: tie.Home.WinnerOf,
+			AwayTeamID:   tie.Away.TeamID,
+			AwayWinnerOf: tie.Away.WinnerOf,
+			MatchID:      tie.MatchID,
+		})
+	}
+
+	event := events.NewEvent("BracketCreated", events.BracketCreated{
+		ID:            bracket.ID,
+		CompetitionID: bracket.CompetitionID,
+		SeasonID:      bracket.SeasonID,
+		Name:          bracket.Name,
+		LockAt:        bracket.LockAt,
+		RoundWeights:  bracket.RoundWeights,
+		Ties:          tieEvents,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create bracket", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(bracket); err != nil {
+		fmt.Printf("error encoding bracket: %v\n", err)
+	}
+}
+
+// ListBrackets retrieves all brackets, optionally filtered by competition ID
+func (h *BracketHandler) ListBrackets(w http.ResponseWriter, r *http.Request) {
+	brackets, err := eventhandlers.LoadBrackets(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve brackets", http.StatusInternalServerError)
+		return
+	}
+
+	if competitionID := r.URL.Query().Get("competitionId"); competitionID != "" {
+		filtered := make([]*domain.Bracket, 0)
+		for _, bracket := range brackets {
+			if bracket.CompetitionID == competitionID {
+				filtered = append(filtered, bracket)
+			}
+		}
+		brackets = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(brackets); err != nil {
+		fmt.Printf("error encoding brackets: %v\n", err)
+	}
+}
+
+// GetBracket retrieves a bracket with the winners of the ties decided so far
+func (h *BracketHandler) GetBracket(w http.ResponseWriter, r *http.Request) {
+	bracket, ok := h.findBracket(w, r)
+	if !ok {
+		return
+	}
+
+	winners, err := h.tieWinners(r.Context(), bracket)
+	if err != nil {
+		writeError(w, "Failed to retrieve bracket results", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(bracketView{Bracket: bracket, Winners: winners}); err != nil {
+		fmt.Printf("error encoding bracket: %v\n", err)
+	}
+}
+
+// linkTieMatchRequest is the body accepted when linking a tie to its match
+type linkTieMatchRequest struct {
+	MatchID string `json:"matchId"`
+}
+
+func (r linkTieMatchRequest) validate(errs *fieldErrors) {
+	errs.required("matchId", r.MatchID)
+}
+
+// LinkTieMatch handles setting the match that decides a tie, e.g. once the
+// teams in a later round are known. The match must be between the teams
+// known to be in the tie. If the match has already finished, the tie is
+// scored straight away.
+func (h *BracketHandler) LinkTieMatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	tieID := vars["tieId"]
+
+	var request linkTieMatchRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	bracket, ok := h.findBracket(w, r)
+	if !ok {
+		return
+	}
+
+	tie := bracket.Tie(tieID)
+	if tie == nil {
+		writeError(w, "Tie not found", http.StatusNotFound)
+		return
+	}
+
+	winners, err := h.tieWinners(r.Context(), bracket)
+	if err != nil {
+		writeError(w, "Failed to retrieve bracket results", http.StatusInternalServerError)
+		return
+	}
+
+	if winners[tie.ID] != "" {
+		writeDomainError(w, fmt.Sprintf("Cannot link match to tie %s", tie.ID), domain.ErrTieDecided, http.StatusConflict)
+		return
+	}
+
+	match, ok := h.loadTieMatch(w, r, bracket, tie, request.MatchID, winners)
+	if !ok {
+		return
+	}
+
+	event := events.NewEvent("BracketTieMatchLinked", events.BracketTieMatchLinked{
+		BracketID: bracket.ID,
+		TieID:     tie.ID,
+		MatchID:   match.ID,
+		LinkedAt:  h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to link match", http.StatusInternalServerError)
+		return
+	}
+
+	tie.MatchID = match.ID
+
+	// Score the tie now if its match has already been played
+	if match.IsFinished() {
+		if err := h.scoringHandler.ScoreMatch(r.Context(), match.ID); err != nil {
+			fmt.Printf("Failed to score bracket %s for match %s: %v\n", bracket.ID, match.ID, err)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tie); err != nil {
+		fmt.Printf("error encoding tie: %v\n", err)
+	}
+}
+
+// bracketEntryRequest is the body accepted when a user submits their bracket
+type bracketEntryRequest struct {
+	UserID string            `json:"userId"`
+	Picks  map[string]string `json:"picks"`
+}
+
+func (r bracketEntryRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// SubmitEntry handles a user submitting their picks for a whole bracket
+// before it locks. Picks map each tie ID to the team expected to advance,
+// given by ID, name, short code or alias. Submitting again before the lock
+// replaces the user's picks.
+func (h *BracketHandler) SubmitEntry(w http.ResponseWriter, r *http.Request) {
+	var request bracketEntryRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	bracket, ok := h.findBracket(w, r)
+	if !ok {
+		return
+	}
+
+	if bracket.IsLocked(h.now()) {
+		writeDomainError(w, "Invalid bracket entry", fmt.Errorf("%w at %s", domain.ErrBracketLocked, bracket.LockAt.Format(time.RFC3339)), http.StatusBadRequest)
+		return
+	}
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	picks := make(map[string]string, len(request.Picks))
+	for tieID, teamRef := range request.Picks {
+		team, ok := resolveTeam(w, registry, "picks", "", teamRef)
+		if !ok {
+			return
+		}
+		picks[tieID] = team.ID
+	}
+
+	if err := bracket.ValidatePicks(picks); err != nil {
+		writeDomainError(w, "Invalid bracket entry", err, http.StatusBadRequest)
+		return
+	}
+
+	entries, err := eventhandlers.LoadBracketEntries(r.Context(), h.eventStore, bracket.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve bracket entries", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusCreated
+	entry := domain.NewBracketEntry(utils.GenerateID(), bracket.ID, request.UserID, picks, h.now())
+	if existing := eventhandlers.FindBracketEntry(entries, request.UserID); existing != nil {
+		entry.ID = existing.ID
+		status = http.StatusOK
+	}
+
+	event := events.NewEvent("BracketEntrySubmitted", events.BracketEntrySubmitted{
+		ID:          entry.ID,
+		BracketID:   entry.BracketID,
+		UserID:      entry.UserID,
+		Picks:       entry.Picks,
+		SubmittedAt: entry.SubmittedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to submit bracket entry", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		fmt.Printf("error encoding bracket entry: %v\n", err)
+	}
+}
+
+// ListEntries retrieves every entry in a bracket, highest points first. Use
+// ?leagueId= to list only the entries of that league's members.
+func (h *BracketHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
+	bracket, ok := h.findBracket(w, r)
+	if !ok {
+		return
+	}
+
+	members, ok := leagueMembers(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	entries, err := eventhandlers.LoadBracketEntries(r.Context(), h.eventStore, bracket.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve bracket entries", http.StatusInternalServerError)
+		return
+	}
+
+	if members != nil {
+		filtered := make([]*domain.BracketEntry, 0, len(entries))
+		for _, entry := range entries {
+			if members[entry.UserID] {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Points > entries[j].Points
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		fmt.Printf("error encoding bracket entries: %v\n", err)
+	}
+}
+
+// GetUserEntry retrieves a user's entry in a bracket
+func (h *BracketHandler) GetUserEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	bracket, ok := h.findBracket(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := eventhandlers.LoadBracketEntries(r.Context(), h.eventStore, bracket.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve bracket entries", http.StatusInternalServerError)
+		return
+	}
+
+	entry := eventhandlers.FindBracketEntry(entries, userID)
+	if entry == nil {
+		writeError(w, "Bracket entry not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		fmt.Printf("error encoding bracket entry: %v\n", err)
+	}
+}
+
+// findBracket writes an error response and returns false if the bracket
+// named in the URL does not exist
+func (h *BracketHandler) findBracket(w http.ResponseWriter, r *http.Request) (*domain.Bracket, bool) {
+	vars := mux.Vars(r)
+	bracketID := vars["id"]
+
+	brackets, err := eventhandlers.LoadBrackets(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve brackets", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	bracket := eventhandlers.FindBracket(brackets, bracketID)
+	if bracket == nil {
+		writeError(w, "Bracket not found", http.StatusNotFound)
+		return nil, false
+	}
+	return bracket, true
+}
+
+// loadTieMatch writes an error response and returns false if the match does
+// not exist or is not played between the teams known to be in the tie
+func (h *BracketHandler) loadTieMatch(w http.ResponseWriter, r *http.Request, bracket *domain.Bracket, tie *domain.BracketTie, matchID string, winners map[string]string) (*domain.Match, bool) {
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), matchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if len(matchEvents) == 0 {
+		writeDomainError(w, fmt.Sprintf("Cannot link match to tie %s", tie.ID), fmt.Errorf("%w %q", domain.ErrUnknownMatch, matchID), http.StatusBadRequest)
+		return nil, false
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if err := bracket.CheckMatch(tie, match, winners); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot link match to tie %s", tie.ID), err, http.StatusBadRequest)
+		return nil, false
+	}
+	return match, true
+}
+
+// tieWinners returns the team that advanced from each tie whose match has
+// finished with a winner
+func (h *BracketHandler) tieWinners(ctx context.Context, bracket *domain.Bracket) (map[string]string, error) {
+	winners := make(map[string]string)
+	for _, tie := range bracket.Ties {
+		if tie.MatchID == "" {
+			continue
+		}
+
+		matchEvents, err := h.eventStore.GetEvents(ctx, tie.MatchID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get match events: %w", err)
+		}
+		if len(matchEvents) == 0 {
+			continue
+		}
+
+		match, err := eventhandlers.ReplayMatch(matchEvents)
+		if err != nil {
+			return nil, err
+		}
+		if match.IsFinished() {
+			if winnerID := match.WinnerID(); winnerID != "" {
+				winners[tie.ID] = winnerID
+			}
+		}
+	}
+	return winners, nil
+}
@@ -0,0 +1,497 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+var bracketLockAt = time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+
+// testBracketEvents returns the events for a four-team bracket: Team A v
+// Team B (decided by match m1) and Team C v Team D, feeding a final worth 3
+func testBracketEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:   "event-b1",
+			Type: "BracketCreated",
+			Data: events.BracketCreated{
+				ID:            "bracket1",
+				CompetitionID: "comp_epl",
+				Name:          "Finals",
+				LockAt:        bracketLockAt,
+				RoundWeights:  []int{1, 3},
+				Ties: []events.BracketTie{
+					{ID: "SF1", Round: 1, HomeTeamID: "team_a", AwayTeamID: "team_b", MatchID: "m1"},
+					{ID: "SF2", Round: 1, HomeTeamID: "team_c", AwayTeamID: "team_d"},
+					{ID: "F", Round: 2, HomeWinnerOf: "SF1", AwayWinnerOf: "SF2"},
+				},
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}
+}
+
+// expectBrackets sets up the event store to return the given bracket events
+func expectBrackets(mockStore *mocks.MockEventStore, ctx context.Context, bracketEvents []*events.Event) {
+	for _, eventType := range []string{"BracketCreated", "BracketTieMatchLinked"} {
+		typeEvents := make([]*events.Event, 0)
+		for _, event := range bracketEvents {
+			if event.Type == eventType {
+				typeEvents = append(typeEvents, event)
+			}
+		}
+		mockStore.On("GetEventsByType", ctx, eventType).Return(typeEvents, nil)
+	}
+}
+
+// expectBracketEntries sets up the event store to return the given entry
+// submissions, with no points awarded yet
+func expectBracketEntries(mockStore *mocks.MockEventStore, ctx context.Context, entryEvents []*events.Event) {
+	mockStore.On("GetEventsByType", ctx, "BracketEntrySubmitted").Return(entryEvents, nil)
+	mockStore.On("GetEventsByType", ctx, "BracketPointsAwarded").Return([]*events.Event{}, nil)
+}
+
+// expectBracketTeams sets up the event store to return Teams A to D
+func expectBracketTeams(mockStore *mocks.MockEventStore, ctx context.Context) {
+	teamEvents := append(testTeamEvents(),
+		&events.Event{ID: "event-t3", Type: "TeamCreated", Data: events.TeamCreated{ID: "team_c", Name: "Team C", ShortCode: "TMC"}, Timestamp: time.Now(), Version: 1},
+		&events.Event{ID: "event-t4", Type: "TeamCreated", Data: events.TeamCreated{ID: "team_d", Name: "Team D", ShortCode: "TMD"}, Timestamp: time.Now(), Version: 1},
+	)
+	mockStore.On("GetEventsByType", ctx, "TeamCreated").Return(teamEvents, nil)
+	mockStore.On("GetEventsByType", ctx, "TeamUpdated").Return([]*events.Event{}, nil)
+}
+
+// testMatchEvents returns the events for a match between two teams, with a
+// score and final status if given
+func testMatchEvents(matchID, homeTeamID, awayTeamID string, score *events.MatchScoreUpdated) []*events.Event {
+	matchEvents := []*events.Event{
+		{
+			ID:        "event-" + matchID,
+			Type:      "MatchCreated",
+			Data:      events.MatchCreated{ID: matchID, HomeTeamID: homeTeamID, AwayTeamID: awayTeamID, Date: bracketLockAt.Add(time.Hour), CompetitionID: "comp_epl"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}
+	if score != nil {
+		score.MatchID = matchID
+		matchEvents = append(matchEvents,
+			&events.Event{ID: "event-" + matchID + "-score", Type: "MatchScoreUpdated", Data: *score, Timestamp: time.Now(), Version: 1},
+			&events.Event{ID: "event-" + matchID + "-status", Type: "MatchStatusChanged", Data: events.MatchStatusChanged{MatchID: matchID, Status: "FINISHED"}, Timestamp: time.Now(), Version: 1},
+		)
+	}
+	return matchEvents
+}
+
+// testEntryEvent returns the submission of a user's bracket entry
+func testEntryEvent(entryID, userID string, picks map[string]string) *events.Event {
+	return &events.Event{
+		ID:        "event-" + entryID,
+		Type:      "BracketEntrySubmitted",
+		Data:      events.BracketEntrySubmitted{ID: entryID, BracketID: "bracket1", UserID: userID, Picks: picks},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+}
+
+func TestCreateBracket(t *testing.T) {
+	validBody := `{
+		"competitionId": "EPL",
+		"name": "Finals",
+		"lockAt": "2026-06-01T18:00:00Z",
+		"roundWeights": [1, 3],
+		"ties": [
+			{"id": "SF1", "round": 1, "homeTeam": "A Team", "awayTeam": "TMB", "matchId": "m1"},
+			{"id": "SF2", "round": 1, "homeTeam": "Team C", "awayTeam": "Team D"},
+			{"id": "F", "round": 2, "homeWinnerOf": "SF1", "awayWinnerOf": "SF2"}
+		]
+	}`
+
+	// Test case 1: Valid bracket creation
+	t.Run("Valid bracket creation", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("POST", "/api/brackets", bytes.NewBufferString(validBody))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		expectBracketTeams(mockStore, req.Context())
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", nil), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.BracketCreated)
+			return ok && event.Type == "BracketCreated" &&
+				created.CompetitionID == "comp_epl" &&
+				len(created.Ties) == 3 &&
+				created.Ties[0].HomeTeamID == "team_a" &&
+				created.Ties[0].AwayTeamID == "team_b" &&
+				created.Ties[2].HomeWinnerOf == "SF1"
+		})).Return(nil)
+
+		handler.CreateBracket(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown team
+	t.Run("Unknown team", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		body := `{"competitionId": "comp_epl", "name": "Final", "lockAt": "2026-06-01T18:00:00Z",
+			"ties": [{"id": "F", "round": 1, "homeTeam": "Team A", "awayTeam": "Team Z"}]}`
+		req := httptest.NewRequest("POST", "/api/brackets", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		expectBracketTeams(mockStore, req.Context())
+
+		handler.CreateBracket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_TEAM" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_TEAM", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "ties" {
+			t.Errorf("expected ties to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Ties that do not lead to a single final
+	t.Run("Ties that do not lead to a single final", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		body := `{"competitionId": "comp_epl", "name": "Semis", "lockAt": "2026-06-01T18:00:00Z",
+			"ties": [
+				{"id": "SF1", "round": 1, "homeTeam": "Team A", "awayTeam": "Team B"},
+				{"id": "SF2", "round": 1, "homeTeam": "Team C", "awayTeam": "Team D"}
+			]}`
+		req := httptest.NewRequest("POST", "/api/brackets", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		expectBracketTeams(mockStore, req.Context())
+
+		handler.CreateBracket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 4: Linked match between other teams
+	t.Run("Linked match between other teams", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("POST", "/api/brackets", bytes.NewBufferString(validBody))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		expectBracketTeams(mockStore, req.Context())
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_c", "team_d", nil), nil)
+
+		handler.CreateBracket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubmitBracketEntry(t *testing.T) {
+	beforeLock := func() time.Time { return bracketLockAt.Add(-time.Hour) }
+	fullBody := `{"userId": "user1", "picks": {"SF1": "Team A", "SF2": "TMD", "F": "team_d"}}`
+
+	// Test case 1: Full bracket before the lock
+	t.Run("Full bracket before the lock", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+		handler.now = beforeLock
+
+		req := httptest.NewRequest("POST", "/api/brackets/bracket1/entries", bytes.NewBufferString(fullBody))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		expectBracketTeams(mockStore, req.Context())
+		expectBracketEntries(mockStore, req.Context(), []*events.Event{})
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			submitted, ok := event.Data.(events.BracketEntrySubmitted)
+			return ok && event.Type == "BracketEntrySubmitted" &&
+				submitted.UserID == "user1" &&
+				submitted.Picks["SF1"] == "team_a" &&
+				submitted.Picks["F"] == "team_d"
+		})).Return(nil)
+
+		handler.SubmitEntry(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Resubmitting replaces the user's picks
+	t.Run("Resubmitting replaces the user's picks", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+		handler.now = beforeLock
+
+		req := httptest.NewRequest("POST", "/api/brackets/bracket1/entries", bytes.NewBufferString(fullBody))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1"})
+
+		existing := testEntryEvent("entry1", "user1", map[string]string{"SF1": "team_b", "SF2": "team_c", "F": "team_b"})
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		expectBracketTeams(mockStore, req.Context())
+		expectBracketEntries(mockStore, req.Context(), []*events.Event{existing})
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			submitted, ok := event.Data.(events.BracketEntrySubmitted)
+			return ok && submitted.ID == "entry1" && submitted.Picks["F"] == "team_d"
+		})).Return(nil)
+
+		handler.SubmitEntry(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Bracket has locked
+	t.Run("Bracket has locked", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+		handler.now = func() time.Time { return bracketLockAt }
+
+		req := httptest.NewRequest("POST", "/api/brackets/bracket1/entries", bytes.NewBufferString(fullBody))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+
+		handler.SubmitEntry(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "BRACKET_LOCKED" {
+			t.Errorf("expected code %q, got %q", "BRACKET_LOCKED", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 4: Champion knocked out in an earlier pick
+	t.Run("Champion knocked out in an earlier pick", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+		handler.now = beforeLock
+
+		body := `{"userId": "user1", "picks": {"SF1": "Team A", "SF2": "Team D", "F": "Team C"}}`
+		req := httptest.NewRequest("POST", "/api/brackets/bracket1/entries", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		expectBracketTeams(mockStore, req.Context())
+
+		handler.SubmitEntry(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 5: Bracket not found
+	t.Run("Bracket not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("POST", "/api/brackets/missing/entries", bytes.NewBufferString(fullBody))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+
+		handler.SubmitEntry(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestLinkTieMatch(t *testing.T) {
+	semiFinal := &events.MatchScoreUpdated{HomeGoals: 2, AwayGoals: 1}
+
+	// Test case 1: Final linked once one semi-final is decided
+	t.Run("Final linked once one semi-final is decided", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/brackets/bracket1/ties/F/match", bytes.NewBufferString(`{"matchId": "m3"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1", "tieId": "F"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", semiFinal), nil)
+		mockStore.On("GetEvents", req.Context(), "m3").Return(testMatchEvents("m3", "team_a", "team_d", nil), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			linked, ok := event.Data.(events.BracketTieMatchLinked)
+			return ok && event.Type == "BracketTieMatchLinked" && linked.TieID == "F" && linked.MatchID == "m3"
+		})).Return(nil)
+
+		handler.LinkTieMatch(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Match without the semi-final winner
+	t.Run("Match without the semi-final winner", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/brackets/bracket1/ties/F/match", bytes.NewBufferString(`{"matchId": "m4"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1", "tieId": "F"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", semiFinal), nil)
+		mockStore.On("GetEvents", req.Context(), "m4").Return(testMatchEvents("m4", "team_b", "team_d", nil), nil)
+
+		handler.LinkTieMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Tie already decided
+	t.Run("Tie already decided", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/brackets/bracket1/ties/SF1/match", bytes.NewBufferString(`{"matchId": "m5"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1", "tieId": "SF1"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", semiFinal), nil)
+
+		handler.LinkTieMatch(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "TIE_DECIDED" {
+			t.Errorf("expected code %q, got %q", "TIE_DECIDED", problem.Code)
+		}
+	})
+
+	// Test case 4: Tie not found
+	t.Run("Tie not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewBracketHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/brackets/bracket1/ties/QF1/match", bytes.NewBufferString(`{"matchId": "m5"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "bracket1", "tieId": "QF1"})
+
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+
+		handler.LinkTieMatch(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestBracketScoredWhenFinalFinishes(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	req := httptest.NewRequest("PUT", "/api/matches/m3/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m3"})
+
+	// The final is level after extra time and Team A win the shootout
+	finalEvents := testMatchEvents("m3", "team_a", "team_d", &events.MatchScoreUpdated{
+		HomeGoals: 1,
+		AwayGoals: 1,
+		ExtraTime: &events.Scoreline{HomeGoals: 1, AwayGoals: 1},
+		Penalties: &events.Scoreline{HomeGoals: 5, AwayGoals: 4},
+	})[:2]
+	bracketEvents := append(testBracketEvents(), &events.Event{
+		ID:        "event-b2",
+		Type:      "BracketTieMatchLinked",
+		Data:      events.BracketTieMatchLinked{BracketID: "bracket1", TieID: "F", MatchID: "m3"},
+		Timestamp: time.Now(),
+		Version:   1,
+	})
+
+	mockStore.On("GetEvents", req.Context(), "m3").Return(finalEvents, nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchStatusChanged"
+	})).Return(nil)
+	mockRepo.On("GetByID", req.Context(), "m3").Return(&domain.Match{ID: "m3", Status: domain.MatchStatusScheduled}, nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+	mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, req.Context(), nil)
+	expectSurvivorPools(mockStore, req.Context(), nil)
+	expectCatalog(mockStore, req.Context(), testCatalogEvents())
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	expectBrackets(mockStore, req.Context(), bracketEvents)
+	expectBracketEntries(mockStore, req.Context(), []*events.Event{
+		testEntryEvent("entry1", "user1", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_a"}),
+		testEntryEvent("entry2", "user2", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_d"}),
+	})
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.BracketPointsAwarded)
+		return ok && awarded.EntryID == "entry1" && awarded.TieID == "F" && awarded.WinnerID == "team_a" && awarded.Points == 3
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.BracketPointsAwarded)
+		return ok && awarded.EntryID == "entry2" && awarded.Points == 0
+	})).Return(nil).Once()
+	expectBadges(mockStore, req.Context())
+
+	handler.UpdateMatchStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	mockStore.AssertExpectations(t)
+}
@@ -0,0 +1,468 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type CompetitionHandler struct {
+	eventStore EventStore
+	now        func() time.Time
+}
+
+func NewCompetitionHandler(eventStore EventStore) *CompetitionHandler {
+	return &CompetitionHandler{
+		eventStore: eventStore,
+		now:        time.Now,
+	}
+}
+
+// createCompetitionRequest is the body accepted when creating a competition
+type createCompetitionRequest struct {
+	Name        string   `json:"name"`
+	Sport       string   `json:"sport"`
+	Aliases     []string `json:"aliases"`
+	ResultBasis string   `json:"resultBasis"`
+	TippingMode string   `json:"tippingMode"`
+}
+
+func (r createCompetitionRequest) validate(errs *fieldErrors) {
+	errs.required("name", r.Name)
+	errs.required("sport", r.Sport)
+}
+
+// CreateCompetition handles the creation of a new competition. Its name and
+// aliases must not already belong to another competition. Tips are scored on
+// the regulation-time result unless another result basis is given, and use
+// the sport's default tipping mode unless another is given.
+func (h *CompetitionHandler) CreateCompetition(w http.ResponseWriter, r *http.Request) {
+	var request createCompetitionRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	competition := domain.NewCompetition(
+		utils.GenerateID(),
+		request.Name,
+		domain.Sport(request.Sport),
+		request.Aliases,
+	)
+	if request.ResultBasis != "" {
+		competition.ResultBasis = domain.ResultBasis(request.ResultBasis)
+	}
+	if request.TippingMode != "" {
+		competition.TippingMode = domain.TippingMode(request.TippingMode)
+	}
+
+	if err := competition.Validate(); err != nil {
+		writeDomainError(w, "Invalid competition", err, http.StatusBadRequest)
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	for _, name := range append([]string{competition.Name}, competition.Aliases...) {
+		if existing := catalog.Competition(name); existing != nil {
+			writeDomainError(w, "Invalid competition", fmt.Errorf("%w: %q already refers to competition %s", domain.ErrCompetitionAlreadyExists, name, existing.ID), http.StatusConflict)
+			return
+		}
+	}
+
+	event := events.NewEvent("CompetitionCreated", events.CompetitionCreated{
+		ID:          competition.ID,
+		Name:        competition.Name,
+		Sport:       string(competition.Sport),
+		Aliases:     competition.Aliases,
+		ResultBasis: string(competition.ResultBasis),
+		TippingMode: string(competition.TippingMode),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create competition", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(competition); err != nil {
+		fmt.Printf("error encoding competition: %v\n", err)
+	}
+}
+
+// ListCompetitions retrieves all competitions
+func (h *CompetitionHandler) ListCompetitions(w http.ResponseWriter, r *http.Request) {
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(catalog.Competitions); err != nil {
+		fmt.Printf("error encoding competitions: %v\n", err)
+	}
+}
+
+// GetCompetition retrieves a competition by ID, name or alias
+func (h *CompetitionHandler) GetCompetition(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(competition); err != nil {
+		fmt.Printf("error encoding competition: %v\n", err)
+	}
+}
+
+// resultBasisRequest is the body accepted when changing a competition's
+// result basis
+type resultBasisRequest struct {
+	ResultBasis string `json:"resultBasis"`
+}
+
+func (r resultBasisRequest) validate(errs *fieldErrors) {
+	if !domain.ResultBasis(r.ResultBasis).IsValid() {
+		errs.rule("resultBasis", domain.ErrInvalidResultBasis)
+	}
+}
+
+// UpdateResultBasis handles changing which part of the score counts for
+// tipping in a competition: REGULATION, EXTRA_TIME or OVERALL. Matches that
+// have already been scored keep their points.
+func (h *CompetitionHandler) UpdateResultBasis(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	var request resultBasisRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	basis := domain.ResultBasis(request.ResultBasis)
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	event := events.NewEvent("CompetitionResultBasisChanged", events.CompetitionResultBasisChanged{
+		CompetitionID: competition.ID,
+		ResultBasis:   string(basis),
+		ChangedAt:     h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update result basis", http.StatusInternalServerError)
+		return
+	}
+
+	competition.ResultBasis = basis
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(competition); err != nil {
+		fmt.Printf("error encoding competition: %v\n", err)
+	}
+}
+
+// tippingModeRequest is the body accepted when changing a competition's
+// tipping mode
+type tippingModeRequest struct {
+	TippingMode string `json:"tippingMode"`
+}
+
+func (r tippingModeRequest) validate(errs *fieldErrors) {
+	if !domain.TippingMode(r.TippingMode).IsValid() {
+		errs.rule("tippingMode", domain.ErrInvalidTippingMode)
+	}
+}
+
+// UpdateTippingMode handles changing what users tip for each match in a
+// competition: EXACT_SCORE or WINNER_MARGIN. Tips already made keep their
+// form, so the mode is best set before tipping opens.
+func (h *CompetitionHandler) UpdateTippingMode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	var request tippingModeRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	mode := domain.TippingMode(request.TippingMode)
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	event := events.NewEvent("CompetitionTippingModeChanged", events.CompetitionTippingModeChanged{
+		CompetitionID: competition.ID,
+		TippingMode:   string(mode),
+		ChangedAt:     h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update tipping mode", http.StatusInternalServerError)
+		return
+	}
+
+	competition.TippingMode = mode
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(competition); err != nil {
+		fmt.Printf("error encoding competition: %v\n", err)
+	}
+}
+
+// tiebreakersRequest is the body accepted when changing a competition's
+// tiebreakers
+type tiebreakersRequest struct {
+	Tiebreakers []string `json:"tiebreakers"`
+}
+
+func (r tiebreakersRequest) validate(errs *fieldErrors) {
+	errs.rule("tiebreakers", domain.ValidateTiebreakers(r.tiebreakers()))
+}
+
+// tiebreakers returns the requested tiebreakers in order
+func (r tiebreakersRequest) tiebreakers() []domain.Tiebreaker {
+	tiebreakers := make([]domain.Tiebreaker, 0, len(r.Tiebreakers))
+	for _, tiebreaker := range r.Tiebreakers {
+		tiebreakers = append(tiebreakers, domain.Tiebreaker(tiebreaker))
+	}
+	return tiebreakers
+}
+
+// UpdateTiebreakers handles setting the ordered tiebreakers that split users
+// level on points in the competition's leaderboard: EXACT_SCORES,
+// MARGIN_ERROR, EARLIEST_TIP and HEAD_TO_HEAD
+func (h *CompetitionHandler) UpdateTiebreakers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	var request tiebreakersRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	event := events.NewEvent("CompetitionTiebreakersChanged", events.CompetitionTiebreakersChanged{
+		CompetitionID: competition.ID,
+		Tiebreakers:   request.Tiebreakers,
+		ChangedAt:     h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update tiebreakers", http.StatusInternalServerError)
+		return
+	}
+
+	competition.Tiebreakers = request.tiebreakers()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(competition); err != nil {
+		fmt.Printf("error encoding competition: %v\n", err)
+	}
+}
+
+// seasonRequest is the body accepted when adding a season
+type seasonRequest struct {
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"startDate"`
+	EndDate   time.Time `json:"endDate"`
+}
+
+func (r seasonRequest) validate(errs *fieldErrors) {
+	errs.required("name", r.Name)
+	errs.requiredTime("startDate", r.StartDate)
+	errs.requiredTime("endDate", r.EndDate)
+}
+
+// CreateSeason handles adding a season to a competition
+func (h *CompetitionHandler) CreateSeason(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	var request seasonRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	if request.Name == "" {
+		request.Name = fmt.Sprintf("%d", request.StartDate.Year())
+	}
+
+	season := domain.NewSeason(
+		utils.GenerateID(),
+		competition.ID,
+		request.Name,
+		request.StartDate,
+		request.EndDate,
+	)
+
+	if err := season.Validate(); err != nil {
+		writeDomainError(w, "Invalid season", err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("SeasonCreated", events.SeasonCreated{
+		ID:            season.ID,
+		CompetitionID: season.CompetitionID,
+		Name:          season.Name,
+		StartDate:     season.StartDate,
+		EndDate:       season.EndDate,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create season", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(season); err != nil {
+		fmt.Printf("error encoding season: %v\n", err)
+	}
+}
+
+// ListSeasons retrieves the seasons of a competition
+func (h *CompetitionHandler) ListSeasons(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competitionID := vars["id"]
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(competitionID)
+	if competition == nil {
+		writeError(w, "Competition not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(catalog.SeasonsOf(competition.ID)); err != nil {
+		fmt.Printf("error encoding seasons: %v\n", err)
+	}
+}
+
+// seasonStatusRequest is the body accepted when changing a season's status
+type seasonStatusRequest struct {
+	Status string `json:"status"`
+}
+
+func (r seasonStatusRequest) validate(errs *fieldErrors) {
+	if !domain.SeasonStatus(r.Status).IsValid() {
+		errs.rule("status", domain.ErrInvalidSeasonStatus)
+	}
+}
+
+// UpdateSeasonStatus handles moving a season through its lifecycle:
+// UPCOMING, then ACTIVE, then COMPLETED
+func (h *CompetitionHandler) UpdateSeasonStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	seasonID := vars["id"]
+
+	var request seasonStatusRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	season := catalog.Season(seasonID)
+	if season == nil {
+		writeError(w, "Season not found", http.StatusNotFound)
+		return
+	}
+
+	status := domain.SeasonStatus(request.Status)
+	previous := season.Status
+	if err := season.ChangeStatus(status); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot change season status from %s to %s", previous, status), err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("SeasonStatusChanged", events.SeasonStatusChanged{
+		SeasonID:  season.ID,
+		Status:    string(season.Status),
+		ChangedAt: h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update season status", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(season); err != nil {
+		fmt.Printf("error encoding season: %v\n", err)
+	}
+}
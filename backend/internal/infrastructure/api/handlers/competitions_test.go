@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// testCatalogEvents returns the events for a small catalog: the Premier League
+// (alias EPL) with a 2025/26 season, and La Liga
+func testCatalogEvents() map[string][]*events.Event {
+	return map[string][]*events.Event{
+		"CompetitionCreated": {
+			{
+				ID:        "event-c1",
+				Type:      "CompetitionCreated",
+				Data:      events.CompetitionCreated{ID: "comp_epl", Name: "Premier League", Sport: "FOOTBALL", Aliases: []string{"EPL"}},
+				Timestamp: time.Now(),
+				Version:   1,
+			},
+			{
+				ID:        "event-c2",
+				Type:      "CompetitionCreated",
+				Data:      events.CompetitionCreated{ID: "comp_laliga", Name: "La Liga", Sport: "FOOTBALL"},
+				Timestamp: time.Now(),
+				Version:   1,
+			},
+		},
+		"SeasonCreated": {
+			{
+				ID:   "event-s1",
+				Type: "SeasonCreated",
+				Data: events.SeasonCreated{
+					ID:            "season_epl_2025",
+					CompetitionID: "comp_epl",
+					Name:          "2025/26",
+					StartDate:     time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC),
+					EndDate:       time.Date(2026, 5, 24, 0, 0, 0, 0, time.UTC),
+				},
+				Timestamp: time.Now(),
+				Version:   1,
+			},
+		},
+		"CompetitionResultBasisChanged": {},
+		"CompetitionTippingModeChanged": {},
+		"CompetitionTiebreakersChanged": {},
+		"SeasonStatusChanged":           {},
+	}
+}
+
+// testAFLCatalogEvents returns the test catalog with an AFL competition added,
+// which uses winner tipping
+func testAFLCatalogEvents() map[string][]*events.Event {
+	catalog := testCatalogEvents()
+	catalog["CompetitionCreated"] = append(catalog["CompetitionCreated"], &events.Event{
+		ID:        "event-c3",
+		Type:      "CompetitionCreated",
+		Data:      events.CompetitionCreated{ID: "comp_afl", Name: "AFL Premiership", Sport: "AFL"},
+		Timestamp: time.Now(),
+		Version:   1,
+	})
+	return catalog
+}
+
+// expectCatalog sets up the event store to return the given catalog events
+func expectCatalog(mockStore *mocks.MockEventStore, ctx context.Context, catalog map[string][]*events.Event) {
+	for _, eventType := range []string{"CompetitionCreated", "CompetitionResultBasisChanged", "CompetitionTippingModeChanged", "CompetitionTiebreakersChanged", "SeasonCreated", "SeasonStatusChanged"} {
+		mockStore.On("GetEventsByType", ctx, eventType).Return(catalog[eventType], nil)
+	}
+}
+
+func TestCreateCompetition(t *testing.T) {
+	// Test case 1: Valid competition creation
+	t.Run("Valid competition creation", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		body := `{"name": "Serie A", "sport": "FOOTBALL", "aliases": ["Italian Serie A"]}`
+		req := httptest.NewRequest("POST", "/api/competitions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.CompetitionCreated)
+			return ok && event.Type == "CompetitionCreated" && created.Name == "Serie A" && created.Sport == "FOOTBALL"
+		})).Return(nil)
+
+		handler.CreateCompetition(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Name already used as an alias
+	t.Run("Name already used as an alias", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		body := `{"name": "EPL", "sport": "FOOTBALL"}`
+		req := httptest.NewRequest("POST", "/api/competitions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateCompetition(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "COMPETITION_ALREADY_EXISTS" {
+			t.Errorf("expected code %q, got %q", "COMPETITION_ALREADY_EXISTS", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "name" {
+			t.Errorf("expected name to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Unknown sport
+	t.Run("Unknown sport", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		body := `{"name": "Super Rugby", "sport": "RUGBY"}`
+		req := httptest.NewRequest("POST", "/api/competitions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.CreateCompetition(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+}
+
+func TestUpdateResultBasis(t *testing.T) {
+	// Test case 1: Count the overall winner
+	t.Run("Count the overall winner", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/result-basis", bytes.NewBufferString(`{"resultBasis": "OVERALL"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			changed, ok := event.Data.(events.CompetitionResultBasisChanged)
+			return ok && changed.CompetitionID == "comp_epl" && changed.ResultBasis == "OVERALL"
+		})).Return(nil)
+
+		handler.UpdateResultBasis(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var competition domain.Competition
+		if err := json.NewDecoder(rr.Body).Decode(&competition); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if competition.ResultBasis != domain.ResultBasisOverall {
+			t.Errorf("expected OVERALL, got %v", competition.ResultBasis)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown basis
+	t.Run("Unknown basis", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/result-basis", bytes.NewBufferString(`{"resultBasis": "GOLDEN_GOAL"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		handler.UpdateResultBasis(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateTippingMode(t *testing.T) {
+	// Test case 1: Switch to winner tipping
+	t.Run("Switch to winner tipping", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/tipping-mode", bytes.NewBufferString(`{"tippingMode": "WINNER_MARGIN"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			changed, ok := event.Data.(events.CompetitionTippingModeChanged)
+			return ok && changed.CompetitionID == "comp_epl" && changed.TippingMode == "WINNER_MARGIN"
+		})).Return(nil)
+
+		handler.UpdateTippingMode(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var competition domain.Competition
+		if err := json.NewDecoder(rr.Body).Decode(&competition); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if competition.TippingMode != domain.TippingModeWinnerMargin {
+			t.Errorf("expected WINNER_MARGIN, got %v", competition.TippingMode)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown mode
+	t.Run("Unknown mode", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/tipping-mode", bytes.NewBufferString(`{"tippingMode": "FIRST_SCORER"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		handler.UpdateTippingMode(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateTiebreakers(t *testing.T) {
+	// Test case 1: Head-to-head before exact scores
+	t.Run("Head-to-head before exact scores", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/tiebreakers", bytes.NewBufferString(`{"tiebreakers": ["HEAD_TO_HEAD", "EXACT_SCORES"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			changed, ok := event.Data.(events.CompetitionTiebreakersChanged)
+			return ok && changed.CompetitionID == "comp_epl" && len(changed.Tiebreakers) == 2 && changed.Tiebreakers[0] == "HEAD_TO_HEAD"
+		})).Return(nil)
+
+		handler.UpdateTiebreakers(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var competition domain.Competition
+		if err := json.NewDecoder(rr.Body).Decode(&competition); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(competition.Tiebreakers) != 2 || competition.Tiebreakers[1] != domain.TiebreakerExactScores {
+			t.Errorf("expected head-to-head then exact scores, got %v", competition.Tiebreakers)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Tiebreaker listed twice
+	t.Run("Tiebreaker listed twice", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/comp_epl/tiebreakers", bytes.NewBufferString(`{"tiebreakers": ["EXACT_SCORES", "EXACT_SCORES"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		handler.UpdateTiebreakers(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetCompetition(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewCompetitionHandler(mockStore)
+
+	// Test case 1: Found by alias
+	t.Run("Found by alias", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/competitions/EPL", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "EPL"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.GetCompetition(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var competition domain.Competition
+		if err := json.NewDecoder(rr.Body).Decode(&competition); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if competition.ID != "comp_epl" {
+			t.Errorf("expected comp_epl, got %v", competition.ID)
+		}
+	})
+
+	// Test case 2: Not found
+	t.Run("Not found", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/competitions/missing", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.GetCompetition(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestCreateSeason(t *testing.T) {
+	// Test case 1: Valid season creation
+	t.Run("Valid season creation", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		body := `{"name": "2026/27", "startDate": "2026-08-14T00:00:00Z", "endDate": "2027-05-23T00:00:00Z"}`
+		req := httptest.NewRequest("POST", "/api/competitions/comp_epl/seasons", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.SeasonCreated)
+			return ok && created.CompetitionID == "comp_epl" && created.Name == "2026/27"
+		})).Return(nil)
+
+		handler.CreateSeason(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+
+		var season domain.Season
+		if err := json.NewDecoder(rr.Body).Decode(&season); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if season.Status != domain.SeasonStatusUpcoming {
+			t.Errorf("expected status UPCOMING, got %v", season.Status)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Season ends before it starts
+	t.Run("Season ends before it starts", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		body := `{"name": "2026/27", "startDate": "2026-08-14T00:00:00Z", "endDate": "2026-01-01T00:00:00Z"}`
+		req := httptest.NewRequest("POST", "/api/competitions/comp_epl/seasons", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "comp_epl"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateSeason(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateSeasonStatus(t *testing.T) {
+	// Test case 1: Upcoming season starts
+	t.Run("Upcoming season starts", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/seasons/season_epl_2025/status", bytes.NewBufferString(`{"status": "ACTIVE"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "season_epl_2025"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			changed, ok := event.Data.(events.SeasonStatusChanged)
+			return ok && changed.SeasonID == "season_epl_2025" && changed.Status == "ACTIVE"
+		})).Return(nil)
+
+		handler.UpdateSeasonStatus(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Upcoming season cannot complete
+	t.Run("Upcoming season cannot complete", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewCompetitionHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/seasons/season_epl_2025/status", bytes.NewBufferString(`{"status": "COMPLETED"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "season_epl_2025"})
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.UpdateSeasonStatus(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
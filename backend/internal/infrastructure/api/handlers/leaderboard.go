@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+)
+
+type LeaderboardHandler struct {
+	eventStore EventStore
+	matchRepo  repository.MatchRepository
+}
+
+func NewLeaderboardHandler(eventStore EventStore, matchRepo repository.MatchRepository) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		eventStore: eventStore,
+		matchRepo:  matchRepo,
+	}
+}
+
+// GetLeaderboard ranks users by the points they have been awarded for match
+// tips, bracket picks and outright tips. Use ?competitionId= and ?seasonId=
+// to count only the points from one competition or season, with users level
+// on points split by that competition's tiebreakers. Use ?leagueId= to rank
+// only the members of a private league.
+func (h *LeaderboardHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	league, ok := requestLeague(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+	h.writeLeaderboard(w, r, league)
+}
+
+// requestLeague returns the league named by the request's ?leagueId=, or nil
+// if there is none. It writes a 404 response and returns false if the league
+// does not exist.
+func requestLeague(w http.ResponseWriter, r *http.Request, eventStore EventStore) (*domain.League, bool) {
+	leagueID := r.URL.Query().Get("leagueId")
+	if leagueID == "" {
+		return nil, true
+	}
+	return findLeague(w, r, eventStore, leagueID)
+}
+
+// writeLeaderboard writes the leaderboard for the request's competition and
+// season scope, as ranked by rankedScope
+func (h *LeaderboardHandler) writeLeaderboard(w http.ResponseWriter, r *http.Request, league *domain.League) {
+	leaderboard, ok := h.leaderboard(w, r, league)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(leaderboard); err != nil {
+		fmt.Printf("error encoding leaderboard: %v\n", err)
+	}
+}
+
+// leaderboard ranks the awards in the request's scope. It writes an error
+// response and returns false if they cannot be loaded.
+func (h *LeaderboardHandler) leaderboard(w http.ResponseWriter, r *http.Request, league *domain.League) ([]*domain.LeaderboardEntry, bool) {
+	scope, ok := h.rankedScope(w, r, league)
+	if !ok {
+		return nil, false
+	}
+	return domain.BuildLeaderboard(scope.awards, scope.tiebreakers), true
+}
+
+// leaderboardScope is what a leaderboard ranks: the awards in scope and the
+// tiebreakers that split users level on points. Matches lists the matches in
+// a competition or season scope, and is nil when every match counts.
+type leaderboardScope struct {
+	awards      []domain.PointsAward
+	tiebreakers []domain.Tiebreaker
+	matches     map[string]bool
+}
+
+// rankedScope works out the request's competition and season scope. A league
+// limits it to the league's members, and its competition and season apply
+// when the request doesn't name its own. Match points come from the league's
+// own scoring if it has its own rules. It writes an error response and
+// returns false if the scope cannot be loaded.
+func (h *LeaderboardHandler) rankedScope(w http.ResponseWriter, r *http.Request, league *domain.League) (*leaderboardScope, bool) {
+	query := r.URL.Query()
+	competitionID := query.Get("competitionId")
+	seasonID := query.Get("seasonId")
+	if league != nil && competitionID == "" && seasonID == "" {
+		competitionID = league.CompetitionID
+		seasonID = league.SeasonID
+	}
+
+	awards, err := h.awards(r.Context(), league)
+	if err != nil {
+		writeError(w, "Failed to retrieve points", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	scope := &leaderboardScope{awards: awards, tiebreakers: domain.DefaultTiebreakers()}
+	if competitionID != "" || seasonID != "" {
+		scope.tiebreakers, err = h.tiebreakers(r.Context(), competitionID, seasonID)
+		if err != nil {
+			writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		inScope, err := h.scope(r.Context(), competitionID, seasonID)
+		if err != nil {
+			writeError(w, "Failed to retrieve leaderboard scope", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		filtered := make([]domain.PointsAward, 0, len(awards))
+		for _, award := range awards {
+			if inScope[award.Source][award.SourceID] {
+				filtered = append(filtered, award)
+			}
+		}
+		scope.awards = filtered
+		scope.matches = inScope[domain.PointsSourceMatch]
+	}
+	return scope, true
+}
+
+// awards returns every points award, or for a league only its members'
+// awards, with match points scored by the league's own rules if it has them
+func (h *LeaderboardHandler) awards(ctx context.Context, league *domain.League) ([]domain.PointsAward, error) {
+	awards, err := eventhandlers.LoadPointsAwards(ctx, h.eventStore)
+	if err != nil {
+		return nil, err
+	}
+	if league == nil {
+		return awards, nil
+	}
+
+	if league.Rules.IsSet() {
+		leagueAwards, err := eventhandlers.LoadLeaguePointsAwards(ctx, h.eventStore, league.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, award := range awards {
+			if award.Source != domain.PointsSourceMatch {
+				leagueAwards = append(leagueAwards, award)
+			}
+		}
+		awards = leagueAwards
+	}
+
+	members := league.MemberIDs()
+	filtered := make([]domain.PointsAward, 0, len(awards))
+	for _, award := range awards {
+		if members[award.UserID] {
+			filtered = append(filtered, award)
+		}
+	}
+	return filtered, nil
+}
+
+// tiebreakers returns the tiebreakers of the competition, or of the season's
+// competition, falling back to the defaults if neither is known
+func (h *LeaderboardHandler) tiebreakers(ctx context.Context, competitionID, seasonID string) ([]domain.Tiebreaker, error) {
+	catalog, err := eventhandlers.LoadCompetitionCatalog(ctx, h.eventStore)
+	if err != nil {
+		return nil, err
+	}
+
+	if competitionID == "" {
+		if season := catalog.Season(seasonID); season != nil {
+			competitionID = season.CompetitionID
+		}
+	}
+	if competition := catalog.Competition(competitionID); competition != nil {
+		return competition.Tiebreakers, nil
+	}
+	return domain.DefaultTiebreakers(), nil
+}
+
+// scope returns the IDs of the matches, brackets and outright markets in the
+// competition and season, keyed by the kind of points they award
+func (h *LeaderboardHandler) scope(ctx context.Context, competitionID, seasonID string) (map[domain.PointsSource]map[string]bool, error) {
+	inScope := map[domain.PointsSource]map[string]bool{
+		domain.PointsSourceMatch:    make(map[string]bool),
+		domain.PointsSourceBracket:  make(map[string]bool),
+		domain.PointsSourceOutright: make(map[string]bool),
+	}
+	matches := func(itemCompetitionID, itemSeasonID string) bool {
+		return (competitionID == "" || itemCompetitionID == competitionID) &&
+			(seasonID == "" || itemSeasonID == seasonID)
+	}
+
+	filters := repository.MatchFilters{}
+	if competitionID != "" {
+		filters.CompetitionID = &competitionID
+	}
+	if seasonID != "" {
+		filters.SeasonID = &seasonID
+	}
+	matchList, err := h.matchRepo.List(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matchList {
+		inScope[domain.PointsSourceMatch][match.ID] = true
+	}
+
+	brackets, err := eventhandlers.LoadBrackets(ctx, h.eventStore)
+	if err != nil {
+		return nil, err
+	}
+	for _, bracket := range brackets {
+		if matches(bracket.CompetitionID, bracket.SeasonID) {
+			inScope[domain.PointsSourceBracket][bracket.ID] = true
+		}
+	}
+
+	markets, err := eventhandlers.LoadOutrightMarkets(ctx, h.eventStore)
+	if err != nil {
+		return nil, err
+	}
+	for _, market := range markets {
+		if matches(market.CompetitionID, market.SeasonID) {
+			inScope[domain.PointsSourceOutright][market.ID] = true
+		}
+	}
+	return inScope, nil
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// expectAwards sets up the event store to return points for a match tip
+// (user1, 3 points on m1), a bracket tie (user2, 3 points) and an outright
+// tip (user2, 5 points on market1)
+func expectAwards(mockStore *mocks.MockEventStore, req *http.Request) {
+	ctx := req.Context()
+	mockStore.On("GetEventsByType", ctx, "PointsAwarded").Return([]*events.Event{
+		{
+			ID:        "event-a1",
+			Type:      "PointsAwarded",
+			Data:      events.PointsAwarded{PredictionID: "pred1", UserID: "user1", MatchID: "m1", Points: 3},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}, nil)
+	mockStore.On("GetEventsByType", ctx, "BracketPointsAwarded").Return([]*events.Event{
+		{
+			ID:        "event-a2",
+			Type:      "BracketPointsAwarded",
+			Data:      events.BracketPointsAwarded{BracketID: "bracket1", EntryID: "entry1", UserID: "user2", TieID: "F", Points: 3},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}, nil)
+	mockStore.On("GetEventsByType", ctx, "OutrightPointsAwarded").Return([]*events.Event{
+		{
+			ID:        "event-a3",
+			Type:      "OutrightPointsAwarded",
+			Data:      events.OutrightPointsAwarded{MarketID: "market1", TipID: "tip1", UserID: "user2", Correct: 1, Points: 5},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}, nil)
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	// Test case 1: Points from every kind of tip
+	t.Run("Points from every kind of tip", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeaderboardHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/leaderboard", nil)
+		rr := httptest.NewRecorder()
+
+		expectAwards(mockStore, req)
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var leaderboard []domain.LeaderboardEntry
+		if err := json.NewDecoder(rr.Body).Decode(&leaderboard); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(leaderboard) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(leaderboard))
+		}
+		if leaderboard[0].UserID != "user2" || leaderboard[0].Points != 8 || leaderboard[0].OutrightPoints != 5 {
+			t.Errorf("expected user2 first on 8 points, got %+v", leaderboard[0])
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Only points from one competition season
+	t.Run("Only points from one competition season", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewLeaderboardHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("GET", "/api/leaderboard?competitionId=comp_epl&seasonId=season_epl_2025", nil)
+		rr := httptest.NewRecorder()
+
+		competitionID := "comp_epl"
+		seasonID := "season_epl_2025"
+		expectAwards(mockStore, req)
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockRepo.On("List", req.Context(), repository.MatchFilters{CompetitionID: &competitionID, SeasonID: &seasonID}).
+			Return([]*domain.Match{{ID: "m1"}}, nil)
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		expectMarkets(mockStore, req.Context())
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var leaderboard []domain.LeaderboardEntry
+		if err := json.NewDecoder(rr.Body).Decode(&leaderboard); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		// The test bracket has no season, so only the match and outright points count
+		if len(leaderboard) != 2 || leaderboard[0].UserID != "user2" || leaderboard[0].Points != 5 || leaderboard[1].Points != 3 {
+			t.Errorf("expected user2 on 5 points then user1 on 3, got %+v", leaderboard)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: The competition's tiebreakers split users on the same points
+	t.Run("Competition tiebreakers split users level on points", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewLeaderboardHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("GET", "/api/leaderboard?competitionId=comp_epl", nil)
+		rr := httptest.NewRecorder()
+		ctx := req.Context()
+
+		competitionID := "comp_epl"
+		award := func(id, predictionID, userID, matchID string, points int, submittedAt time.Time) *events.Event {
+			return &events.Event{
+				ID:        id,
+				Type:      "PointsAwarded",
+				Data:      events.PointsAwarded{PredictionID: predictionID, UserID: userID, MatchID: matchID, Points: points, SubmittedAt: submittedAt},
+				Timestamp: time.Now(),
+				Version:   1,
+			}
+		}
+		submittedAt := time.Date(2025, 8, 10, 12, 0, 0, 0, time.UTC)
+		mockStore.On("GetEventsByType", ctx, "PointsAwarded").Return([]*events.Event{
+			award("event-a1", "p1", "user1", "m1", 1, submittedAt.Add(time.Hour)),
+			award("event-a2", "p2", "user2", "m1", 1, submittedAt),
+		}, nil)
+		mockStore.On("GetEventsByType", ctx, "BracketPointsAwarded").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", ctx, "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+
+		catalog := testCatalogEvents()
+		catalog["CompetitionTiebreakersChanged"] = []*events.Event{
+			{
+				ID:        "event-t1",
+				Type:      "CompetitionTiebreakersChanged",
+				Data:      events.CompetitionTiebreakersChanged{CompetitionID: "comp_epl", Tiebreakers: []string{"EARLIEST_TIP"}},
+				Timestamp: time.Now(),
+				Version:   1,
+			},
+		}
+		expectCatalog(mockStore, ctx, catalog)
+		mockRepo.On("List", ctx, repository.MatchFilters{CompetitionID: &competitionID}).
+			Return([]*domain.Match{{ID: "m1"}}, nil)
+		expectBrackets(mockStore, ctx, nil)
+		expectMarkets(mockStore, ctx)
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var leaderboard []domain.LeaderboardEntry
+		if err := json.NewDecoder(rr.Body).Decode(&leaderboard); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(leaderboard) != 2 || leaderboard[0].UserID != "user2" || leaderboard[1].Rank != 2 {
+			t.Fatalf("expected user2 ahead on the earlier tip, got %+v", leaderboard)
+		}
+		if leaderboard[1].DecidedBy != string(domain.TiebreakerEarliestTip) {
+			t.Errorf("expected the earliest tip to decide the pair, got %q", leaderboard[1].DecidedBy)
+		}
+	})
+}
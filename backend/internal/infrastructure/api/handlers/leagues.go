@@ -0,0 +1,704 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type LeagueHandler struct {
+	eventStore  EventStore
+	matchRepo   repository.MatchRepository
+	leaderboard *LeaderboardHandler
+	now         func() time.Time
+}
+
+func NewLeagueHandler(eventStore EventStore, matchRepo repository.MatchRepository) *LeagueHandler {
+	return &LeagueHandler{
+		eventStore:  eventStore,
+		matchRepo:   matchRepo,
+		leaderboard: NewLeaderboardHandler(eventStore, matchRepo),
+		now:         time.Now,
+	}
+}
+
+// createLeagueRequest is the body accepted when creating a league
+type createLeagueRequest struct {
+	Name          string `json:"name"`
+	OwnerID       string `json:"ownerId"`
+	CompetitionID string `json:"competitionId"`
+	SeasonID      string `json:"seasonId"`
+	Format        string `json:"format"`
+}
+
+func (r createLeagueRequest) validate(errs *fieldErrors) {
+	errs.required("name", r.Name)
+	errs.required("ownerId", r.OwnerID)
+}
+
+// CreateLeague handles creating a private league. The owner joins it
+// straight away and shares its invite code with everyone else. A competition
+// or season limits the league's leaderboard to points from it, and the
+// HEAD_TO_HEAD format plays weekly matchups instead of one cumulative table.
+func (h *LeagueHandler) CreateLeague(w http.ResponseWriter, r *http.Request) {
+	var request createLeagueRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	leagues, err := eventhandlers.LoadLeagues(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve leagues", http.StatusInternalServerError)
+		return
+	}
+
+	league := domain.NewLeague(utils.GenerateID(), request.Name, request.OwnerID, newInviteCode(leagues), h.now())
+	if request.Format != "" {
+		league.Format = domain.LeagueFormat(request.Format)
+	}
+	if err := league.Validate(); err != nil {
+		writeDomainError(w, "Invalid league", err, http.StatusBadRequest)
+		return
+	}
+
+	competitionID, seasonID, ok := h.resolveScope(w, r, request.CompetitionID, request.SeasonID)
+	if !ok {
+		return
+	}
+	league.CompetitionID = competitionID
+	league.SeasonID = seasonID
+
+	event := events.NewEvent("LeagueCreated", events.LeagueCreated{
+		ID:            league.ID,
+		Name:          league.Name,
+		OwnerID:       league.OwnerID,
+		InviteCode:    league.InviteCode,
+		CompetitionID: league.CompetitionID,
+		SeasonID:      league.SeasonID,
+		Format:        string(league.Format),
+		CreatedAt:     league.CreatedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create league", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// ListLeagues retrieves all leagues, optionally only those a user belongs to
+// with ?userId=
+func (h *LeagueHandler) ListLeagues(w http.ResponseWriter, r *http.Request) {
+	leagues, err := eventhandlers.LoadLeagues(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve leagues", http.StatusInternalServerError)
+		return
+	}
+
+	userID := r.URL.Query().Get("userId")
+	filtered := make([]*domain.League, 0, len(leagues))
+	for _, league := range leagues {
+		if userID != "" && !league.IsMember(userID) {
+			continue
+		}
+		filtered = append(filtered, league)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		fmt.Printf("error encoding leagues: %v\n", err)
+	}
+}
+
+// GetLeague retrieves a league and its members by ID
+func (h *LeagueHandler) GetLeague(w http.ResponseWriter, r *http.Request) {
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// updateLeagueRequest is the body accepted when the owner changes a league's
+// settings
+type updateLeagueRequest struct {
+	UserID               string `json:"userId"`
+	Name                 string `json:"name"`
+	CompetitionID        string `json:"competitionId"`
+	SeasonID             string `json:"seasonId"`
+	Format               string `json:"format"`
+	RegenerateInviteCode bool   `json:"regenerateInviteCode"`
+}
+
+func (r updateLeagueRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// UpdateLeague handles the owner renaming a league, changing the competition
+// or season it ranks, switching its format, or regenerating its invite code
+// so the old one stops working. An empty format leaves it unchanged.
+func (h *LeagueHandler) UpdateLeague(w http.ResponseWriter, r *http.Request) {
+	var request updateLeagueRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	leagues, err := eventhandlers.LoadLeagues(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve leagues", http.StatusInternalServerError)
+		return
+	}
+
+	league := eventhandlers.FindLeague(leagues, mux.Vars(r)["id"])
+	if league == nil {
+		writeError(w, "League not found", http.StatusNotFound)
+		return
+	}
+
+	if request.UserID != league.OwnerID {
+		writeDomainError(w, "Cannot change league settings", domain.ErrNotLeagueOwner, http.StatusForbidden)
+		return
+	}
+
+	league.Name = request.Name
+	if request.Format != "" {
+		league.Format = domain.LeagueFormat(request.Format)
+	}
+	if err := league.Validate(); err != nil {
+		writeDomainError(w, "Invalid league", err, http.StatusBadRequest)
+		return
+	}
+
+	competitionID, seasonID, ok := h.resolveScope(w, r, request.CompetitionID, request.SeasonID)
+	if !ok {
+		return
+	}
+	league.CompetitionID = competitionID
+	league.SeasonID = seasonID
+
+	settingsChanged := events.LeagueSettingsChanged{
+		LeagueID:      league.ID,
+		Name:          league.Name,
+		CompetitionID: league.CompetitionID,
+		SeasonID:      league.SeasonID,
+		Format:        request.Format,
+		ChangedAt:     h.now(),
+	}
+	if request.RegenerateInviteCode {
+		league.InviteCode = newInviteCode(leagues)
+		settingsChanged.InviteCode = league.InviteCode
+	}
+
+	event := events.NewEvent("LeagueSettingsChanged", settingsChanged)
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update league", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// leagueRulesRequest is the body accepted when the owner changes a league's
+// rules
+type leagueRulesRequest struct {
+	UserID string `json:"userId"`
+	domain.LeagueRules
+}
+
+func (r leagueRulesRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// UpdateRules handles the owner setting the league's own rules: its scoring,
+// an earlier lock cutoff, whether jokers count, which rounds or matches are
+// included and how members who miss a lock are auto-tipped. Tips are shared,
+// so the rules only change how the league scores them, from the next match
+// to finish.
+func (h *LeagueHandler) UpdateRules(w http.ResponseWriter, r *http.Request) {
+	var request leagueRulesRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	if request.UserID != league.OwnerID {
+		writeDomainError(w, "Cannot change league rules", domain.ErrNotLeagueOwner, http.StatusForbidden)
+		return
+	}
+
+	if err := request.LeagueRules.Validate(); err != nil {
+		writeDomainError(w, "Invalid league rules", err, http.StatusBadRequest)
+		return
+	}
+	league.Rules = request.LeagueRules
+
+	rulesChanged := events.LeagueRulesChanged{
+		LeagueID:   league.ID,
+		LockCutoff: league.Rules.LockCutoff,
+		Jokers:     league.Rules.Jokers,
+		RoundIDs:   league.Rules.RoundIDs,
+		MatchIDs:   league.Rules.MatchIDs,
+		AutoTip:    string(league.Rules.AutoTip),
+		ChangedAt:  h.now(),
+	}
+	if league.Rules.Scoring != nil {
+		rulesChanged.Scoring = make([]events.ScoringRule, 0, len(league.Rules.Scoring.Rules))
+		for _, rule := range league.Rules.Scoring.Rules {
+			rulesChanged.Scoring = append(rulesChanged.Scoring, events.ScoringRule{
+				Type:   rule.Type,
+				Points: rule.Points,
+				Final:  rule.Final,
+			})
+		}
+	}
+
+	event := events.NewEvent("LeagueRulesChanged", rulesChanged)
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update league rules", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// joinLeagueRequest is the body accepted when a user joins a league
+type joinLeagueRequest struct {
+	UserID     string `json:"userId"`
+	InviteCode string `json:"inviteCode"`
+}
+
+func (r joinLeagueRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+	errs.required("inviteCode", r.InviteCode)
+}
+
+// JoinLeague handles a user joining the league their invite code opens
+func (h *LeagueHandler) JoinLeague(w http.ResponseWriter, r *http.Request) {
+	var request joinLeagueRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	leagues, err := eventhandlers.LoadLeagues(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve leagues", http.StatusInternalServerError)
+		return
+	}
+
+	league := eventhandlers.FindLeagueByInviteCode(leagues, request.InviteCode)
+	if league == nil {
+		writeDomainError(w, "Cannot join league", fmt.Errorf("%w %q", domain.ErrUnknownInviteCode, request.InviteCode), http.StatusNotFound)
+		return
+	}
+
+	joinedAt := h.now()
+	if err := league.Join(request.UserID, joinedAt); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot join %s", league.Name), err, http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("LeagueMemberJoined", events.LeagueMemberJoined{
+		LeagueID: league.ID,
+		UserID:   request.UserID,
+		JoinedAt: joinedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to join league", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// leaveLeagueRequest is the body accepted when a member leaves a league
+type leaveLeagueRequest struct {
+	UserID string `json:"userId"`
+}
+
+func (r leaveLeagueRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// LeaveLeague handles a member leaving a league. The owner cannot leave.
+func (h *LeagueHandler) LeaveLeague(w http.ResponseWriter, r *http.Request) {
+	var request leaveLeagueRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	if err := league.Leave(request.UserID); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot leave %s", league.Name), err, http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("LeagueMemberLeft", events.LeagueMemberLeft{
+		LeagueID: league.ID,
+		UserID:   request.UserID,
+		LeftAt:   h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to leave league", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(league); err != nil {
+		fmt.Printf("error encoding league: %v\n", err)
+	}
+}
+
+// GetLeaderboard ranks the league's members, counting only points from the
+// league's competition or season if it has one and scoring match tips by the
+// league's own rules if it has them. ?competitionId= and ?seasonId= override
+// the league's own scope.
+func (h *LeagueHandler) GetLeaderboard(w http.ResponseWriter, r *http.Request) {
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+	h.leaderboard.writeLeaderboard(w, r, league)
+}
+
+// fixturesRequest is the body accepted when the owner generates head-to-head
+// fixtures
+type fixturesRequest struct {
+	UserID   string   `json:"userId"`
+	RoundIDs []string `json:"roundIds"`
+}
+
+func (r fixturesRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// GenerateFixtures handles the owner pairing a head-to-head league's members
+// round-robin. Without roundIds the fixtures cover every round of the
+// league's competition and season in order. Generating again, e.g. after
+// members join, replaces the fixtures.
+func (h *LeagueHandler) GenerateFixtures(w http.ResponseWriter, r *http.Request) {
+	var request fixturesRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	if request.UserID != league.OwnerID {
+		writeDomainError(w, fmt.Sprintf("Cannot generate fixtures for %s", league.Name), domain.ErrNotLeagueOwner, http.StatusForbidden)
+		return
+	}
+
+	if league.Format != domain.LeagueFormatHeadToHead {
+		writeDomainError(w, fmt.Sprintf("Cannot generate fixtures for %s", league.Name), domain.ErrNotHeadToHead, http.StatusBadRequest)
+		return
+	}
+
+	roundIDs, ok := h.fixtureRounds(w, r, league, request.RoundIDs)
+	if !ok {
+		return
+	}
+
+	fixtures, err := domain.GenerateFixtures(league.MemberList(), roundIDs)
+	if err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot generate fixtures for %s", league.Name), err, http.StatusBadRequest)
+		return
+	}
+
+	fixturesGenerated := events.LeagueFixturesGenerated{
+		LeagueID:    league.ID,
+		Fixtures:    make([]events.LeagueFixture, 0, len(fixtures)),
+		GeneratedAt: h.now(),
+	}
+	for _, fixture := range fixtures {
+		fixturesGenerated.Fixtures = append(fixturesGenerated.Fixtures, events.LeagueFixture{
+			RoundID:    fixture.RoundID,
+			HomeUserID: fixture.HomeUserID,
+			AwayUserID: fixture.AwayUserID,
+		})
+	}
+
+	event := events.NewEvent("LeagueFixturesGenerated", fixturesGenerated)
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to generate fixtures", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(fixtures); err != nil {
+		fmt.Printf("error encoding fixtures: %v\n", err)
+	}
+}
+
+// ListMatchups retrieves a head-to-head league's matchups with their round
+// scores and results. Use ?userId= for one member's schedule and ?roundId=
+// for one round.
+func (h *LeagueHandler) ListMatchups(w http.ResponseWriter, r *http.Request) {
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	matchups, err := h.results(r.Context(), league)
+	if err != nil {
+		writeError(w, "Failed to retrieve matchup results", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	userID := query.Get("userId")
+	roundID := query.Get("roundId")
+
+	filtered := make([]*domain.Matchup, 0, len(matchups))
+	for _, matchup := range matchups {
+		if userID != "" && !matchup.Involves(userID) {
+			continue
+		}
+		if roundID != "" && matchup.RoundID != roundID {
+			continue
+		}
+		filtered = append(filtered, matchup)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		fmt.Printf("error encoding matchups: %v\n", err)
+	}
+}
+
+// GetLadder ranks a head-to-head league's members on their decided matchups:
+// 3 points for a win and 1 for a draw, then tip points scored
+func (h *LeagueHandler) GetLadder(w http.ResponseWriter, r *http.Request) {
+	league, ok := findLeague(w, r, h.eventStore, mux.Vars(r)["id"])
+	if !ok {
+		return
+	}
+
+	matchups, err := h.results(r.Context(), league)
+	if err != nil {
+		writeError(w, "Failed to retrieve matchup results", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(domain.BuildLadder(league.MemberList(), matchups)); err != nil {
+		fmt.Printf("error encoding ladder: %v\n", err)
+	}
+}
+
+// fixtureRounds writes an error response and returns false if any requested
+// round is unknown. Without requested rounds it returns the rounds of the
+// league's competition and season, in order.
+func (h *LeagueHandler) fixtureRounds(w http.ResponseWriter, r *http.Request, league *domain.League, roundIDs []string) ([]string, bool) {
+	roundEvents, err := eventhandlers.LoadRoundEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	rounds, err := eventhandlers.ReplayRounds(roundEvents)
+	if err != nil {
+		writeError(w, "Failed to process round data", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if len(roundIDs) > 0 {
+		for _, roundID := range roundIDs {
+			if eventhandlers.FindRound(rounds, roundID) == nil {
+				writeDomainError(w, fmt.Sprintf("Cannot generate fixtures for %s", league.Name), fmt.Errorf("%w %q", domain.ErrUnknownRound.OnField("roundIds"), roundID), http.StatusBadRequest)
+				return nil, false
+			}
+		}
+		return roundIDs, true
+	}
+
+	if league.CompetitionID == "" {
+		return nil, true
+	}
+	inScope := make([]*domain.Round, 0, len(rounds))
+	for _, round := range rounds {
+		if round.CompetitionID == league.CompetitionID && (league.SeasonID == "" || round.SeasonID == league.SeasonID) {
+			inScope = append(inScope, round)
+		}
+	}
+	sort.SliceStable(inScope, func(i, j int) bool {
+		return inScope[i].StartDate.Before(inScope[j].StartDate)
+	})
+
+	ids := make([]string, 0, len(inScope))
+	for _, round := range inScope {
+		ids = append(ids, round.ID)
+	}
+	return ids, true
+}
+
+// results scores the league's fixtures. Each member scores the points their
+// tips earned in the round, counted as the league's leaderboard counts them
+// with corrected awards replacing the originals, and a matchup is decided
+// once every match in its round has a final result.
+func (h *LeagueHandler) results(ctx context.Context, league *domain.League) ([]*domain.Matchup, error) {
+	awards, err := h.leaderboard.awards(ctx, league)
+	if err != nil {
+		return nil, err
+	}
+	awards = domain.LatestAwards(awards)
+
+	type roundResult struct {
+		scores  map[string]int
+		decided bool
+	}
+	rounds := make(map[string]*roundResult)
+	matchups := make([]*domain.Matchup, 0, len(league.Fixtures))
+	for _, fixture := range league.Fixtures {
+		result, ok := rounds[fixture.RoundID]
+		if !ok {
+			roundID := fixture.RoundID
+			matches, err := h.matchRepo.List(ctx, repository.MatchFilters{RoundID: &roundID})
+			if err != nil {
+				return nil, err
+			}
+
+			inRound := make(map[string]bool, len(matches))
+			result = &roundResult{scores: make(map[string]int), decided: len(matches) > 0}
+			for _, match := range matches {
+				inRound[match.ID] = true
+				if !match.IsFinished() && !match.IsAbandoned() {
+					result.decided = false
+				}
+			}
+			for _, award := range awards {
+				if award.Source == domain.PointsSourceMatch && inRound[award.SourceID] {
+					result.scores[award.UserID] += award.Points
+				}
+			}
+			rounds[roundID] = result
+		}
+
+		matchup := *fixture
+		matchup.Record(result.scores[fixture.HomeUserID], result.scores[fixture.AwayUserID], result.decided)
+		matchups = append(matchups, &matchup)
+	}
+	return matchups, nil
+}
+
+// resolveScope writes an error response and returns false if the competition
+// or season is unknown or the season belongs to another competition. A season
+// on its own implies its competition.
+func (h *LeagueHandler) resolveScope(w http.ResponseWriter, r *http.Request, competitionRef, seasonID string) (string, string, bool) {
+	if competitionRef == "" && seasonID == "" {
+		return "", "", true
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return "", "", false
+	}
+
+	var season *domain.Season
+	if seasonID != "" {
+		if season = catalog.Season(seasonID); season == nil {
+			writeDomainError(w, "Invalid league", fmt.Errorf("%w %q", domain.ErrUnknownSeason, seasonID), http.StatusBadRequest)
+			return "", "", false
+		}
+		if competitionRef == "" {
+			competitionRef = season.CompetitionID
+		}
+	}
+
+	competition := catalog.Competition(competitionRef)
+	if competition == nil {
+		writeDomainError(w, "Invalid league", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, competitionRef), http.StatusBadRequest)
+		return "", "", false
+	}
+
+	if season != nil && season.CompetitionID != competition.ID {
+		writeDomainError(w, "Invalid league", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, seasonID, competition.Name), http.StatusBadRequest)
+		return "", "", false
+	}
+	return competition.ID, seasonID, true
+}
+
+// newInviteCode returns an invite code that no existing league uses
+func newInviteCode(leagues []*domain.League) string {
+	for {
+		code := utils.GenerateInviteCode()
+		if eventhandlers.FindLeagueByInviteCode(leagues, code) == nil {
+			return code
+		}
+	}
+}
+
+// findLeague writes an error response and returns false if the league does
+// not exist
+func findLeague(w http.ResponseWriter, r *http.Request, eventStore EventStore, leagueID string) (*domain.League, bool) {
+	leagues, err := eventhandlers.LoadLeagues(r.Context(), eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve leagues", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	league := eventhandlers.FindLeague(leagues, leagueID)
+	if league == nil {
+		writeError(w, "League not found", http.StatusNotFound)
+		return nil, false
+	}
+	return league, true
+}
+
+// leagueMembers writes an error response and returns false if the request
+// names a ?leagueId= that does not exist. Otherwise it returns the league's
+// member IDs, or nil if the request isn't scoped to a league.
+func leagueMembers(w http.ResponseWriter, r *http.Request, eventStore EventStore) (map[string]bool, bool) {
+	leagueID := r.URL.Query().Get("leagueId")
+	if leagueID == "" {
+		return nil, true
+	}
+
+	league, ok := findLeague(w, r, eventStore, leagueID)
+	if !ok {
+		return nil, false
+	}
+	return league.MemberIDs(), true
+}
@@ -0,0 +1,893 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// testLeagueEvents returns the events creating league1, owned by user1 with
+// invite code ABCD2345, and user2 joining it
+func testLeagueEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:        "event-l1",
+			Type:      "LeagueCreated",
+			Data:      events.LeagueCreated{ID: "league1", Name: "Office", OwnerID: "user1", InviteCode: "ABCD2345"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+		{
+			ID:        "event-l2",
+			Type:      "LeagueMemberJoined",
+			Data:      events.LeagueMemberJoined{LeagueID: "league1", UserID: "user2"},
+			Timestamp: time.Now().Add(time.Second),
+			Version:   1,
+		},
+	}
+}
+
+// expectLeagues sets up the event store to return the given league events
+func expectLeagues(mockStore *mocks.MockEventStore, ctx context.Context, leagueEvents []*events.Event) {
+	byType := map[string][]*events.Event{
+		"LeagueCreated":           {},
+		"LeagueMemberJoined":      {},
+		"LeagueMemberLeft":        {},
+		"LeagueSettingsChanged":   {},
+		"LeagueRulesChanged":      {},
+		"LeagueFixturesGenerated": {},
+	}
+	for _, event := range leagueEvents {
+		byType[event.Type] = append(byType[event.Type], event)
+	}
+	for eventType, typeEvents := range byType {
+		mockStore.On("GetEventsByType", ctx, eventType).Return(typeEvents, nil)
+	}
+}
+
+func TestCreateLeague(t *testing.T) {
+	// Test case 1: League for every competition
+	t.Run("League for every competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"name": "Office", "ownerId": "user1"}`
+		req := httptest.NewRequest("POST", "/api/leagues", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.LeagueCreated)
+			return ok && event.Type == "LeagueCreated" &&
+				created.OwnerID == "user1" &&
+				len(created.InviteCode) == 8
+		})).Return(nil)
+
+		handler.CreateLeague(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+
+		var league domain.League
+		if err := json.NewDecoder(rr.Body).Decode(&league); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !league.IsMember("user1") {
+			t.Errorf("expected the owner to be a member, got %+v", league.Members)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: League for one season
+	t.Run("League for one season", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"name": "Office", "ownerId": "user1", "seasonId": "season_epl_2025"}`
+		req := httptest.NewRequest("POST", "/api/leagues", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), nil)
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.LeagueCreated)
+			return ok && created.CompetitionID == "comp_epl" && created.SeasonID == "season_epl_2025"
+		})).Return(nil)
+
+		handler.CreateLeague(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: League without a name
+	t.Run("League without a name", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"ownerId": "user1"}`
+		req := httptest.NewRequest("POST", "/api/leagues", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), nil)
+
+		handler.CreateLeague(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJoinLeague(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{"Valid invite code", `{"userId": "user3", "inviteCode": "abcd2345"}`, http.StatusOK, ""},
+		{"Unknown invite code", `{"userId": "user3", "inviteCode": "ZZZZ9999"}`, http.StatusNotFound, "UNKNOWN_INVITE_CODE"},
+		{"Already a member", `{"userId": "user2", "inviteCode": "ABCD2345"}`, http.StatusConflict, "ALREADY_LEAGUE_MEMBER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(mocks.MockEventStore)
+			handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+			req := httptest.NewRequest("POST", "/api/leagues/join", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+
+			expectLeagues(mockStore, req.Context(), testLeagueEvents())
+			mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+				joined, ok := event.Data.(events.LeagueMemberJoined)
+				return ok && joined.LeagueID == "league1" && joined.UserID == "user3"
+			})).Return(nil)
+
+			handler.JoinLeague(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				if problem := decodeProblem(t, rr); problem.Code != tt.expectedCode {
+					t.Errorf("expected code %q, got %q", tt.expectedCode, problem.Code)
+				}
+				mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLeaveLeague(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         string
+		expectedStatus int
+	}{
+		{"Member leaves", "user2", http.StatusOK},
+		{"Owner cannot leave", "user1", http.StatusConflict},
+		{"Not a member", "user3", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(mocks.MockEventStore)
+			handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+			body := `{"userId": "` + tt.userID + `"}`
+			req := httptest.NewRequest("POST", "/api/leagues/league1/leave", bytes.NewBufferString(body))
+			req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+			rr := httptest.NewRecorder()
+
+			expectLeagues(mockStore, req.Context(), testLeagueEvents())
+			mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+				left, ok := event.Data.(events.LeagueMemberLeft)
+				return ok && left.UserID == "user2"
+			})).Return(nil)
+
+			handler.LeaveLeague(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestUpdateLeague(t *testing.T) {
+	// Test case 1: Owner regenerates the invite code
+	t.Run("Owner regenerates the invite code", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"userId": "user1", "name": "Office 2025", "regenerateInviteCode": true}`
+		req := httptest.NewRequest("PUT", "/api/leagues/league1", bytes.NewBufferString(body))
+		req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), testLeagueEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			changed, ok := event.Data.(events.LeagueSettingsChanged)
+			return ok && event.Type == "LeagueSettingsChanged" &&
+				changed.Name == "Office 2025" &&
+				changed.InviteCode != "" && changed.InviteCode != "ABCD2345"
+		})).Return(nil)
+
+		handler.UpdateLeague(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Member who isn't the owner
+	t.Run("Member who isn't the owner", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"userId": "user2", "name": "Mine now"}`
+		req := httptest.NewRequest("PUT", "/api/leagues/league1", bytes.NewBufferString(body))
+		req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), testLeagueEvents())
+
+		handler.UpdateLeague(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+		if problem := decodeProblem(t, rr); problem.Code != "NOT_LEAGUE_OWNER" {
+			t.Errorf("expected code %q, got %q", "NOT_LEAGUE_OWNER", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestLeagueScope(t *testing.T) {
+	// leagueEvents holds league1 with only its owner, user1
+	leagueEvents := testLeagueEvents()[:1]
+
+	// Test case 1: League leaderboard ranks only members
+	t.Run("League leaderboard ranks only members", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/leagues/league1/leaderboard", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+		expectAwards(mockStore, req)
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var leaderboard []domain.LeaderboardEntry
+		if err := json.NewDecoder(rr.Body).Decode(&leaderboard); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(leaderboard) != 1 || leaderboard[0].UserID != "user1" {
+			t.Errorf("expected only user1, got %+v", leaderboard)
+		}
+	})
+
+	// Test case 2: League with its own rules ranks on its own match points
+	t.Run("League with its own rules ranks on its own match points", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/leagues/league1/leaderboard", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+		rr := httptest.NewRecorder()
+
+		jokers := false
+		expectLeagues(mockStore, req.Context(), append(testLeagueEvents(), &events.Event{
+			ID:        "event-l3",
+			Type:      "LeagueRulesChanged",
+			Data:      events.LeagueRulesChanged{LeagueID: "league1", Jokers: &jokers},
+			Timestamp: time.Now().Add(2 * time.Second),
+			Version:   1,
+		}))
+		expectAwards(mockStore, req)
+		mockStore.On("GetEventsByType", req.Context(), "LeaguePointsAwarded").Return([]*events.Event{
+			{
+				ID:   "event-la1",
+				Type: "LeaguePointsAwarded",
+				Data: events.LeaguePointsAwarded{
+					LeagueID:      "league1",
+					PointsAwarded: events.PointsAwarded{PredictionID: "pred1", UserID: "user1", MatchID: "m1", Points: 10},
+				},
+				Timestamp: time.Now(),
+				Version:   1,
+			},
+		}, nil)
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var leaderboard []domain.LeaderboardEntry
+		if err := json.NewDecoder(rr.Body).Decode(&leaderboard); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(leaderboard) != 2 || leaderboard[0].UserID != "user1" || leaderboard[0].MatchPoints != 10 {
+			t.Errorf("expected user1 first on 10 league match points, got %+v", leaderboard)
+		}
+	})
+
+	// Test case 3: Unknown league on the global leaderboard
+	t.Run("Unknown league on the global leaderboard", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeaderboardHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/leaderboard?leagueId=missing", nil)
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+
+		handler.GetLeaderboard(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	// Test case 4: Outright tips of members only
+	t.Run("Outright tips of members only", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+
+		req := httptest.NewRequest("GET", "/api/outrights/market1/tips?leagueId=league1", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+		rr := httptest.NewRecorder()
+
+		expectMarkets(mockStore, req.Context())
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+		expectOutrightTips(mockStore, req.Context(), []*events.Event{
+			testTipEvent("tip1", "user1", "team_a", "team_b"),
+			testTipEvent("tip2", "user3", "team_b", "team_a"),
+		})
+
+		handler.ListTips(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var tips []domain.OutrightTip
+		if err := json.NewDecoder(rr.Body).Decode(&tips); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(tips) != 1 || tips[0].UserID != "user1" {
+			t.Errorf("expected only user1's tip, got %+v", tips)
+		}
+	})
+}
+
+func TestUpdateLeagueRules(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{"Owner sets rules", `{"userId": "user1", "scoring": {"rules": [{"type": "correct_result", "points": 1}]}, "jokers": false, "lockCutoff": "1h", "autoTip": "FAVOURITE_ODDS"}`, http.StatusOK},
+		{"Member who isn't the owner", `{"userId": "user2", "lockCutoff": "1h"}`, http.StatusForbidden},
+		{"Invalid lock cutoff", `{"userId": "user1", "lockCutoff": "soon"}`, http.StatusBadRequest},
+		{"Unknown scoring rule", `{"userId": "user1", "scoring": {"rules": [{"type": "own_goals", "points": 1}]}}`, http.StatusBadRequest},
+		{"Unknown auto-tip policy", `{"userId": "user1", "autoTip": "RANDOM"}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(mocks.MockEventStore)
+			handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+			req := httptest.NewRequest("PUT", "/api/leagues/league1/rules", bytes.NewBufferString(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+			rr := httptest.NewRecorder()
+
+			expectLeagues(mockStore, req.Context(), testLeagueEvents())
+			mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+				changed, ok := event.Data.(events.LeagueRulesChanged)
+				return ok && event.Type == "LeagueRulesChanged" &&
+					len(changed.Scoring) == 1 &&
+					changed.Jokers != nil && !*changed.Jokers &&
+					changed.LockCutoff == "1h" &&
+					changed.AutoTip == "FAVOURITE_ODDS"
+			})).Return(nil)
+
+			handler.UpdateRules(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, rr.Code, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestLeagueRulesScoring(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	req := httptest.NewRequest("PUT", "/api/matches/m9/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m9"})
+
+	// The league scores 5 for an exact score or 2 for the result, ignores
+	// jokers, and locks three hours before kickoff
+	now := time.Now()
+	kickoff := now.Add(2 * time.Hour)
+	jokers := false
+	leagueEvents := append(testLeagueEvents(), &events.Event{
+		ID:   "event-l3",
+		Type: "LeagueRulesChanged",
+		Data: events.LeagueRulesChanged{
+			LeagueID: "league1",
+			Scoring: []events.ScoringRule{
+				{Type: domain.RuleExactScore, Points: 5, Final: true},
+				{Type: domain.RuleCorrectResult, Points: 2},
+			},
+			LockCutoff: "3h",
+			Jokers:     &jokers,
+		},
+		Timestamp: now.Add(2 * time.Second),
+		Version:   1,
+	})
+
+	tip := func(predictionID, userID string, homeGoals, awayGoals int, joker bool, at time.Time) *events.Event {
+		return &events.Event{
+			ID:        "event-" + predictionID,
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: predictionID, UserID: userID, MatchID: "m9", HomeGoals: homeGoals, AwayGoals: awayGoals, Joker: joker},
+			Timestamp: at,
+			Version:   1,
+		}
+	}
+
+	mockStore.On("GetEvents", req.Context(), "m9").Return([]*events.Event{
+		{
+			ID:        "event-m9",
+			Type:      "MatchCreated",
+			Data:      events.MatchCreated{ID: "m9", HomeTeam: "Team A", AwayTeam: "Team B", Date: kickoff, Competition: "Premier League"},
+			Timestamp: now.Add(-48 * time.Hour),
+			Version:   1,
+		},
+		{
+			ID:        "event-m9-score",
+			Type:      "MatchScoreUpdated",
+			Data:      events.MatchScoreUpdated{MatchID: "m9", HomeGoals: 2, AwayGoals: 1},
+			Timestamp: now,
+			Version:   1,
+		},
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, req.Context(), leagueEvents)
+	expectSurvivorPools(mockStore, req.Context(), nil)
+	expectCatalog(mockStore, req.Context(), testCatalogEvents())
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+		tip("pred1", "user1", 2, 1, true, now.Add(-5*time.Hour)),
+		tip("pred2", "user2", 1, 0, false, now.Add(-time.Minute)),
+		tip("pred3", "user3", 2, 1, false, now.Add(-5*time.Hour)),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	expectBrackets(mockStore, req.Context(), nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchStatusChanged"
+	})).Return(nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.PointsAwarded)
+		return ok && awarded.PredictionID == "pred1" && awarded.Points == 6
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.PointsAwarded)
+		return ok && awarded.PredictionID != "pred1"
+	})).Return(nil).Twice()
+	// Only user1's tip counts in the league: user2 tipped after its lock and
+	// user3 isn't a member
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.LeaguePointsAwarded)
+		return ok && awarded.LeagueID == "league1" && awarded.PredictionID == "pred1" && awarded.Points == 5
+	})).Return(nil).Once()
+	mockRepo.On("GetByID", req.Context(), "m9").Return(&domain.Match{ID: "m9", Status: domain.MatchStatusScheduled}, nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+	expectBadges(mockStore, req.Context())
+
+	handler.UpdateMatchStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestAutoTipLeagueScoring(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	req := httptest.NewRequest("PUT", "/api/matches/m9/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m9"})
+
+	// user2 is in league1, which auto-tips the home team at its lock three
+	// hours before kickoff, and in league2, which has no auto-tips
+	now := time.Now()
+	kickoff := now.Add(2 * time.Hour)
+	jokers := false
+	leagueEvents := append(testLeagueEvents(),
+		&events.Event{
+			ID:        "event-l3",
+			Type:      "LeagueRulesChanged",
+			Data:      events.LeagueRulesChanged{LeagueID: "league1", LockCutoff: "3h", AutoTip: string(domain.AutoTipHomeTeam)},
+			Timestamp: now.Add(2 * time.Second),
+			Version:   1,
+		},
+		&events.Event{
+			ID:        "event-l4",
+			Type:      "LeagueCreated",
+			Data:      events.LeagueCreated{ID: "league2", Name: "Pub", OwnerID: "user1", InviteCode: "WXYZ2345"},
+			Timestamp: now.Add(3 * time.Second),
+			Version:   1,
+		},
+		&events.Event{
+			ID:        "event-l5",
+			Type:      "LeagueMemberJoined",
+			Data:      events.LeagueMemberJoined{LeagueID: "league2", UserID: "user2"},
+			Timestamp: now.Add(4 * time.Second),
+			Version:   1,
+		},
+		&events.Event{
+			ID:        "event-l6",
+			Type:      "LeagueRulesChanged",
+			Data:      events.LeagueRulesChanged{LeagueID: "league2", Jokers: &jokers},
+			Timestamp: now.Add(5 * time.Second),
+			Version:   1,
+		},
+	)
+
+	mockStore.On("GetEvents", req.Context(), "m9").Return([]*events.Event{
+		{
+			ID:        "event-m9",
+			Type:      "MatchCreated",
+			Data:      events.MatchCreated{ID: "m9", HomeTeam: "Team A", AwayTeam: "Team B", Date: kickoff, Competition: "Premier League"},
+			Timestamp: now.Add(-48 * time.Hour),
+			Version:   1,
+		},
+		{
+			ID:        "event-m9-score",
+			Type:      "MatchScoreUpdated",
+			Data:      events.MatchScoreUpdated{MatchID: "m9", HomeGoals: 2, AwayGoals: 1},
+			Timestamp: now,
+			Version:   1,
+		},
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, req.Context(), leagueEvents)
+	expectSurvivorPools(mockStore, req.Context(), nil)
+	expectCatalog(mockStore, req.Context(), testCatalogEvents())
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+		{
+			ID:        "event-pred1",
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: "pred1", UserID: "user1", MatchID: "m9", HomeGoals: 2, AwayGoals: 1},
+			Timestamp: now.Add(-5 * time.Hour),
+			Version:   1,
+		},
+		{
+			ID:        "event-pred2",
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: "pred2", UserID: "user2", MatchID: "m9", HomeGoals: 1, AwayGoals: 0, Auto: true, LeagueID: "league1"},
+			Timestamp: now.Add(-time.Hour),
+			Version:   1,
+		},
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	expectBrackets(mockStore, req.Context(), nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchStatusChanged"
+	})).Return(nil)
+	// The auto-tip counts only in league1, though it was made after its lock
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.PointsAwarded)
+		return ok && awarded.PredictionID == "pred1" && awarded.Points == 3
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.LeaguePointsAwarded)
+		return ok && awarded.LeagueID == "league1" && awarded.PredictionID == "pred1" && awarded.Points == 3
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.LeaguePointsAwarded)
+		return ok && awarded.LeagueID == "league1" && awarded.PredictionID == "pred2" && awarded.Points == 1 && awarded.Auto
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		awarded, ok := event.Data.(events.LeaguePointsAwarded)
+		return ok && awarded.LeagueID == "league2" && awarded.PredictionID == "pred1"
+	})).Return(nil).Once()
+	mockRepo.On("GetByID", req.Context(), "m9").Return(&domain.Match{ID: "m9", Status: domain.MatchStatusScheduled}, nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+	expectBadges(mockStore, req.Context())
+
+	handler.UpdateMatchStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	mockStore.AssertExpectations(t)
+}
+
+// testHeadToHeadEvents returns the events creating a head-to-head league for
+// the Premier League, league2, owned by user1 with user2 as a member
+func testHeadToHeadEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:        "event-h1",
+			Type:      "LeagueCreated",
+			Data:      events.LeagueCreated{ID: "league2", Name: "Rivals", OwnerID: "user1", InviteCode: "EFGH6789", CompetitionID: "comp_epl", Format: "HEAD_TO_HEAD"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+		{
+			ID:        "event-h2",
+			Type:      "LeagueMemberJoined",
+			Data:      events.LeagueMemberJoined{LeagueID: "league2", UserID: "user2"},
+			Timestamp: time.Now().Add(time.Second),
+			Version:   1,
+		},
+	}
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	roundEvent := func(roundID string, number int) *events.Event {
+		start := time.Date(2025, 8, 7*number, 0, 0, 0, 0, time.UTC)
+		return &events.Event{
+			ID:        "event-" + roundID,
+			Type:      "RoundCreated",
+			Data:      events.RoundCreated{ID: roundID, CompetitionID: "comp_epl", Number: number, StartDate: start, EndDate: start.Add(72 * time.Hour)},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+
+	// Test case 1: Fixtures for every round of the competition
+	t.Run("Fixtures for every round of the competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/leagues/league2/fixtures", bytes.NewBufferString(`{"userId": "user1"}`))
+		req = mux.SetURLVars(req, map[string]string{"id": "league2"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), testHeadToHeadEvents())
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundEvent("r2", 2), roundEvent("r1", 1)}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			generated, ok := event.Data.(events.LeagueFixturesGenerated)
+			return ok && event.Type == "LeagueFixturesGenerated" &&
+				len(generated.Fixtures) == 2 &&
+				generated.Fixtures[0].RoundID == "r1" && generated.Fixtures[1].RoundID == "r2"
+		})).Return(nil)
+
+		handler.GenerateFixtures(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Classic league
+	t.Run("Classic league", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/leagues/league1/fixtures", bytes.NewBufferString(`{"userId": "user1", "roundIds": ["r1"]}`))
+		req = mux.SetURLVars(req, map[string]string{"id": "league1"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), testLeagueEvents())
+
+		handler.GenerateFixtures(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Member who isn't the owner
+	t.Run("Member who isn't the owner", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewLeagueHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/leagues/league2/fixtures", bytes.NewBufferString(`{"userId": "user2"}`))
+		req = mux.SetURLVars(req, map[string]string{"id": "league2"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), testHeadToHeadEvents())
+
+		handler.GenerateFixtures(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+		if problem := decodeProblem(t, rr); problem.Code != "NOT_LEAGUE_OWNER" {
+			t.Errorf("expected code %q, got %q", "NOT_LEAGUE_OWNER", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestHeadToHeadResults(t *testing.T) {
+	// user1 meets user2 in r1, which is finished, and again in r2, which is
+	// still being played
+	leagueEvents := append(testHeadToHeadEvents(), &events.Event{
+		ID:   "event-h3",
+		Type: "LeagueFixturesGenerated",
+		Data: events.LeagueFixturesGenerated{
+			LeagueID: "league2",
+			Fixtures: []events.LeagueFixture{
+				{RoundID: "r1", HomeUserID: "user1", AwayUserID: "user2"},
+				{RoundID: "r2", HomeUserID: "user2", AwayUserID: "user1"},
+			},
+		},
+		Timestamp: time.Now().Add(2 * time.Second),
+		Version:   1,
+	})
+	expectRounds := func(mockRepo *mocks.MockMatchRepository, ctx context.Context) {
+		r1, r2 := "r1", "r2"
+		mockRepo.On("List", ctx, repository.MatchFilters{RoundID: &r1}).Return([]*domain.Match{
+			{ID: "m1", RoundID: "r1", Status: domain.MatchStatusFinished},
+		}, nil)
+		mockRepo.On("List", ctx, repository.MatchFilters{RoundID: &r2}).Return([]*domain.Match{
+			{ID: "m2", RoundID: "r2", Status: domain.MatchStatusScheduled},
+		}, nil)
+	}
+
+	// Test case 1: A member's schedule and results
+	t.Run("A member's schedule and results", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewLeagueHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("GET", "/api/leagues/league2/matchups?userId=user1", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "league2"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+		expectAwards(mockStore, req)
+		expectRounds(mockRepo, req.Context())
+
+		handler.ListMatchups(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var matchups []domain.Matchup
+		if err := json.NewDecoder(rr.Body).Decode(&matchups); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(matchups) != 2 {
+			t.Fatalf("expected 2 matchups, got %d", len(matchups))
+		}
+		if !matchups[0].IsDecided() || matchups[0].WinnerID != "user1" || matchups[0].HomeScore != 3 {
+			t.Errorf("expected user1 to win r1 3-0, got %+v", matchups[0])
+		}
+		if matchups[1].IsDecided() {
+			t.Errorf("expected r2 to be undecided, got %+v", matchups[1])
+		}
+	})
+
+	// Test case 2: Ladder
+	t.Run("Ladder", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewLeagueHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("GET", "/api/leagues/league2/ladder", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "league2"})
+		rr := httptest.NewRecorder()
+
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+		expectAwards(mockStore, req)
+		expectRounds(mockRepo, req.Context())
+
+		handler.GetLadder(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var ladder []domain.LadderEntry
+		if err := json.NewDecoder(rr.Body).Decode(&ladder); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(ladder) != 2 || ladder[0].UserID != "user1" || ladder[0].Won != 1 || ladder[0].Points != domain.LadderPointsWin || ladder[1].Lost != 1 {
+			t.Errorf("expected user1 top after beating user2, got %+v", ladder)
+		}
+	})
+
+	// Test case 3: A score correction replaces the original awards
+	t.Run("Corrected awards", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewLeagueHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("GET", "/api/leagues/league2/ladder", nil)
+		req = mux.SetURLVars(req, map[string]string{"id": "league2"})
+		rr := httptest.NewRecorder()
+
+		// m1 was corrected: user1's exact score became a wrong tip and user2's
+		// wrong tip an exact score
+		awarded := func(id, predictionID, userID string, points, adjustment int, at time.Time) *events.Event {
+			return &events.Event{
+				ID:   id,
+				Type: "PointsAwarded",
+				Data: events.PointsAwarded{
+					PredictionID: predictionID, UserID: userID, MatchID: "m1", Points: points,
+					Correction: adjustment != 0, Adjustment: adjustment,
+				},
+				Timestamp: at,
+				Version:   1,
+			}
+		}
+		now := time.Now()
+		expectLeagues(mockStore, req.Context(), leagueEvents)
+		mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{
+			awarded("event-a1", "pred1", "user1", 3, 0, now),
+			awarded("event-a2", "pred2", "user2", 0, 0, now),
+			awarded("event-a3", "pred1", "user1", 0, -3, now.Add(time.Minute)),
+			awarded("event-a4", "pred2", "user2", 3, 3, now.Add(time.Minute)),
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+		expectRounds(mockRepo, req.Context())
+
+		handler.GetLadder(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var ladder []domain.LadderEntry
+		if err := json.NewDecoder(rr.Body).Decode(&ladder); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(ladder) != 2 || ladder[0].UserID != "user2" || ladder[0].Won != 1 || ladder[0].PointsFor != 3 || ladder[1].PointsFor != 0 {
+			t.Errorf("expected user2 top after winning r1 3-0 once corrected, got %+v", ladder)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -15,51 +16,173 @@ import (
 )
 
 type MatchHandler struct {
-	eventStore   EventStore
-	matchRepo    repository.MatchRepository
-	eventHandler *eventhandlers.MatchEventHandler
+	eventStore          EventStore
+	matchRepo           repository.MatchRepository
+	eventHandler        *eventhandlers.MatchEventHandler
+	scoringHandler      *eventhandlers.ScoringEventHandler
+	bracketHandler      *eventhandlers.BracketScoringHandler
+	survivorHandler     *eventhandlers.SurvivorEliminationHandler
+	notificationHandler *eventhandlers.RescheduleNotificationHandler
+	badgeHandler        *eventhandlers.BadgeAwardHandler
+	lockCutoff          time.Duration
 }
 
 func NewMatchHandler(eventStore EventStore, matchRepo repository.MatchRepository) *MatchHandler {
 	return &MatchHandler{
-		eventStore:   eventStore,
-		matchRepo:    matchRepo,
-		eventHandler: eventhandlers.NewMatchEventHandler(matchRepo),
+		eventStore:          eventStore,
+		matchRepo:           matchRepo,
+		eventHandler:        eventhandlers.NewMatchEventHandler(matchRepo),
+		scoringHandler:      eventhandlers.NewScoringEventHandler(eventStore),
+		bracketHandler:      eventhandlers.NewBracketScoringHandler(eventStore),
+		survivorHandler:     eventhandlers.NewSurvivorEliminationHandler(eventStore),
+		notificationHandler: eventhandlers.NewRescheduleNotificationHandler(eventStore),
+		badgeHandler:        eventhandlers.NewBadgeAwardHandler(eventStore),
 	}
 }
 
+// WithLockCutoff sets how long before kickoff tipping closes, for reporting
+// a rescheduled match's new lock time. By default tips lock at kickoff.
+func (h *MatchHandler) WithLockCutoff(cutoff time.Duration) *MatchHandler {
+	h.lockCutoff = cutoff
+	return h
+}
+
+// createMatchRequest is the body accepted when creating a match. Teams and
+// the competition may be given by ID or by name. The venue's time zone
+// defaults to the home team's.
+type createMatchRequest struct {
+	HomeTeam      string    `json:"homeTeam"`
+	AwayTeam      string    `json:"awayTeam"`
+	HomeTeamID    string    `json:"homeTeamId"`
+	AwayTeamID    string    `json:"awayTeamId"`
+	Date          time.Time `json:"date"`
+	Competition   string    `json:"competition"`
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId"`
+	RoundID       string    `json:"roundId"`
+	TimeZone      string    `json:"timeZone"`
+}
+
+func (r createMatchRequest) validate(errs *fieldErrors) {
+	homeTeam := firstNonBlank(r.HomeTeamID, r.HomeTeam)
+	awayTeam := firstNonBlank(r.AwayTeamID, r.AwayTeam)
+	errs.required("homeTeam", homeTeam)
+	errs.required("awayTeam", awayTeam)
+	if homeTeam != "" && strings.EqualFold(homeTeam, awayTeam) {
+		errs.rule("awayTeam", domain.ErrTeamPlaysItself)
+	}
+	errs.requiredTime("date", r.Date)
+	errs.required("competition", firstNonBlank(r.CompetitionID, r.Competition))
+	errs.timeZone("timeZone", r.TimeZone)
+}
+
 // CreateMatch handles the creation of a new match
 func (h *MatchHandler) CreateMatch(w http.ResponseWriter, r *http.Request) {
-	var request struct {
-		HomeTeam    string    `json:"homeTeam"`
-		AwayTeam    string    `json:"awayTeam"`
-		Date        time.Time `json:"date"`
-		Competition string    `json:"competition"`
+	var request createMatchRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	// Resolve both teams by ID, or by name, short code or alias
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	homeTeam, ok := resolveTeam(w, registry, "homeTeam", request.HomeTeamID, request.HomeTeam)
+	if !ok {
+		return
+	}
+	awayTeam, ok := resolveTeam(w, registry, "awayTeam", request.AwayTeamID, request.AwayTeam)
+	if !ok {
 		return
 	}
+	if homeTeam.ID == awayTeam.ID {
+		writeDomainError(w, "Invalid match", domain.ErrTeamPlaysItself, http.StatusBadRequest)
+		return
+	}
+
+	// Resolve the competition by ID, or by name or alias
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competitionRef := firstNonBlank(request.CompetitionID, request.Competition)
+	competition := catalog.Competition(competitionRef)
+	if competition == nil {
+		writeDomainError(w, "Invalid match", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, competitionRef), http.StatusBadRequest)
+		return
+	}
+
+	if request.SeasonID != "" {
+		season := catalog.Season(request.SeasonID)
+		if season == nil || season.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid match", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, request.SeasonID, competition.Name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Matches may only join a round in their own competition
+	if request.RoundID != "" {
+		roundEvents, err := eventhandlers.LoadRoundEvents(r.Context(), h.eventStore)
+		if err != nil {
+			writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+			return
+		}
+
+		rounds, err := eventhandlers.ReplayRounds(roundEvents)
+		if err != nil {
+			writeError(w, "Failed to process round data", http.StatusInternalServerError)
+			return
+		}
+
+		round := eventhandlers.FindRound(rounds, request.RoundID)
+		if round == nil {
+			writeDomainError(w, "Invalid match", fmt.Errorf("%w %q", domain.ErrUnknownRound, request.RoundID), http.StatusBadRequest)
+			return
+		}
+		if round.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid match", fmt.Errorf("%w: %s is not a round of %s", domain.ErrRoundNotInCompetition, round.Name, competition.Name), http.StatusBadRequest)
+			return
+		}
+		if request.SeasonID == "" {
+			request.SeasonID = round.SeasonID
+		}
+	}
 
 	match := domain.NewMatch(
 		utils.GenerateID(),
-		request.HomeTeam,
-		request.AwayTeam,
+		homeTeam.Name,
+		awayTeam.Name,
 		request.Date,
-		request.Competition,
+		competition.Name,
 	)
+	match.HomeTeamID = homeTeam.ID
+	match.AwayTeamID = awayTeam.ID
+	match.CompetitionID = competition.ID
+	match.SeasonID = request.SeasonID
+	match.RoundID = request.RoundID
+	match.TimeZone = firstNonBlank(request.TimeZone, homeTeam.TimeZone)
 
 	event := events.NewEvent("MatchCreated", events.MatchCreated{
-		ID:          match.ID,
-		HomeTeam:    match.HomeTeam,
-		AwayTeam:    match.AwayTeam,
-		Date:        match.Date,
-		Competition: match.Competition,
+		ID:            match.ID,
+		HomeTeam:      match.HomeTeam,
+		AwayTeam:      match.AwayTeam,
+		HomeTeamID:    match.HomeTeamID,
+		AwayTeamID:    match.AwayTeamID,
+		Date:          match.Date,
+		Competition:   match.Competition,
+		CompetitionID: match.CompetitionID,
+		SeasonID:      match.SeasonID,
+		RoundID:       match.RoundID,
+		TimeZone:      match.TimeZone,
 	})
 
 	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
-		http.Error(w, "Failed to create match", http.StatusInternalServerError)
+		writeError(w, "Failed to create match", http.StatusInternalServerError)
 		return
 	}
 
@@ -76,52 +199,463 @@ func (h *MatchHandler) CreateMatch(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// UpdateMatchScore handles updating a match's score
+// scoreRequest is the body accepted when recording a match's score
+type scoreRequest struct {
+	HomeGoals int               `json:"homeGoals"`
+	AwayGoals int               `json:"awayGoals"`
+	ExtraTime *events.Scoreline `json:"extraTime"`
+	Penalties *events.Scoreline `json:"penalties"`
+}
+
+func (r scoreRequest) validate(errs *fieldErrors) {
+	errs.nonNegative("homeGoals", r.HomeGoals)
+	errs.nonNegative("awayGoals", r.AwayGoals)
+	validateScoreline(errs, "extraTime", r.ExtraTime)
+	validateScoreline(errs, "penalties", r.Penalties)
+}
+
+// validateScoreline records the goals of an optional scoreline that are
+// negative, as fields of the scoreline's own field
+func validateScoreline(errs *fieldErrors, field string, line *events.Scoreline) {
+	if line == nil {
+		return
+	}
+	errs.nonNegative(field+".homeGoals", line.HomeGoals)
+	errs.nonNegative(field+".awayGoals", line.AwayGoals)
+}
+
+// scoreUpdated returns the event recording the requested score
+func (r scoreRequest) scoreUpdated(matchID string) events.MatchScoreUpdated {
+	return events.MatchScoreUpdated{
+		MatchID:   matchID,
+		HomeGoals: r.HomeGoals,
+		AwayGoals: r.AwayGoals,
+		ExtraTime: r.ExtraTime,
+		Penalties: r.Penalties,
+		UpdatedAt: time.Now(),
+	}
+}
+
+// UpdateMatchScore handles updating a match's score. Cup matches may also
+// record the score after extra time and the penalty shootout. Once a match has
+// finished its score can only be changed with CorrectMatchScore, so that its
+// points are recalculated.
 func (h *MatchHandler) UpdateMatchScore(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	matchID := vars["id"]
 
-	var request struct {
-		HomeGoals int `json:"homeGoals"`
-		AwayGoals int `json:"awayGoals"`
+	var request scoreRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	scoreUpdated := request.scoreUpdated(matchID)
+
+	if err := eventhandlers.ScoreFromEvent(scoreUpdated).Validate(); err != nil {
+		writeDomainError(w, "Invalid score", err, http.StatusBadRequest)
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	match, ok := h.findMatch(w, r, matchID)
+	if !ok {
 		return
 	}
 
-	event := events.NewEvent("MatchScoreUpdated", events.MatchScoreUpdated{
+	if match.IsFinished() {
+		writeDomainError(w, "Cannot update score", fmt.Errorf("%w with POST /api/matches/%s/corrections", domain.ErrMatchAlreadyFinished, matchID), http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("MatchScoreUpdated", scoreUpdated)
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update match score", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for score update: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// matchStatusRequest is the body accepted when changing a match's status
+type matchStatusRequest struct {
+	Status string `json:"status"`
+}
+
+func (r matchStatusRequest) validate(errs *fieldErrors) {
+	errs.required("status", r.Status)
+	if r.Status != "" && !domain.MatchStatus(r.Status).IsValid() {
+		errs.rule("status", domain.ErrInvalidMatchStatus)
+	}
+}
+
+// UpdateMatchStatus handles moving a match to a new status. Finishing or
+// abandoning a match triggers scoring of its predictions and settles its
+// survivor picks, and finishing it scores any bracket ties it decides.
+func (h *MatchHandler) UpdateMatchStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request matchStatusRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	status := domain.MatchStatus(request.Status)
+
+	match, ok := h.findMatch(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	if err := match.ChangeStatus(status); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot change match status from %s to %s", match.Status, status), err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("MatchStatusChanged", events.MatchStatusChanged{
 		MatchID:   matchID,
-		HomeGoals: request.HomeGoals,
-		AwayGoals: request.AwayGoals,
-		UpdatedAt: time.Now(),
+		Status:    string(status),
+		ChangedAt: time.Now(),
 	})
 
 	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
-		http.Error(w, "Failed to update match score", http.StatusInternalServerError)
+		writeError(w, "Failed to update match status", http.StatusInternalServerError)
 		return
 	}
 
 	// Process event to update read model
 	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
-		fmt.Printf("Failed to process event for score update: %v\n", err)
+		fmt.Printf("Failed to process event for status update: %v\n", err)
 		// Continue anyway since the event is saved
 	}
 
+	// Award points if the match result is now final
+	if err := h.scoringHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to score predictions for match %s: %v\n", matchID, err)
+	}
+
+	// Score any bracket ties the match decides
+	if err := h.bracketHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to score brackets for match %s: %v\n", matchID, err)
+	}
+
+	// Knock out survivor players whose team lost or drew
+	if err := h.survivorHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to settle survivor picks for match %s: %v\n", matchID, err)
+	}
+
+	// Unlock any badges the new points earn
+	if err := h.badgeHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to award badges for match %s: %v\n", matchID, err)
+	}
+
 	w.WriteHeader(http.StatusOK)
 }
 
-// GetMatch retrieves a match by ID
+// scoreCorrectionRequest is the body accepted when correcting a finished
+// match's score. The reason is kept for the audit trail.
+type scoreCorrectionRequest struct {
+	scoreRequest
+	Reason string `json:"reason"`
+}
+
+func (r scoreCorrectionRequest) validate(errs *fieldErrors) {
+	r.scoreRequest.validate(errs)
+	errs.required("reason", r.Reason)
+}
+
+// CorrectMatchScore handles fixing the score of a finished match that was
+// recorded wrongly. A reason is required for the audit trail. Every
+// prediction whose points change is re-awarded, with the adjustment from
+// its earlier award, as are the bracket entries for a tie whose winner
+// changes. Survivor picks whose outcome changes are settled again.
+func (h *MatchHandler) CorrectMatchScore(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request scoreCorrectionRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	match, ok := h.findMatch(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	scoreCorrected := events.MatchScoreCorrected{
+		MatchScoreUpdated: request.scoreUpdated(matchID),
+		Reason:            strings.TrimSpace(request.Reason),
+	}
+
+	if err := match.CorrectScore(eventhandlers.ScoreFromEvent(scoreCorrected.MatchScoreUpdated)); err != nil {
+		status := http.StatusBadRequest
+		if err == domain.ErrMatchNotFinished || err == domain.ErrScoreUnchanged {
+			status = http.StatusConflict
+		}
+		writeDomainError(w, "Cannot correct score", err, status)
+		return
+	}
+
+	event := events.NewEvent("MatchScoreCorrected", scoreCorrected)
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to correct match score", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for score correction: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	// Re-award points for the predictions the correction changes
+	if err := h.scoringHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to rescore predictions for match %s: %v\n", matchID, err)
+	}
+
+	// Rescore any bracket tie the match decides
+	if err := h.bracketHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to rescore bracket ties for match %s: %v\n", matchID, err)
+	}
+
+	// Resettle survivor picks on the match
+	if err := h.survivorHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to resettle survivor picks for match %s: %v\n", matchID, err)
+	}
+
+	// Unlock any badges the corrected points earn
+	if err := h.badgeHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to award badges for match %s: %v\n", matchID, err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(match); err != nil {
+		fmt.Printf("error encoding match: %v\n", err)
+	}
+}
+
+// rescheduleRequest is the body accepted when moving a match's kickoff
+type rescheduleRequest struct {
+	Date   time.Time `json:"date"`
+	Reason string    `json:"reason"`
+}
+
+func (r rescheduleRequest) validate(errs *fieldErrors) {
+	errs.requiredTime("date", r.Date)
+	errs.required("reason", r.Reason)
+}
+
+// RescheduleMatch handles moving a match that has not started to a new
+// kickoff, with a reason. Tips lock relative to the new kickoff, and users who
+// have tipped the match are notified. The response is the match with its new
+// lock time.
+func (h *MatchHandler) RescheduleMatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request rescheduleRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	match, ok := h.findMatch(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	previousDate := match.Date
+	if err := match.Reschedule(request.Date); err != nil {
+		status := http.StatusBadRequest
+		if err == domain.ErrCannotReschedule {
+			status = http.StatusConflict
+		}
+		writeDomainError(w, "Cannot reschedule match", err, status)
+		return
+	}
+
+	event := events.NewEvent("MatchRescheduled", events.MatchRescheduled{
+		MatchID:       matchID,
+		PreviousDate:  previousDate,
+		Date:          match.Date,
+		Reason:        strings.TrimSpace(request.Reason),
+		RescheduledAt: time.Now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to reschedule match", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for rescheduling: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	// Let everyone who tipped the match know it has moved
+	if err := h.notificationHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to notify tippers of match %s: %v\n", matchID, err)
+	}
+
+	response := struct {
+		*domain.Match
+		LockAt time.Time `json:"lockAt"`
+	}{match, match.LockTime(h.lockCutoff)}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		fmt.Printf("error encoding match: %v\n", err)
+	}
+}
+
+// oddsRequest is the body accepted when recording a match's decimal odds. The
+// draw price may be left out for sports without draws.
+type oddsRequest struct {
+	Home   float64 `json:"home"`
+	Draw   float64 `json:"draw"`
+	Away   float64 `json:"away"`
+	Source string  `json:"source"`
+}
+
+func (r oddsRequest) validate(errs *fieldErrors) {
+	errs.rule("odds", r.odds(time.Time{}).Validate())
+}
+
+// odds returns the requested odds as recorded at the given time
+func (r oddsRequest) odds(at time.Time) domain.MatchOdds {
+	return domain.MatchOdds{
+		Home:      r.Home,
+		Draw:      r.Draw,
+		Away:      r.Away,
+		Source:    strings.TrimSpace(r.Source),
+		UpdatedAt: at,
+	}
+}
+
+// UpdateMatchOdds handles recording the latest odds for a match that has not
+// started. Leagues tipping the favourite by odds follow the odds recorded
+// when the match locks.
+func (h *MatchHandler) UpdateMatchOdds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request oddsRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	match, ok := h.findMatch(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	if !match.AcceptsPredictions() {
+		writeDomainError(w, fmt.Sprintf("Cannot update odds for %s match", strings.ToLower(string(match.Status))), domain.ErrOddsClosed, http.StatusConflict)
+		return
+	}
+
+	odds := request.odds(time.Now())
+	event := events.NewEvent("MatchOddsUpdated", events.MatchOddsUpdated{
+		MatchID:   matchID,
+		Home:      odds.Home,
+		Draw:      odds.Draw,
+		Away:      odds.Away,
+		Source:    odds.Source,
+		UpdatedAt: odds.UpdatedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update match odds", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for odds update: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	match.Odds = &odds
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(match); err != nil {
+		fmt.Printf("error encoding match: %v\n", err)
+	}
+}
+
+// GetScoreHistory retrieves every score recorded for a match, including
+// corrections and their reasons
+func (h *MatchHandler) GetScoreHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), matchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return
+	}
+
+	if len(matchEvents) == 0 {
+		writeError(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	history, err := eventhandlers.ReplayScoreHistory(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		fmt.Printf("error encoding score history: %v\n", err)
+	}
+}
+
+// matchView is a match as returned by the read endpoints, with its kickoff in
+// UTC, at the venue and in the viewer's time zone
+type matchView struct {
+	*domain.Match
+	Kickoff domain.Kickoff `json:"kickoff"`
+}
+
+func newMatchView(match *domain.Match, location *time.Location) matchView {
+	return matchView{Match: match, Kickoff: match.Kickoff(location)}
+}
+
+// newMatchViews returns the views of matches with kickoffs in location
+func newMatchViews(matches []*domain.Match, location *time.Location) []matchView {
+	views := make([]matchView, 0, len(matches))
+	for _, match := range matches {
+		views = append(views, newMatchView(match, location))
+	}
+	return views
+}
+
+// GetMatch retrieves a match by ID. Its kickoff is also given in the time zone
+// named by the timeZone query parameter, or else the one preferred by the
+// userId query parameter's user, or else UTC.
 func (h *MatchHandler) GetMatch(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	matchID := vars["id"]
 
+	location, ok := requestLocation(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
 	// Try to get from read model first
 	match, err := h.matchRepo.GetByID(r.Context(), matchID)
 	if err == nil {
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(match); err != nil {
+		if err := json.NewEncoder(w).Encode(newMatchView(match, location)); err != nil {
 			fmt.Printf("error encoding match: %v\n", err)
 		}
 		return
@@ -130,85 +664,86 @@ func (h *MatchHandler) GetMatch(w http.ResponseWriter, r *http.Request) {
 	// Fallback to rebuilding from events if not in read model
 	events, err := h.eventStore.GetEvents(r.Context(), matchID)
 	if err != nil {
-		http.Error(w, "Failed to retrieve match", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
 		return
 	}
 
 	if len(events) == 0 {
-		http.Error(w, "Match not found", http.StatusNotFound)
+		writeError(w, "Match not found", http.StatusNotFound)
 		return
 	}
 
 	// Rebuild match from events
-	match = &domain.Match{}
-	for _, event := range events {
-		switch event.Type {
-		case "MatchCreated":
-			data, err := json.Marshal(event.Data)
-			if err != nil {
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			var matchCreated struct {
-				ID          string    `json:"id"`
-				HomeTeam    string    `json:"homeTeam"`
-				AwayTeam    string    `json:"awayTeam"`
-				Date        time.Time `json:"date"`
-				Competition string    `json:"competition"`
-			}
-			if err := json.Unmarshal(data, &matchCreated); err != nil {
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			match.ID = matchCreated.ID
-			match.HomeTeam = matchCreated.HomeTeam
-			match.AwayTeam = matchCreated.AwayTeam
-			match.Date = matchCreated.Date
-			match.Competition = matchCreated.Competition
-			match.Status = domain.MatchStatusScheduled
-		case "MatchScoreUpdated":
-			data, err := json.Marshal(event.Data)
-			if err != nil {
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			var scoreUpdated struct {
-				MatchID   string    `json:"matchId"`
-				HomeGoals int       `json:"homeGoals"`
-				AwayGoals int       `json:"awayGoals"`
-				UpdatedAt time.Time `json:"updatedAt"`
-			}
-			if err := json.Unmarshal(data, &scoreUpdated); err != nil {
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			match.UpdateScore(scoreUpdated.HomeGoals, scoreUpdated.AwayGoals)
-		}
+	match, err = eventhandlers.ReplayMatch(events)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(match); err != nil {
+	if err := json.NewEncoder(w).Encode(newMatchView(match, location)); err != nil {
 		fmt.Printf("error encoding match: %v\n", err)
 	}
 }
 
-// ListMatches retrieves all matches from the read model
+// ListMatches retrieves matches from the read model, optionally filtered by
+// the team, competitionId, seasonId, roundId and status query parameters. The
+// team may be given by ID, name, short code or alias. Kickoffs are localised
+// as for GetMatch.
 func (h *MatchHandler) ListMatches(w http.ResponseWriter, r *http.Request) {
+	location, ok := requestLocation(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	var filters repository.MatchFilters
+	query := r.URL.Query()
+	if teamRef := query.Get("team"); teamRef != "" {
+		registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+		if err != nil {
+			writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+			return
+		}
+		team := registry.Team(teamRef)
+		if team == nil {
+			writeDomainError(w, "Invalid filter", fmt.Errorf("%w %q", domain.ErrUnknownTeam, teamRef), http.StatusBadRequest)
+			return
+		}
+		filters.TeamID = &team.ID
+	}
+	if competitionID := query.Get("competitionId"); competitionID != "" {
+		filters.CompetitionID = &competitionID
+	}
+	if seasonID := query.Get("seasonId"); seasonID != "" {
+		filters.SeasonID = &seasonID
+	}
+	if roundID := query.Get("roundId"); roundID != "" {
+		filters.RoundID = &roundID
+	}
+	if status := query.Get("status"); status != "" {
+		filters.Status = &status
+	}
+
 	// Use read model for better performance and consistent date formatting
-	matches, err := h.matchRepo.List(r.Context(), repository.MatchFilters{})
+	matches, err := h.matchRepo.List(r.Context(), filters)
 	if err != nil {
-		http.Error(w, "Failed to retrieve matches", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve matches", http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(matches); err != nil {
+	if err := json.NewEncoder(w).Encode(newMatchViews(matches, location)); err != nil {
 		fmt.Printf("error encoding matches: %v\n", err)
 	}
 }
 
 // ListUpcomingMatches retrieves upcoming matches (scheduled matches)
 func (h *MatchHandler) ListUpcomingMatches(w http.ResponseWriter, r *http.Request) {
+	location, ok := requestLocation(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
 	status := string(domain.MatchStatusScheduled)
 
 	// Use read model with filters for upcoming matches
@@ -217,7 +752,7 @@ func (h *MatchHandler) ListUpcomingMatches(w http.ResponseWriter, r *http.Reques
 		Status: &status,
 	})
 	if err != nil {
-		http.Error(w, "Failed to retrieve upcoming matches", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve upcoming matches", http.StatusInternalServerError)
 		return
 	}
 
@@ -227,7 +762,56 @@ func (h *MatchHandler) ListUpcomingMatches(w http.ResponseWriter, r *http.Reques
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(matches); err != nil {
+	if err := json.NewEncoder(w).Encode(newMatchViews(matches, location)); err != nil {
 		fmt.Printf("error encoding upcoming matches: %v\n", err)
 	}
 }
+
+// resolveTeam looks a team up by ID, falling back to its name. It writes a 400
+// response naming the request field, suffixed Id when the ID was given, and
+// returns false if the team is not registered.
+func resolveTeam(w http.ResponseWriter, registry *eventhandlers.TeamRegistry, field, id, name string) (*domain.Team, bool) {
+	ref := firstNonBlank(id, name)
+	team := registry.Team(ref)
+	if team == nil {
+		if ref == id {
+			field += "Id"
+		}
+		writeDomainError(w, "Invalid team", fmt.Errorf("%w %q", domain.ErrUnknownTeam.OnField(field), ref), http.StatusBadRequest)
+		return nil, false
+	}
+	return team, true
+}
+
+// firstNonBlank returns the first of the values that is not blank, e.g. an ID
+// given in place of a name
+func firstNonBlank(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// findMatch writes an error response and returns false if the match does not
+// exist; otherwise it returns the match replayed from its events
+func (h *MatchHandler) findMatch(w http.ResponseWriter, r *http.Request, matchID string) (*domain.Match, bool) {
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), matchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if len(matchEvents) == 0 {
+		writeError(w, "Match not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return nil, false
+	}
+	return match, true
+}
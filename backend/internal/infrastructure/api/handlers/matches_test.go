@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -40,6 +42,8 @@ func TestCreateMatch(t *testing.T) {
 		rr := httptest.NewRecorder()
 
 		// Set up mock expectation - use mock.MatchedBy for dynamic fields
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
 		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
 			// Type check
 			if event.Type != "MatchCreated" {
@@ -55,7 +59,10 @@ func TestCreateMatch(t *testing.T) {
 			// Check static fields
 			return matchCreated.HomeTeam == match.HomeTeam &&
 				matchCreated.AwayTeam == match.AwayTeam &&
-				matchCreated.Competition == match.Competition
+				matchCreated.Competition == match.Competition &&
+				matchCreated.CompetitionID == "comp_epl" &&
+				matchCreated.HomeTeamID == "team_a" &&
+				matchCreated.AwayTeamID == "team_b"
 		})).Return(nil)
 
 		// Mock repository create call (from event handler)
@@ -83,6 +90,88 @@ func TestCreateMatch(t *testing.T) {
 			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
 		}
 	})
+
+	// Test case 3: Competition resolved by alias
+	t.Run("Competition resolved by alias", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "epl", "seasonId": "season_epl_2025"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			matchCreated, ok := event.Data.(events.MatchCreated)
+			return ok && matchCreated.CompetitionID == "comp_epl" &&
+				matchCreated.Competition == "Premier League" &&
+				matchCreated.SeasonID == "season_epl_2025"
+		})).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 4: Unknown competition
+	t.Run("Unknown competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "Sunday League"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "competitionId" {
+			t.Errorf("expected competitionId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 5: Season from another competition
+	t.Run("Season from another competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competitionId": "comp_laliga", "seasonId": "season_epl_2025"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "SEASON_NOT_IN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "SEASON_NOT_IN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "seasonId" {
+			t.Errorf("expected seasonId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
 }
 
 func TestGetMatch(t *testing.T) {
@@ -147,6 +236,98 @@ func TestGetMatch(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockStore.AssertExpectations(t)
 	})
+
+	// Test case 3: Kickoff localised to the requested time zone
+	t.Run("Kickoff localised to the requested time zone", func(t *testing.T) {
+		matchID := "789"
+
+		req := httptest.NewRequest("GET", "/api/matches/"+matchID+"?timeZone=Australia/Perth", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		match := &domain.Match{
+			ID:       matchID,
+			HomeTeam: "Team A",
+			AwayTeam: "Team B",
+			Date:     time.Date(2025, 8, 16, 14, 0, 0, 0, time.UTC),
+			TimeZone: "Europe/London",
+			Status:   domain.MatchStatusScheduled,
+		}
+		mockRepo.On("GetByID", req.Context(), matchID).Return(match, nil)
+
+		handler.GetMatch(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var response struct {
+			Kickoff map[string]string `json:"kickoff"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		want := map[string]string{
+			"utc":           "2025-08-16T14:00:00Z",
+			"venue":         "2025-08-16T15:00:00+01:00",
+			"venueTimeZone": "Europe/London",
+			"local":         "2025-08-16T22:00:00+08:00",
+			"localTimeZone": "Australia/Perth",
+		}
+		if !reflect.DeepEqual(response.Kickoff, want) {
+			t.Errorf("expected kickoff %v, got %v", want, response.Kickoff)
+		}
+	})
+
+	// Test case 4: Kickoff in the user's preferred time zone
+	t.Run("Kickoff in the user's preferred time zone", func(t *testing.T) {
+		matchID := "790"
+
+		req := httptest.NewRequest("GET", "/api/matches/"+matchID+"?userId=user1", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		mockStore.On("GetEventsByType", req.Context(), "UserPreferencesUpdated").Return([]*events.Event{
+			events.NewEvent("UserPreferencesUpdated", events.UserPreferencesUpdated{UserID: "user1", TimeZone: "Asia/Tokyo"}),
+		}, nil)
+		mockRepo.On("GetByID", req.Context(), matchID).Return(&domain.Match{
+			ID:   matchID,
+			Date: time.Date(2025, 8, 16, 14, 0, 0, 0, time.UTC),
+		}, nil)
+
+		handler.GetMatch(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var response matchView
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Kickoff.VenueTimeZone != "UTC" {
+			t.Errorf("expected a venue without a zone to be UTC, got %q", response.Kickoff.VenueTimeZone)
+		}
+		if response.Kickoff.LocalTimeZone != "Asia/Tokyo" || response.Kickoff.Local.Hour() != 23 {
+			t.Errorf("expected kickoff at 23:00 in Asia/Tokyo, got %v in %q", response.Kickoff.Local, response.Kickoff.LocalTimeZone)
+		}
+	})
+
+	// Test case 5: Unknown time zone
+	t.Run("Unknown time zone", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/matches/123?timeZone=Mars/Olympus", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "123"})
+
+		handler.GetMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		want := []FieldError{{Field: "timeZone", Code: "INVALID_TIME_ZONE", Message: domain.ErrInvalidTimeZone.Error()}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("expected errors %v, got %v", want, problem.Errors)
+		}
+	})
 }
 
 func TestListMatches(t *testing.T) {
@@ -207,7 +388,8 @@ func TestUpdateMatchScore(t *testing.T) {
 		rr := httptest.NewRecorder()
 		req = mux.SetURLVars(req, map[string]string{"id": matchID})
 
-		// Set up mock expectation for SaveEvent
+		// Set up mock expectations for the match lookup and SaveEvent
+		mockStore.On("GetEvents", req.Context(), matchID).Return(testMatchEvents(matchID, "team_a", "team_b", nil), nil)
 		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
 			return event.Type == "MatchScoreUpdated"
 		})).Return(nil)
@@ -232,4 +414,1008 @@ func TestUpdateMatchScore(t *testing.T) {
 		mockStore.AssertExpectations(t)
 		mockRepo.AssertExpectations(t)
 	})
+
+	// Test case: Cup match decided on penalties
+	t.Run("Cup match decided on penalties", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"homeGoals": 1, "awayGoals": 1, "extraTime": {"homeGoals": 2, "awayGoals": 2}, "penalties": {"homeGoals": 4, "awayGoals": 5}}`
+		req := httptest.NewRequest("PUT", "/api/matches/123/score", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "123"})
+
+		mockStore.On("GetEvents", req.Context(), "123").Return(testMatchEvents("123", "team_a", "team_b", nil), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			scoreUpdated, ok := event.Data.(events.MatchScoreUpdated)
+			return ok && scoreUpdated.HomeGoals == 1 &&
+				scoreUpdated.ExtraTime != nil && scoreUpdated.ExtraTime.HomeGoals == 2 &&
+				scoreUpdated.Penalties != nil && scoreUpdated.Penalties.AwayGoals == 5
+		})).Return(nil)
+
+		match := domain.NewMatch("123", "Team A", "Team B", time.Now(), "Premier League")
+		mockRepo.On("GetByID", req.Context(), "123").Return(match, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(updated *domain.Match) bool {
+			return updated.Score != nil && updated.Score.Penalties != nil && updated.Score.Penalties.HomeGoals == 4
+		})).Return(nil)
+
+		handler.UpdateMatchScore(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case: Extra time after a decisive result
+	t.Run("Extra time after a decisive result", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeGoals": 2, "awayGoals": 1, "extraTime": {"homeGoals": 3, "awayGoals": 1}}`
+		req := httptest.NewRequest("PUT", "/api/matches/123/score", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "123"})
+
+		handler.UpdateMatchScore(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case: Finished match
+	t.Run("Finished match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/matches/123/score", bytes.NewBufferString(`{"homeGoals": 3, "awayGoals": 1}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "123"})
+
+		mockStore.On("GetEvents", req.Context(), "123").Return(testMatchEvents("123", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 2, AwayGoals: 1}), nil)
+
+		handler.UpdateMatchScore(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "MATCH_ALREADY_FINISHED" {
+			t.Errorf("expected code %q, got %q", "MATCH_ALREADY_FINISHED", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateMatchStatus(t *testing.T) {
+	matchID := "123"
+	matchCreated := &events.Event{
+		ID:   "event123",
+		Type: "MatchCreated",
+		Data: events.MatchCreated{
+			ID:          matchID,
+			HomeTeam:    "Team A",
+			AwayTeam:    "Team B",
+			Date:        time.Now(),
+			Competition: "Premier League",
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+	scoreUpdated := &events.Event{
+		ID:        "event124",
+		Type:      "MatchScoreUpdated",
+		Data:      events.MatchScoreUpdated{MatchID: matchID, HomeGoals: 2, AwayGoals: 1},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+
+	// Test case 1: Scheduled match goes live
+	t.Run("Scheduled match goes live", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/matches/"+matchID+"/status", bytes.NewBufferString(`{"status": "LIVE"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		mockStore.On("GetEvents", req.Context(), matchID).Return([]*events.Event{matchCreated}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			statusChanged, ok := event.Data.(events.MatchStatusChanged)
+			return ok && event.Type == "MatchStatusChanged" && statusChanged.Status == "LIVE"
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), matchID).Return(&domain.Match{ID: matchID, Status: domain.MatchStatusScheduled}, nil)
+		mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+
+		handler.UpdateMatchStatus(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Finishing a match awards points for its predictions
+	t.Run("Finishing a match awards points", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/matches/"+matchID+"/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		predictionMade := &events.Event{
+			ID:   "event125",
+			Type: "PredictionMade",
+			Data: events.PredictionMade{
+				ID:        "pred123",
+				UserID:    "user123",
+				MatchID:   matchID,
+				HomeGoals: 2,
+				AwayGoals: 1,
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+
+		mockStore.On("GetEvents", req.Context(), matchID).Return([]*events.Event{matchCreated, scoreUpdated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+		expectLeagues(mockStore, req.Context(), nil)
+		expectSurvivorPools(mockStore, req.Context(), nil)
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		expectBrackets(mockStore, req.Context(), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			return event.Type == "MatchStatusChanged"
+		})).Return(nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			pointsAwarded, ok := event.Data.(events.PointsAwarded)
+			return ok && pointsAwarded.PredictionID == "pred123" && pointsAwarded.Points == 3 &&
+				len(pointsAwarded.Breakdown) == 1 && pointsAwarded.Breakdown[0].Rule == domain.RuleExactScore
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), matchID).Return(&domain.Match{ID: matchID, Status: domain.MatchStatusScheduled}, nil)
+		mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+		expectBadges(mockStore, req.Context())
+
+		handler.UpdateMatchStatus(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 3: Unknown status
+	t.Run("Unknown status", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/matches/"+matchID+"/status", bytes.NewBufferString(`{"status": "HALF_TIME"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		handler.UpdateMatchStatus(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+
+	// Test case 4: Finishing a match without a score
+	t.Run("Finishing a match without a score", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/matches/"+matchID+"/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": matchID})
+
+		mockStore.On("GetEvents", req.Context(), matchID).Return([]*events.Event{matchCreated}, nil)
+
+		handler.UpdateMatchStatus(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 5: Match not found
+	t.Run("Match not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/matches/missing/status", bytes.NewBufferString(`{"status": "LIVE"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		mockStore.On("GetEvents", req.Context(), "missing").Return([]*events.Event{}, nil)
+
+		handler.UpdateMatchStatus(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestWinnerTipScoring(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	req := httptest.NewRequest("PUT", "/api/matches/m1/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+	kickoff := time.Now().Add(-3 * time.Hour)
+	aflMatchEvent := func(matchID string, date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event-" + matchID,
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:            matchID,
+				HomeTeam:      "Team A",
+				AwayTeam:      "Team B",
+				Date:          date,
+				Competition:   "AFL Premiership",
+				CompetitionID: "comp_afl",
+				RoundID:       "round_afl_1",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	scoreUpdated := &events.Event{
+		ID:        "event-score",
+		Type:      "MatchScoreUpdated",
+		Data:      events.MatchScoreUpdated{MatchID: "m1", HomeGoals: 95, AwayGoals: 80},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+	margin := 20
+	predictionMade := &events.Event{
+		ID:        "event-pred",
+		Type:      "PredictionMade",
+		Data:      events.PredictionMade{ID: "pred1", UserID: "user1", MatchID: "m1", Winner: "HOME", Margin: &margin},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+
+	mockStore.On("GetEvents", req.Context(), "m1").Return([]*events.Event{aflMatchEvent("m1", kickoff), scoreUpdated}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, req.Context(), nil)
+	expectSurvivorPools(mockStore, req.Context(), nil)
+	expectCatalog(mockStore, req.Context(), testAFLCatalogEvents())
+	mockStore.On("GetEventsByType", req.Context(), "MatchCreated").Return([]*events.Event{
+		aflMatchEvent("m1", kickoff),
+		aflMatchEvent("m2", kickoff.Add(24*time.Hour)),
+	}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "MatchRescheduled").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	expectBrackets(mockStore, req.Context(), nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchStatusChanged"
+	})).Return(nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		pointsAwarded, ok := event.Data.(events.PointsAwarded)
+		return ok && pointsAwarded.Points == 1 && pointsAwarded.MarginError != nil && *pointsAwarded.MarginError == 5
+	})).Return(nil)
+	mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusScheduled}, nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+	expectBadges(mockStore, req.Context())
+
+	handler.UpdateMatchStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestCreateMatchInRound(t *testing.T) {
+	roundCreated := &events.Event{
+		ID:   "event1",
+		Type: "RoundCreated",
+		Data: events.RoundCreated{
+			ID:            "round1",
+			CompetitionID: "comp_epl",
+			SeasonID:      "season_epl_2025",
+			Number:        1,
+			Name:          "Round 1",
+			StartDate:     time.Now(),
+			EndDate:       time.Now().Add(72 * time.Hour),
+			Deadline:      time.Now(),
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+
+	// Test case 1: Match joins a round in its competition
+	t.Run("Match joins a round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "Premier League", "roundId": "round1"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			matchCreated, ok := event.Data.(events.MatchCreated)
+			return ok && matchCreated.RoundID == "round1" && matchCreated.SeasonID == "season_epl_2025"
+		})).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.RoundID == "round1"
+		})).Return(nil)
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Round from another competition
+	t.Run("Round from another competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "La Liga", "roundId": "round1"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "ROUND_NOT_IN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "ROUND_NOT_IN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "roundId" {
+			t.Errorf("expected roundId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Unknown round
+	t.Run("Unknown round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Team A", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "Premier League", "roundId": "missing"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_ROUND" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_ROUND", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "roundId" {
+			t.Errorf("expected roundId to be at fault, got %v", problem.Errors)
+		}
+	})
+}
+
+func TestCreateMatchTeams(t *testing.T) {
+	// Test case 1: Teams resolved by alias and short code, played at the home
+	// team's ground
+	t.Run("Teams resolved by alias and short code", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"homeTeam": "a team", "awayTeam": "TMB", "date": "2025-08-16T15:00:00Z", "competition": "Premier League"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			matchCreated, ok := event.Data.(events.MatchCreated)
+			return ok && matchCreated.HomeTeam == "Team A" && matchCreated.HomeTeamID == "team_a" &&
+				matchCreated.AwayTeam == "Team B" && matchCreated.AwayTeamID == "team_b" &&
+				matchCreated.TimeZone == "Europe/London"
+		})).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown team
+	t.Run("Unknown team", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Man United", "awayTeam": "Team B", "date": "2025-08-16T15:00:00Z", "competition": "Premier League"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_TEAM" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_TEAM", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "homeTeam" {
+			t.Errorf("expected homeTeam to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Team playing itself
+	t.Run("Team playing itself", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeTeam": "Team A", "awayTeamId": "team_a", "date": "2025-08-16T15:00:00Z", "competition": "Premier League"}`
+		req := httptest.NewRequest("POST", "/api/matches", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+
+		handler.CreateMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCorrectMatchScore(t *testing.T) {
+	// Test case 1: Correcting a result re-awards the tips it changes
+	t.Run("Correcting a result re-awards the tips it changes", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		// The match finished 2-2 but was recorded as 2-1
+		body := `{"homeGoals": 2, "awayGoals": 2, "reason": "Late equaliser missed"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/corrections", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		prediction := func(predictionID, userID string, homeGoals, awayGoals int) *events.Event {
+			return &events.Event{
+				ID:        "event-" + predictionID,
+				Type:      "PredictionMade",
+				Data:      events.PredictionMade{ID: predictionID, UserID: userID, MatchID: "m1", HomeGoals: homeGoals, AwayGoals: awayGoals},
+				Timestamp: time.Now(),
+				Version:   1,
+			}
+		}
+		awarded := func(predictionID, userID string, points int, breakdown ...events.RuleAward) *events.Event {
+			return &events.Event{
+				ID:        "event-" + predictionID + "-points",
+				Type:      "PointsAwarded",
+				Data:      events.PointsAwarded{PredictionID: predictionID, UserID: userID, MatchID: "m1", Points: points, Breakdown: breakdown},
+				Timestamp: time.Now(),
+				Version:   1,
+			}
+		}
+
+		recorded := testMatchEvents("m1", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 2, AwayGoals: 1})
+		corrected := append(append([]*events.Event{}, recorded...), &events.Event{
+			ID:   "event-m1-corrected",
+			Type: "MatchScoreCorrected",
+			Data: events.MatchScoreCorrected{
+				MatchScoreUpdated: events.MatchScoreUpdated{MatchID: "m1", HomeGoals: 2, AwayGoals: 2},
+				Reason:            "Late equaliser missed",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		})
+		// Re-scoring replays the match after the correction has been saved
+		mockStore.On("GetEvents", req.Context(), "m1").Return(recorded, nil).Once()
+		mockStore.On("GetEvents", req.Context(), "m1").Return(corrected, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			corrected, ok := event.Data.(events.MatchScoreCorrected)
+			return ok && corrected.AwayGoals == 2 && corrected.Reason == "Late equaliser missed"
+		})).Return(nil).Once()
+		mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusFinished}, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.Score != nil && match.Score.AwayGoals == 2
+		})).Return(nil)
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+			prediction("pred1", "user1", 2, 1),
+			prediction("pred2", "user2", 2, 2),
+			prediction("pred3", "user3", 1, 3),
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{
+			awarded("pred1", "user1", 3, events.RuleAward{Rule: domain.RuleExactScore, Points: 3}),
+			awarded("pred2", "user2", 0),
+			awarded("pred3", "user3", 0),
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+		expectLeagues(mockStore, req.Context(), nil)
+		expectBrackets(mockStore, req.Context(), nil)
+		expectSurvivorPools(mockStore, req.Context(), nil)
+
+		// pred3 is wrong either way, so only pred1 and pred2 are re-awarded
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			pointsAwarded, ok := event.Data.(events.PointsAwarded)
+			return ok && pointsAwarded.PredictionID == "pred1" && pointsAwarded.Points == 0 &&
+				pointsAwarded.Correction && pointsAwarded.Adjustment == -3
+		})).Return(nil).Once()
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			pointsAwarded, ok := event.Data.(events.PointsAwarded)
+			return ok && pointsAwarded.PredictionID == "pred2" && pointsAwarded.Points == 3 &&
+				pointsAwarded.Correction && pointsAwarded.Adjustment == 3
+		})).Return(nil).Once()
+		expectBadges(mockStore, req.Context())
+
+		handler.CorrectMatchScore(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Correcting the winner re-awards the bracket tie
+	t.Run("Correcting the winner re-awards the bracket tie", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		// Team B won the semi-final 2-1 but it was recorded the other way round
+		body := `{"homeGoals": 1, "awayGoals": 2, "reason": "Scores swapped"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/corrections", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		expectCorrection(mockStore, mockRepo, req.Context(), &events.MatchScoreUpdated{HomeGoals: 2, AwayGoals: 1}, 1, 2)
+		expectBrackets(mockStore, req.Context(), testBracketEvents())
+		mockStore.On("GetEventsByType", req.Context(), "BracketEntrySubmitted").Return([]*events.Event{
+			testEntryEvent("entry1", "user1", map[string]string{"SF1": "team_a", "SF2": "team_d", "F": "team_a"}),
+			testEntryEvent("entry2", "user2", map[string]string{"SF1": "team_b", "SF2": "team_d", "F": "team_d"}),
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{
+			{
+				ID:        "event-entry1-SF1",
+				Type:      "BracketPointsAwarded",
+				Data:      events.BracketPointsAwarded{BracketID: "bracket1", EntryID: "entry1", UserID: "user1", TieID: "SF1", MatchID: "m1", WinnerID: "team_a", Points: 1},
+				Timestamp: time.Now().Add(time.Second),
+				Version:   1,
+			},
+			{
+				ID:        "event-entry2-SF1",
+				Type:      "BracketPointsAwarded",
+				Data:      events.BracketPointsAwarded{BracketID: "bracket1", EntryID: "entry2", UserID: "user2", TieID: "SF1", MatchID: "m1", WinnerID: "team_a", Points: 0},
+				Timestamp: time.Now().Add(time.Second),
+				Version:   1,
+			},
+		}, nil)
+		expectSurvivorPools(mockStore, req.Context(), nil)
+
+		// The tie's points move from entry1 to entry2
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			awarded, ok := event.Data.(events.BracketPointsAwarded)
+			return ok && awarded.EntryID == "entry1" && awarded.WinnerID == "team_b" && awarded.Points == 0 &&
+				awarded.Correction && awarded.Adjustment == -1
+		})).Return(nil).Once()
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			awarded, ok := event.Data.(events.BracketPointsAwarded)
+			return ok && awarded.EntryID == "entry2" && awarded.WinnerID == "team_b" && awarded.Points == 1 &&
+				awarded.Correction && awarded.Adjustment == 1
+		})).Return(nil).Once()
+		expectBadges(mockStore, req.Context())
+
+		handler.CorrectMatchScore(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Correcting the winner resettles survivor picks
+	t.Run("Correcting the winner resettles survivor picks", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		// Team A won 2-1, so user1 survives and user2 is out after all
+		body := `{"homeGoals": 2, "awayGoals": 1, "reason": "Scores swapped"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/corrections", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		expectCorrection(mockStore, mockRepo, req.Context(), &events.MatchScoreUpdated{HomeGoals: 1, AwayGoals: 2}, 2, 1)
+		expectBrackets(mockStore, req.Context(), nil)
+		mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+		expectSurvivorPools(mockStore, req.Context(), testSurvivorPoolEvents())
+		expectSurvivorEntries(mockStore, req.Context(),
+			[]*events.Event{
+				testPickEvent("pick1", "user1", survivorRound, "m1", "team_a"),
+				testPickEvent("pick2", "user2", survivorRound, "m1", "team_b"),
+			},
+			[]*events.Event{
+				testSettledEvent("pick1", "user1", domain.SurvivorOutcomeLost),
+				testSettledEvent("pick2", "user2", domain.SurvivorOutcomeWon),
+			},
+		)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			settled, ok := event.Data.(events.SurvivorPickSettled)
+			return ok && settled.PickID == "pick1" && settled.Outcome == string(domain.SurvivorOutcomeWon) &&
+				settled.Correction && settled.PreviousOutcome == string(domain.SurvivorOutcomeLost)
+		})).Return(nil).Once()
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			settled, ok := event.Data.(events.SurvivorPickSettled)
+			return ok && settled.PickID == "pick2" && settled.Outcome == string(domain.SurvivorOutcomeLost) &&
+				settled.Correction && settled.PreviousOutcome == string(domain.SurvivorOutcomeWon)
+		})).Return(nil).Once()
+		expectBadges(mockStore, req.Context())
+
+		handler.CorrectMatchScore(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 4: Match still being played
+	t.Run("Match still being played", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"homeGoals": 2, "awayGoals": 2, "reason": "Typo"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/corrections", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", nil), nil)
+
+		handler.CorrectMatchScore(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 5: No reason given
+	t.Run("No reason given", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/corrections", bytes.NewBufferString(`{"homeGoals": 2, "awayGoals": 2}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		handler.CorrectMatchScore(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+// expectCorrection sets up the event store for correcting m1 from the
+// recorded score to the given one, with no tips on the match
+func expectCorrection(mockStore *mocks.MockEventStore, mockRepo *mocks.MockMatchRepository, ctx context.Context, recordedScore *events.MatchScoreUpdated, homeGoals, awayGoals int) {
+	recorded := testMatchEvents("m1", "team_a", "team_b", recordedScore)
+	corrected := append(append([]*events.Event{}, recorded...), &events.Event{
+		ID:   "event-m1-corrected",
+		Type: "MatchScoreCorrected",
+		Data: events.MatchScoreCorrected{
+			MatchScoreUpdated: events.MatchScoreUpdated{MatchID: "m1", HomeGoals: homeGoals, AwayGoals: awayGoals},
+			Reason:            "Scores swapped",
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	})
+	mockStore.On("GetEvents", ctx, "m1").Return(recorded, nil).Once()
+	mockStore.On("GetEvents", ctx, "m1").Return(corrected, nil)
+	mockStore.On("SaveEvent", ctx, mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchScoreCorrected"
+	})).Return(nil).Once()
+	mockRepo.On("GetByID", ctx, "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusFinished}, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.Match")).Return(nil)
+	expectCatalog(mockStore, ctx, testCatalogEvents())
+	mockStore.On("GetEventsByType", ctx, "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", ctx, "PredictionMade").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", ctx, "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", ctx, "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", ctx, "PointsAwarded").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", ctx, "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, ctx, nil)
+}
+
+func TestGetScoreHistory(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("GET", "/api/matches/m1/score-history", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+	matchEvents := append(testMatchEvents("m1", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 2, AwayGoals: 1}), &events.Event{
+		ID:   "event-m1-corrected",
+		Type: "MatchScoreCorrected",
+		Data: events.MatchScoreCorrected{
+			MatchScoreUpdated: events.MatchScoreUpdated{MatchID: "m1", HomeGoals: 2, AwayGoals: 2},
+			Reason:            "Late equaliser missed",
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	})
+	mockStore.On("GetEvents", req.Context(), "m1").Return(matchEvents, nil)
+
+	handler.GetScoreHistory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var history []domain.ScoreRevision
+	if err := json.NewDecoder(rr.Body).Decode(&history); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(history) != 2 || history[0].Correction || !history[1].Correction ||
+		history[1].Score.AwayGoals != 2 || history[1].Reason != "Late equaliser missed" {
+		t.Errorf("expected the recorded score then its correction, got %+v", history)
+	}
+}
+
+func TestRescheduleMatch(t *testing.T) {
+	moved := bracketLockAt.Add(49 * time.Hour)
+
+	// Test case 1: Moving a match notifies its tippers
+	t.Run("Moving a match notifies its tippers", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo).WithLockCutoff(time.Hour)
+
+		body := `{"date": "2026-06-03T19:00:00Z", "reason": "Broadcast change"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/reschedule", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		matchEvents := testMatchEvents("m1", "team_a", "team_b", nil)
+		matchEvents[0].Data = events.MatchCreated{ID: "m1", HomeTeam: "Team A", AwayTeam: "Team B", HomeTeamID: "team_a", AwayTeamID: "team_b", Date: bracketLockAt.Add(time.Hour), CompetitionID: "comp_epl"}
+		mockStore.On("GetEvents", req.Context(), "m1").Return(matchEvents, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			rescheduled, ok := event.Data.(events.MatchRescheduled)
+			return ok && rescheduled.PreviousDate.Equal(bracketLockAt.Add(time.Hour)) &&
+				rescheduled.Date.Equal(moved) && rescheduled.Reason == "Broadcast change"
+		})).Return(nil).Once()
+		mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusScheduled}, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.Date.Equal(moved)
+		})).Return(nil)
+
+		// user1 tipped twice and user2 withdrew their tip, so only user1 hears of it
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{
+			{ID: "event-pred1", Type: "PredictionMade", Data: events.PredictionMade{ID: "pred1", UserID: "user1", MatchID: "m1"}, Timestamp: time.Now(), Version: 1},
+			{ID: "event-pred2", Type: "PredictionMade", Data: events.PredictionMade{ID: "pred2", UserID: "user1", MatchID: "m1", Joker: true}, Timestamp: time.Now(), Version: 1},
+			{ID: "event-pred3", Type: "PredictionMade", Data: events.PredictionMade{ID: "pred3", UserID: "user2", MatchID: "m1"}, Timestamp: time.Now(), Version: 1},
+			{ID: "event-pred4", Type: "PredictionMade", Data: events.PredictionMade{ID: "pred4", UserID: "user3", MatchID: "m2"}, Timestamp: time.Now(), Version: 1},
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{
+			{ID: "event-pred3-withdrawn", Type: "PredictionWithdrawn", Data: events.PredictionWithdrawn{PredictionID: "pred3", UserID: "user2", MatchID: "m1", WithdrawnAt: bracketLockAt}, Timestamp: time.Now(), Version: 1},
+		}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			notification, ok := event.Data.(events.NotificationSent)
+			return ok && notification.UserID == "user1" && notification.MatchID == "m1" &&
+				notification.Message == "Team A v Team B has moved from Mon 1 Jun 19:00 UTC to Wed 3 Jun 19:00 UTC: Broadcast change"
+		})).Return(nil).Once()
+
+		handler.RescheduleMatch(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var response struct {
+			Date   time.Time `json:"date"`
+			LockAt time.Time `json:"lockAt"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if !response.Date.Equal(moved) || !response.LockAt.Equal(moved.Add(-time.Hour)) {
+			t.Errorf("expected kickoff %v locking an hour earlier, got %v locking at %v", moved, response.Date, response.LockAt)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Match already finished
+	t.Run("Match already finished", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"date": "2026-06-03T19:00:00Z", "reason": "Broadcast change"}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/reschedule", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 1}), nil)
+
+		handler.RescheduleMatch(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 5: No reason given
+	t.Run("No reason given", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/reschedule", bytes.NewBufferString(`{"date": "2026-06-03T19:00:00Z"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		handler.RescheduleMatch(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateMatchOdds(t *testing.T) {
+	// Test case 1: Odds recorded for a scheduled match
+	t.Run("Odds recorded for a scheduled match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"home": 2.6, "draw": 3.2, "away": 2.1, "source": "Betfair"}`
+		req := httptest.NewRequest("PUT", "/api/matches/m1/odds", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", nil), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			updated, ok := event.Data.(events.MatchOddsUpdated)
+			return ok && updated.MatchID == "m1" && updated.Home == 2.6 && updated.Draw == 3.2 &&
+				updated.Away == 2.1 && updated.Source == "Betfair"
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusScheduled}, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.Odds != nil && match.Odds.Away == 2.1
+		})).Return(nil)
+
+		handler.UpdateMatchOdds(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+
+		var response domain.Match
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if response.Odds == nil || response.Odds.Favourite() != domain.MatchSideAway {
+			t.Errorf("expected the away side to be favourite, got odds %+v", response.Odds)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Prices must be greater than 1
+	t.Run("Prices must be greater than 1", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/matches/m1/odds", bytes.NewBufferString(`{"home": 1.8, "away": 0.5}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		handler.UpdateMatchOdds(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		if !bytes.Contains(rr.Body.Bytes(), []byte("INVALID_ODDS")) {
+			t.Errorf("expected INVALID_ODDS, got %s", rr.Body.String())
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Match already finished
+	t.Run("Match already finished", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/matches/m1/odds", bytes.NewBufferString(`{"home": 1.8, "away": 2.2}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 1}), nil)
+
+		handler.UpdateMatchOdds(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "ODDS_CLOSED" {
+			t.Errorf("expected code %q, got %q", "ODDS_CLOSED", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 4: Unknown match
+	t.Run("Unknown match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/matches/missing/odds", bytes.NewBufferString(`{"home": 1.8, "away": 2.2}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		mockStore.On("GetEvents", req.Context(), "missing").Return([]*events.Event{}, nil)
+
+		handler.UpdateMatchOdds(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
 }
@@ -38,3 +38,117 @@ func (m *MockMatchRepository) List(ctx context.Context, filters repository.Match
 	}
 	return args.Get(0).([]*domain.Match), args.Error(1)
 }
+
+// MockPredictionRepository is a mock implementation of repository.PredictionRepository
+type MockPredictionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPredictionRepository) Create(ctx context.Context, prediction *domain.Prediction) error {
+	args := m.Called(ctx, prediction)
+	return args.Error(0)
+}
+
+func (m *MockPredictionRepository) Update(ctx context.Context, prediction *domain.Prediction) error {
+	args := m.Called(ctx, prediction)
+	return args.Error(0)
+}
+
+func (m *MockPredictionRepository) GetByID(ctx context.Context, id string) (*domain.Prediction, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Prediction), args.Error(1)
+}
+
+func (m *MockPredictionRepository) GetByUserAndMatch(ctx context.Context, userID, matchID string) (*domain.Prediction, error) {
+	args := m.Called(ctx, userID, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Prediction), args.Error(1)
+}
+
+func (m *MockPredictionRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Prediction, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Prediction), args.Error(1)
+}
+
+func (m *MockPredictionRepository) ListByMatch(ctx context.Context, matchID string) ([]*domain.Prediction, error) {
+	args := m.Called(ctx, matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Prediction), args.Error(1)
+}
+
+func (m *MockPredictionRepository) AddRevision(ctx context.Context, revision *domain.PredictionRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+// MockRoundRepository is a mock implementation of repository.RoundRepository
+type MockRoundRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoundRepository) Create(ctx context.Context, round *domain.Round) error {
+	args := m.Called(ctx, round)
+	return args.Error(0)
+}
+
+func (m *MockRoundRepository) Update(ctx context.Context, round *domain.Round) error {
+	args := m.Called(ctx, round)
+	return args.Error(0)
+}
+
+func (m *MockRoundRepository) GetByID(ctx context.Context, id string) (*domain.Round, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Round), args.Error(1)
+}
+
+func (m *MockRoundRepository) List(ctx context.Context, filters repository.RoundFilters) ([]*domain.Round, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Round), args.Error(1)
+}
+
+// MockUserRepository is a mock implementation of repository.UserRepository
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Update(ctx context.Context, user *domain.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
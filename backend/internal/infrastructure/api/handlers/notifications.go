@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+)
+
+type NotificationHandler struct {
+	eventStore EventStore
+}
+
+func NewNotificationHandler(eventStore EventStore) *NotificationHandler {
+	return &NotificationHandler{
+		eventStore: eventStore,
+	}
+}
+
+// ListUserNotifications retrieves the notifications sent to a user, newest
+// first
+func (h *NotificationHandler) ListUserNotifications(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	notifications, err := eventhandlers.LoadNotifications(r.Context(), h.eventStore, userID)
+	if err != nil {
+		writeError(w, "Failed to retrieve notifications", http.StatusInternalServerError)
+		return
+	}
+
+	for i, j := 0, len(notifications)-1; i < j; i, j = i+1, j-1 {
+		notifications[i], notifications[j] = notifications[j], notifications[i]
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(notifications); err != nil {
+		fmt.Printf("error encoding notifications: %v\n", err)
+	}
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+func TestListUserNotifications(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewNotificationHandler(mockStore)
+
+	req := httptest.NewRequest("GET", "/api/users/user1/notifications", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+	notification := func(id, userID, message string) *events.Event {
+		return &events.Event{
+			ID:        "event-" + id,
+			Type:      "NotificationSent",
+			Data:      events.NotificationSent{ID: id, UserID: userID, Type: "MATCH_RESCHEDULED", MatchID: "m1", Message: message},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	mockStore.On("GetEventsByType", req.Context(), "NotificationSent").Return([]*events.Event{
+		notification("n1", "user1", "First move"),
+		notification("n2", "user2", "Someone else's"),
+		notification("n3", "user1", "Second move"),
+	}, nil)
+
+	handler.ListUserNotifications(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var notifications []domain.Notification
+	if err := json.NewDecoder(rr.Body).Decode(&notifications); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(notifications) != 2 || notifications[0].ID != "n3" || notifications[1].ID != "n1" {
+		t.Errorf("expected user1's notifications newest first, got %+v", notifications)
+	}
+	if notifications[0].Type != domain.NotificationMatchRescheduled {
+		t.Errorf("expected type %s, got %s", domain.NotificationMatchRescheduled, notifications[0].Type)
+	}
+}
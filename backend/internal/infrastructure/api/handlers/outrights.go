@@ -0,0 +1,387 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type OutrightHandler struct {
+	eventStore        EventStore
+	settlementHandler *eventhandlers.OutrightSettlementHandler
+	now               func() time.Time
+}
+
+func NewOutrightHandler(eventStore EventStore) *OutrightHandler {
+	return &OutrightHandler{
+		eventStore:        eventStore,
+		settlementHandler: eventhandlers.NewOutrightSettlementHandler(eventStore),
+		now:               time.Now,
+	}
+}
+
+// createMarketRequest is the body accepted when opening an outright market
+type createMarketRequest struct {
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId"`
+	Name          string    `json:"name"`
+	Selections    int       `json:"selections"`
+	PointsPerPick int       `json:"pointsPerPick"`
+	Deadline      time.Time `json:"deadline"`
+}
+
+func (r createMarketRequest) validate(errs *fieldErrors) {
+	errs.required("competitionId", r.CompetitionID)
+	errs.required("name", r.Name)
+	errs.requiredTime("deadline", r.Deadline)
+}
+
+// CreateMarket handles opening an outright market for a competition, e.g.
+// the league winner (one selection) or the relegated teams (three)
+func (h *OutrightHandler) CreateMarket(w http.ResponseWriter, r *http.Request) {
+	var request createMarketRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(request.CompetitionID)
+	if competition == nil {
+		writeDomainError(w, "Invalid market", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, request.CompetitionID), http.StatusBadRequest)
+		return
+	}
+
+	if request.SeasonID != "" {
+		season := catalog.Season(request.SeasonID)
+		if season == nil || season.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid market", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, request.SeasonID, competition.Name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	market := domain.NewOutrightMarket(
+		utils.GenerateID(),
+		competition.ID,
+		request.Name,
+		request.Selections,
+		request.PointsPerPick,
+		request.Deadline,
+	)
+	market.SeasonID = request.SeasonID
+
+	if err := market.Validate(); err != nil {
+		writeDomainError(w, "Invalid market", err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("OutrightMarketCreated", events.OutrightMarketCreated{
+		ID:            market.ID,
+		CompetitionID: market.CompetitionID,
+		SeasonID:      market.SeasonID,
+		Name:          market.Name,
+		Selections:    market.Selections,
+		PointsPerPick: market.PointsPerPick,
+		Deadline:      market.Deadline,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create market", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(market); err != nil {
+		fmt.Printf("error encoding market: %v\n", err)
+	}
+}
+
+// ListMarkets retrieves all outright markets, optionally filtered by
+// competition and season ID
+func (h *OutrightHandler) ListMarkets(w http.ResponseWriter, r *http.Request) {
+	markets, err := eventhandlers.LoadOutrightMarkets(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve markets", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	competitionID := query.Get("competitionId")
+	seasonID := query.Get("seasonId")
+
+	filtered := make([]*domain.OutrightMarket, 0, len(markets))
+	for _, market := range markets {
+		if competitionID != "" && market.CompetitionID != competitionID {
+			continue
+		}
+		if seasonID != "" && market.SeasonID != seasonID {
+			continue
+		}
+		filtered = append(filtered, market)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		fmt.Printf("error encoding markets: %v\n", err)
+	}
+}
+
+// GetMarket retrieves an outright market by ID
+func (h *OutrightHandler) GetMarket(w http.ResponseWriter, r *http.Request) {
+	market, ok := h.findMarket(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(market); err != nil {
+		fmt.Printf("error encoding market: %v\n", err)
+	}
+}
+
+// outrightTipRequest is the body accepted when a user tips an outright market
+type outrightTipRequest struct {
+	UserID string   `json:"userId"`
+	Picks  []string `json:"picks"`
+}
+
+func (r outrightTipRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+}
+
+// SubmitTip handles a user submitting their picks for an outright market
+// before its deadline. Teams are given by ID, name, short code or alias.
+// Submitting again before the deadline replaces the user's picks.
+func (h *OutrightHandler) SubmitTip(w http.ResponseWriter, r *http.Request) {
+	var request outrightTipRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	market, ok := h.findMarket(w, r)
+	if !ok {
+		return
+	}
+
+	if market.IsLocked(h.now()) {
+		writeDomainError(w, "Invalid outright tip", fmt.Errorf("%w at %s", domain.ErrOutrightTipsLocked, market.Deadline.Format(time.RFC3339)), http.StatusBadRequest)
+		return
+	}
+
+	picks, ok := h.resolveTeams(w, r, "picks", request.Picks)
+	if !ok {
+		return
+	}
+
+	if err := market.ValidatePicks(picks); err != nil {
+		writeDomainError(w, "Invalid outright tip", err, http.StatusBadRequest)
+		return
+	}
+
+	tips, err := eventhandlers.LoadOutrightTips(r.Context(), h.eventStore, market.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve outright tips", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusCreated
+	tip := domain.NewOutrightTip(utils.GenerateID(), market.ID, request.UserID, picks, h.now())
+	if existing := eventhandlers.FindOutrightTip(tips, request.UserID); existing != nil {
+		tip.ID = existing.ID
+		status = http.StatusOK
+	}
+
+	event := events.NewEvent("OutrightTipSubmitted", events.OutrightTipSubmitted{
+		ID:          tip.ID,
+		MarketID:    tip.MarketID,
+		UserID:      tip.UserID,
+		Picks:       tip.Picks,
+		SubmittedAt: tip.SubmittedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to submit outright tip", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(tip); err != nil {
+		fmt.Printf("error encoding outright tip: %v\n", err)
+	}
+}
+
+// ListTips retrieves every tip in an outright market, or with ?leagueId=
+// only the tips of that league's members
+func (h *OutrightHandler) ListTips(w http.ResponseWriter, r *http.Request) {
+	market, ok := h.findMarket(w, r)
+	if !ok {
+		return
+	}
+
+	members, ok := leagueMembers(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	tips, err := eventhandlers.LoadOutrightTips(r.Context(), h.eventStore, market.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve outright tips", http.StatusInternalServerError)
+		return
+	}
+
+	if members != nil {
+		filtered := make([]*domain.OutrightTip, 0, len(tips))
+		for _, tip := range tips {
+			if members[tip.UserID] {
+				filtered = append(filtered, tip)
+			}
+		}
+		tips = filtered
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tips); err != nil {
+		fmt.Printf("error encoding outright tips: %v\n", err)
+	}
+}
+
+// GetUserTip retrieves a user's tip in an outright market
+func (h *OutrightHandler) GetUserTip(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	market, ok := h.findMarket(w, r)
+	if !ok {
+		return
+	}
+
+	tips, err := eventhandlers.LoadOutrightTips(r.Context(), h.eventStore, market.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve outright tips", http.StatusInternalServerError)
+		return
+	}
+
+	tip := eventhandlers.FindOutrightTip(tips, userID)
+	if tip == nil {
+		writeError(w, "Outright tip not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tip); err != nil {
+		fmt.Printf("error encoding outright tip: %v\n", err)
+	}
+}
+
+// settleMarketRequest is the body accepted when settling an outright market
+type settleMarketRequest struct {
+	Result []string `json:"result"`
+}
+
+func (r settleMarketRequest) validate(errs *fieldErrors) {
+	if len(r.Result) == 0 {
+		errs.add("result", CodeRequired, "is required")
+	}
+}
+
+// SettleMarket handles recording an outright market's result once its
+// deadline has passed. Settling awards points for every tip in the market.
+func (h *OutrightHandler) SettleMarket(w http.ResponseWriter, r *http.Request) {
+	var request settleMarketRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	market, ok := h.findMarket(w, r)
+	if !ok {
+		return
+	}
+
+	result, ok := h.resolveTeams(w, r, "result", request.Result)
+	if !ok {
+		return
+	}
+
+	if err := market.Settle(result, h.now()); err != nil {
+		status := http.StatusBadRequest
+		if err == domain.ErrMarketSettled {
+			status = http.StatusConflict
+		}
+		writeDomainError(w, fmt.Sprintf("Cannot settle %s", market.Name), err, status)
+		return
+	}
+
+	event := events.NewEvent("OutrightMarketSettled", events.OutrightMarketSettled{
+		MarketID:  market.ID,
+		Result:    market.Result,
+		SettledAt: *market.SettledAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to settle market", http.StatusInternalServerError)
+		return
+	}
+
+	// Award points for every tip now the result is known
+	if err := h.settlementHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to score outright tips for market %s: %v\n", market.ID, err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(market); err != nil {
+		fmt.Printf("error encoding market: %v\n", err)
+	}
+}
+
+// findMarket writes an error response and returns false if the market named
+// in the URL does not exist
+func (h *OutrightHandler) findMarket(w http.ResponseWriter, r *http.Request) (*domain.OutrightMarket, bool) {
+	vars := mux.Vars(r)
+	marketID := vars["id"]
+
+	markets, err := eventhandlers.LoadOutrightMarkets(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve markets", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	market := eventhandlers.FindOutrightMarket(markets, marketID)
+	if market == nil {
+		writeError(w, "Market not found", http.StatusNotFound)
+		return nil, false
+	}
+	return market, true
+}
+
+// resolveTeams writes an error response and returns false if any of the
+// references is not a registered team; otherwise it returns their team IDs
+func (h *OutrightHandler) resolveTeams(w http.ResponseWriter, r *http.Request, field string, refs []string) ([]string, bool) {
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	teamIDs := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		team, ok := resolveTeam(w, registry, field, "", ref)
+		if !ok {
+			return nil, false
+		}
+		teamIDs = append(teamIDs, team.ID)
+	}
+	return teamIDs, true
+}
@@ -0,0 +1,321 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+var outrightDeadline = time.Date(2025, 8, 15, 19, 0, 0, 0, time.UTC)
+
+// testMarketEvents returns the events opening a Premier League top-two market
+// worth 5 points per correct team
+func testMarketEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:   "event-o1",
+			Type: "OutrightMarketCreated",
+			Data: events.OutrightMarketCreated{
+				ID:            "market1",
+				CompetitionID: "comp_epl",
+				SeasonID:      "season_epl_2025",
+				Name:          "Top two",
+				Selections:    2,
+				PointsPerPick: 5,
+				Deadline:      outrightDeadline,
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}
+}
+
+// expectMarkets sets up the event store to return the test market, unsettled
+func expectMarkets(mockStore *mocks.MockEventStore, ctx context.Context) {
+	mockStore.On("GetEventsByType", ctx, "OutrightMarketCreated").Return(testMarketEvents(), nil)
+	mockStore.On("GetEventsByType", ctx, "OutrightMarketSettled").Return([]*events.Event{}, nil)
+}
+
+// expectOutrightTips sets up the event store to return the given tip
+// submissions, with no points awarded yet
+func expectOutrightTips(mockStore *mocks.MockEventStore, ctx context.Context, tipEvents []*events.Event) {
+	mockStore.On("GetEventsByType", ctx, "OutrightTipSubmitted").Return(tipEvents, nil)
+	mockStore.On("GetEventsByType", ctx, "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+}
+
+// testTipEvent returns the submission of a user's outright tip
+func testTipEvent(tipID, userID string, picks ...string) *events.Event {
+	return &events.Event{
+		ID:        "event-" + tipID,
+		Type:      "OutrightTipSubmitted",
+		Data:      events.OutrightTipSubmitted{ID: tipID, MarketID: "market1", UserID: userID, Picks: picks},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+}
+
+func TestCreateOutrightMarket(t *testing.T) {
+	// Test case 1: Valid market
+	t.Run("Valid market", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+
+		body := `{"competitionId": "EPL", "seasonId": "season_epl_2025", "name": "Relegated", "selections": 3, "pointsPerPick": 5, "deadline": "2025-08-15T19:00:00Z"}`
+		req := httptest.NewRequest("POST", "/api/outrights", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.OutrightMarketCreated)
+			return ok && event.Type == "OutrightMarketCreated" &&
+				created.CompetitionID == "comp_epl" &&
+				created.Selections == 3 &&
+				created.PointsPerPick == 5
+		})).Return(nil)
+
+		handler.CreateMarket(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Market without a deadline
+	t.Run("Market without a deadline", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+
+		body := `{"competitionId": "comp_epl", "name": "Winner", "selections": 1, "pointsPerPick": 10}`
+		req := httptest.NewRequest("POST", "/api/outrights", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateMarket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Season from another competition
+	t.Run("Season from another competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+
+		body := `{"competitionId": "comp_laliga", "seasonId": "season_epl_2025", "name": "Winner", "selections": 1, "pointsPerPick": 10, "deadline": "2025-08-15T19:00:00Z"}`
+		req := httptest.NewRequest("POST", "/api/outrights", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateMarket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "SEASON_NOT_IN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "SEASON_NOT_IN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "seasonId" {
+			t.Errorf("expected seasonId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSubmitOutrightTip(t *testing.T) {
+	beforeDeadline := func() time.Time { return outrightDeadline.Add(-time.Hour) }
+
+	// Test case 1: New tip before the deadline
+	t.Run("New tip before the deadline", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = beforeDeadline
+
+		body := `{"userId": "user1", "picks": ["A Team", "TMB"]}`
+		req := httptest.NewRequest("POST", "/api/outrights/market1/tips", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+		expectTeams(mockStore, req.Context())
+		expectOutrightTips(mockStore, req.Context(), []*events.Event{})
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			submitted, ok := event.Data.(events.OutrightTipSubmitted)
+			return ok && event.Type == "OutrightTipSubmitted" &&
+				submitted.UserID == "user1" &&
+				len(submitted.Picks) == 2 && submitted.Picks[0] == "team_a" && submitted.Picks[1] == "team_b"
+		})).Return(nil)
+
+		handler.SubmitTip(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Resubmitting replaces the user's picks
+	t.Run("Resubmitting replaces the user's picks", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = beforeDeadline
+
+		body := `{"userId": "user1", "picks": ["Team B", "Team A"]}`
+		req := httptest.NewRequest("POST", "/api/outrights/market1/tips", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+		expectTeams(mockStore, req.Context())
+		expectOutrightTips(mockStore, req.Context(), []*events.Event{testTipEvent("tip1", "user1", "team_a", "team_b")})
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			submitted, ok := event.Data.(events.OutrightTipSubmitted)
+			return ok && submitted.ID == "tip1" && submitted.Picks[0] == "team_b"
+		})).Return(nil)
+
+		handler.SubmitTip(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: After the deadline
+	t.Run("After the deadline", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = func() time.Time { return outrightDeadline }
+
+		body := `{"userId": "user1", "picks": ["Team A", "Team B"]}`
+		req := httptest.NewRequest("POST", "/api/outrights/market1/tips", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+
+		handler.SubmitTip(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "OUTRIGHT_TIPS_LOCKED" {
+			t.Errorf("expected code %q, got %q", "OUTRIGHT_TIPS_LOCKED", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 4: Wrong number of teams
+	t.Run("Wrong number of teams", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = beforeDeadline
+
+		body := `{"userId": "user1", "picks": ["Team A"]}`
+		req := httptest.NewRequest("POST", "/api/outrights/market1/tips", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+		expectTeams(mockStore, req.Context())
+
+		handler.SubmitTip(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSettleOutrightMarket(t *testing.T) {
+	// Test case 1: Settling awards points for every tip
+	t.Run("Settling awards points for every tip", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = func() time.Time { return outrightDeadline.Add(24 * time.Hour) }
+
+		req := httptest.NewRequest("PUT", "/api/outrights/market1/settle", bytes.NewBufferString(`{"result": ["Team B", "Team A"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+		expectTeams(mockStore, req.Context())
+		expectOutrightTips(mockStore, req.Context(), []*events.Event{
+			testTipEvent("tip1", "user1", "team_a", "team_b"),
+			testTipEvent("tip2", "user2", "team_a", "team_c"),
+		})
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			settled, ok := event.Data.(events.OutrightMarketSettled)
+			return ok && event.Type == "OutrightMarketSettled" && len(settled.Result) == 2
+		})).Return(nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			awarded, ok := event.Data.(events.OutrightPointsAwarded)
+			return ok && awarded.TipID == "tip1" && awarded.Correct == 2 && awarded.Points == 10
+		})).Return(nil).Once()
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			awarded, ok := event.Data.(events.OutrightPointsAwarded)
+			return ok && awarded.TipID == "tip2" && awarded.Correct == 1 && awarded.Points == 5
+		})).Return(nil).Once()
+
+		handler.SettleMarket(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Settling before the deadline
+	t.Run("Settling before the deadline", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+		handler.now = func() time.Time { return outrightDeadline.Add(-time.Hour) }
+
+		req := httptest.NewRequest("PUT", "/api/outrights/market1/settle", bytes.NewBufferString(`{"result": ["Team B", "Team A"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "market1"})
+
+		expectMarkets(mockStore, req.Context())
+		expectTeams(mockStore, req.Context())
+
+		handler.SettleMarket(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Market not found
+	t.Run("Market not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewOutrightHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/outrights/missing/settle", bytes.NewBufferString(`{"result": ["Team A"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		expectMarkets(mockStore, req.Context())
+
+		handler.SettleMarket(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
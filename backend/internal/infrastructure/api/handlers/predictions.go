@@ -1,111 +1,193 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
 	"github.com/parkertr2/footy-tipping/pkg/events"
 	"github.com/parkertr2/footy-tipping/pkg/utils"
 )
 
 type PredictionHandler struct {
-	eventStore EventStore
+	eventStore   EventStore
+	predRepo     repository.PredictionRepository
+	eventHandler *eventhandlers.PredictionEventHandler
+	badgeHandler *eventhandlers.BadgeAwardHandler
+	lockCutoff   time.Duration
+	now          func() time.Time
 }
 
-func NewPredictionHandler(eventStore EventStore) *PredictionHandler {
+func NewPredictionHandler(eventStore EventStore, predRepo repository.PredictionRepository) *PredictionHandler {
 	return &PredictionHandler{
-		eventStore: eventStore,
+		eventStore:   eventStore,
+		predRepo:     predRepo,
+		eventHandler: eventhandlers.NewPredictionEventHandler(predRepo),
+		badgeHandler: eventhandlers.NewBadgeAwardHandler(eventStore),
+		now:          time.Now,
 	}
 }
 
-// CreatePrediction handles the creation of a new prediction
+// WithLockCutoff sets how long before kickoff tipping closes. By default tips
+// lock at kickoff.
+func (h *PredictionHandler) WithLockCutoff(cutoff time.Duration) *PredictionHandler {
+	h.lockCutoff = cutoff
+	return h
+}
+
+// tipRequest is the tip part of a prediction request: a score, or a winner
+// and margin in winner tipping
+type tipRequest struct {
+	HomeGoals int    `json:"homeGoals"`
+	AwayGoals int    `json:"awayGoals"`
+	Winner    string `json:"winner"`
+	Margin    *int   `json:"margin"`
+}
+
+func (r tipRequest) validate(errs *fieldErrors) {
+	errs.nonNegative("homeGoals", r.HomeGoals)
+	errs.nonNegative("awayGoals", r.AwayGoals)
+}
+
+// createPredictionRequest is the body accepted when making a prediction
+type createPredictionRequest struct {
+	tipRequest
+	UserID  string `json:"userId"`
+	MatchID string `json:"matchId"`
+	Joker   bool   `json:"joker"`
+}
+
+func (r createPredictionRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+	errs.required("matchId", r.MatchID)
+	r.tipRequest.validate(errs)
+}
+
+// amendPredictionRequest is the body accepted when changing a prediction.
+// Goals and the joker left out keep their current values, so a body with
+// only the joker leaves the tip as it is.
+type amendPredictionRequest struct {
+	HomeGoals *int   `json:"homeGoals"`
+	AwayGoals *int   `json:"awayGoals"`
+	Winner    string `json:"winner"`
+	Margin    *int   `json:"margin"`
+	Joker     *bool  `json:"joker"`
+}
+
+func (r amendPredictionRequest) validate(errs *fieldErrors) {
+	if r.HomeGoals != nil {
+		errs.nonNegative("homeGoals", *r.HomeGoals)
+	}
+	if r.AwayGoals != nil {
+		errs.nonNegative("awayGoals", *r.AwayGoals)
+	}
+}
+
+// changesTip returns true if the body changes the tip itself, not just the
+// joker
+func (r amendPredictionRequest) changesTip() bool {
+	return r.HomeGoals != nil || r.AwayGoals != nil || r.Winner != "" || r.Margin != nil
+}
+
+// CreatePrediction handles the creation of a new prediction. Competitions in
+// winner tipping take a winner (HOME or AWAY) and margin instead of a score.
 func (h *PredictionHandler) CreatePrediction(w http.ResponseWriter, r *http.Request) {
-	var request struct {
-		UserID    string `json:"userId"`
-		MatchID   string `json:"matchId"`
-		HomeGoals int    `json:"homeGoals"`
-		AwayGoals int    `json:"awayGoals"`
+	var request createPredictionRequest
+	if !decodeRequest(w, r, &request) {
+		return
 	}
 
-	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	if !h.checkTipper(w, r, request.UserID) {
 		return
 	}
 
-	// Check if match exists and is not finished
+	// Check if match exists and is still open for tipping
 	matchEvents, err := h.eventStore.GetEvents(r.Context(), request.MatchID)
 	if err != nil {
-		http.Error(w, "Failed to retrieve match", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
 		return
 	}
 
 	if len(matchEvents) == 0 {
-		http.Error(w, "Match not found", http.StatusNotFound)
-		return
-	}
-
-	match := &domain.Match{}
-	fmt.Printf("Processing %d events\n", len(matchEvents))
-	for _, event := range matchEvents {
-		// Debug logging
-		data, _ := json.Marshal(event.Data)
-		fmt.Printf("Event type: %s, Data: %s\n", event.Type, string(data))
-
-		switch event.Type {
-		case "MatchCreated":
-			var matchCreated events.MatchCreated
-			if err := json.Unmarshal(data, &matchCreated); err != nil {
-				fmt.Printf("Failed to unmarshal MatchCreated: %v\n", err)
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			fmt.Printf("Successfully processed MatchCreated event\n")
-			match.ID = matchCreated.ID
-			match.HomeTeam = matchCreated.HomeTeam
-			match.AwayTeam = matchCreated.AwayTeam
-			match.Date = matchCreated.Date
-			match.Competition = matchCreated.Competition
-			match.Status = domain.MatchStatusScheduled
-		case "MatchScoreUpdated":
-			var scoreUpdated events.MatchScoreUpdated
-			if err := json.Unmarshal(data, &scoreUpdated); err != nil {
-				fmt.Printf("Failed to unmarshal MatchScoreUpdated: %v\n", err)
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			fmt.Printf("Successfully processed MatchScoreUpdated event\n")
-			match.UpdateScore(scoreUpdated.HomeGoals, scoreUpdated.AwayGoals)
-		case "MatchStatusChanged":
-			var statusChanged events.MatchStatusChanged
-			if err := json.Unmarshal(data, &statusChanged); err != nil {
-				fmt.Printf("Failed to unmarshal MatchStatusChanged: %v\n", err)
-				http.Error(w, "Failed to process match data", http.StatusInternalServerError)
-				return
-			}
-			fmt.Printf("Successfully processed MatchStatusChanged event, new status: %s\n", statusChanged.Status)
-			match.Status = domain.MatchStatus(statusChanged.Status)
-		}
+		writeError(w, "Match not found", http.StatusNotFound)
+		return
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.checkMatchOpen(w, r, match) {
+		return
+	}
+
+	var prediction *domain.Prediction
+	if request.Winner != "" {
+		prediction = domain.NewWinnerPrediction(
+			utils.GenerateID(),
+			request.UserID,
+			request.MatchID,
+			domain.MatchSide(request.Winner),
+			request.Margin,
+		)
+	} else {
+		prediction = domain.NewPrediction(
+			utils.GenerateID(),
+			request.UserID,
+			request.MatchID,
+			request.HomeGoals,
+			request.AwayGoals,
+		)
+		prediction.Margin = request.Margin
+	}
+	prediction.Joker = request.Joker
+
+	if !h.checkTipType(w, r, match, prediction) {
+		return
 	}
 
-	// Debug logging
-	fmt.Printf("Match status after events: %s\n", match.Status)
-	if match.IsFinished() {
-		fmt.Printf("Match is finished, returning 400\n")
-		http.Error(w, "Cannot create prediction for finished match", http.StatusBadRequest)
+	predictionEvents, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
 		return
 	}
 
-	prediction := domain.NewPrediction(
-		utils.GenerateID(),
-		request.UserID,
-		request.MatchID,
-		request.HomeGoals,
-		request.AwayGoals,
-	)
+	predictions, err := eventhandlers.ReplayPredictions(predictionEvents, "")
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	// Each user gets one active tip of their own per match; changes go through
+	// AmendPrediction. A league's auto-tip only stands in for them there.
+	for _, existing := range predictions {
+		if existing.UserID == request.UserID && existing.MatchID == request.MatchID && !existing.IsWithdrawn() && !existing.Auto {
+			writeDomainError(w, "Cannot make prediction", fmt.Errorf("%w, amend it with PUT /api/predictions/%s", domain.ErrPredictionExists, existing.ID), http.StatusConflict)
+			return
+		}
+	}
+
+	// Only one joker may be played per user per round
+	if request.Joker {
+		played, err := h.jokerPlayed(r.Context(), request.UserID, match, predictions)
+		if err != nil {
+			writeError(w, "Failed to check joker", http.StatusInternalServerError)
+			return
+		}
+		if played {
+			writeDomainError(w, "Cannot play joker", domain.ErrJokerAlreadyPlayed, http.StatusConflict)
+			return
+		}
+	}
 
 	event := events.NewEvent("PredictionMade", events.PredictionMade{
 		ID:        prediction.ID,
@@ -113,14 +195,28 @@ func (h *PredictionHandler) CreatePrediction(w http.ResponseWriter, r *http.Requ
 		MatchID:   prediction.MatchID,
 		HomeGoals: prediction.HomeGoals,
 		AwayGoals: prediction.AwayGoals,
+		Winner:    string(prediction.Winner),
+		Margin:    prediction.Margin,
+		Joker:     prediction.Joker,
 		CreatedAt: prediction.CreatedAt,
 	})
 
 	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
-		http.Error(w, "Failed to create prediction", http.StatusInternalServerError)
+		writeError(w, "Failed to create prediction", http.StatusInternalServerError)
 		return
 	}
 
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for prediction creation: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	// Unlock any tip-count badges
+	if err := h.badgeHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to award badges to user %s: %v\n", prediction.UserID, err)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(prediction); err != nil {
@@ -128,44 +224,386 @@ func (h *PredictionHandler) CreatePrediction(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-// GetUserPredictions retrieves all predictions for a user
-func (h *PredictionHandler) GetUserPredictions(w http.ResponseWriter, r *http.Request) {
+// AmendPrediction handles changing an existing prediction before the match locks
+func (h *PredictionHandler) AmendPrediction(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	userID := vars["userId"]
+	predictionID := vars["id"]
 
-	events, err := h.eventStore.GetEventsByType(r.Context(), "PredictionMade")
+	var request amendPredictionRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	predictionEvents, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
 	if err != nil {
-		http.Error(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
 		return
 	}
 
-	predictions := make([]*domain.Prediction, 0)
-	for _, event := range events {
-		data, err := json.Marshal(event.Data)
+	predictions, err := eventhandlers.ReplayPredictions(predictionEvents, "")
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	var prediction *domain.Prediction
+	for _, existing := range predictions {
+		if existing.ID == predictionID {
+			prediction = existing
+			break
+		}
+	}
+
+	if prediction == nil {
+		writeError(w, "Prediction not found", http.StatusNotFound)
+		return
+	}
+
+	if prediction.IsWithdrawn() {
+		writeDomainError(w, "Cannot amend prediction", domain.ErrPredictionWithdrawn, http.StatusConflict)
+		return
+	}
+
+	if !h.checkTipper(w, r, prediction.UserID) {
+		return
+	}
+
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), prediction.MatchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.checkMatchOpen(w, r, match) {
+		return
+	}
+
+	joker := prediction.Joker
+	if request.Joker != nil {
+		joker = *request.Joker
+	}
+
+	if joker && !prediction.Joker {
+		played, err := h.jokerPlayed(r.Context(), prediction.UserID, match, predictions)
 		if err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
+			writeError(w, "Failed to check joker", http.StatusInternalServerError)
 			return
 		}
-		var predictionMade struct {
-			ID        string    `json:"id"`
-			UserID    string    `json:"userId"`
-			MatchID   string    `json:"matchId"`
-			HomeGoals int       `json:"homeGoals"`
-			AwayGoals int       `json:"awayGoals"`
-			CreatedAt time.Time `json:"createdAt"`
-		}
-		if err := json.Unmarshal(data, &predictionMade); err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
+		if played {
+			writeDomainError(w, "Cannot play joker", domain.ErrJokerAlreadyPlayed, http.StatusConflict)
 			return
 		}
-		if predictionMade.UserID == userID {
-			prediction := domain.NewPrediction(
-				predictionMade.ID,
-				predictionMade.UserID,
-				predictionMade.MatchID,
-				predictionMade.HomeGoals,
-				predictionMade.AwayGoals,
-			)
+	}
+
+	switch {
+	case !request.changesTip() && prediction.IsWinnerTip():
+		prediction.AmendWinner(prediction.Winner, prediction.Margin, joker, h.now())
+	case request.Winner != "":
+		prediction.AmendWinner(domain.MatchSide(request.Winner), request.Margin, joker, h.now())
+	default:
+		homeGoals, awayGoals, margin := prediction.HomeGoals, prediction.AwayGoals, prediction.Margin
+		if request.HomeGoals != nil {
+			homeGoals = *request.HomeGoals
+		}
+		if request.AwayGoals != nil {
+			awayGoals = *request.AwayGoals
+		}
+		if request.changesTip() {
+			margin = request.Margin
+		}
+		prediction.Amend(homeGoals, awayGoals, joker, h.now())
+		prediction.Margin = margin
+	}
+
+	if !h.checkTipType(w, r, match, prediction) {
+		return
+	}
+
+	event := events.NewEvent("PredictionAmended", events.PredictionAmended{
+		PredictionID: prediction.ID,
+		UserID:       prediction.UserID,
+		MatchID:      prediction.MatchID,
+		HomeGoals:    prediction.HomeGoals,
+		AwayGoals:    prediction.AwayGoals,
+		Winner:       string(prediction.Winner),
+		Margin:       prediction.Margin,
+		Joker:        prediction.Joker,
+		Revision:     prediction.Revision,
+		AmendedAt:    prediction.UpdatedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to amend prediction", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for prediction amendment: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prediction); err != nil {
+		fmt.Printf("error encoding prediction: %v\n", err)
+	}
+}
+
+// WithdrawPrediction handles pulling a prediction before the match locks
+func (h *PredictionHandler) WithdrawPrediction(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	predictionID := vars["id"]
+
+	predictionEvents, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		return
+	}
+
+	predictions, err := eventhandlers.ReplayPredictions(predictionEvents, "")
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	var prediction *domain.Prediction
+	for _, existing := range predictions {
+		if existing.ID == predictionID {
+			prediction = existing
+			break
+		}
+	}
+
+	if prediction == nil {
+		writeError(w, "Prediction not found", http.StatusNotFound)
+		return
+	}
+
+	if !h.checkTipper(w, r, prediction.UserID) {
+		return
+	}
+
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), prediction.MatchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return
+	}
+
+	if !h.checkMatchOpen(w, r, match) {
+		return
+	}
+
+	if err := prediction.Withdraw(h.now()); err != nil {
+		writeDomainError(w, "Cannot withdraw prediction", err, http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("PredictionWithdrawn", events.PredictionWithdrawn{
+		PredictionID: prediction.ID,
+		UserID:       prediction.UserID,
+		MatchID:      prediction.MatchID,
+		Revision:     prediction.Revision,
+		WithdrawnAt:  *prediction.WithdrawnAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to withdraw prediction", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for prediction withdrawal: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prediction); err != nil {
+		fmt.Printf("error encoding prediction: %v\n", err)
+	}
+}
+
+// GetPredictionHistory retrieves every revision of a prediction
+func (h *PredictionHandler) GetPredictionHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	predictionID := vars["id"]
+
+	predictionEvents, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		return
+	}
+
+	history, err := eventhandlers.ReplayPredictionHistory(predictionEvents, predictionID)
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	if len(history) == 0 {
+		writeError(w, "Prediction not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(history); err != nil {
+		fmt.Printf("error encoding prediction history: %v\n", err)
+	}
+}
+
+// checkTipper writes an error response and returns false if the user is not
+// registered or has been deactivated
+func (h *PredictionHandler) checkTipper(w http.ResponseWriter, r *http.Request, userID string) bool {
+	users, err := eventhandlers.LoadUsers(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve users", http.StatusInternalServerError)
+		return false
+	}
+
+	user := eventhandlers.FindUser(users, userID)
+	if user == nil {
+		writeDomainError(w, "Invalid prediction", domain.ErrUnknownUser, http.StatusBadRequest)
+		return false
+	}
+	if err := user.CanTip(); err != nil {
+		writeDomainError(w, "Cannot change predictions", err, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// checkMatchOpen writes an error response and returns false if tips for the
+// match can no longer be submitted or changed. Matches in a round also close
+// at the round's tipping deadline.
+func (h *PredictionHandler) checkMatchOpen(w http.ResponseWriter, r *http.Request, match *domain.Match) bool {
+	if !match.AcceptsPredictions() {
+		writeDomainError(w, fmt.Sprintf("Cannot change predictions for %s match", strings.ToLower(string(match.Status))), domain.ErrPredictionsLocked, http.StatusBadRequest)
+		return false
+	}
+
+	if match.IsLocked(h.now(), h.lockCutoff) {
+		writeDomainError(w, "Cannot change predictions", fmt.Errorf("%w at %s", domain.ErrPredictionsLocked, match.LockTime(h.lockCutoff).Format(time.RFC3339)), http.StatusBadRequest)
+		return false
+	}
+
+	if match.RoundID == "" {
+		return true
+	}
+
+	roundEvents, err := eventhandlers.LoadRoundEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+		return false
+	}
+
+	rounds, err := eventhandlers.ReplayRounds(roundEvents)
+	if err != nil {
+		writeError(w, "Failed to process round data", http.StatusInternalServerError)
+		return false
+	}
+
+	if round := eventhandlers.FindRound(rounds, match.RoundID); round != nil && round.IsLocked(h.now()) {
+		writeDomainError(w, "Cannot change predictions", fmt.Errorf("%w, %s locked at %s", domain.ErrPredictionsLocked, round.Name, round.Deadline.UTC().Format(time.RFC3339)), http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// checkTipType writes an error response and returns false if the prediction
+// is not the kind the match's competition takes. In winner tipping the first
+// match of each round also needs a margin, which breaks ties.
+func (h *PredictionHandler) checkTipType(w http.ResponseWriter, r *http.Request, match *domain.Match, prediction *domain.Prediction) bool {
+	mode := domain.TippingModeExactScore
+	if match.CompetitionID != "" {
+		catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+		if err != nil {
+			writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+			return false
+		}
+		if competition := catalog.Competition(match.CompetitionID); competition != nil {
+			mode = competition.TippingMode
+		}
+	}
+
+	if err := prediction.ValidateFor(mode); err != nil {
+		writeDomainError(w, "Invalid prediction", err, http.StatusBadRequest)
+		return false
+	}
+
+	if mode != domain.TippingModeWinnerMargin || prediction.Margin != nil {
+		return true
+	}
+
+	marginMatch, err := eventhandlers.LoadMarginMatch(r.Context(), h.eventStore, match)
+	if err != nil {
+		writeError(w, "Failed to retrieve round matches", http.StatusInternalServerError)
+		return false
+	}
+	if marginMatch.ID == match.ID {
+		writeDomainError(w, "Invalid prediction", domain.ErrMarginRequired, http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
+
+// jokerPlayed reports whether the user has already played their joker on
+// another match in the same round as the given match
+func (h *PredictionHandler) jokerPlayed(ctx context.Context, userID string, match *domain.Match, predictions []*domain.Prediction) (bool, error) {
+	for _, prediction := range predictions {
+		if prediction.UserID != userID || !prediction.Joker || prediction.IsWithdrawn() || prediction.MatchID == match.ID {
+			continue
+		}
+
+		matchEvents, err := h.eventStore.GetEvents(ctx, prediction.MatchID)
+		if err != nil {
+			return false, err
+		}
+		other, err := eventhandlers.ReplayMatch(matchEvents)
+		if err != nil {
+			return false, err
+		}
+		if other.RoundKey() == match.RoundKey() {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetUserPredictions retrieves all predictions for a user, including withdrawn ones
+func (h *PredictionHandler) GetUserPredictions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	events, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		return
+	}
+
+	allPredictions, err := eventhandlers.ReplayPredictions(events, "")
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	predictions := make([]*domain.Prediction, 0)
+	for _, prediction := range allPredictions {
+		if prediction.UserID == userID {
 			predictions = append(predictions, prediction)
 		}
 	}
@@ -176,44 +614,26 @@ func (h *PredictionHandler) GetUserPredictions(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// GetMatchPredictions retrieves all predictions for a match
+// GetMatchPredictions retrieves all active predictions for a match
 func (h *PredictionHandler) GetMatchPredictions(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	matchID := vars["matchId"]
 
-	events, err := h.eventStore.GetEventsByType(r.Context(), "PredictionMade")
+	events, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
 	if err != nil {
-		http.Error(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		return
+	}
+
+	allPredictions, err := eventhandlers.ReplayPredictions(events, matchID)
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
 		return
 	}
 
 	predictions := make([]*domain.Prediction, 0)
-	for _, event := range events {
-		data, err := json.Marshal(event.Data)
-		if err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
-			return
-		}
-		var predictionMade struct {
-			ID        string    `json:"id"`
-			UserID    string    `json:"userId"`
-			MatchID   string    `json:"matchId"`
-			HomeGoals int       `json:"homeGoals"`
-			AwayGoals int       `json:"awayGoals"`
-			CreatedAt time.Time `json:"createdAt"`
-		}
-		if err := json.Unmarshal(data, &predictionMade); err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
-			return
-		}
-		if predictionMade.MatchID == matchID {
-			prediction := domain.NewPrediction(
-				predictionMade.ID,
-				predictionMade.UserID,
-				predictionMade.MatchID,
-				predictionMade.HomeGoals,
-				predictionMade.AwayGoals,
-			)
+	for _, prediction := range allPredictions {
+		if !prediction.IsWithdrawn() {
 			predictions = append(predictions, prediction)
 		}
 	}
@@ -224,52 +644,44 @@ func (h *PredictionHandler) GetMatchPredictions(w http.ResponseWriter, r *http.R
 	}
 }
 
-// GetUserPredictionForMatch retrieves a specific user's prediction for a specific match
+// GetUserPredictionForMatch retrieves a specific user's prediction for a specific match,
+// their own tip ahead of any league's auto-tip
 func (h *PredictionHandler) GetUserPredictionForMatch(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	matchID := vars["matchId"]
 	userID := vars["userId"]
 
-	events, err := h.eventStore.GetEventsByType(r.Context(), "PredictionMade")
+	events, err := eventhandlers.LoadPredictionEvents(r.Context(), h.eventStore)
 	if err != nil {
-		http.Error(w, "Failed to retrieve predictions", http.StatusInternalServerError)
+		writeError(w, "Failed to retrieve predictions", http.StatusInternalServerError)
 		return
 	}
 
-	for _, event := range events {
-		data, err := json.Marshal(event.Data)
-		if err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
-			return
-		}
-		var predictionMade struct {
-			ID        string    `json:"id"`
-			UserID    string    `json:"userId"`
-			MatchID   string    `json:"matchId"`
-			HomeGoals int       `json:"homeGoals"`
-			AwayGoals int       `json:"awayGoals"`
-			CreatedAt time.Time `json:"createdAt"`
-		}
-		if err := json.Unmarshal(data, &predictionMade); err != nil {
-			http.Error(w, "Failed to process prediction data", http.StatusInternalServerError)
-			return
+	predictions, err := eventhandlers.ReplayPredictions(events, matchID)
+	if err != nil {
+		writeError(w, "Failed to process prediction data", http.StatusInternalServerError)
+		return
+	}
+
+	// The user's own tip takes precedence over the auto-tips leagues made for
+	// them
+	var found *domain.Prediction
+	for _, prediction := range predictions {
+		if prediction.UserID != userID || prediction.IsWithdrawn() {
+			continue
 		}
-		if predictionMade.MatchID == matchID && predictionMade.UserID == userID {
-			prediction := domain.NewPrediction(
-				predictionMade.ID,
-				predictionMade.UserID,
-				predictionMade.MatchID,
-				predictionMade.HomeGoals,
-				predictionMade.AwayGoals,
-			)
-			w.Header().Set("Content-Type", "application/json")
-			if err := json.NewEncoder(w).Encode(prediction); err != nil {
-				fmt.Printf("error encoding prediction: %v\n", err)
-			}
-			return
+		if found == nil || (found.Auto && !prediction.Auto) {
+			found = prediction
 		}
 	}
 
-	// No prediction found
-	http.Error(w, "Prediction not found", http.StatusNotFound)
+	if found == nil {
+		writeError(w, "Prediction not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(found); err != nil {
+		fmt.Printf("error encoding prediction: %v\n", err)
+	}
 }
@@ -21,7 +21,8 @@ func TestCreatePrediction(t *testing.T) {
 	// Test case 1: Valid prediction creation
 	t.Run("Valid prediction creation", func(t *testing.T) {
 		mockStore := new(mocks.MockEventStore)
-		handler := NewPredictionHandler(mockStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
 		prediction := domain.Prediction{
 			UserID:    "user123",
 			MatchID:   "match123",
@@ -54,6 +55,15 @@ func TestCreatePrediction(t *testing.T) {
 		// Set up mock expectation for GetEvents (match check)
 		mockStore.On("GetEvents", req.Context(), prediction.MatchID).Return([]*events.Event{matchEvent}, nil)
 
+		// Set up mock expectations for existing predictions (duplicate check)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+
+		// Set up mock expectations for the read model projection
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+
 		// Set up mock expectation for SaveEvent - use mock.MatchedBy for dynamic fields
 		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
 			// Type check
@@ -73,6 +83,8 @@ func TestCreatePrediction(t *testing.T) {
 				predictionMade.HomeGoals == prediction.HomeGoals &&
 				predictionMade.AwayGoals == prediction.AwayGoals
 		})).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+		expectBadges(mockStore, req.Context())
 
 		// Handle request
 		handler.CreatePrediction(rr, req)
@@ -82,12 +94,14 @@ func TestCreatePrediction(t *testing.T) {
 			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
 		}
 		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
 	})
 
 	// Test case 2: Invalid JSON
 	t.Run("Invalid JSON", func(t *testing.T) {
 		mockStore := new(mocks.MockEventStore)
-		handler := NewPredictionHandler(mockStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
 		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString("invalid json"))
 		rr := httptest.NewRecorder()
 
@@ -101,7 +115,8 @@ func TestCreatePrediction(t *testing.T) {
 	// Test case 3: Match not found
 	t.Run("Match not found", func(t *testing.T) {
 		mockStore := new(mocks.MockEventStore)
-		handler := NewPredictionHandler(mockStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
 		prediction := domain.Prediction{
 			UserID:    "user123",
 			MatchID:   "nonexistent",
@@ -117,6 +132,7 @@ func TestCreatePrediction(t *testing.T) {
 
 		// Set up mock expectation for GetEvents (match not found)
 		mockStore.On("GetEvents", req.Context(), prediction.MatchID).Return([]*events.Event{}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
 
 		// Handle request
 		handler.CreatePrediction(rr, req)
@@ -131,7 +147,8 @@ func TestCreatePrediction(t *testing.T) {
 	// Test case 4: Match already finished
 	t.Run("Match already finished", func(t *testing.T) {
 		mockStore := new(mocks.MockEventStore)
-		handler := NewPredictionHandler(mockStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
 		prediction := domain.Prediction{
 			UserID:    "user123",
 			MatchID:   "match123",
@@ -190,6 +207,7 @@ func TestCreatePrediction(t *testing.T) {
 
 		// Set up mock expectation for GetEvents (finished match)
 		mockStore.On("GetEvents", req.Context(), prediction.MatchID).Return([]*events.Event{matchEvent, scoreEvent, statusEvent}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
 
 		// Handle request
 		handler.CreatePrediction(rr, req)
@@ -198,6 +216,52 @@ func TestCreatePrediction(t *testing.T) {
 		fmt.Printf("TestCreatePrediction/Match_already_finished: response code = %d, body = %s\n", rr.Code, rr.Body.String())
 
 		// Assert response
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTIONS_LOCKED" {
+			t.Errorf("expected code %q, got %q", "PREDICTIONS_LOCKED", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 5: Match abandoned
+	t.Run("Match abandoned", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user123", "matchId": "match123", "homeGoals": 2, "awayGoals": 1}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		matchEvent := &events.Event{
+			ID:   "event123",
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:          "match123",
+				HomeTeam:    "Team A",
+				AwayTeam:    "Team B",
+				Date:        time.Now().Add(24 * time.Hour),
+				Competition: "Premier League",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+		statusEvent := &events.Event{
+			ID:        "event124",
+			Type:      "MatchStatusChanged",
+			Data:      events.MatchStatusChanged{MatchID: "match123", Status: "ABANDONED"},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchEvent, statusEvent}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
 		if rr.Code != http.StatusBadRequest {
 			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
 		}
@@ -205,10 +269,287 @@ func TestCreatePrediction(t *testing.T) {
 	})
 }
 
+func TestCreateJokerPrediction(t *testing.T) {
+	kickoff := time.Now().Add(24 * time.Hour)
+	matchEvent := func(matchID string, date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event-" + matchID,
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:          matchID,
+				HomeTeam:    "Team A",
+				AwayTeam:    "Team B",
+				Date:        date,
+				Competition: "Premier League",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	jokerEvent := func(matchID string) *events.Event {
+		return &events.Event{
+			ID:   "event-joker-" + matchID,
+			Type: "PredictionMade",
+			Data: events.PredictionMade{
+				ID:        "pred-" + matchID,
+				UserID:    "user123",
+				MatchID:   matchID,
+				HomeGoals: 1,
+				AwayGoals: 0,
+				Joker:     true,
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	body := `{"userId": "user123", "matchId": "match123", "homeGoals": 2, "awayGoals": 1, "joker": true}`
+
+	// Test case 1: First joker of the round
+	t.Run("First joker of the round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchEvent("match123", kickoff)}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			predictionMade, ok := event.Data.(events.PredictionMade)
+			return ok && predictionMade.Joker
+		})).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+		expectBadges(mockStore, req.Context())
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		var prediction domain.Prediction
+		if err := json.NewDecoder(rr.Body).Decode(&prediction); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !prediction.Joker {
+			t.Errorf("expected prediction to be marked as joker")
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Joker already played on another match in the round
+	t.Run("Joker already played this round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchEvent("match123", kickoff)}, nil)
+		mockStore.On("GetEvents", req.Context(), "match456").Return([]*events.Event{matchEvent("match456", kickoff)}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{jokerEvent("match456")}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		var problem Problem
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if problem.Code != "JOKER_ALREADY_PLAYED" {
+			t.Errorf("expected code JOKER_ALREADY_PLAYED, got %q", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Joker played in a different round
+	t.Run("Joker played in a different round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchEvent("match123", kickoff)}, nil)
+		mockStore.On("GetEvents", req.Context(), "match456").Return([]*events.Event{matchEvent("match456", kickoff.Add(-14*24*time.Hour))}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{jokerEvent("match456")}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.AnythingOfType("*events.Event")).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+		expectBadges(mockStore, req.Context())
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestCreateWinnerPrediction(t *testing.T) {
+	firstKickoff := time.Now().Add(24 * time.Hour)
+	aflMatchEvent := func(matchID string, date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event-" + matchID,
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:            matchID,
+				HomeTeam:      "Team A",
+				AwayTeam:      "Team B",
+				Date:          date,
+				Competition:   "AFL Premiership",
+				CompetitionID: "comp_afl",
+				RoundID:       "round_afl_1",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	roundMatches := []*events.Event{
+		aflMatchEvent("m1", firstKickoff),
+		aflMatchEvent("m2", firstKickoff.Add(3*time.Hour)),
+	}
+	expectOpenMatch := func(mockStore *mocks.MockEventStore, req *http.Request, matchEvent *events.Event) {
+		ctx := req.Context()
+		mockStore.On("GetEvents", ctx, matchEvent.Data.(events.MatchCreated).ID).Return([]*events.Event{matchEvent}, nil)
+		mockStore.On("GetEventsByType", ctx, "RoundCreated").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", ctx, "RoundUpdated").Return([]*events.Event{}, nil)
+		expectCatalog(mockStore, ctx, testAFLCatalogEvents())
+	}
+	expectCreated := func(mockStore *mocks.MockEventStore, mockRepo *mocks.MockPredictionRepository, req *http.Request) {
+		ctx := req.Context()
+		mockStore.On("GetEventsByType", ctx, "PredictionMade").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", ctx, "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", ctx, "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", ctx, mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+	}
+
+	// Test case 1: Winner and margin on the first match of the round
+	t.Run("Winner and margin on the first match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user123", "matchId": "m1", "winner": "HOME", "margin": 12}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectOpenMatch(mockStore, req, roundMatches[0])
+		expectUsers(mockStore, req.Context(), "user123")
+		expectCreated(mockStore, mockRepo, req)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			predictionMade, ok := event.Data.(events.PredictionMade)
+			return ok && predictionMade.Winner == "HOME" && predictionMade.Margin != nil && *predictionMade.Margin == 12
+		})).Return(nil)
+		expectBadges(mockStore, req.Context())
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		var prediction domain.Prediction
+		if err := json.NewDecoder(rr.Body).Decode(&prediction); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if prediction.Winner != domain.MatchSideHome {
+			t.Errorf("expected a home tip, got %q", prediction.Winner)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: The first match of the round needs a margin
+	t.Run("Margin missing on the first match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user123", "matchId": "m1", "winner": "AWAY"}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectOpenMatch(mockStore, req, roundMatches[0])
+		expectUsers(mockStore, req.Context(), "user123")
+		mockStore.On("GetEventsByType", req.Context(), "MatchCreated").Return(roundMatches, nil)
+		mockStore.On("GetEventsByType", req.Context(), "MatchRescheduled").Return([]*events.Event{}, nil)
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Later matches take a winner alone
+	t.Run("Winner alone on a later match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user123", "matchId": "m2", "winner": "AWAY"}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectOpenMatch(mockStore, req, roundMatches[1])
+		expectUsers(mockStore, req.Context(), "user123")
+		mockStore.On("GetEventsByType", req.Context(), "MatchCreated").Return(roundMatches, nil)
+		mockStore.On("GetEventsByType", req.Context(), "MatchRescheduled").Return([]*events.Event{}, nil)
+		expectCreated(mockStore, mockRepo, req)
+		mockStore.On("SaveEvent", req.Context(), mock.AnythingOfType("*events.Event")).Return(nil)
+		expectBadges(mockStore, req.Context())
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 4: Score tips are rejected in winner tipping
+	t.Run("Score tip in a winner competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user123", "matchId": "m2", "homeGoals": 90, "awayGoals": 70}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectOpenMatch(mockStore, req, roundMatches[1])
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
 func TestGetUserPredictions(t *testing.T) {
 	// Create mock event store
 	mockStore := new(mocks.MockEventStore)
-	handler := NewPredictionHandler(mockStore)
+	mockRepo := new(mocks.MockPredictionRepository)
+	handler := NewPredictionHandler(mockStore, mockRepo)
 
 	// Test case 1: User has predictions
 	t.Run("User has predictions", func(t *testing.T) {
@@ -252,6 +593,9 @@ func TestGetUserPredictions(t *testing.T) {
 			Version:   1,
 		}
 
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
 		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{event1, event2}, nil)
 
 		// Handle request
@@ -273,6 +617,9 @@ func TestGetUserPredictions(t *testing.T) {
 		rr := httptest.NewRecorder()
 		req = mux.SetURLVars(req, map[string]string{"userId": userID})
 
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
 		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
 
 		handler.GetUserPredictions(rr, req)
@@ -287,7 +634,8 @@ func TestGetUserPredictions(t *testing.T) {
 func TestGetMatchPredictions(t *testing.T) {
 	// Create mock event store
 	mockStore := new(mocks.MockEventStore)
-	handler := NewPredictionHandler(mockStore)
+	mockRepo := new(mocks.MockPredictionRepository)
+	handler := NewPredictionHandler(mockStore, mockRepo)
 
 	// Test case 1: Match has predictions
 	t.Run("Match has predictions", func(t *testing.T) {
@@ -331,6 +679,9 @@ func TestGetMatchPredictions(t *testing.T) {
 			Version:   1,
 		}
 
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
 		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{event1, event2}, nil)
 
 		// Handle request
@@ -352,6 +703,9 @@ func TestGetMatchPredictions(t *testing.T) {
 		rr := httptest.NewRecorder()
 		req = mux.SetURLVars(req, map[string]string{"matchId": matchID})
 
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
 		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
 
 		handler.GetMatchPredictions(rr, req)
@@ -362,3 +716,693 @@ func TestGetMatchPredictions(t *testing.T) {
 		mockStore.AssertExpectations(t)
 	})
 }
+
+func TestAutoTipsFromTwoLeagues(t *testing.T) {
+	// user1 belongs to two leagues whose policies both auto-tipped match123
+	autoTip := func(id, leagueID string) *events.Event {
+		return &events.Event{
+			ID:        "event-" + id,
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: id, UserID: "user1", MatchID: "match123", HomeGoals: 1, AwayGoals: 0, Auto: true, LeagueID: leagueID},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+	autoTips := []*events.Event{autoTip("auto1", "league1"), autoTip("auto2", "league2")}
+
+	// Test case 1: The member can still make their own tip
+	t.Run("Own tip alongside both auto-tips", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		body := `{"userId": "user1", "matchId": "match123", "homeGoals": 2, "awayGoals": 2}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{{
+			ID:        "event-match123",
+			Type:      "MatchCreated",
+			Data:      events.MatchCreated{ID: "match123", HomeTeam: "Team A", AwayTeam: "Team B", Date: time.Now().Add(24 * time.Hour), Competition: "Premier League"},
+			Timestamp: time.Now(),
+			Version:   1,
+		}}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return(autoTips, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			made, ok := event.Data.(events.PredictionMade)
+			return ok && made.UserID == "user1" && !made.Auto && made.LeagueID == ""
+		})).Return(nil)
+		expectUsers(mockStore, req.Context(), "user1")
+		expectBadges(mockStore, req.Context())
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: The member's own tip is their tip for the match
+	t.Run("Own tip ahead of both auto-tips", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewPredictionHandler(mockStore, new(mocks.MockPredictionRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1/predictions/match123", nil)
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1", "matchId": "match123"})
+		rr := httptest.NewRecorder()
+
+		ownTip := &events.Event{
+			ID:        "event-own",
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: "own", UserID: "user1", MatchID: "match123", HomeGoals: 2, AwayGoals: 2},
+			Timestamp: time.Now().Add(time.Second),
+			Version:   1,
+		}
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return(append(autoTips, ownTip), nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+
+		handler.GetUserPredictionForMatch(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var prediction domain.Prediction
+		if err := json.NewDecoder(rr.Body).Decode(&prediction); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if prediction.ID != "own" || prediction.Auto {
+			t.Errorf("expected user1's own tip, got %+v", prediction)
+		}
+	})
+}
+
+func TestPredictionLock(t *testing.T) {
+	body := `{"userId": "user123", "matchId": "match123", "homeGoals": 2, "awayGoals": 1}`
+	matchAt := func(date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event123",
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:          "match123",
+				HomeTeam:    "Team A",
+				AwayTeam:    "Team B",
+				Date:        date,
+				Competition: "Premier League",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+
+	// Test case 1: Match already kicked off
+	t.Run("Match already kicked off", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(-80 * time.Minute))}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTIONS_LOCKED" {
+			t.Errorf("expected code %q, got %q", "PREDICTIONS_LOCKED", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Inside the configured cutoff
+	t.Run("Inside the configured cutoff", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo).WithLockCutoff(time.Hour)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(30 * time.Minute))}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Second tip for the same match
+	t.Run("Second tip for the same match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		existing := &events.Event{
+			ID:        "event124",
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: "pred123", UserID: "user123", MatchID: "match123", HomeGoals: 1, AwayGoals: 0},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(24 * time.Hour))}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{existing}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTION_EXISTS" {
+			t.Errorf("expected code %q, got %q", "PREDICTION_EXISTS", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestAmendPrediction(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	predictionMade := &events.Event{
+		ID:   "event124",
+		Type: "PredictionMade",
+		Data: events.PredictionMade{
+			ID:        "pred123",
+			UserID:    "user123",
+			MatchID:   "match123",
+			HomeGoals: 1,
+			AwayGoals: 0,
+			CreatedAt: created,
+		},
+		Timestamp: created,
+		Version:   1,
+	}
+	matchAt := func(date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event123",
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:          "match123",
+				HomeTeam:    "Team A",
+				AwayTeam:    "Team B",
+				Date:        date,
+				Competition: "Premier League",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+
+	// Test case 1: Amend before kickoff
+	t.Run("Amend before kickoff", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/predictions/pred123", bytes.NewBufferString(`{"homeGoals": 3, "awayGoals": 3}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(24 * time.Hour))}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			amended, ok := event.Data.(events.PredictionAmended)
+			return ok && event.Type == "PredictionAmended" && amended.PredictionID == "pred123" &&
+				amended.HomeGoals == 3 && amended.AwayGoals == 3 && amended.Revision == 2
+		})).Return(nil)
+
+		existing := domain.NewPrediction("pred123", "user123", "match123", 1, 0)
+		mockRepo.On("GetByID", req.Context(), "pred123").Return(existing, nil)
+		mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.MatchedBy(func(revision *domain.PredictionRevision) bool {
+			return revision.Revision == 2
+		})).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.AmendPrediction(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Amend after kickoff
+	t.Run("Amend after kickoff", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/predictions/pred123", bytes.NewBufferString(`{"homeGoals": 3, "awayGoals": 3}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(-10 * time.Minute))}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.AmendPrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTIONS_LOCKED" {
+			t.Errorf("expected code %q, got %q", "PREDICTIONS_LOCKED", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Prediction not found
+	t.Run("Prediction not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/predictions/missing", bytes.NewBufferString(`{"homeGoals": 3, "awayGoals": 3}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.AmendPrediction(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 4: Joker already played on another match in the round
+	t.Run("Joker already played this round", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/predictions/pred123", bytes.NewBufferString(`{"homeGoals": 3, "awayGoals": 3, "joker": true}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		kickoff := time.Now().Add(24 * time.Hour)
+		otherMatch := matchAt(kickoff)
+		otherMatch.Data = events.MatchCreated{ID: "match456", HomeTeam: "Team C", AwayTeam: "Team D", Date: kickoff, Competition: "Premier League"}
+		jokerMade := &events.Event{
+			ID:        "event125",
+			Type:      "PredictionMade",
+			Data:      events.PredictionMade{ID: "pred456", UserID: "user123", MatchID: "match456", HomeGoals: 2, AwayGoals: 0, Joker: true, CreatedAt: created},
+			Timestamp: created,
+			Version:   1,
+		}
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade, jokerMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(kickoff)}, nil)
+		mockStore.On("GetEvents", req.Context(), "match456").Return([]*events.Event{otherMatch}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.AmendPrediction(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		var problem Problem
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if problem.Code != "JOKER_ALREADY_PLAYED" {
+			t.Errorf("expected code JOKER_ALREADY_PLAYED, got %q", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 5: Amend only the joker
+	t.Run("Amend only the joker", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("PUT", "/api/predictions/pred123", bytes.NewBufferString(`{"joker": true}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(24 * time.Hour))}, nil)
+		// The 1-0 tip keeps its score
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			amended, ok := event.Data.(events.PredictionAmended)
+			return ok && amended.HomeGoals == 1 && amended.AwayGoals == 0 && amended.Joker && amended.Revision == 2
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "pred123").Return(domain.NewPrediction("pred123", "user123", "match123", 1, 0), nil)
+		mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Prediction")).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.AnythingOfType("*domain.PredictionRevision")).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.AmendPrediction(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var prediction domain.Prediction
+		if err := json.NewDecoder(rr.Body).Decode(&prediction); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if prediction.HomeGoals != 1 || prediction.AwayGoals != 0 || !prediction.Joker {
+			t.Errorf("expected the 1-0 tip played as a joker, got %d-%d joker=%v", prediction.HomeGoals, prediction.AwayGoals, prediction.Joker)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestGetPredictionHistory(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockPredictionRepository)
+	handler := NewPredictionHandler(mockStore, mockRepo)
+
+	created := time.Now().Add(-2 * time.Hour)
+	amended := time.Now().Add(-time.Hour)
+	withdrawn := time.Now().Add(-30 * time.Minute)
+
+	req := httptest.NewRequest("GET", "/api/predictions/pred123/history", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{{
+		ID:        "event1",
+		Type:      "PredictionMade",
+		Data:      events.PredictionMade{ID: "pred123", UserID: "user123", MatchID: "match123", HomeGoals: 1, AwayGoals: 0, CreatedAt: created},
+		Timestamp: created,
+		Version:   1,
+	}}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{{
+		ID:        "event2",
+		Type:      "PredictionAmended",
+		Data:      events.PredictionAmended{PredictionID: "pred123", UserID: "user123", MatchID: "match123", HomeGoals: 2, AwayGoals: 2, Revision: 2, AmendedAt: amended},
+		Timestamp: amended,
+		Version:   1,
+	}}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{{
+		ID:        "event3",
+		Type:      "PredictionWithdrawn",
+		Data:      events.PredictionWithdrawn{PredictionID: "pred123", UserID: "user123", MatchID: "match123", Revision: 3, WithdrawnAt: withdrawn},
+		Timestamp: withdrawn,
+		Version:   1,
+	}}, nil)
+
+	handler.GetPredictionHistory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var history []domain.PredictionRevision
+	if err := json.NewDecoder(rr.Body).Decode(&history); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(history) != 3 {
+		t.Fatalf("expected 3 revisions, got %d", len(history))
+	}
+	if history[0].HomeGoals != 1 || history[1].HomeGoals != 2 || history[1].Revision != 2 {
+		t.Errorf("unexpected history %+v", history)
+	}
+	if !history[2].Withdrawn || history[2].Revision != 3 || history[1].Withdrawn {
+		t.Errorf("expected final revision to record the withdrawal, got %+v", history[2])
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestWithdrawPrediction(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	predictionMade := &events.Event{
+		ID:   "event124",
+		Type: "PredictionMade",
+		Data: events.PredictionMade{
+			ID:        "pred123",
+			UserID:    "user123",
+			MatchID:   "match123",
+			HomeGoals: 1,
+			AwayGoals: 0,
+			CreatedAt: created,
+		},
+		Timestamp: created,
+		Version:   1,
+	}
+	matchAt := func(date time.Time) *events.Event {
+		return &events.Event{
+			ID:   "event123",
+			Type: "MatchCreated",
+			Data: events.MatchCreated{
+				ID:          "match123",
+				HomeTeam:    "Team A",
+				AwayTeam:    "Team B",
+				Date:        date,
+				Competition: "Premier League",
+			},
+			Timestamp: time.Now(),
+			Version:   1,
+		}
+	}
+
+	// Test case 1: Withdraw before kickoff
+	t.Run("Withdraw before kickoff", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("DELETE", "/api/predictions/pred123", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(24 * time.Hour))}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			withdrawn, ok := event.Data.(events.PredictionWithdrawn)
+			return ok && event.Type == "PredictionWithdrawn" && withdrawn.PredictionID == "pred123" && withdrawn.Revision == 2
+		})).Return(nil)
+
+		existing := domain.NewPrediction("pred123", "user123", "match123", 1, 0)
+		mockRepo.On("GetByID", req.Context(), "pred123").Return(existing, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(prediction *domain.Prediction) bool {
+			return prediction.IsWithdrawn()
+		})).Return(nil)
+		mockRepo.On("AddRevision", req.Context(), mock.MatchedBy(func(revision *domain.PredictionRevision) bool {
+			return revision.Withdrawn && revision.Revision == 2
+		})).Return(nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.WithdrawPrediction(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var prediction domain.Prediction
+		if err := json.NewDecoder(rr.Body).Decode(&prediction); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if prediction.WithdrawnAt == nil {
+			t.Errorf("expected prediction to be withdrawn")
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Withdraw after kickoff
+	t.Run("Withdraw after kickoff", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("DELETE", "/api/predictions/pred123", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(-10 * time.Minute))}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.WithdrawPrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTIONS_LOCKED" {
+			t.Errorf("expected code %q, got %q", "PREDICTIONS_LOCKED", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 3: Already withdrawn
+	t.Run("Already withdrawn", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockPredictionRepository)
+		handler := NewPredictionHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("DELETE", "/api/predictions/pred123", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "pred123"})
+
+		withdrawn := &events.Event{
+			ID:        "event125",
+			Type:      "PredictionWithdrawn",
+			Data:      events.PredictionWithdrawn{PredictionID: "pred123", UserID: "user123", MatchID: "match123", Revision: 2, WithdrawnAt: created.Add(time.Minute)},
+			Timestamp: created.Add(time.Minute),
+			Version:   1,
+		}
+
+		mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{predictionMade}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{withdrawn}, nil)
+		mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchAt(time.Now().Add(24 * time.Hour))}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.WithdrawPrediction(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "PREDICTION_WITHDRAWN" {
+			t.Errorf("expected code %q, got %q", "PREDICTION_WITHDRAWN", problem.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestPredictionRoundDeadline(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockPredictionRepository)
+	handler := NewPredictionHandler(mockStore, mockRepo)
+
+	body := `{"userId": "user123", "matchId": "match123", "homeGoals": 2, "awayGoals": 1}`
+	req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+
+	// The match kicks off tomorrow but its round's deadline has passed
+	kickoff := time.Now().Add(24 * time.Hour)
+	matchCreated := &events.Event{
+		ID:   "event123",
+		Type: "MatchCreated",
+		Data: events.MatchCreated{
+			ID:          "match123",
+			HomeTeam:    "Team A",
+			AwayTeam:    "Team B",
+			Date:        kickoff,
+			Competition: "Premier League",
+			RoundID:     "round1",
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+	roundCreated := &events.Event{
+		ID:   "event100",
+		Type: "RoundCreated",
+		Data: events.RoundCreated{
+			ID:            "round1",
+			CompetitionID: "comp_epl",
+			Number:        1,
+			Name:          "Round 1",
+			StartDate:     time.Now().Add(-time.Hour),
+			EndDate:       kickoff.Add(48 * time.Hour),
+			Deadline:      time.Now().Add(-time.Minute),
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+
+	mockStore.On("GetEvents", req.Context(), "match123").Return([]*events.Event{matchCreated}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+	expectUsers(mockStore, req.Context(), "user123")
+
+	handler.CreatePrediction(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+	}
+	mockStore.AssertExpectations(t)
+}
+
+func TestPredictionTipper(t *testing.T) {
+	// Test case 1: Unknown user
+	t.Run("Unknown user", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewPredictionHandler(mockStore, new(mocks.MockPredictionRepository))
+
+		body := `{"userId": "nobody", "matchId": "match123", "homeGoals": 2, "awayGoals": 1}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		var problem Problem
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if problem.Code != "UNKNOWN_USER" {
+			t.Errorf("expected code UNKNOWN_USER, got %q", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 2: Deactivated user
+	t.Run("Deactivated user", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewPredictionHandler(mockStore, new(mocks.MockPredictionRepository))
+
+		body := `{"userId": "user123", "matchId": "match123", "homeGoals": 2, "awayGoals": 1}`
+		req := httptest.NewRequest("POST", "/api/predictions", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		deactivated := events.NewEvent("UserDeactivated", events.UserDeactivated{UserID: "user123", DeactivatedAt: time.Now()})
+		deactivated.Timestamp = time.Now().Add(time.Minute)
+		mockStore.On("GetEventsByType", req.Context(), "UserDeactivated").Return([]*events.Event{deactivated}, nil)
+		expectUsers(mockStore, req.Context(), "user123")
+
+		handler.CreatePrediction(rr, req)
+
+		if rr.Code != http.StatusForbidden {
+			t.Errorf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
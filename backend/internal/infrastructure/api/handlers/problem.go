@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+)
+
+// Problem is an RFC 7807 problem details body. Code is stable, so clients
+// can rely on it where the wording of Detail may change, and Errors lists
+// the request fields at fault.
+type Problem struct {
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Status int          `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+	Code   string       `json:"code"`
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError is a problem with one field of a request. Nested fields are
+// dotted, e.g. extraTime.homeGoals.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Problem codes that are not broken domain rules. Field errors use the
+// field codes, or the code of the domain rule the field breaks.
+const (
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeInvalidBody      = "INVALID_BODY"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeInternal         = "INTERNAL_ERROR"
+
+	CodeRequired     = "REQUIRED"
+	CodeInvalidType  = "INVALID_TYPE"
+	CodeNegative     = "NEGATIVE"
+	CodeInvalidValue = "INVALID_VALUE"
+)
+
+// problemType is the type URI of problems with no more specific type
+const problemType = "about:blank"
+
+// statusCodes gives the code of a problem that only has an HTTP status
+var statusCodes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusInternalServerError: CodeInternal,
+}
+
+// writeProblem writes a problem details response
+func writeProblem(w http.ResponseWriter, problem Problem) {
+	if problem.Type == "" {
+		problem.Type = problemType
+	}
+	if problem.Title == "" {
+		problem.Title = http.StatusText(problem.Status)
+	}
+	if problem.Code == "" {
+		problem.Code = statusCodes[problem.Status]
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		fmt.Printf("error encoding problem: %v\n", err)
+	}
+}
+
+// writeError writes a problem with the given detail and status. It takes the
+// place of http.Error.
+func writeError(w http.ResponseWriter, detail string, status int) {
+	writeProblem(w, Problem{Status: status, Detail: detail})
+}
+
+// writeDomainError writes a problem for a failed command, with the detail
+// followed by the error. Broken domain rules keep their code, and rules about
+// a single field name it.
+func writeDomainError(w http.ResponseWriter, detail string, err error, status int) {
+	problem := Problem{Status: status, Detail: fmt.Sprintf("%s: %v", detail, err)}
+	if domainErr := domain.AsError(err); domainErr != nil {
+		problem.Code = domainErr.Code
+		if domainErr.Field != "" {
+			problem.Errors = []FieldError{{Field: domainErr.Field, Code: domainErr.Code, Message: domainErr.Message}}
+		}
+	}
+	writeProblem(w, problem)
+}
+
+// validatable is a command request that checks its own fields once decoded
+type validatable interface {
+	validate(errs *fieldErrors)
+}
+
+// decodeRequest decodes a command request body into request, writes a
+// problem response and returns false if the body is malformed or, for
+// validatable requests, if any field is invalid
+func decodeRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	return decodeBody(w, r, request, false)
+}
+
+// decodeOptionalRequest is decodeRequest for commands whose body may be left
+// out: an empty body decodes as an empty request
+func decodeOptionalRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	return decodeBody(w, r, request, true)
+}
+
+// decodeBody decodes and validates a command request body, which may be
+// empty if it is optional
+func decodeBody(w http.ResponseWriter, r *http.Request, request interface{}, optional bool) bool {
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil && !(optional && errors.Is(err, io.EOF)) {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			writeFieldErrors(w, fieldErrors{{
+				Field:   typeErr.Field,
+				Code:    CodeInvalidType,
+				Message: fmt.Sprintf("must be %s", jsonType(typeErr.Type)),
+			}})
+			return false
+		}
+		writeProblem(w, Problem{Status: http.StatusBadRequest, Code: CodeInvalidBody, Detail: "Invalid request body"})
+		return false
+	}
+
+	if v, ok := request.(validatable); ok {
+		var errs fieldErrors
+		v.validate(&errs)
+		if len(errs) > 0 {
+			writeFieldErrors(w, errs)
+			return false
+		}
+	}
+	return true
+}
+
+// jsonType names the JSON type a Go type is decoded from
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	default:
+		return "an object"
+	}
+}
+
+// writeFieldErrors writes a validation problem listing every invalid field
+func writeFieldErrors(w http.ResponseWriter, errs fieldErrors) {
+	fields := make([]string, 0, len(errs))
+	for _, fieldErr := range errs {
+		fields = append(fields, fieldErr.Field)
+	}
+	writeProblem(w, Problem{
+		Status: http.StatusBadRequest,
+		Code:   CodeValidationFailed,
+		Detail: fmt.Sprintf("Invalid %s", strings.Join(fields, ", ")),
+		Errors: errs,
+	})
+}
+
+// fieldErrors collects the invalid fields of a request
+type fieldErrors []FieldError
+
+// add records a problem with a field
+func (e *fieldErrors) add(field, code, message string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message})
+}
+
+// required records the field if it is blank
+func (e *fieldErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		e.add(field, CodeRequired, "is required")
+	}
+}
+
+// requiredTime records the field if it was not given
+func (e *fieldErrors) requiredTime(field string, value time.Time) {
+	if value.IsZero() {
+		e.add(field, CodeRequired, "is required")
+	}
+}
+
+// nonNegative records the field if it is below zero
+func (e *fieldErrors) nonNegative(field string, value int) {
+	if value < 0 {
+		e.add(field, CodeNegative, "cannot be negative")
+	}
+}
+
+// timeZone records the field if it is not blank and not an IANA time zone
+func (e *fieldErrors) timeZone(field, name string) {
+	if _, err := domain.LoadTimeZone(name); err != nil {
+		e.rule(field, err)
+	}
+}
+
+// rule records the field against a broken domain rule, if err is one
+func (e *fieldErrors) rule(field string, err error) {
+	if err == nil {
+		return
+	}
+	code, message := CodeInvalidValue, err.Error()
+	if domainErr := domain.AsError(err); domainErr != nil {
+		code = domainErr.Code
+	}
+	e.add(field, code, message)
+}
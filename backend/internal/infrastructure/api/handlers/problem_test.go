@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+)
+
+// decodeProblem checks the response is a problem details body and decodes it
+func decodeProblem(t *testing.T, rr *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("expected problem content type, got %q", contentType)
+	}
+
+	var problem Problem
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("failed to decode problem: %v", err)
+	}
+	if problem.Status != rr.Code {
+		t.Errorf("expected problem status %d, got %d", rr.Code, problem.Status)
+	}
+	return problem
+}
+
+func TestWriteError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	writeError(rr, "Match not found", http.StatusNotFound)
+
+	problem := decodeProblem(t, rr)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+	}
+	if problem.Type != "about:blank" {
+		t.Errorf("expected type %q, got %q", "about:blank", problem.Type)
+	}
+	if problem.Title != "Not Found" {
+		t.Errorf("expected title %q, got %q", "Not Found", problem.Title)
+	}
+	if problem.Detail != "Match not found" {
+		t.Errorf("expected detail %q, got %q", "Match not found", problem.Detail)
+	}
+	if problem.Code != CodeNotFound {
+		t.Errorf("expected code %q, got %q", CodeNotFound, problem.Code)
+	}
+	if len(problem.Errors) != 0 {
+		t.Errorf("expected no field errors, got %v", problem.Errors)
+	}
+}
+
+func TestWriteDomainError(t *testing.T) {
+	t.Run("Field rule names the field", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		writeDomainError(rr, "Invalid team", domain.ErrTeamNameRequired, http.StatusBadRequest)
+
+		problem := decodeProblem(t, rr)
+		if problem.Code != "TEAM_NAME_REQUIRED" {
+			t.Errorf("expected code %q, got %q", "TEAM_NAME_REQUIRED", problem.Code)
+		}
+		if problem.Detail != "Invalid team: team name is required" {
+			t.Errorf("expected detail %q, got %q", "Invalid team: team name is required", problem.Detail)
+		}
+		want := []FieldError{{Field: "name", Code: "TEAM_NAME_REQUIRED", Message: "team name is required"}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("expected errors %v, got %v", want, problem.Errors)
+		}
+	})
+
+	t.Run("Rule broken through another field", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		err := fmt.Errorf("%w %q", domain.ErrUnknownTeam.OnField("homeTeamId"), "team_z")
+		writeDomainError(rr, "Invalid team", err, http.StatusBadRequest)
+
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_TEAM" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_TEAM", problem.Code)
+		}
+		if problem.Detail != `Invalid team: unknown team "team_z"` {
+			t.Errorf("expected detail %q, got %q", `Invalid team: unknown team "team_z"`, problem.Detail)
+		}
+		want := []FieldError{{Field: "homeTeamId", Code: "UNKNOWN_TEAM", Message: "unknown team"}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("expected errors %v, got %v", want, problem.Errors)
+		}
+		if domain.ErrUnknownTeam.Field != "team" {
+			t.Errorf("expected the shared rule to keep its field, got %q", domain.ErrUnknownTeam.Field)
+		}
+	})
+
+	t.Run("Rule about no one field", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		writeDomainError(rr, "Cannot correct score", domain.ErrMatchNotFinished, http.StatusConflict)
+
+		problem := decodeProblem(t, rr)
+		if problem.Code != "MATCH_NOT_FINISHED" {
+			t.Errorf("expected code %q, got %q", "MATCH_NOT_FINISHED", problem.Code)
+		}
+		if len(problem.Errors) != 0 {
+			t.Errorf("expected no field errors, got %v", problem.Errors)
+		}
+	})
+}
+
+func TestDecodeRequest(t *testing.T) {
+	decode := func(body string) (*httptest.ResponseRecorder, bool) {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", bytes.NewBufferString(body))
+		var request scoreCorrectionRequest
+		return rr, decodeRequest(rr, req, &request)
+	}
+
+	t.Run("Valid request", func(t *testing.T) {
+		rr, ok := decode(`{"homeGoals": 2, "awayGoals": 1, "reason": "Wrong scorer"}`)
+		if !ok {
+			t.Error("expected the request to decode")
+		}
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+	})
+
+	t.Run("Malformed body", func(t *testing.T) {
+		rr, ok := decode(`invalid json`)
+		if ok {
+			t.Fatal("expected the request to be rejected")
+		}
+
+		problem := decodeProblem(t, rr)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		if problem.Code != CodeInvalidBody {
+			t.Errorf("expected code %q, got %q", CodeInvalidBody, problem.Code)
+		}
+	})
+
+	t.Run("Field of the wrong type", func(t *testing.T) {
+		rr, ok := decode(`{"homeGoals": "two", "awayGoals": 1, "reason": "Wrong scorer"}`)
+		if ok {
+			t.Fatal("expected the request to be rejected")
+		}
+
+		problem := decodeProblem(t, rr)
+		if problem.Code != CodeValidationFailed {
+			t.Errorf("expected code %q, got %q", CodeValidationFailed, problem.Code)
+		}
+		want := []FieldError{{Field: "homeGoals", Code: CodeInvalidType, Message: "must be a number"}}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("expected errors %v, got %v", want, problem.Errors)
+		}
+	})
+
+	t.Run("Every invalid field is listed", func(t *testing.T) {
+		rr, ok := decode(`{"homeGoals": -1, "awayGoals": 1, "extraTime": {"homeGoals": 1, "awayGoals": -2}}`)
+		if ok {
+			t.Fatal("expected the request to be rejected")
+		}
+
+		problem := decodeProblem(t, rr)
+		if problem.Code != CodeValidationFailed {
+			t.Errorf("expected code %q, got %q", CodeValidationFailed, problem.Code)
+		}
+		if problem.Detail != "Invalid homeGoals, extraTime.awayGoals, reason" {
+			t.Errorf("expected detail to list the fields, got %q", problem.Detail)
+		}
+		want := []FieldError{
+			{Field: "homeGoals", Code: CodeNegative, Message: "cannot be negative"},
+			{Field: "extraTime.awayGoals", Code: CodeNegative, Message: "cannot be negative"},
+			{Field: "reason", Code: CodeRequired, Message: "is required"},
+		}
+		if !reflect.DeepEqual(problem.Errors, want) {
+			t.Errorf("expected errors %v, got %v", want, problem.Errors)
+		}
+	})
+}
+
+func TestValidationProblems(t *testing.T) {
+	// Every request is rejected before the event store is touched, so the
+	// mocks have no expectations
+	matchHandler := NewMatchHandler(new(mocks.MockEventStore), new(mocks.MockMatchRepository))
+	teamHandler := NewTeamHandler(new(mocks.MockEventStore), new(mocks.MockMatchRepository))
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+		errors  []FieldError
+	}{
+		{
+			name:    "Match without a date",
+			handler: matchHandler.CreateMatch,
+			body:    `{"homeTeam": "Team A", "awayTeam": "Team B", "competition": "Premier League"}`,
+			errors:  []FieldError{{Field: "date", Code: CodeRequired, Message: "is required"}},
+		},
+		{
+			name:    "Team playing itself",
+			handler: matchHandler.CreateMatch,
+			body:    `{"homeTeam": "Team A", "awayTeam": "team a", "date": "2025-08-16T15:00:00Z", "competition": "Premier League"}`,
+			errors:  []FieldError{{Field: "awayTeam", Code: "TEAM_PLAYS_ITSELF", Message: "a team cannot play itself"}},
+		},
+		{
+			name:    "Match without teams",
+			handler: matchHandler.CreateMatch,
+			body:    `{"date": "2025-08-16T15:00:00Z", "competitionId": "comp_epl"}`,
+			errors: []FieldError{
+				{Field: "homeTeam", Code: CodeRequired, Message: "is required"},
+				{Field: "awayTeam", Code: CodeRequired, Message: "is required"},
+			},
+		},
+		{
+			name:    "Negative goals",
+			handler: matchHandler.UpdateMatchScore,
+			body:    `{"homeGoals": -1, "awayGoals": 0}`,
+			errors:  []FieldError{{Field: "homeGoals", Code: CodeNegative, Message: "cannot be negative"}},
+		},
+		{
+			name:    "Unknown match status",
+			handler: matchHandler.UpdateMatchStatus,
+			body:    `{"status": "PAUSED"}`,
+			errors:  []FieldError{{Field: "status", Code: "INVALID_MATCH_STATUS", Message: "invalid match status"}},
+		},
+		{
+			name:    "Team without a name",
+			handler: teamHandler.CreateTeam,
+			body:    `{"name": "  ", "shortCode": "ARS"}`,
+			errors:  []FieldError{{Field: "name", Code: CodeRequired, Message: "is required"}},
+		},
+		{
+			name:    "Team in an unknown time zone",
+			handler: teamHandler.CreateTeam,
+			body:    `{"name": "Arsenal", "shortCode": "ARS", "timeZone": "London"}`,
+			errors:  []FieldError{{Field: "timeZone", Code: "INVALID_TIME_ZONE", Message: domain.ErrInvalidTimeZone.Error()}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", bytes.NewBufferString(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"id": "match1"})
+			rr := httptest.NewRecorder()
+
+			tt.handler(rr, req)
+
+			if rr.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+			}
+			problem := decodeProblem(t, rr)
+			if problem.Code != CodeValidationFailed {
+				t.Errorf("expected code %q, got %q", CodeValidationFailed, problem.Code)
+			}
+			if !reflect.DeepEqual(problem.Errors, tt.errors) {
+				t.Errorf("expected errors %v, got %v", tt.errors, problem.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type RoundHandler struct {
+	eventStore   EventStore
+	roundRepo    repository.RoundRepository
+	matchRepo    repository.MatchRepository
+	eventHandler *eventhandlers.RoundEventHandler
+	now          func() time.Time
+}
+
+func NewRoundHandler(eventStore EventStore, roundRepo repository.RoundRepository, matchRepo repository.MatchRepository) *RoundHandler {
+	return &RoundHandler{
+		eventStore:   eventStore,
+		roundRepo:    roundRepo,
+		matchRepo:    matchRepo,
+		eventHandler: eventhandlers.NewRoundEventHandler(roundRepo),
+		now:          time.Now,
+	}
+}
+
+// roundRequest is the body accepted when creating or updating a round
+type roundRequest struct {
+	CompetitionID string    `json:"competitionId"`
+	SeasonID      string    `json:"seasonId"`
+	Number        int       `json:"number"`
+	Name          string    `json:"name"`
+	StartDate     time.Time `json:"startDate"`
+	EndDate       time.Time `json:"endDate"`
+	Deadline      time.Time `json:"deadline"`
+}
+
+func (r roundRequest) validate(errs *fieldErrors) {
+	errs.requiredTime("startDate", r.StartDate)
+	errs.requiredTime("endDate", r.EndDate)
+}
+
+// createRoundRequest is the body accepted when creating a round, which must
+// name its competition
+type createRoundRequest struct {
+	roundRequest
+}
+
+func (r createRoundRequest) validate(errs *fieldErrors) {
+	errs.required("competitionId", r.CompetitionID)
+	r.roundRequest.validate(errs)
+}
+
+// CreateRound handles the creation of a new round. If no deadline is given,
+// tipping for the round closes when it starts.
+func (h *RoundHandler) CreateRound(w http.ResponseWriter, r *http.Request) {
+	var request createRoundRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(request.CompetitionID)
+	if competition == nil {
+		writeDomainError(w, "Invalid round", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, request.CompetitionID), http.StatusBadRequest)
+		return
+	}
+
+	if request.SeasonID != "" {
+		season := catalog.Season(request.SeasonID)
+		if season == nil || season.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid round", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, request.SeasonID, competition.Name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if request.Deadline.IsZero() {
+		request.Deadline = request.StartDate
+	}
+	if request.Name == "" {
+		request.Name = fmt.Sprintf("Round %d", request.Number)
+	}
+
+	round := domain.NewRound(
+		utils.GenerateID(),
+		competition.ID,
+		request.Number,
+		request.Name,
+		request.StartDate,
+		request.EndDate,
+		request.Deadline,
+	)
+	round.SeasonID = request.SeasonID
+
+	if err := round.Validate(); err != nil {
+		writeDomainError(w, "Invalid round", err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("RoundCreated", events.RoundCreated{
+		ID:            round.ID,
+		CompetitionID: round.CompetitionID,
+		SeasonID:      round.SeasonID,
+		Number:        round.Number,
+		Name:          round.Name,
+		StartDate:     round.StartDate,
+		EndDate:       round.EndDate,
+		Deadline:      round.Deadline,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create round", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for round creation: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(round); err != nil {
+		fmt.Printf("error encoding round: %v\n", err)
+	}
+}
+
+// UpdateRound handles changing a round's details. Fields missing from the
+// request keep their current values; the competition and season cannot be
+// changed.
+func (h *RoundHandler) UpdateRound(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roundID := vars["id"]
+
+	roundEvents, err := eventhandlers.LoadRoundEvents(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+		return
+	}
+
+	rounds, err := eventhandlers.ReplayRounds(roundEvents)
+	if err != nil {
+		writeError(w, "Failed to process round data", http.StatusInternalServerError)
+		return
+	}
+
+	round := eventhandlers.FindRound(rounds, roundID)
+	if round == nil {
+		writeError(w, "Round not found", http.StatusNotFound)
+		return
+	}
+
+	request := roundRequest{
+		Number:    round.Number,
+		Name:      round.Name,
+		StartDate: round.StartDate,
+		EndDate:   round.EndDate,
+		Deadline:  round.Deadline,
+	}
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	round.Number = request.Number
+	round.Name = request.Name
+	round.SetDates(request.StartDate, request.EndDate, request.Deadline)
+
+	if err := round.Validate(); err != nil {
+		writeDomainError(w, "Invalid round", err, http.StatusBadRequest)
+		return
+	}
+
+	event := events.NewEvent("RoundUpdated", events.RoundUpdated{
+		RoundID:   round.ID,
+		Number:    round.Number,
+		Name:      round.Name,
+		StartDate: round.StartDate,
+		EndDate:   round.EndDate,
+		Deadline:  round.Deadline,
+		UpdatedAt: h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update round", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for round update: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(round); err != nil {
+		fmt.Printf("error encoding round: %v\n", err)
+	}
+}
+
+// ListRounds retrieves all rounds, optionally filtered by the competitionId
+// and seasonId query parameters
+func (h *RoundHandler) ListRounds(w http.ResponseWriter, r *http.Request) {
+	rounds, err := h.roundRepo.List(r.Context(), roundFilters(r))
+	if err != nil {
+		writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+		return
+	}
+
+	if rounds == nil {
+		rounds = make([]*domain.Round, 0)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(rounds); err != nil {
+		fmt.Printf("error encoding rounds: %v\n", err)
+	}
+}
+
+// GetCurrentRound retrieves the round currently in play, optionally within a
+// single competition or season
+func (h *RoundHandler) GetCurrentRound(w http.ResponseWriter, r *http.Request) {
+	rounds, err := h.roundRepo.List(r.Context(), roundFilters(r))
+	if err != nil {
+		writeError(w, "Failed to retrieve rounds", http.StatusInternalServerError)
+		return
+	}
+
+	round := domain.CurrentRound(rounds, h.now())
+	if round == nil {
+		writeError(w, "No rounds found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(round); err != nil {
+		fmt.Printf("error encoding round: %v\n", err)
+	}
+}
+
+// GetRound retrieves a round by ID
+func (h *RoundHandler) GetRound(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roundID := vars["id"]
+
+	round, err := h.roundRepo.GetByID(r.Context(), roundID)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, "Round not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeError(w, "Failed to retrieve round", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(round); err != nil {
+		fmt.Printf("error encoding round: %v\n", err)
+	}
+}
+
+// GetRoundMatches retrieves the matches in a round, with kickoffs localised
+// as for MatchHandler.GetMatch
+func (h *RoundHandler) GetRoundMatches(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	roundID := vars["id"]
+
+	location, ok := requestLocation(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	_, err := h.roundRepo.GetByID(r.Context(), roundID)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, "Round not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeError(w, "Failed to retrieve round", http.StatusInternalServerError)
+		return
+	}
+
+	matches, err := h.matchRepo.List(r.Context(), repository.MatchFilters{
+		RoundID: &roundID,
+	})
+	if err != nil {
+		writeError(w, "Failed to retrieve matches", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newMatchViews(matches, location)); err != nil {
+		fmt.Printf("error encoding matches: %v\n", err)
+	}
+}
+
+// roundFilters builds round filters from the request's query string
+func roundFilters(r *http.Request) repository.RoundFilters {
+	var filters repository.RoundFilters
+	query := r.URL.Query()
+	if competitionID := query.Get("competitionId"); competitionID != "" {
+		filters.CompetitionID = &competitionID
+	}
+	if seasonID := query.Get("seasonId"); seasonID != "" {
+		filters.SeasonID = &seasonID
+	}
+	return filters
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateRound(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+
+	// Test case 1: Valid round creation
+	t.Run("Valid round creation", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRounds := new(mocks.MockRoundRepository)
+		handler := NewRoundHandler(mockStore, mockRounds, new(mocks.MockMatchRepository))
+
+		body := fmt.Sprintf(`{"competitionId": "comp_epl", "number": 1, "startDate": %q, "endDate": %q}`,
+			start.Format(time.RFC3339), start.Add(72*time.Hour).Format(time.RFC3339))
+		req := httptest.NewRequest("POST", "/api/rounds", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			roundCreated, ok := event.Data.(events.RoundCreated)
+			return ok && event.Type == "RoundCreated" &&
+				roundCreated.CompetitionID == "comp_epl" &&
+				roundCreated.Name == "Round 1" &&
+				roundCreated.Deadline.Equal(start)
+		})).Return(nil)
+		mockRounds.On("Create", req.Context(), mock.AnythingOfType("*domain.Round")).Return(nil)
+
+		handler.CreateRound(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRounds.AssertExpectations(t)
+	})
+
+	// Test case 2: Round ends before it starts
+	t.Run("Round ends before it starts", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewRoundHandler(mockStore, new(mocks.MockRoundRepository), new(mocks.MockMatchRepository))
+
+		body := fmt.Sprintf(`{"competitionId": "comp_epl", "number": 1, "startDate": %q, "endDate": %q}`,
+			start.Format(time.RFC3339), start.Add(-time.Hour).Format(time.RFC3339))
+		req := httptest.NewRequest("POST", "/api/rounds", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateRound(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Unknown competition
+	t.Run("Unknown competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewRoundHandler(mockStore, new(mocks.MockRoundRepository), new(mocks.MockMatchRepository))
+
+		body := fmt.Sprintf(`{"competitionId": "comp_unknown", "number": 1, "startDate": %q, "endDate": %q}`,
+			start.Format(time.RFC3339), start.Add(72*time.Hour).Format(time.RFC3339))
+		req := httptest.NewRequest("POST", "/api/rounds", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreateRound(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "competitionId" {
+			t.Errorf("expected competitionId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateRound(t *testing.T) {
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	roundCreated := &events.Event{
+		ID:   "event1",
+		Type: "RoundCreated",
+		Data: events.RoundCreated{
+			ID:            "round1",
+			CompetitionID: "comp_epl",
+			Number:        1,
+			Name:          "Round 1",
+			StartDate:     start,
+			EndDate:       start.Add(72 * time.Hour),
+			Deadline:      start,
+		},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+
+	// Test case 1: Move the deadline
+	t.Run("Move the deadline", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRounds := new(mocks.MockRoundRepository)
+		handler := NewRoundHandler(mockStore, mockRounds, new(mocks.MockMatchRepository))
+
+		deadline := start.Add(-2 * time.Hour)
+		body := fmt.Sprintf(`{"deadline": %q}`, deadline.Format(time.RFC3339))
+		req := httptest.NewRequest("PUT", "/api/rounds/round1", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "round1"})
+
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			roundUpdated, ok := event.Data.(events.RoundUpdated)
+			return ok && event.Type == "RoundUpdated" &&
+				roundUpdated.RoundID == "round1" &&
+				roundUpdated.Name == "Round 1" &&
+				roundUpdated.Deadline.Equal(deadline)
+		})).Return(nil)
+		mockRounds.On("GetByID", req.Context(), "round1").Return(&domain.Round{ID: "round1"}, nil)
+		mockRounds.On("Update", req.Context(), mock.AnythingOfType("*domain.Round")).Return(nil)
+
+		handler.UpdateRound(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRounds.AssertExpectations(t)
+	})
+
+	// Test case 2: Round not found
+	t.Run("Round not found", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewRoundHandler(mockStore, new(mocks.MockRoundRepository), new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/rounds/missing", bytes.NewBufferString(`{}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		mockStore.On("GetEventsByType", req.Context(), "RoundCreated").Return([]*events.Event{roundCreated}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "RoundUpdated").Return([]*events.Event{}, nil)
+
+		handler.UpdateRound(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestGetCurrentRound(t *testing.T) {
+	now := time.Now()
+	past := domain.NewRound("round1", "comp_epl", 1, "Round 1", now.Add(-10*24*time.Hour), now.Add(-7*24*time.Hour), now.Add(-10*24*time.Hour))
+	current := domain.NewRound("round2", "comp_epl", 2, "Round 2", now.Add(-24*time.Hour), now.Add(48*time.Hour), now.Add(-24*time.Hour))
+
+	// Test case 1: Round in play
+	t.Run("Round in play", func(t *testing.T) {
+		mockRounds := new(mocks.MockRoundRepository)
+		handler := NewRoundHandler(new(mocks.MockEventStore), mockRounds, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/rounds/current?competitionId=comp_epl", nil)
+		rr := httptest.NewRecorder()
+
+		competitionID := "comp_epl"
+		mockRounds.On("List", req.Context(), repository.RoundFilters{CompetitionID: &competitionID}).Return([]*domain.Round{past, current}, nil)
+
+		handler.GetCurrentRound(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var round domain.Round
+		if err := json.NewDecoder(rr.Body).Decode(&round); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if round.ID != "round2" {
+			t.Errorf("expected round2, got %v", round.ID)
+		}
+		mockRounds.AssertExpectations(t)
+	})
+
+	// Test case 2: No rounds
+	t.Run("No rounds", func(t *testing.T) {
+		mockRounds := new(mocks.MockRoundRepository)
+		handler := NewRoundHandler(new(mocks.MockEventStore), mockRounds, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/rounds/current", nil)
+		rr := httptest.NewRecorder()
+
+		mockRounds.On("List", req.Context(), repository.RoundFilters{}).Return([]*domain.Round{}, nil)
+
+		handler.GetCurrentRound(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockRounds.AssertExpectations(t)
+	})
+}
+
+func TestGetRoundMatches(t *testing.T) {
+	// Test case 1: Matches in the round
+	t.Run("Matches in the round", func(t *testing.T) {
+		mockRounds := new(mocks.MockRoundRepository)
+		mockMatches := new(mocks.MockMatchRepository)
+		handler := NewRoundHandler(new(mocks.MockEventStore), mockRounds, mockMatches)
+
+		req := httptest.NewRequest("GET", "/api/rounds/round1/matches", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "round1"})
+
+		roundID := "round1"
+		match := domain.NewMatch("match1", "Team A", "Team B", time.Now(), "Premier League")
+		match.RoundID = roundID
+
+		mockRounds.On("GetByID", req.Context(), "round1").Return(&domain.Round{ID: "round1"}, nil)
+		mockMatches.On("List", req.Context(), repository.MatchFilters{RoundID: &roundID}).Return([]*domain.Match{match}, nil)
+
+		handler.GetRoundMatches(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var matches []domain.Match
+		if err := json.NewDecoder(rr.Body).Decode(&matches); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(matches) != 1 || matches[0].RoundID != "round1" {
+			t.Errorf("expected 1 match in round1, got %+v", matches)
+		}
+		mockRounds.AssertExpectations(t)
+		mockMatches.AssertExpectations(t)
+	})
+
+	// Test case 2: Round not found
+	t.Run("Round not found", func(t *testing.T) {
+		mockRounds := new(mocks.MockRoundRepository)
+		handler := NewRoundHandler(new(mocks.MockEventStore), mockRounds, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/rounds/missing/matches", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		mockRounds.On("GetByID", req.Context(), "missing").Return(nil, repository.ErrNotFound)
+
+		handler.GetRoundMatches(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockRounds.AssertExpectations(t)
+	})
+}
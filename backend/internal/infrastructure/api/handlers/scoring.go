@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+type ScoringHandler struct {
+	eventStore EventStore
+}
+
+func NewScoringHandler(eventStore EventStore) *ScoringHandler {
+	return &ScoringHandler{
+		eventStore: eventStore,
+	}
+}
+
+// GetScoringRules retrieves the scoring spec used for a competition
+func (h *ScoringHandler) GetScoringRules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competition := vars["competition"]
+
+	configEvents, err := h.eventStore.GetEventsByType(r.Context(), "ScoringRulesConfigured")
+	if err != nil {
+		writeError(w, "Failed to retrieve scoring rules", http.StatusInternalServerError)
+		return
+	}
+
+	spec, err := eventhandlers.ReplayScoringSpec(configEvents, competition)
+	if err != nil {
+		writeError(w, "Failed to process scoring rules", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(spec); err != nil {
+		fmt.Printf("error encoding scoring spec: %v\n", err)
+	}
+}
+
+// UpdateScoringRules replaces the scoring spec for a competition. The spec may
+// be sent as JSON or YAML.
+func (h *ScoringHandler) UpdateScoringRules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	competition := vars["competition"]
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeProblem(w, Problem{Status: http.StatusBadRequest, Code: CodeInvalidBody, Detail: "Invalid request body"})
+		return
+	}
+
+	spec, err := domain.ParseScoringSpec(body)
+	if err != nil {
+		writeDomainError(w, "Invalid scoring spec", err, http.StatusBadRequest)
+		return
+	}
+
+	rules := make([]events.ScoringRule, 0, len(spec.Rules))
+	for _, rule := range spec.Rules {
+		rules = append(rules, events.ScoringRule{
+			Type:   rule.Type,
+			Points: rule.Points,
+			Final:  rule.Final,
+		})
+	}
+
+	event := events.NewEvent("ScoringRulesConfigured", events.ScoringRulesConfigured{
+		Competition:  competition,
+		Rules:        rules,
+		ConfiguredAt: time.Now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to save scoring rules", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(spec); err != nil {
+		fmt.Printf("error encoding scoring spec: %v\n", err)
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetScoringRules(t *testing.T) {
+	// Test case 1: Competition without configured rules uses the default spec
+	t.Run("Default spec", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewScoringHandler(mockStore)
+
+		req := httptest.NewRequest("GET", "/api/competitions/EPL/scoring", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"competition": "EPL"})
+
+		mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+
+		handler.GetScoringRules(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var spec domain.ScoringSpec
+		if err := json.NewDecoder(rr.Body).Decode(&spec); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(spec.Rules) != len(domain.DefaultScoringSpec().Rules) {
+			t.Errorf("expected default rules, got %+v", spec.Rules)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Latest configured spec for the competition wins
+	t.Run("Configured spec", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewScoringHandler(mockStore)
+
+		req := httptest.NewRequest("GET", "/api/competitions/EPL/scoring", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"competition": "EPL"})
+
+		configured := func(competition string, points int) *events.Event {
+			return &events.Event{
+				ID:   "event" + competition,
+				Type: "ScoringRulesConfigured",
+				Data: events.ScoringRulesConfigured{
+					Competition: competition,
+					Rules:       []events.ScoringRule{{Type: domain.RuleExactScore, Points: points}},
+				},
+				Timestamp: time.Now(),
+				Version:   1,
+			}
+		}
+
+		mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{
+			configured("EPL", 4),
+			configured("EPL", 5),
+			configured("A-League", 10),
+		}, nil)
+
+		handler.GetScoringRules(rr, req)
+
+		var spec domain.ScoringSpec
+		if err := json.NewDecoder(rr.Body).Decode(&spec); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(spec.Rules) != 1 || spec.Rules[0].Points != 5 {
+			t.Errorf("expected latest EPL spec, got %+v", spec.Rules)
+		}
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestUpdateScoringRules(t *testing.T) {
+	// Test case 1: YAML spec is saved
+	t.Run("Valid YAML spec", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewScoringHandler(mockStore)
+
+		body := "rules:\n  - type: exact_score\n    points: 5\n    final: true\n  - type: goal_difference\n    points: 2\n"
+		req := httptest.NewRequest("PUT", "/api/competitions/EPL/scoring", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"competition": "EPL"})
+
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			configured, ok := event.Data.(events.ScoringRulesConfigured)
+			return ok && configured.Competition == "EPL" && len(configured.Rules) == 2 &&
+				configured.Rules[1].Type == domain.RuleGoalDifference
+		})).Return(nil)
+
+		handler.UpdateScoringRules(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown rule type
+	t.Run("Unknown rule type", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewScoringHandler(mockStore)
+
+		req := httptest.NewRequest("PUT", "/api/competitions/EPL/scoring", bytes.NewBufferString(`{"rules": [{"type": "bogus", "points": 1}]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"competition": "EPL"})
+
+		handler.UpdateScoringRules(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+}
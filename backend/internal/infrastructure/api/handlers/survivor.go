@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type SurvivorHandler struct {
+	eventStore EventStore
+	now        func() time.Time
+}
+
+func NewSurvivorHandler(eventStore EventStore) *SurvivorHandler {
+	return &SurvivorHandler{
+		eventStore: eventStore,
+		now:        time.Now,
+	}
+}
+
+// createPoolRequest is the body accepted when opening a survivor pool
+type createPoolRequest struct {
+	Name          string `json:"name"`
+	CompetitionID string `json:"competitionId"`
+	SeasonID      string `json:"seasonId"`
+}
+
+func (r createPoolRequest) validate(errs *fieldErrors) {
+	errs.required("name", r.Name)
+	errs.required("competitionId", r.CompetitionID)
+}
+
+// CreatePool handles opening a survivor pool over a competition's matches,
+// optionally limited to one season
+func (h *SurvivorHandler) CreatePool(w http.ResponseWriter, r *http.Request) {
+	var request createPoolRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	pool := domain.NewSurvivorPool(utils.GenerateID(), request.Name, request.CompetitionID, h.now())
+	pool.SeasonID = request.SeasonID
+
+	if err := pool.Validate(); err != nil {
+		writeDomainError(w, "Invalid survivor pool", err, http.StatusBadRequest)
+		return
+	}
+
+	catalog, err := eventhandlers.LoadCompetitionCatalog(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve competitions", http.StatusInternalServerError)
+		return
+	}
+
+	competition := catalog.Competition(request.CompetitionID)
+	if competition == nil {
+		writeDomainError(w, "Invalid survivor pool", fmt.Errorf("%w %q", domain.ErrUnknownCompetition, request.CompetitionID), http.StatusBadRequest)
+		return
+	}
+	pool.CompetitionID = competition.ID
+
+	if request.SeasonID != "" {
+		season := catalog.Season(request.SeasonID)
+		if season == nil || season.CompetitionID != competition.ID {
+			writeDomainError(w, "Invalid survivor pool", fmt.Errorf("%w: %q is not a season of %s", domain.ErrSeasonNotInCompetition, request.SeasonID, competition.Name), http.StatusBadRequest)
+			return
+		}
+	}
+
+	event := events.NewEvent("SurvivorPoolCreated", events.SurvivorPoolCreated{
+		ID:            pool.ID,
+		Name:          pool.Name,
+		CompetitionID: pool.CompetitionID,
+		SeasonID:      pool.SeasonID,
+		CreatedAt:     pool.CreatedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create survivor pool", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(pool); err != nil {
+		fmt.Printf("error encoding survivor pool: %v\n", err)
+	}
+}
+
+// ListPools retrieves all survivor pools, optionally filtered by competition
+// and season ID
+func (h *SurvivorHandler) ListPools(w http.ResponseWriter, r *http.Request) {
+	pools, err := eventhandlers.LoadSurvivorPools(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve survivor pools", http.StatusInternalServerError)
+		return
+	}
+
+	query := r.URL.Query()
+	competitionID := query.Get("competitionId")
+	seasonID := query.Get("seasonId")
+
+	filtered := make([]*domain.SurvivorPool, 0, len(pools))
+	for _, pool := range pools {
+		if competitionID != "" && pool.CompetitionID != competitionID {
+			continue
+		}
+		if seasonID != "" && pool.SeasonID != seasonID {
+			continue
+		}
+		filtered = append(filtered, pool)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(filtered); err != nil {
+		fmt.Printf("error encoding survivor pools: %v\n", err)
+	}
+}
+
+// GetPool retrieves a survivor pool by ID
+func (h *SurvivorHandler) GetPool(w http.ResponseWriter, r *http.Request) {
+	pool, ok := h.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(pool); err != nil {
+		fmt.Printf("error encoding survivor pool: %v\n", err)
+	}
+}
+
+// survivorPickRequest is the body accepted when a player makes their pick
+type survivorPickRequest struct {
+	UserID  string `json:"userId"`
+	MatchID string `json:"matchId"`
+	Team    string `json:"team"`
+}
+
+func (r survivorPickRequest) validate(errs *fieldErrors) {
+	errs.required("userId", r.UserID)
+	errs.required("matchId", r.MatchID)
+	errs.required("team", r.Team)
+}
+
+// MakePick handles a player picking a team to win one of the pool's matches.
+// Players get one pick per round, which they can change until both the old
+// and new picks' matches lock at kickoff. A team can only be picked once, and
+// eliminated players can't pick. The team is given by ID, name, short code
+// or alias.
+func (h *SurvivorHandler) MakePick(w http.ResponseWriter, r *http.Request) {
+	var request survivorPickRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	pool, ok := h.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	match, ok := h.findMatch(w, r, request.MatchID)
+	if !ok {
+		return
+	}
+
+	if !pool.Covers(match) {
+		writeDomainError(w, "Invalid survivor pick", fmt.Errorf("%w %s", domain.ErrMatchNotInPool, pool.Name), http.StatusBadRequest)
+		return
+	}
+
+	if match.IsLocked(h.now(), 0) {
+		writeDomainError(w, "Invalid survivor pick", fmt.Errorf("%w at %s", domain.ErrSurvivorPickLocked, match.LockTime(0).Format(time.RFC3339)), http.StatusBadRequest)
+		return
+	}
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	team, ok := resolveTeam(w, registry, "team", "", request.Team)
+	if !ok {
+		return
+	}
+
+	entries, err := eventhandlers.LoadSurvivorEntries(r.Context(), h.eventStore, pool.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve survivor entries", http.StatusInternalServerError)
+		return
+	}
+
+	entry := eventhandlers.FindSurvivorEntry(entries, request.UserID)
+	if entry == nil {
+		entry = domain.NewSurvivorEntry(pool.ID, request.UserID)
+	}
+
+	if err := entry.ValidatePick(match, team.ID); err != nil {
+		status := http.StatusConflict
+		if err == domain.ErrTeamNotInMatch {
+			status = http.StatusBadRequest
+		}
+		writeDomainError(w, "Invalid survivor pick", err, status)
+		return
+	}
+
+	status := http.StatusCreated
+	pick := &domain.SurvivorPick{
+		ID:       utils.GenerateID(),
+		RoundID:  match.RoundKey(),
+		MatchID:  match.ID,
+		TeamID:   team.ID,
+		PickedAt: h.now(),
+		Outcome:  domain.SurvivorOutcomePending,
+	}
+	if existing := entry.PickFor(pick.RoundID); existing != nil {
+		if !h.checkPickOpen(w, r, existing) {
+			return
+		}
+		pick.ID = existing.ID
+		status = http.StatusOK
+	}
+
+	event := events.NewEvent("SurvivorPickMade", events.SurvivorPickMade{
+		ID:       pick.ID,
+		PoolID:   pool.ID,
+		UserID:   request.UserID,
+		RoundID:  pick.RoundID,
+		MatchID:  pick.MatchID,
+		TeamID:   pick.TeamID,
+		PickedAt: pick.PickedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to save survivor pick", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(pick); err != nil {
+		fmt.Printf("error encoding survivor pick: %v\n", err)
+	}
+}
+
+// ListEntries retrieves every player's picks and status in a survivor pool,
+// or with ?leagueId= only those of that league's members
+func (h *SurvivorHandler) ListEntries(w http.ResponseWriter, r *http.Request) {
+	entries, ok := h.entries(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		fmt.Printf("error encoding survivor entries: %v\n", err)
+	}
+}
+
+// ListSurvivors retrieves the entries of the players still alive in a
+// survivor pool, optionally limited to a league's members with ?leagueId=
+func (h *SurvivorHandler) ListSurvivors(w http.ResponseWriter, r *http.Request) {
+	entries, ok := h.entries(w, r)
+	if !ok {
+		return
+	}
+
+	survivors := make([]*domain.SurvivorEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Alive {
+			survivors = append(survivors, entry)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(survivors); err != nil {
+		fmt.Printf("error encoding survivors: %v\n", err)
+	}
+}
+
+// GetUserEntry retrieves a player's entry in a survivor pool
+func (h *SurvivorHandler) GetUserEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	pool, ok := h.findPool(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := eventhandlers.LoadSurvivorEntries(r.Context(), h.eventStore, pool.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve survivor entries", http.StatusInternalServerError)
+		return
+	}
+
+	entry := eventhandlers.FindSurvivorEntry(entries, userID)
+	if entry == nil {
+		writeError(w, "Survivor entry not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		fmt.Printf("error encoding survivor entry: %v\n", err)
+	}
+}
+
+// entries writes an error response and returns false if the pool's entries
+// can't be loaded; otherwise it returns them, filtered to the members of any
+// league named with ?leagueId=
+func (h *SurvivorHandler) entries(w http.ResponseWriter, r *http.Request) ([]*domain.SurvivorEntry, bool) {
+	pool, ok := h.findPool(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	members, ok := leagueMembers(w, r, h.eventStore)
+	if !ok {
+		return nil, false
+	}
+
+	entries, err := eventhandlers.LoadSurvivorEntries(r.Context(), h.eventStore, pool.ID)
+	if err != nil {
+		writeError(w, "Failed to retrieve survivor entries", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if members != nil {
+		filtered := make([]*domain.SurvivorEntry, 0, len(entries))
+		for _, entry := range entries {
+			if members[entry.UserID] {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	return entries, true
+}
+
+// findPool writes an error response and returns false if the pool named in
+// the URL does not exist
+func (h *SurvivorHandler) findPool(w http.ResponseWriter, r *http.Request) (*domain.SurvivorPool, bool) {
+	vars := mux.Vars(r)
+	poolID := vars["id"]
+
+	pools, err := eventhandlers.LoadSurvivorPools(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve survivor pools", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	pool := eventhandlers.FindSurvivorPool(pools, poolID)
+	if pool == nil {
+		writeError(w, "Survivor pool not found", http.StatusNotFound)
+		return nil, false
+	}
+	return pool, true
+}
+
+// findMatch writes an error response and returns false if the match does
+// not exist
+func (h *SurvivorHandler) findMatch(w http.ResponseWriter, r *http.Request, matchID string) (*domain.Match, bool) {
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), matchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if len(matchEvents) == 0 {
+		writeError(w, "Match not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	match, err := eventhandlers.ReplayMatch(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return nil, false
+	}
+	return match, true
+}
+
+// checkPickOpen writes an error response and returns false if the player's
+// existing pick for the round can no longer be changed because its match has
+// kicked off
+func (h *SurvivorHandler) checkPickOpen(w http.ResponseWriter, r *http.Request, pick *domain.SurvivorPick) bool {
+	match, ok := h.findMatch(w, r, pick.MatchID)
+	if !ok {
+		return false
+	}
+
+	if pick.IsSettled() || match.IsLocked(h.now(), 0) {
+		writeDomainError(w, "Invalid survivor pick", domain.ErrSurvivorPickLocked, http.StatusConflict)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// survivorRound is the round key of the matches from testMatchEvents, which
+// have no round of their own
+var survivorRound = (&domain.Match{CompetitionID: "comp_epl", Date: bracketLockAt.Add(time.Hour)}).RoundKey()
+
+// testSurvivorPoolEvents returns the events opening a Premier League survivor pool
+func testSurvivorPoolEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:        "event-sp1",
+			Type:      "SurvivorPoolCreated",
+			Data:      events.SurvivorPoolCreated{ID: "pool1", Name: "Last one standing", CompetitionID: "comp_epl"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}
+}
+
+// expectSurvivorPools sets up the event store to return the given pools
+func expectSurvivorPools(mockStore *mocks.MockEventStore, ctx context.Context, poolEvents []*events.Event) {
+	mockStore.On("GetEventsByType", ctx, "SurvivorPoolCreated").Return(poolEvents, nil)
+}
+
+// expectSurvivorEntries sets up the event store to return the given picks
+// and outcomes
+func expectSurvivorEntries(mockStore *mocks.MockEventStore, ctx context.Context, pickEvents, settledEvents []*events.Event) {
+	mockStore.On("GetEventsByType", ctx, "SurvivorPickMade").Return(pickEvents, nil)
+	mockStore.On("GetEventsByType", ctx, "SurvivorPickSettled").Return(settledEvents, nil)
+}
+
+// testPickEvent returns a player's survivor pick in the test pool
+func testPickEvent(pickID, userID, roundID, matchID, teamID string) *events.Event {
+	return &events.Event{
+		ID:        "event-" + pickID,
+		Type:      "SurvivorPickMade",
+		Data:      events.SurvivorPickMade{ID: pickID, PoolID: "pool1", UserID: userID, RoundID: roundID, MatchID: matchID, TeamID: teamID},
+		Timestamp: time.Now(),
+		Version:   1,
+	}
+}
+
+// testSettledEvent returns the outcome of a player's survivor pick
+func testSettledEvent(pickID, userID string, outcome domain.SurvivorOutcome) *events.Event {
+	return &events.Event{
+		ID:        "event-" + pickID + "-settled",
+		Type:      "SurvivorPickSettled",
+		Data:      events.SurvivorPickSettled{PoolID: "pool1", PickID: pickID, UserID: userID, Outcome: string(outcome)},
+		Timestamp: time.Now().Add(time.Second),
+		Version:   1,
+	}
+}
+
+func TestCreateSurvivorPool(t *testing.T) {
+	// Test case 1: Valid pool
+	t.Run("Valid pool", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewSurvivorHandler(mockStore)
+
+		body := `{"name": "Last one standing", "competitionId": "EPL", "seasonId": "season_epl_2025"}`
+		req := httptest.NewRequest("POST", "/api/survivor-pools", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.SurvivorPoolCreated)
+			return ok && event.Type == "SurvivorPoolCreated" &&
+				created.CompetitionID == "comp_epl" &&
+				created.SeasonID == "season_epl_2025"
+		})).Return(nil)
+
+		handler.CreatePool(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown competition
+	t.Run("Unknown competition", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewSurvivorHandler(mockStore)
+
+		body := `{"name": "Last one standing", "competitionId": "comp_serie_a"}`
+		req := httptest.NewRequest("POST", "/api/survivor-pools", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectCatalog(mockStore, req.Context(), testCatalogEvents())
+
+		handler.CreatePool(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "UNKNOWN_COMPETITION" {
+			t.Errorf("expected code %q, got %q", "UNKNOWN_COMPETITION", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "competitionId" {
+			t.Errorf("expected competitionId to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestMakeSurvivorPick(t *testing.T) {
+	beforeKickoff := func() time.Time { return bracketLockAt }
+
+	tests := []struct {
+		name          string
+		team          string
+		now           func() time.Time
+		pickEvents    []*events.Event
+		settledEvents []*events.Event
+		expected      int
+		code          string
+	}{
+		{"First pick", "A Team", beforeKickoff, nil, nil, http.StatusCreated, ""},
+		{
+			"Changing the round's pick",
+			"TMB", beforeKickoff,
+			[]*events.Event{testPickEvent("pick1", "user1", survivorRound, "m1", "team_a")},
+			nil,
+			http.StatusOK, "",
+		},
+		{
+			"Team used in an earlier round",
+			"team_a", beforeKickoff,
+			[]*events.Event{testPickEvent("pick0", "user1", "comp_epl/2026-W01", "m0", "team_a")},
+			[]*events.Event{testSettledEvent("pick0", "user1", domain.SurvivorOutcomeWon)},
+			http.StatusConflict, "TEAM_ALREADY_PICKED",
+		},
+		{
+			"Eliminated player",
+			"team_a", beforeKickoff,
+			[]*events.Event{testPickEvent("pick0", "user1", "comp_epl/2026-W01", "m0", "team_c")},
+			[]*events.Event{testSettledEvent("pick0", "user1", domain.SurvivorOutcomeLost)},
+			http.StatusConflict, "SURVIVOR_ELIMINATED",
+		},
+		{
+			"After kickoff",
+			"team_a", func() time.Time { return bracketLockAt.Add(2 * time.Hour) },
+			nil, nil,
+			http.StatusBadRequest, "SURVIVOR_PICK_LOCKED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := new(mocks.MockEventStore)
+			handler := NewSurvivorHandler(mockStore)
+			handler.now = tt.now
+
+			body := `{"userId": "user1", "matchId": "m1", "team": "` + tt.team + `"}`
+			req := httptest.NewRequest("POST", "/api/survivor-pools/pool1/picks", bytes.NewBufferString(body))
+			req = mux.SetURLVars(req, map[string]string{"id": "pool1"})
+			rr := httptest.NewRecorder()
+
+			expectSurvivorPools(mockStore, req.Context(), testSurvivorPoolEvents())
+			mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", nil), nil)
+			expectTeams(mockStore, req.Context())
+			expectSurvivorEntries(mockStore, req.Context(), tt.pickEvents, tt.settledEvents)
+			mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+				pick, ok := event.Data.(events.SurvivorPickMade)
+				return ok && pick.RoundID == survivorRound && pick.MatchID == "m1"
+			})).Return(nil)
+
+			handler.MakePick(rr, req)
+
+			if rr.Code != tt.expected {
+				t.Fatalf("expected status %d, got %d: %s", tt.expected, rr.Code, rr.Body.String())
+			}
+			if tt.expected >= http.StatusBadRequest {
+				if problem := decodeProblem(t, rr); problem.Code != tt.code {
+					t.Errorf("expected code %q, got %q", tt.code, problem.Code)
+				}
+				mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestListSurvivors(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewSurvivorHandler(mockStore)
+
+	req := httptest.NewRequest("GET", "/api/survivor-pools/pool1/survivors", nil)
+	req = mux.SetURLVars(req, map[string]string{"id": "pool1"})
+	rr := httptest.NewRecorder()
+
+	expectSurvivorPools(mockStore, req.Context(), testSurvivorPoolEvents())
+	expectSurvivorEntries(mockStore, req.Context(),
+		[]*events.Event{
+			testPickEvent("pick1", "user1", survivorRound, "m1", "team_a"),
+			testPickEvent("pick2", "user2", survivorRound, "m1", "team_b"),
+		},
+		[]*events.Event{
+			testSettledEvent("pick1", "user1", domain.SurvivorOutcomeWon),
+			testSettledEvent("pick2", "user2", domain.SurvivorOutcomeLost),
+		},
+	)
+
+	handler.ListSurvivors(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	var survivors []domain.SurvivorEntry
+	if err := json.NewDecoder(rr.Body).Decode(&survivors); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(survivors) != 1 || survivors[0].UserID != "user1" {
+		t.Errorf("expected only user1 to survive, got %+v", survivors)
+	}
+}
+
+func TestSurvivorElimination(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	req := httptest.NewRequest("PUT", "/api/matches/m1/status", bytes.NewBufferString(`{"status": "FINISHED"}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+	// Team B win 2-1; user1 backed Team A and user2 Team B
+	matchEvents := testMatchEvents("m1", "team_a", "team_b", &events.MatchScoreUpdated{HomeGoals: 1, AwayGoals: 2})[:2]
+
+	mockStore.On("GetEvents", req.Context(), "m1").Return(matchEvents, nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		return event.Type == "MatchStatusChanged"
+	})).Return(nil)
+	mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusScheduled}, nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.Match")).Return(nil)
+	mockStore.On("GetEventsByType", req.Context(), "ScoringRulesConfigured").Return([]*events.Event{}, nil)
+	expectLeagues(mockStore, req.Context(), nil)
+	expectCatalog(mockStore, req.Context(), testCatalogEvents())
+	mockStore.On("GetEventsByType", req.Context(), "PredictionMade").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionAmended").Return([]*events.Event{}, nil)
+	mockStore.On("GetEventsByType", req.Context(), "PredictionWithdrawn").Return([]*events.Event{}, nil)
+	expectBrackets(mockStore, req.Context(), nil)
+	expectSurvivorPools(mockStore, req.Context(), testSurvivorPoolEvents())
+	expectSurvivorEntries(mockStore, req.Context(), []*events.Event{
+		testPickEvent("pick1", "user1", survivorRound, "m1", "team_a"),
+		testPickEvent("pick2", "user2", survivorRound, "m1", "team_b"),
+	}, nil)
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		settled, ok := event.Data.(events.SurvivorPickSettled)
+		return ok && settled.PickID == "pick1" && settled.Outcome == string(domain.SurvivorOutcomeLost)
+	})).Return(nil).Once()
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		settled, ok := event.Data.(events.SurvivorPickSettled)
+		return ok && settled.PickID == "pick2" && settled.Outcome == string(domain.SurvivorOutcomeWon)
+	})).Return(nil).Once()
+	expectBadges(mockStore, req.Context())
+
+	handler.UpdateMatchStatus(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	mockStore.AssertExpectations(t)
+}
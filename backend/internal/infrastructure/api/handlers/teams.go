@@ -0,0 +1,233 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type TeamHandler struct {
+	eventStore EventStore
+	matchRepo  repository.MatchRepository
+	now        func() time.Time
+}
+
+func NewTeamHandler(eventStore EventStore, matchRepo repository.MatchRepository) *TeamHandler {
+	return &TeamHandler{
+		eventStore: eventStore,
+		matchRepo:  matchRepo,
+		now:        time.Now,
+	}
+}
+
+// teamRequest is the body accepted when registering or updating a team
+type teamRequest struct {
+	Name      string   `json:"name"`
+	ShortCode string   `json:"shortCode"`
+	Aliases   []string `json:"aliases"`
+	TimeZone  string   `json:"timeZone"`
+}
+
+func (r teamRequest) validate(errs *fieldErrors) {
+	errs.required("name", r.Name)
+	errs.timeZone("timeZone", r.TimeZone)
+}
+
+// CreateTeam handles registering a new team. Its name, short code and aliases
+// must not already belong to another team.
+func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
+	var request teamRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	team := domain.NewTeam(utils.GenerateID(), request.Name, request.ShortCode, request.Aliases)
+	team.TimeZone = request.TimeZone
+	if err := team.Validate(); err != nil {
+		writeDomainError(w, "Invalid team", err, http.StatusBadRequest)
+		return
+	}
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	if !checkTeamNamesFree(w, registry, team) {
+		return
+	}
+
+	event := events.NewEvent("TeamCreated", events.TeamCreated{
+		ID:        team.ID,
+		Name:      team.Name,
+		ShortCode: team.ShortCode,
+		Aliases:   team.Aliases,
+		TimeZone:  team.TimeZone,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to create team", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		fmt.Printf("error encoding team: %v\n", err)
+	}
+}
+
+// UpdateTeam handles changing a team's name, short code or aliases. Fields
+// missing from the request keep their current values. Existing matches keep
+// the team's ID, so they follow the change.
+func (h *TeamHandler) UpdateTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["id"]
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	existing := registry.Team(teamID)
+	if existing == nil {
+		writeError(w, "Team not found", http.StatusNotFound)
+		return
+	}
+
+	request := teamRequest{
+		Name:      existing.Name,
+		ShortCode: existing.ShortCode,
+		Aliases:   existing.Aliases,
+		TimeZone:  existing.TimeZone,
+	}
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	team := domain.NewTeam(existing.ID, request.Name, request.ShortCode, request.Aliases)
+	team.TimeZone = request.TimeZone
+	if err := team.Validate(); err != nil {
+		writeDomainError(w, "Invalid team", err, http.StatusBadRequest)
+		return
+	}
+
+	if !checkTeamNamesFree(w, registry, team) {
+		return
+	}
+
+	event := events.NewEvent("TeamUpdated", events.TeamUpdated{
+		TeamID:    team.ID,
+		Name:      team.Name,
+		ShortCode: team.ShortCode,
+		Aliases:   team.Aliases,
+		TimeZone:  team.TimeZone,
+		UpdatedAt: h.now(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update team", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		fmt.Printf("error encoding team: %v\n", err)
+	}
+}
+
+// ListTeams retrieves all registered teams
+func (h *TeamHandler) ListTeams(w http.ResponseWriter, r *http.Request) {
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(registry.Teams); err != nil {
+		fmt.Printf("error encoding teams: %v\n", err)
+	}
+}
+
+// GetTeam retrieves a team by ID, name, short code or alias
+func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["id"]
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	team := registry.Team(teamID)
+	if team == nil {
+		writeError(w, "Team not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(team); err != nil {
+		fmt.Printf("error encoding team: %v\n", err)
+	}
+}
+
+// GetTeamMatches retrieves a team's fixture list, home and away, with
+// kickoffs localised as for MatchHandler.GetMatch
+func (h *TeamHandler) GetTeamMatches(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	teamID := vars["id"]
+
+	location, ok := requestLocation(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+
+	registry, err := eventhandlers.LoadTeamRegistry(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve teams", http.StatusInternalServerError)
+		return
+	}
+
+	team := registry.Team(teamID)
+	if team == nil {
+		writeError(w, "Team not found", http.StatusNotFound)
+		return
+	}
+
+	matches, err := h.matchRepo.List(r.Context(), repository.MatchFilters{
+		TeamID: &team.ID,
+	})
+	if err != nil {
+		writeError(w, "Failed to retrieve matches", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newMatchViews(matches, location)); err != nil {
+		fmt.Printf("error encoding matches: %v\n", err)
+	}
+}
+
+// checkTeamNamesFree writes a 409 response and returns false if any of the
+// team's names already refers to a different team
+func checkTeamNamesFree(w http.ResponseWriter, registry *eventhandlers.TeamRegistry, team *domain.Team) bool {
+	for _, name := range team.Names() {
+		if existing := registry.Team(name); existing != nil && existing.ID != team.ID {
+			writeDomainError(w, "Invalid team", fmt.Errorf("%w: %q already refers to team %s", domain.ErrTeamAlreadyExists, name, existing.ID), http.StatusConflict)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// testTeamEvents returns the events registering Team A (alias "A Team", playing
+// in London) and Team B
+func testTeamEvents() []*events.Event {
+	return []*events.Event{
+		{
+			ID:        "event-t1",
+			Type:      "TeamCreated",
+			Data:      events.TeamCreated{ID: "team_a", Name: "Team A", ShortCode: "TMA", Aliases: []string{"A Team"}, TimeZone: "Europe/London"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+		{
+			ID:        "event-t2",
+			Type:      "TeamCreated",
+			Data:      events.TeamCreated{ID: "team_b", Name: "Team B", ShortCode: "TMB"},
+			Timestamp: time.Now(),
+			Version:   1,
+		},
+	}
+}
+
+// expectTeams sets up the event store to return the test team registry
+func expectTeams(mockStore *mocks.MockEventStore, ctx context.Context) {
+	mockStore.On("GetEventsByType", ctx, "TeamCreated").Return(testTeamEvents(), nil)
+	mockStore.On("GetEventsByType", ctx, "TeamUpdated").Return([]*events.Event{}, nil)
+}
+
+func TestCreateTeam(t *testing.T) {
+	// Test case 1: Valid team registration
+	t.Run("Valid team registration", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewTeamHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"name": "Manchester United", "shortCode": "mun", "aliases": ["Man United", "Man Utd"]}`
+		req := httptest.NewRequest("POST", "/api/teams", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			created, ok := event.Data.(events.TeamCreated)
+			return ok && event.Type == "TeamCreated" &&
+				created.Name == "Manchester United" &&
+				created.ShortCode == "MUN" &&
+				len(created.Aliases) == 2
+		})).Return(nil)
+
+		handler.CreateTeam(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Alias already used by another team
+	t.Run("Alias already used by another team", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewTeamHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"name": "Team Z", "shortCode": "TMZ", "aliases": ["a team"]}`
+		req := httptest.NewRequest("POST", "/api/teams", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectTeams(mockStore, req.Context())
+
+		handler.CreateTeam(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "TEAM_ALREADY_EXISTS" {
+			t.Errorf("expected code %q, got %q", "TEAM_ALREADY_EXISTS", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "name" {
+			t.Errorf("expected name to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Invalid short code
+	t.Run("Invalid short code", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewTeamHandler(mockStore, new(mocks.MockMatchRepository))
+
+		body := `{"name": "Team Z", "shortCode": "Z"}`
+		req := httptest.NewRequest("POST", "/api/teams", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.CreateTeam(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+	})
+}
+
+func TestUpdateTeam(t *testing.T) {
+	// Test case 1: Add an alias
+	t.Run("Add an alias", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewTeamHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/teams/team_b", bytes.NewBufferString(`{"aliases": ["B Team"]}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "team_b"})
+
+		expectTeams(mockStore, req.Context())
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			updated, ok := event.Data.(events.TeamUpdated)
+			return ok && updated.TeamID == "team_b" &&
+				updated.Name == "Team B" &&
+				updated.ShortCode == "TMB" &&
+				len(updated.Aliases) == 1 && updated.Aliases[0] == "B Team"
+		})).Return(nil)
+
+		handler.UpdateTeam(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+	})
+
+	// Test case 2: Rename onto another team's short code
+	t.Run("Rename onto another team's short code", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewTeamHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("PUT", "/api/teams/team_b", bytes.NewBufferString(`{"shortCode": "TMA"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "team_b"})
+
+		expectTeams(mockStore, req.Context())
+
+		handler.UpdateTeam(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		problem := decodeProblem(t, rr)
+		if problem.Code != "TEAM_ALREADY_EXISTS" {
+			t.Errorf("expected code %q, got %q", "TEAM_ALREADY_EXISTS", problem.Code)
+		}
+		if len(problem.Errors) != 1 || problem.Errors[0].Field != "name" {
+			t.Errorf("expected name to be at fault, got %v", problem.Errors)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTeamMatches(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewTeamHandler(mockStore, mockRepo)
+
+	// Test case 1: Fixture list looked up by alias
+	t.Run("Fixture list looked up by alias", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/teams/A%20Team/matches", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "A Team"})
+
+		match := domain.NewMatch("match1", "Team A", "Team B", time.Now(), "Premier League")
+		match.HomeTeamID = "team_a"
+		match.AwayTeamID = "team_b"
+
+		teamID := "team_a"
+		expectTeams(mockStore, req.Context())
+		mockRepo.On("List", req.Context(), repository.MatchFilters{TeamID: &teamID}).Return([]*domain.Match{match}, nil)
+
+		handler.GetTeamMatches(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+
+		var matches []*domain.Match
+		if err := json.NewDecoder(rr.Body).Decode(&matches); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(matches) != 1 || matches[0].HomeTeamID != "team_a" {
+			t.Errorf("expected Team A's match, got %v", matches)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown team
+	t.Run("Unknown team", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/teams/missing/matches", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "missing"})
+
+		expectTeams(mockStore, req.Context())
+
+		handler.GetTeamMatches(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
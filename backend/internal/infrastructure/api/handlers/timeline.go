@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+// goalRequest is the body accepted when recording a goal
+type goalRequest struct {
+	Side      string `json:"side"`
+	Player    string `json:"player"`
+	Minute    int    `json:"minute"`
+	AddedTime int    `json:"addedTime"`
+	OwnGoal   bool   `json:"ownGoal"`
+	Penalty   bool   `json:"penalty"`
+}
+
+func (r goalRequest) validate(errs *fieldErrors) {
+	if !domain.MatchSide(r.Side).IsValid() {
+		errs.rule("side", domain.ErrInvalidSide)
+	}
+	validateMinute(errs, r.Minute, r.AddedTime)
+	if r.OwnGoal && r.Penalty {
+		errs.rule("penalty", domain.ErrOwnGoalAndPenalty)
+	}
+}
+
+// RecordGoal handles a goal in a live match. The goal is credited to the
+// period being played and moves the live score on.
+func (h *MatchHandler) RecordGoal(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request goalRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	timeline, ok := h.findTimeline(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	goal := &domain.TimelineEntry{
+		ID:         utils.GenerateID(),
+		Type:       domain.TimelineGoal,
+		Minute:     request.Minute,
+		AddedTime:  request.AddedTime,
+		Period:     timeline.Period,
+		Side:       domain.MatchSide(request.Side),
+		Player:     strings.TrimSpace(request.Player),
+		OwnGoal:    request.OwnGoal,
+		Penalty:    request.Penalty,
+		RecordedAt: time.Now(),
+	}
+
+	if err := timeline.ValidateGoal(goal); err != nil {
+		writeDomainError(w, "Invalid goal", err, timelineErrorStatus(err))
+		return
+	}
+
+	event := events.NewEvent("GoalScored", events.GoalScored{
+		ID:        goal.ID,
+		MatchID:   matchID,
+		Side:      string(goal.Side),
+		Player:    goal.Player,
+		Minute:    goal.Minute,
+		AddedTime: goal.AddedTime,
+		Period:    string(goal.Period),
+		OwnGoal:   goal.OwnGoal,
+		Penalty:   goal.Penalty,
+		ScoredAt:  goal.RecordedAt,
+	})
+
+	h.saveTimelineEntry(w, r, event, goal, "Failed to record goal")
+}
+
+// disallowGoalRequest is the body accepted when ruling out a goal
+type disallowGoalRequest struct {
+	Minute    int    `json:"minute"`
+	AddedTime int    `json:"addedTime"`
+	Reason    string `json:"reason"`
+}
+
+func (r disallowGoalRequest) validate(errs *fieldErrors) {
+	validateMinute(errs, r.Minute, r.AddedTime)
+}
+
+// DisallowGoal handles a goal being ruled out, e.g. after a video review. The
+// goal stays on the timeline, marked disallowed, and comes off the score.
+func (h *MatchHandler) DisallowGoal(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+	goalID := vars["goalId"]
+
+	var request disallowGoalRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	timeline, ok := h.findTimeline(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	goal, err := timeline.ValidateDisallow(goalID, request.Minute, request.AddedTime)
+	if err != nil {
+		writeDomainError(w, "Cannot disallow goal", err, timelineErrorStatus(err))
+		return
+	}
+
+	disallowed := &domain.TimelineEntry{
+		ID:         utils.GenerateID(),
+		Type:       domain.TimelineGoalDisallowed,
+		Minute:     request.Minute,
+		AddedTime:  request.AddedTime,
+		Period:     goal.Period,
+		Side:       goal.Side,
+		GoalID:     goal.ID,
+		Reason:     strings.TrimSpace(request.Reason),
+		RecordedAt: time.Now(),
+	}
+
+	event := events.NewEvent("GoalDisallowed", events.GoalDisallowed{
+		ID:           disallowed.ID,
+		MatchID:      matchID,
+		GoalID:       disallowed.GoalID,
+		Side:         string(disallowed.Side),
+		Period:       string(disallowed.Period),
+		Minute:       disallowed.Minute,
+		AddedTime:    disallowed.AddedTime,
+		Reason:       disallowed.Reason,
+		DisallowedAt: disallowed.RecordedAt,
+	})
+
+	h.saveTimelineEntry(w, r, event, disallowed, "Failed to disallow goal")
+}
+
+// cardRequest is the body accepted when showing a card
+type cardRequest struct {
+	Side      string `json:"side"`
+	Player    string `json:"player"`
+	Card      string `json:"card"`
+	Minute    int    `json:"minute"`
+	AddedTime int    `json:"addedTime"`
+}
+
+func (r cardRequest) validate(errs *fieldErrors) {
+	if !domain.MatchSide(r.Side).IsValid() {
+		errs.rule("side", domain.ErrInvalidSide)
+	}
+	if strings.TrimSpace(r.Player) == "" {
+		errs.rule("player", domain.ErrPlayerRequired)
+	}
+	if !domain.CardType(r.Card).IsValid() {
+		errs.rule("card", domain.ErrInvalidCard)
+	}
+	validateMinute(errs, r.Minute, r.AddedTime)
+}
+
+// ShowCard handles a yellow or red card shown in a live match
+func (h *MatchHandler) ShowCard(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request cardRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	timeline, ok := h.findTimeline(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	card := &domain.TimelineEntry{
+		ID:         utils.GenerateID(),
+		Type:       domain.TimelineCard,
+		Minute:     request.Minute,
+		AddedTime:  request.AddedTime,
+		Period:     timeline.Period,
+		Side:       domain.MatchSide(request.Side),
+		Player:     strings.TrimSpace(request.Player),
+		Card:       domain.CardType(request.Card),
+		RecordedAt: time.Now(),
+	}
+
+	if err := timeline.ValidateCard(card); err != nil {
+		writeDomainError(w, "Invalid card", err, timelineErrorStatus(err))
+		return
+	}
+
+	event := events.NewEvent("CardShown", events.CardShown{
+		ID:        card.ID,
+		MatchID:   matchID,
+		Side:      string(card.Side),
+		Player:    card.Player,
+		Card:      string(card.Card),
+		Minute:    card.Minute,
+		AddedTime: card.AddedTime,
+		Period:    string(card.Period),
+		ShownAt:   card.RecordedAt,
+	})
+
+	h.saveTimelineEntry(w, r, event, card, "Failed to record card")
+}
+
+// periodRequest is the body accepted when a match moves into a new period
+type periodRequest struct {
+	Period string `json:"period"`
+	Minute int    `json:"minute"`
+}
+
+func (r periodRequest) validate(errs *fieldErrors) {
+	if !domain.MatchPeriod(r.Period).IsValid() {
+		errs.rule("period", domain.ErrInvalidPeriod)
+	}
+	validateMinute(errs, r.Minute, 0)
+}
+
+// ChangePeriod handles a live match moving into a new period: kickoff, half
+// time, the second half, extra time, a shootout or full time. Full time does
+// not finish the match; its status is still changed separately.
+func (h *MatchHandler) ChangePeriod(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	var request periodRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	timeline, ok := h.findTimeline(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	period := domain.MatchPeriod(request.Period)
+	if err := timeline.ValidatePeriod(period, request.Minute); err != nil {
+		writeDomainError(w, fmt.Sprintf("Cannot change period from %q to %s", timeline.Period, period), err, timelineErrorStatus(err))
+		return
+	}
+
+	entry := &domain.TimelineEntry{
+		ID:         utils.GenerateID(),
+		Type:       domain.TimelinePeriod,
+		Minute:     request.Minute,
+		Period:     period,
+		RecordedAt: time.Now(),
+	}
+
+	event := events.NewEvent("PeriodChanged", events.PeriodChanged{
+		ID:        entry.ID,
+		MatchID:   matchID,
+		Period:    string(entry.Period),
+		Minute:    entry.Minute,
+		ChangedAt: entry.RecordedAt,
+	})
+
+	h.saveTimelineEntry(w, r, event, entry, "Failed to change match period")
+}
+
+// GetTimeline retrieves a match's in-play events in the order they were
+// recorded, with its current period and live score
+func (h *MatchHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	matchID := vars["id"]
+
+	timeline, ok := h.findTimeline(w, r, matchID)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(timeline); err != nil {
+		fmt.Printf("error encoding timeline: %v\n", err)
+	}
+}
+
+// findTimeline writes an error response and returns false if the match does
+// not exist; otherwise it returns the match's timeline replayed from its events
+func (h *MatchHandler) findTimeline(w http.ResponseWriter, r *http.Request, matchID string) (*domain.MatchTimeline, bool) {
+	matchEvents, err := h.eventStore.GetEvents(r.Context(), matchID)
+	if err != nil {
+		writeError(w, "Failed to retrieve match", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if len(matchEvents) == 0 {
+		writeError(w, "Match not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	timeline, err := eventhandlers.ReplayTimeline(matchEvents)
+	if err != nil {
+		writeError(w, "Failed to process match data", http.StatusInternalServerError)
+		return nil, false
+	}
+	return timeline, true
+}
+
+// saveTimelineEntry saves an in-play event, updates the live score in the
+// read model and responds with the new timeline entry
+func (h *MatchHandler) saveTimelineEntry(w http.ResponseWriter, r *http.Request, event *events.Event, entry *domain.TimelineEntry, failure string) {
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, failure, http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process %s event: %v\n", event.Type, err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		fmt.Printf("error encoding timeline entry: %v\n", err)
+	}
+}
+
+// validateMinute records the minute of an in-play event if it is outside the
+// match, or its added time if that is negative
+func validateMinute(errs *fieldErrors, minute, addedTime int) {
+	if minute < 0 || minute > domain.MaxMatchMinute {
+		errs.rule("minute", domain.ErrInvalidMinute)
+	}
+	errs.nonNegative("addedTime", addedTime)
+}
+
+// timelineErrorStatus returns the HTTP status for an in-play event the
+// match's state rules out, as opposed to a malformed one
+func timelineErrorStatus(err error) int {
+	switch err {
+	case domain.ErrGoalNotFound:
+		return http.StatusNotFound
+	case domain.ErrMatchNotLive, domain.ErrBallNotInPlay, domain.ErrInvalidPeriodTransition,
+		domain.ErrGoalAlreadyDisallowed, domain.ErrMinuteBeforeLatestPeriod:
+		return http.StatusConflict
+	}
+	return http.StatusBadRequest
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// liveMatchEvents returns the history of a live match between team_a and
+// team_b that kicked off, followed by the given in-play events
+func liveMatchEvents(matchID string, inPlay ...interface{}) []*events.Event {
+	matchEvents := append(testMatchEvents(matchID, "team_a", "team_b", nil),
+		&events.Event{ID: "event-" + matchID + "-live", Type: "MatchStatusChanged", Data: events.MatchStatusChanged{MatchID: matchID, Status: "LIVE"}, Timestamp: time.Now(), Version: 1},
+		&events.Event{ID: "event-" + matchID + "-kickoff", Type: "PeriodChanged", Data: events.PeriodChanged{ID: "kickoff", MatchID: matchID, Period: "FIRST_HALF"}, Timestamp: time.Now(), Version: 1},
+	)
+	for _, data := range inPlay {
+		eventType := ""
+		switch data.(type) {
+		case events.GoalScored:
+			eventType = "GoalScored"
+		case events.GoalDisallowed:
+			eventType = "GoalDisallowed"
+		case events.CardShown:
+			eventType = "CardShown"
+		case events.PeriodChanged:
+			eventType = "PeriodChanged"
+		}
+		matchEvents = append(matchEvents, &events.Event{ID: "event-" + eventType, Type: eventType, Data: data, Timestamp: time.Now(), Version: 1})
+	}
+	return matchEvents
+}
+
+func TestRecordGoal(t *testing.T) {
+	// Test case 1: Goal in a live match
+	t.Run("Goal in a live match", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		body := `{"side": "AWAY", "player": "Jones", "minute": 45, "addedTime": 2, "penalty": true}`
+		req := httptest.NewRequest("POST", "/api/matches/m1/goals", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1"), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			goal, ok := event.Data.(events.GoalScored)
+			return ok && goal.MatchID == "m1" && goal.Side == "AWAY" && goal.Player == "Jones" &&
+				goal.Period == "FIRST_HALF" && goal.AddedTime == 2 && goal.Penalty
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusLive, Score: &domain.Score{}}, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.Score.HomeGoals == 0 && match.Score.AwayGoals == 1
+		})).Return(nil)
+
+		handler.RecordGoal(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		var goal domain.TimelineEntry
+		if err := json.NewDecoder(rr.Body).Decode(&goal); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if goal.ID == "" || goal.Type != domain.TimelineGoal || goal.Period != domain.MatchPeriodFirstHalf {
+			t.Errorf("expected a first-half goal with an ID, got %+v", goal)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Match not live
+	t.Run("Match not live", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/goals", bytes.NewBufferString(`{"side": "HOME", "minute": 10}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(testMatchEvents("m1", "team_a", "team_b", nil), nil)
+
+		handler.RecordGoal(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Unknown side
+	t.Run("Unknown side", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/goals", bytes.NewBufferString(`{"side": "team_a", "minute": 10}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1"), nil)
+
+		handler.RecordGoal(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDisallowGoal(t *testing.T) {
+	goal := events.GoalScored{ID: "g1", MatchID: "m1", Side: "HOME", Player: "Smith", Minute: 12, Period: "FIRST_HALF"}
+
+	// Test case 1: Ruling out a goal
+	t.Run("Ruling out a goal", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockMatchRepository)
+		handler := NewMatchHandler(mockStore, mockRepo)
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/goals/g1/disallow", bytes.NewBufferString(`{"minute": 14, "reason": "Offside"}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1", "goalId": "g1"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1", goal), nil)
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			disallowed, ok := event.Data.(events.GoalDisallowed)
+			return ok && disallowed.GoalID == "g1" && disallowed.Side == "HOME" &&
+				disallowed.Period == "FIRST_HALF" && disallowed.Reason == "Offside"
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "m1").Return(&domain.Match{ID: "m1", Status: domain.MatchStatusLive, Score: &domain.Score{HomeGoals: 1}}, nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(match *domain.Match) bool {
+			return match.Score.HomeGoals == 0
+		})).Return(nil)
+
+		handler.DisallowGoal(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Errorf("expected status %d, got %d: %s", http.StatusCreated, rr.Code, rr.Body.String())
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown goal
+	t.Run("Unknown goal", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("POST", "/api/matches/m1/goals/g2/disallow", bytes.NewBufferString(`{"minute": 14}`))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"id": "m1", "goalId": "g2"})
+
+		mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1", goal), nil)
+
+		handler.DisallowGoal(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangePeriod(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockMatchRepository)
+	handler := NewMatchHandler(mockStore, mockRepo)
+
+	// Skipping half time is rejected
+	req := httptest.NewRequest("PUT", "/api/matches/m1/period", bytes.NewBufferString(`{"period": "SECOND_HALF", "minute": 45}`))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+	mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1"), nil)
+
+	handler.ChangePeriod(rr, req)
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+	}
+	mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+}
+
+func TestGetTimeline(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	handler := NewMatchHandler(mockStore, new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("GET", "/api/matches/m1/timeline", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"id": "m1"})
+
+	mockStore.On("GetEvents", req.Context(), "m1").Return(liveMatchEvents("m1",
+		events.GoalScored{ID: "g1", MatchID: "m1", Side: "HOME", Player: "Smith", Minute: 12, Period: "FIRST_HALF"},
+		events.CardShown{ID: "c1", MatchID: "m1", Side: "AWAY", Player: "Jones", Card: "YELLOW", Minute: 20, Period: "FIRST_HALF"},
+		events.GoalScored{ID: "g2", MatchID: "m1", Side: "AWAY", Player: "Smith", Minute: 30, Period: "FIRST_HALF", OwnGoal: true},
+		events.GoalDisallowed{ID: "d1", MatchID: "m1", GoalID: "g1", Side: "HOME", Period: "FIRST_HALF", Minute: 13, Reason: "Handball"},
+		events.PeriodChanged{ID: "ht", MatchID: "m1", Period: "HALF_TIME", Minute: 45},
+	), nil)
+
+	handler.GetTimeline(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+
+	var timeline domain.MatchTimeline
+	if err := json.NewDecoder(rr.Body).Decode(&timeline); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if timeline.Status != domain.MatchStatusLive || timeline.Period != domain.MatchPeriodHalfTime {
+		t.Errorf("expected a live match at half time, got %s in %s", timeline.Status, timeline.Period)
+	}
+	if timeline.Score == nil || timeline.Score.HomeGoals != 0 || timeline.Score.AwayGoals != 1 {
+		t.Errorf("expected the own goal to stand and the disallowed goal to be off, got %+v", timeline.Score)
+	}
+	if len(timeline.Entries) != 6 || !timeline.Entries[1].Disallowed {
+		t.Errorf("expected 6 entries with the first goal disallowed, got %+v", timeline.Entries)
+	}
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+type UserHandler struct {
+	eventStore   EventStore
+	userRepo     repository.UserRepository
+	eventHandler *eventhandlers.UserEventHandler
+	leaderboard  *LeaderboardHandler
+	now          func() time.Time
+}
+
+func NewUserHandler(eventStore EventStore, userRepo repository.UserRepository, matchRepo repository.MatchRepository) *UserHandler {
+	return &UserHandler{
+		eventStore:   eventStore,
+		userRepo:     userRepo,
+		eventHandler: eventhandlers.NewUserEventHandler(userRepo),
+		leaderboard:  NewLeaderboardHandler(eventStore, matchRepo),
+		now:          time.Now,
+	}
+}
+
+// registerUserRequest is the body accepted when a user registers
+type registerUserRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (r registerUserRequest) validate(errs *fieldErrors) {
+	errs.required("username", r.Username)
+	errs.required("email", r.Email)
+}
+
+// RegisterUser handles a new user signing up. Usernames and emails are
+// unique, ignoring case.
+func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
+	var request registerUserRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	user := domain.NewUser(utils.GenerateID(), request.Username, request.Email)
+	user.JoinDate = h.now().UTC()
+	if err := user.Validate(); err != nil {
+		writeDomainError(w, "Invalid user", err, http.StatusBadRequest)
+		return
+	}
+
+	users, err := eventhandlers.LoadUsers(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve users", http.StatusInternalServerError)
+		return
+	}
+	if err := domain.CheckUserUnique(users, user); err != nil {
+		writeDomainError(w, "Cannot register user", err, http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("UserRegistered", events.UserRegistered{
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		RegisteredAt: user.JoinDate,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to register user", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for user registration: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		fmt.Printf("error encoding user: %v\n", err)
+	}
+}
+
+// GetUser retrieves a user's profile with their points, tip counts and rank
+// on the overall leaderboard. The same ?leagueId=, ?competitionId= and
+// ?seasonId= as GetLeaderboard give their standing on that leaderboard instead.
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	user, err := h.userRepo.GetByID(r.Context(), userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, "User not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeError(w, "Failed to retrieve user", http.StatusInternalServerError)
+		return
+	}
+
+	league, ok := requestLeague(w, r, h.eventStore)
+	if !ok {
+		return
+	}
+	leaderboard, ok := h.leaderboard.leaderboard(w, r, league)
+	if !ok {
+		return
+	}
+
+	user.UpdateFromLeaderboard(leaderboard)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		fmt.Printf("error encoding user: %v\n", err)
+	}
+}
+
+// updateProfileRequest is the body accepted when a user changes their
+// profile
+type updateProfileRequest struct {
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+func (r updateProfileRequest) validate(errs *fieldErrors) {
+	errs.required("username", r.Username)
+	errs.required("email", r.Email)
+}
+
+// UpdateProfile handles a user changing their username or email. Fields
+// missing from the request keep their current values; deactivated users
+// cannot change their profile.
+func (h *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	users, err := eventhandlers.LoadUsers(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve users", http.StatusInternalServerError)
+		return
+	}
+
+	user := eventhandlers.FindUser(users, userID)
+	if user == nil {
+		writeError(w, "User not found", http.StatusNotFound)
+		return
+	}
+	if !user.IsActive() {
+		writeDomainError(w, "Cannot update profile", domain.ErrUserDeactivated, http.StatusForbidden)
+		return
+	}
+
+	request := updateProfileRequest{
+		Username: user.Username,
+		Email:    user.Email,
+	}
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	updated := domain.NewUser(user.ID, request.Username, request.Email)
+	if err := updated.Validate(); err != nil {
+		writeDomainError(w, "Invalid user", err, http.StatusBadRequest)
+		return
+	}
+	if err := domain.CheckUserUnique(users, updated); err != nil {
+		writeDomainError(w, "Cannot update profile", err, http.StatusConflict)
+		return
+	}
+	user.Username = updated.Username
+	user.Email = updated.Email
+
+	event := events.NewEvent("UserProfileUpdated", events.UserProfileUpdated{
+		UserID:    user.ID,
+		Username:  user.Username,
+		Email:     user.Email,
+		UpdatedAt: h.now().UTC(),
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update profile", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for profile update: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		fmt.Printf("error encoding user: %v\n", err)
+	}
+}
+
+// deactivateUserRequest is the body accepted when a user is deactivated
+type deactivateUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// DeactivateUser handles closing a user's account. The user keeps their
+// history and username but can no longer tip. The body, with its reason, is
+// optional.
+func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	var request deactivateUserRequest
+	if !decodeOptionalRequest(w, r, &request) {
+		return
+	}
+
+	users, err := eventhandlers.LoadUsers(r.Context(), h.eventStore)
+	if err != nil {
+		writeError(w, "Failed to retrieve users", http.StatusInternalServerError)
+		return
+	}
+
+	user := eventhandlers.FindUser(users, userID)
+	if user == nil {
+		writeError(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	deactivatedAt := h.now().UTC()
+	if err := user.Deactivate(deactivatedAt); err != nil {
+		writeDomainError(w, "Cannot deactivate user", err, http.StatusConflict)
+		return
+	}
+
+	event := events.NewEvent("UserDeactivated", events.UserDeactivated{
+		UserID:        user.ID,
+		Reason:        request.Reason,
+		DeactivatedAt: deactivatedAt,
+	})
+
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to deactivate user", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for user deactivation: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(user); err != nil {
+		fmt.Printf("error encoding user: %v\n", err)
+	}
+}
+
+// GetPreferences retrieves a user's display preferences
+func (h *UserHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	preferences, err := eventhandlers.LoadUserPreferences(r.Context(), h.eventStore, userID)
+	if err != nil {
+		writeError(w, "Failed to retrieve preferences", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(preferences); err != nil {
+		fmt.Printf("error encoding preferences: %v\n", err)
+	}
+}
+
+// preferencesRequest is the body accepted when a user changes their
+// preferences
+type preferencesRequest struct {
+	TimeZone string `json:"timeZone"`
+}
+
+func (r preferencesRequest) validate(errs *fieldErrors) {
+	errs.required("timeZone", r.TimeZone)
+	errs.timeZone("timeZone", r.TimeZone)
+}
+
+// UpdatePreferences handles a user choosing the time zone kickoffs are shown
+// in
+func (h *UserHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+
+	var request preferencesRequest
+	if !decodeRequest(w, r, &request) {
+		return
+	}
+
+	updatedAt := h.now().UTC()
+	preferences := domain.NewUserPreferences(userID)
+	preferences.TimeZone = request.TimeZone
+	preferences.UpdatedAt = &updatedAt
+
+	event := events.NewEvent("UserPreferencesUpdated", events.UserPreferencesUpdated{
+		UserID:    userID,
+		TimeZone:  preferences.TimeZone,
+		UpdatedAt: updatedAt,
+	})
+	if err := h.eventStore.SaveEvent(r.Context(), event); err != nil {
+		writeError(w, "Failed to update preferences", http.StatusInternalServerError)
+		return
+	}
+
+	// Process event to update read model
+	if err := h.eventHandler.HandleEvent(r.Context(), event); err != nil {
+		fmt.Printf("Failed to process event for preferences update: %v\n", err)
+		// Continue anyway since the event is saved
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(preferences); err != nil {
+		fmt.Printf("error encoding preferences: %v\n", err)
+	}
+}
+
+// requestLocation returns the time zone a request wants kickoffs shown in:
+// the timeZone query parameter, else the preferred time zone of the userId
+// query parameter, else UTC. It writes the error response and returns false
+// if the zone cannot be worked out.
+func requestLocation(w http.ResponseWriter, r *http.Request, eventStore EventStore) (*time.Location, bool) {
+	query := r.URL.Query()
+	if name := query.Get("timeZone"); name != "" {
+		location, err := domain.LoadTimeZone(name)
+		if err != nil {
+			var errs fieldErrors
+			errs.rule("timeZone", err)
+			writeFieldErrors(w, errs)
+			return nil, false
+		}
+		return location, true
+	}
+
+	userID := query.Get("userId")
+	if userID == "" {
+		return time.UTC, true
+	}
+	preferences, err := eventhandlers.LoadUserPreferences(r.Context(), eventStore, userID)
+	if err != nil {
+		writeError(w, "Failed to retrieve preferences", http.StatusInternalServerError)
+		return nil, false
+	}
+	return preferences.Location(), true
+}
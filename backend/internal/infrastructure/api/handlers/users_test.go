@@ -0,0 +1,509 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers/mocks"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// expectUsers sets up the event store with the given users registered, for
+// commands that check who is tipping
+func expectUsers(mockStore *mocks.MockEventStore, ctx context.Context, userIDs ...string) {
+	registered := make([]*events.Event, 0, len(userIDs))
+	for _, userID := range userIDs {
+		registered = append(registered, events.NewEvent("UserRegistered", events.UserRegistered{
+			ID:           userID,
+			Username:     userID,
+			Email:        userID + "@example.com",
+			RegisteredAt: time.Now().Add(-time.Hour),
+		}))
+	}
+	mockStore.On("GetEventsByType", ctx, "UserRegistered").Return(registered, nil).Maybe()
+	mockStore.On("GetEventsByType", ctx, "UserProfileUpdated").Return([]*events.Event{}, nil).Maybe()
+	mockStore.On("GetEventsByType", ctx, "UserDeactivated").Return([]*events.Event{}, nil).Maybe()
+}
+
+func TestGetPreferences(t *testing.T) {
+	// Test case 1: Preferences never set
+	t.Run("Preferences never set", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1/preferences", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		mockStore.On("GetEventsByType", req.Context(), "UserPreferencesUpdated").Return([]*events.Event{}, nil)
+
+		handler.GetPreferences(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var preferences domain.UserPreferences
+		if err := json.NewDecoder(rr.Body).Decode(&preferences); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if preferences.TimeZone != "UTC" {
+			t.Errorf("expected time zone UTC, got %q", preferences.TimeZone)
+		}
+	})
+
+	// Test case 2: Latest preferences win
+	t.Run("Latest preferences win", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1/preferences", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		mockStore.On("GetEventsByType", req.Context(), "UserPreferencesUpdated").Return([]*events.Event{
+			events.NewEvent("UserPreferencesUpdated", events.UserPreferencesUpdated{UserID: "user1", TimeZone: "Europe/London"}),
+			events.NewEvent("UserPreferencesUpdated", events.UserPreferencesUpdated{UserID: "user2", TimeZone: "Asia/Tokyo"}),
+			events.NewEvent("UserPreferencesUpdated", events.UserPreferencesUpdated{UserID: "user1", TimeZone: "Australia/Perth"}),
+		}, nil)
+
+		handler.GetPreferences(rr, req)
+
+		var preferences domain.UserPreferences
+		if err := json.NewDecoder(rr.Body).Decode(&preferences); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if preferences.TimeZone != "Australia/Perth" {
+			t.Errorf("expected time zone Australia/Perth, got %q", preferences.TimeZone)
+		}
+	})
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	now := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+
+	// Test case 1: Valid time zone
+	t.Run("Valid time zone", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+		handler.now = func() time.Time { return now }
+
+		body := `{"timeZone": "Australia/Perth"}`
+		req := httptest.NewRequest("PUT", "/api/users/user1/preferences", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			updated, ok := event.Data.(events.UserPreferencesUpdated)
+			return ok && event.Type == "UserPreferencesUpdated" &&
+				updated.UserID == "user1" && updated.TimeZone == "Australia/Perth" && updated.UpdatedAt.Equal(now)
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "user1").Return(domain.NewUser("user1", "alice", "alice@example.com"), nil)
+		mockRepo.On("Update", req.Context(), mock.MatchedBy(func(user *domain.User) bool {
+			return user.TimeZone == "Australia/Perth"
+		})).Return(nil)
+
+		handler.UpdatePreferences(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Unknown time zone
+	t.Run("Unknown time zone", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		body := `{"timeZone": "Perth"}`
+		req := httptest.NewRequest("PUT", "/api/users/user1/preferences", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		handler.UpdatePreferences(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRegisterUser(t *testing.T) {
+	now := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+
+	// Test case 1: New user
+	t.Run("New user", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+		handler.now = func() time.Time { return now }
+
+		body := `{"username": "alice", "email": "Alice@Example.com"}`
+		req := httptest.NewRequest("POST", "/api/users", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectUsers(mockStore, req.Context(), "bob")
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			registered, ok := event.Data.(events.UserRegistered)
+			return ok && event.Type == "UserRegistered" && registered.ID != "" &&
+				registered.Username == "alice" && registered.Email == "alice@example.com" && registered.RegisteredAt.Equal(now)
+		})).Return(nil)
+		mockRepo.On("Create", req.Context(), mock.AnythingOfType("*domain.User")).Return(nil)
+
+		handler.RegisterUser(rr, req)
+
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("expected status %d, got %d", http.StatusCreated, rr.Code)
+		}
+		var user domain.User
+		if err := json.NewDecoder(rr.Body).Decode(&user); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if user.ID == "" || user.Email != "alice@example.com" {
+			t.Errorf("expected a new user with a lower case email, got %+v", user)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Username taken, ignoring case
+	t.Run("Username taken", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		body := `{"username": "BOB", "email": "robert@example.com"}`
+		req := httptest.NewRequest("POST", "/api/users", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		expectUsers(mockStore, req.Context(), "bob")
+
+		handler.RegisterUser(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		var problem Problem
+		if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if problem.Code != "USERNAME_TAKEN" {
+			t.Errorf("expected code USERNAME_TAKEN, got %q", problem.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Invalid email
+	t.Run("Invalid email", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		body := `{"username": "alice", "email": "alice"}`
+		req := httptest.NewRequest("POST", "/api/users", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+
+		handler.RegisterUser(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d, got %d", http.StatusBadRequest, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetUser(t *testing.T) {
+	// Test case 1: Registered user with points
+	t.Run("Registered user", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		mockRepo.On("GetByID", req.Context(), "user1").Return(domain.NewUser("user1", "alice", "alice@example.com"), nil)
+		mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return([]*events.Event{
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p1", UserID: "user2", MatchID: "m1", Points: 3}),
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p2", UserID: "user1", MatchID: "m1", Points: 1}),
+		}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+		mockStore.On("GetEventsByType", req.Context(), "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+
+		handler.GetUser(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		var user domain.User
+		if err := json.NewDecoder(rr.Body).Decode(&user); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if user.Stats.TotalPoints != 1 || user.Stats.TotalPredictions != 1 || user.Stats.CurrentRank != 2 {
+			t.Errorf("expected 1 point from 1 tip ranked 2nd, got %+v", user.Stats)
+		}
+	})
+
+	// Test case 2: The rank is the user's leaderboard position, ties included
+	t.Run("Rank matches the leaderboard", func(t *testing.T) {
+		awardEvents := []*events.Event{
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p1", UserID: "user1", MatchID: "m1", Points: 3, Breakdown: []events.RuleAward{{Rule: domain.RuleExactScore, Points: 3}}}),
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p2", UserID: "user2", MatchID: "m1", Points: 3}),
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p3", UserID: "user3", MatchID: "m1", Points: 3}),
+			events.NewEvent("PointsAwarded", events.PointsAwarded{PredictionID: "p4", UserID: "user4", MatchID: "m1", Points: 1}),
+		}
+		// user1 wins the tie on exact scores; user2 and user3 stay level
+		expected := map[string]int{"user1": 1, "user2": 2, "user3": 2, "user4": 4}
+
+		for userID, rank := range expected {
+			mockStore := new(mocks.MockEventStore)
+			mockRepo := new(mocks.MockUserRepository)
+			handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+			req := httptest.NewRequest("GET", "/api/users/"+userID, nil)
+			rr := httptest.NewRecorder()
+			req = mux.SetURLVars(req, map[string]string{"userId": userID})
+
+			mockRepo.On("GetByID", req.Context(), userID).Return(domain.NewUser(userID, userID, userID+"@example.com"), nil)
+			mockStore.On("GetEventsByType", req.Context(), "PointsAwarded").Return(awardEvents, nil)
+			mockStore.On("GetEventsByType", req.Context(), "BracketPointsAwarded").Return([]*events.Event{}, nil)
+			mockStore.On("GetEventsByType", req.Context(), "OutrightPointsAwarded").Return([]*events.Event{}, nil)
+
+			handler.GetUser(rr, req)
+
+			var user domain.User
+			if err := json.NewDecoder(rr.Body).Decode(&user); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if user.Stats.CurrentRank != rank {
+				t.Errorf("expected %s ranked %d, got %d", userID, rank, user.Stats.CurrentRank)
+			}
+		}
+	})
+
+	// Test case 3: Standing within a league that scores by its own rules
+	t.Run("Standing within a league", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1?leagueId=league1", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		jokers := false
+		mockRepo.On("GetByID", req.Context(), "user1").Return(domain.NewUser("user1", "alice", "alice@example.com"), nil)
+		expectLeagues(mockStore, req.Context(), append(testLeagueEvents(), &events.Event{
+			ID:        "event-l3",
+			Type:      "LeagueRulesChanged",
+			Data:      events.LeagueRulesChanged{LeagueID: "league1", Jokers: &jokers},
+			Timestamp: time.Now().Add(2 * time.Second),
+			Version:   1,
+		}))
+		// Overall user1's 3 points trail user2's 8; the league scores user1's
+		// tip at 10
+		expectAwards(mockStore, req)
+		mockStore.On("GetEventsByType", req.Context(), "LeaguePointsAwarded").Return([]*events.Event{
+			events.NewEvent("LeaguePointsAwarded", events.LeaguePointsAwarded{
+				LeagueID:      "league1",
+				PointsAwarded: events.PointsAwarded{PredictionID: "pred1", UserID: "user1", MatchID: "m1", Points: 10},
+			}),
+		}, nil)
+
+		handler.GetUser(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+		}
+		var user domain.User
+		if err := json.NewDecoder(rr.Body).Decode(&user); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if user.Stats.TotalPoints != 10 || user.Stats.CurrentRank != 1 {
+			t.Errorf("expected 10 league points ranked 1st, got %+v", user.Stats)
+		}
+	})
+
+	// Test case 4: Unknown league
+	t.Run("Unknown league", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/user1?leagueId=nope", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "user1"})
+
+		mockRepo.On("GetByID", req.Context(), "user1").Return(domain.NewUser("user1", "alice", "alice@example.com"), nil)
+		expectLeagues(mockStore, req.Context(), testLeagueEvents())
+
+		handler.GetUser(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+
+	// Test case 5: Unknown user
+	t.Run("Unknown user", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(new(mocks.MockEventStore), mockRepo, new(mocks.MockMatchRepository))
+
+		req := httptest.NewRequest("GET", "/api/users/nobody", nil)
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "nobody"})
+
+		mockRepo.On("GetByID", req.Context(), "nobody").Return(nil, repository.ErrNotFound)
+
+		handler.GetUser(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestUpdateProfile(t *testing.T) {
+	// Test case 1: New username keeps the email
+	t.Run("New username", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		mockRepo := new(mocks.MockUserRepository)
+		handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+		body := `{"username": "alice2"}`
+		req := httptest.NewRequest("PUT", "/api/users/alice", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "alice"})
+
+		expectUsers(mockStore, req.Context(), "alice", "bob")
+		mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+			updated, ok := event.Data.(events.UserProfileUpdated)
+			return ok && updated.UserID == "alice" && updated.Username == "alice2" && updated.Email == "alice@example.com"
+		})).Return(nil)
+		mockRepo.On("GetByID", req.Context(), "alice").Return(domain.NewUser("alice", "alice", "alice@example.com"), nil)
+		mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.User")).Return(nil)
+
+		handler.UpdateProfile(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Errorf("expected status %d, got %d", http.StatusOK, rr.Code)
+		}
+		mockStore.AssertExpectations(t)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// Test case 2: Email already registered to another user
+	t.Run("Email taken", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		body := `{"email": "bob@example.com"}`
+		req := httptest.NewRequest("PUT", "/api/users/alice", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "alice"})
+
+		expectUsers(mockStore, req.Context(), "alice", "bob")
+
+		handler.UpdateProfile(rr, req)
+
+		if rr.Code != http.StatusConflict {
+			t.Errorf("expected status %d, got %d", http.StatusConflict, rr.Code)
+		}
+		mockStore.AssertNotCalled(t, "SaveEvent", mock.Anything, mock.Anything)
+	})
+
+	// Test case 3: Unknown user
+	t.Run("Unknown user", func(t *testing.T) {
+		mockStore := new(mocks.MockEventStore)
+		handler := NewUserHandler(mockStore, new(mocks.MockUserRepository), new(mocks.MockMatchRepository))
+
+		body := `{"username": "nobody"}`
+		req := httptest.NewRequest("PUT", "/api/users/nobody", bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		req = mux.SetURLVars(req, map[string]string{"userId": "nobody"})
+
+		expectUsers(mockStore, req.Context(), "alice")
+
+		handler.UpdateProfile(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("expected status %d, got %d", http.StatusNotFound, rr.Code)
+		}
+	})
+}
+
+func TestDeactivateUser(t *testing.T) {
+	now := time.Date(2025, 8, 1, 9, 0, 0, 0, time.UTC)
+
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+	handler.now = func() time.Time { return now }
+
+	body := `{"reason": "left the competition"}`
+	req := httptest.NewRequest("POST", "/api/users/alice/deactivate", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"userId": "alice"})
+
+	expectUsers(mockStore, req.Context(), "alice")
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		deactivated, ok := event.Data.(events.UserDeactivated)
+		return ok && deactivated.UserID == "alice" && deactivated.Reason == "left the competition" && deactivated.DeactivatedAt.Equal(now)
+	})).Return(nil)
+	mockRepo.On("GetByID", req.Context(), "alice").Return(domain.NewUser("alice", "alice", "alice@example.com"), nil)
+	mockRepo.On("Update", req.Context(), mock.MatchedBy(func(user *domain.User) bool {
+		return user.DeactivatedAt != nil && user.DeactivatedAt.Equal(now)
+	})).Return(nil)
+
+	handler.DeactivateUser(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rr.Code)
+	}
+	var user domain.User
+	if err := json.NewDecoder(rr.Body).Decode(&user); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if user.IsActive() {
+		t.Errorf("expected user to be deactivated")
+	}
+	mockStore.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDeactivateUserWithoutBody(t *testing.T) {
+	mockStore := new(mocks.MockEventStore)
+	mockRepo := new(mocks.MockUserRepository)
+	handler := NewUserHandler(mockStore, mockRepo, new(mocks.MockMatchRepository))
+
+	req := httptest.NewRequest("POST", "/api/users/alice/deactivate", nil)
+	rr := httptest.NewRecorder()
+	req = mux.SetURLVars(req, map[string]string{"userId": "alice"})
+
+	expectUsers(mockStore, req.Context(), "alice")
+	mockStore.On("SaveEvent", req.Context(), mock.MatchedBy(func(event *events.Event) bool {
+		deactivated, ok := event.Data.(events.UserDeactivated)
+		return ok && deactivated.UserID == "alice" && deactivated.Reason == ""
+	})).Return(nil)
+	mockRepo.On("GetByID", req.Context(), "alice").Return(domain.NewUser("alice", "alice", "alice@example.com"), nil)
+	mockRepo.On("Update", req.Context(), mock.AnythingOfType("*domain.User")).Return(nil)
+
+	handler.DeactivateUser(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+	mockStore.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+}
@@ -1,11 +1,16 @@
 package server
 
 import (
+	"context"
 	"database/sql"
+	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/api/handlers"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventhandlers"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository/postgres"
 )
@@ -16,6 +21,8 @@ type Server struct {
 	eventStore eventstore.EventStore
 	matchRepo  *postgres.MatchRepository
 	predRepo   *postgres.PredictionRepository
+	roundRepo  *postgres.RoundRepository
+	userRepo   *postgres.UserRepository
 }
 
 // NewServer creates a new server instance
@@ -23,6 +30,8 @@ func NewServer(db *sql.DB) (*Server, error) {
 	// Create repositories
 	matchRepo := postgres.NewMatchRepository(db)
 	predRepo := postgres.NewPredictionRepository(db)
+	roundRepo := postgres.NewRoundRepository(db)
+	userRepo := postgres.NewUserRepository(db)
 
 	// Create event store
 	eventStore, err := eventstore.NewPostgresEventStore(db)
@@ -36,6 +45,8 @@ func NewServer(db *sql.DB) (*Server, error) {
 		eventStore: eventStore,
 		matchRepo:  matchRepo,
 		predRepo:   predRepo,
+		roundRepo:  roundRepo,
+		userRepo:   userRepo,
 	}
 
 	// Add middleware
@@ -51,21 +62,174 @@ func NewServer(db *sql.DB) (*Server, error) {
 // setupRoutes configures the server routes
 func (s *Server) setupRoutes() {
 	// Create handlers
-	matchHandler := handlers.NewMatchHandler(s.eventStore, s.matchRepo)
-	predictionHandler := handlers.NewPredictionHandler(s.eventStore)
+	cutoff := lockCutoff()
+	matchHandler := handlers.NewMatchHandler(s.eventStore, s.matchRepo).WithLockCutoff(cutoff)
+	predictionHandler := handlers.NewPredictionHandler(s.eventStore, s.predRepo).WithLockCutoff(cutoff)
+	scoringHandler := handlers.NewScoringHandler(s.eventStore)
+	roundHandler := handlers.NewRoundHandler(s.eventStore, s.roundRepo, s.matchRepo)
+	competitionHandler := handlers.NewCompetitionHandler(s.eventStore)
+	teamHandler := handlers.NewTeamHandler(s.eventStore, s.matchRepo)
+	bracketHandler := handlers.NewBracketHandler(s.eventStore)
+	outrightHandler := handlers.NewOutrightHandler(s.eventStore)
+	leaderboardHandler := handlers.NewLeaderboardHandler(s.eventStore, s.matchRepo)
+	leagueHandler := handlers.NewLeagueHandler(s.eventStore, s.matchRepo)
+	survivorHandler := handlers.NewSurvivorHandler(s.eventStore)
+	notificationHandler := handlers.NewNotificationHandler(s.eventStore)
+	userHandler := handlers.NewUserHandler(s.eventStore, s.userRepo, s.matchRepo)
+	achievementHandler := handlers.NewAchievementHandler(s.eventStore, s.matchRepo)
 
 	// Match routes
 	s.router.HandleFunc("/api/matches", matchHandler.CreateMatch).Methods("POST")
 	s.router.HandleFunc("/api/matches", matchHandler.ListMatches).Methods("GET")
 	s.router.HandleFunc("/api/matches/upcoming", matchHandler.ListUpcomingMatches).Methods("GET")
 	s.router.HandleFunc("/api/matches/{id}/score", matchHandler.UpdateMatchScore).Methods("PUT")
+	s.router.HandleFunc("/api/matches/{id}/status", matchHandler.UpdateMatchStatus).Methods("PUT")
+	s.router.HandleFunc("/api/matches/{id}/corrections", matchHandler.CorrectMatchScore).Methods("POST")
+	s.router.HandleFunc("/api/matches/{id}/score-history", matchHandler.GetScoreHistory).Methods("GET")
+	s.router.HandleFunc("/api/matches/{id}/reschedule", matchHandler.RescheduleMatch).Methods("POST")
+	s.router.HandleFunc("/api/matches/{id}/odds", matchHandler.UpdateMatchOdds).Methods("PUT")
+	s.router.HandleFunc("/api/matches/{id}/goals", matchHandler.RecordGoal).Methods("POST")
+	s.router.HandleFunc("/api/matches/{id}/goals/{goalId}/disallow", matchHandler.DisallowGoal).Methods("POST")
+	s.router.HandleFunc("/api/matches/{id}/cards", matchHandler.ShowCard).Methods("POST")
+	s.router.HandleFunc("/api/matches/{id}/period", matchHandler.ChangePeriod).Methods("PUT")
+	s.router.HandleFunc("/api/matches/{id}/timeline", matchHandler.GetTimeline).Methods("GET")
 	s.router.HandleFunc("/api/matches/{id}", matchHandler.GetMatch).Methods("GET")
 
 	// Prediction routes
 	s.router.HandleFunc("/api/predictions", predictionHandler.CreatePrediction).Methods("POST")
+	s.router.HandleFunc("/api/predictions/{id}", predictionHandler.AmendPrediction).Methods("PUT")
+	s.router.HandleFunc("/api/predictions/{id}", predictionHandler.WithdrawPrediction).Methods("DELETE")
+	s.router.HandleFunc("/api/predictions/{id}/history", predictionHandler.GetPredictionHistory).Methods("GET")
 	s.router.HandleFunc("/api/users/{userId}/predictions", predictionHandler.GetUserPredictions).Methods("GET")
 	s.router.HandleFunc("/api/matches/{matchId}/predictions", predictionHandler.GetMatchPredictions).Methods("GET")
 	s.router.HandleFunc("/api/matches/{matchId}/predictions/{userId}", predictionHandler.GetUserPredictionForMatch).Methods("GET")
+
+	// Team routes
+	s.router.HandleFunc("/api/teams", teamHandler.CreateTeam).Methods("POST")
+	s.router.HandleFunc("/api/teams", teamHandler.ListTeams).Methods("GET")
+	s.router.HandleFunc("/api/teams/{id}", teamHandler.GetTeam).Methods("GET")
+	s.router.HandleFunc("/api/teams/{id}", teamHandler.UpdateTeam).Methods("PUT")
+	s.router.HandleFunc("/api/teams/{id}/matches", teamHandler.GetTeamMatches).Methods("GET")
+
+	// Competition and season routes
+	s.router.HandleFunc("/api/competitions", competitionHandler.CreateCompetition).Methods("POST")
+	s.router.HandleFunc("/api/competitions", competitionHandler.ListCompetitions).Methods("GET")
+	s.router.HandleFunc("/api/competitions/{id}", competitionHandler.GetCompetition).Methods("GET")
+	s.router.HandleFunc("/api/competitions/{id}/result-basis", competitionHandler.UpdateResultBasis).Methods("PUT")
+	s.router.HandleFunc("/api/competitions/{id}/tipping-mode", competitionHandler.UpdateTippingMode).Methods("PUT")
+	s.router.HandleFunc("/api/competitions/{id}/tiebreakers", competitionHandler.UpdateTiebreakers).Methods("PUT")
+	s.router.HandleFunc("/api/competitions/{id}/seasons", competitionHandler.CreateSeason).Methods("POST")
+	s.router.HandleFunc("/api/competitions/{id}/seasons", competitionHandler.ListSeasons).Methods("GET")
+	s.router.HandleFunc("/api/seasons/{id}/status", competitionHandler.UpdateSeasonStatus).Methods("PUT")
+
+	// Bracket routes
+	s.router.HandleFunc("/api/brackets", bracketHandler.CreateBracket).Methods("POST")
+	s.router.HandleFunc("/api/brackets", bracketHandler.ListBrackets).Methods("GET")
+	s.router.HandleFunc("/api/brackets/{id}", bracketHandler.GetBracket).Methods("GET")
+	s.router.HandleFunc("/api/brackets/{id}/ties/{tieId}/match", bracketHandler.LinkTieMatch).Methods("PUT")
+	s.router.HandleFunc("/api/brackets/{id}/entries", bracketHandler.SubmitEntry).Methods("POST")
+	s.router.HandleFunc("/api/brackets/{id}/entries", bracketHandler.ListEntries).Methods("GET")
+	s.router.HandleFunc("/api/brackets/{id}/entries/{userId}", bracketHandler.GetUserEntry).Methods("GET")
+
+	// Outright routes
+	s.router.HandleFunc("/api/outrights", outrightHandler.CreateMarket).Methods("POST")
+	s.router.HandleFunc("/api/outrights", outrightHandler.ListMarkets).Methods("GET")
+	s.router.HandleFunc("/api/outrights/{id}", outrightHandler.GetMarket).Methods("GET")
+	s.router.HandleFunc("/api/outrights/{id}/settle", outrightHandler.SettleMarket).Methods("PUT")
+	s.router.HandleFunc("/api/outrights/{id}/tips", outrightHandler.SubmitTip).Methods("POST")
+	s.router.HandleFunc("/api/outrights/{id}/tips", outrightHandler.ListTips).Methods("GET")
+	s.router.HandleFunc("/api/outrights/{id}/tips/{userId}", outrightHandler.GetUserTip).Methods("GET")
+
+	// Leaderboard routes
+	s.router.HandleFunc("/api/leaderboard", leaderboardHandler.GetLeaderboard).Methods("GET")
+
+	// League routes
+	s.router.HandleFunc("/api/leagues", leagueHandler.CreateLeague).Methods("POST")
+	s.router.HandleFunc("/api/leagues", leagueHandler.ListLeagues).Methods("GET")
+	s.router.HandleFunc("/api/leagues/join", leagueHandler.JoinLeague).Methods("POST")
+	s.router.HandleFunc("/api/leagues/{id}", leagueHandler.GetLeague).Methods("GET")
+	s.router.HandleFunc("/api/leagues/{id}", leagueHandler.UpdateLeague).Methods("PUT")
+	s.router.HandleFunc("/api/leagues/{id}/rules", leagueHandler.UpdateRules).Methods("PUT")
+	s.router.HandleFunc("/api/leagues/{id}/leave", leagueHandler.LeaveLeague).Methods("POST")
+	s.router.HandleFunc("/api/leagues/{id}/leaderboard", leagueHandler.GetLeaderboard).Methods("GET")
+	s.router.HandleFunc("/api/leagues/{id}/fixtures", leagueHandler.GenerateFixtures).Methods("POST")
+	s.router.HandleFunc("/api/leagues/{id}/matchups", leagueHandler.ListMatchups).Methods("GET")
+	s.router.HandleFunc("/api/leagues/{id}/ladder", leagueHandler.GetLadder).Methods("GET")
+
+	// Survivor routes
+	s.router.HandleFunc("/api/survivor-pools", survivorHandler.CreatePool).Methods("POST")
+	s.router.HandleFunc("/api/survivor-pools", survivorHandler.ListPools).Methods("GET")
+	s.router.HandleFunc("/api/survivor-pools/{id}", survivorHandler.GetPool).Methods("GET")
+	s.router.HandleFunc("/api/survivor-pools/{id}/picks", survivorHandler.MakePick).Methods("POST")
+	s.router.HandleFunc("/api/survivor-pools/{id}/entries", survivorHandler.ListEntries).Methods("GET")
+	s.router.HandleFunc("/api/survivor-pools/{id}/entries/{userId}", survivorHandler.GetUserEntry).Methods("GET")
+	s.router.HandleFunc("/api/survivor-pools/{id}/survivors", survivorHandler.ListSurvivors).Methods("GET")
+
+	// Notification routes
+	s.router.HandleFunc("/api/users/{userId}/notifications", notificationHandler.ListUserNotifications).Methods("GET")
+
+	// User routes
+	s.router.HandleFunc("/api/users", userHandler.RegisterUser).Methods("POST")
+	s.router.HandleFunc("/api/users/{userId}", userHandler.GetUser).Methods("GET")
+	s.router.HandleFunc("/api/users/{userId}", userHandler.UpdateProfile).Methods("PUT")
+	s.router.HandleFunc("/api/users/{userId}/deactivate", userHandler.DeactivateUser).Methods("POST")
+	s.router.HandleFunc("/api/users/{userId}/preferences", userHandler.GetPreferences).Methods("GET")
+	s.router.HandleFunc("/api/users/{userId}/preferences", userHandler.UpdatePreferences).Methods("PUT")
+
+	// Achievement routes
+	s.router.HandleFunc("/api/badges", achievementHandler.ListAchievements).Methods("GET")
+	s.router.HandleFunc("/api/users/{userId}/badges", achievementHandler.GetUserBadges).Methods("GET")
+
+	// Round routes
+	s.router.HandleFunc("/api/rounds", roundHandler.CreateRound).Methods("POST")
+	s.router.HandleFunc("/api/rounds", roundHandler.ListRounds).Methods("GET")
+	s.router.HandleFunc("/api/rounds/current", roundHandler.GetCurrentRound).Methods("GET")
+	s.router.HandleFunc("/api/rounds/{id}", roundHandler.GetRound).Methods("GET")
+	s.router.HandleFunc("/api/rounds/{id}", roundHandler.UpdateRound).Methods("PUT")
+	s.router.HandleFunc("/api/rounds/{id}/matches", roundHandler.GetRoundMatches).Methods("GET")
+
+	// Scoring routes
+	s.router.HandleFunc("/api/competitions/{competition}/scoring", scoringHandler.GetScoringRules).Methods("GET")
+	s.router.HandleFunc("/api/competitions/{competition}/scoring", scoringHandler.UpdateScoringRules).Methods("PUT")
+}
+
+// lockCutoff reads how long before kickoff tips lock from TIP_LOCK_CUTOFF
+// (e.g. "15m"). Tips lock at kickoff if it is unset or invalid.
+func lockCutoff() time.Duration {
+	value := os.Getenv("TIP_LOCK_CUTOFF")
+	if value == "" {
+		return 0
+	}
+
+	cutoff, err := time.ParseDuration(value)
+	if err != nil || cutoff < 0 {
+		log.Printf("Ignoring invalid TIP_LOCK_CUTOFF %q", value)
+		return 0
+	}
+	return cutoff
+}
+
+// autoTipInterval reads how often locked matches are checked for auto-tips
+// from AUTO_TIP_INTERVAL (e.g. "30s"). It is a minute if unset or invalid.
+func autoTipInterval() time.Duration {
+	value := os.Getenv("AUTO_TIP_INTERVAL")
+	if value == "" {
+		return time.Minute
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		log.Printf("Ignoring invalid AUTO_TIP_INTERVAL %q", value)
+		return time.Minute
+	}
+	return interval
+}
+
+// StartAutoTips makes auto-tips for leagues' members as matches lock, in the
+// background until the context is cancelled
+func (s *Server) StartAutoTips(ctx context.Context) {
+	scheduler := eventhandlers.NewAutoTipScheduler(s.eventStore, s.predRepo, lockCutoff())
+	go scheduler.Run(ctx, autoTipInterval())
 }
 
 // ServeHTTP implements the http.Handler interface
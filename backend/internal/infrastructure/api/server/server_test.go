@@ -44,6 +44,12 @@ func TestNewServer(t *testing.T) {
 	if server.predRepo == nil {
 		t.Errorf("expected predRepo to be non-nil")
 	}
+	if server.roundRepo == nil {
+		t.Errorf("expected roundRepo to be non-nil")
+	}
+	if server.userRepo == nil {
+		t.Errorf("expected userRepo to be non-nil")
+	}
 }
 
 func TestServerRoutes(t *testing.T) {
@@ -79,9 +85,63 @@ func TestServerRoutes(t *testing.T) {
 		{"Get Match", "GET", "/api/matches/123", http.StatusOK},
 		{"Create Match", "POST", "/api/matches", http.StatusOK},
 		{"Update Match Score", "PUT", "/api/matches/123/score", http.StatusOK},
+		{"Update Match Status", "PUT", "/api/matches/123/status", http.StatusOK},
+		{"Correct Match Score", "POST", "/api/matches/123/corrections", http.StatusOK},
+		{"Get Match Score History", "GET", "/api/matches/123/score-history", http.StatusOK},
+		{"Reschedule Match", "POST", "/api/matches/123/reschedule", http.StatusOK},
+		{"Update Match Odds", "PUT", "/api/matches/123/odds", http.StatusOK},
+		{"Record Goal", "POST", "/api/matches/123/goals", http.StatusOK},
+		{"Disallow Goal", "POST", "/api/matches/123/goals/456/disallow", http.StatusOK},
+		{"Show Card", "POST", "/api/matches/123/cards", http.StatusOK},
+		{"Change Match Period", "PUT", "/api/matches/123/period", http.StatusOK},
+		{"Get Match Timeline", "GET", "/api/matches/123/timeline", http.StatusOK},
 		{"Create Prediction", "POST", "/api/predictions", http.StatusOK},
+		{"Amend Prediction", "PUT", "/api/predictions/123", http.StatusOK},
+		{"Withdraw Prediction", "DELETE", "/api/predictions/123", http.StatusOK},
+		{"Get Prediction History", "GET", "/api/predictions/123/history", http.StatusOK},
 		{"Get User Predictions", "GET", "/api/users/123/predictions", http.StatusOK},
+		{"Get User Notifications", "GET", "/api/users/123/notifications", http.StatusOK},
+		{"Register User", "POST", "/api/users", http.StatusOK},
+		{"Get User", "GET", "/api/users/123", http.StatusOK},
+		{"Update User Profile", "PUT", "/api/users/123", http.StatusOK},
+		{"Deactivate User", "POST", "/api/users/123/deactivate", http.StatusOK},
+		{"Get User Preferences", "GET", "/api/users/123/preferences", http.StatusOK},
+		{"Update User Preferences", "PUT", "/api/users/123/preferences", http.StatusOK},
+		{"List Badges", "GET", "/api/badges", http.StatusOK},
+		{"Get User Badges", "GET", "/api/users/123/badges", http.StatusOK},
 		{"Get Match Predictions", "GET", "/api/matches/123/predictions", http.StatusOK},
+		{"Create Team", "POST", "/api/teams", http.StatusOK},
+		{"List Teams", "GET", "/api/teams", http.StatusOK},
+		{"Create Competition", "POST", "/api/competitions", http.StatusOK},
+		{"List Competitions", "GET", "/api/competitions", http.StatusOK},
+		{"Update Result Basis", "PUT", "/api/competitions/123/result-basis", http.StatusOK},
+		{"Update Tipping Mode", "PUT", "/api/competitions/123/tipping-mode", http.StatusOK},
+		{"Update Tiebreakers", "PUT", "/api/competitions/123/tiebreakers", http.StatusOK},
+		{"Create Season", "POST", "/api/competitions/123/seasons", http.StatusOK},
+		{"Update Season Status", "PUT", "/api/seasons/123/status", http.StatusOK},
+		{"Create Round", "POST", "/api/rounds", http.StatusOK},
+		{"Create Bracket", "POST", "/api/brackets", http.StatusCreated},
+		{"List Brackets", "GET", "/api/brackets", http.StatusOK},
+		{"Create Outright Market", "POST", "/api/outrights", http.StatusCreated},
+		{"List Outright Markets", "GET", "/api/outrights", http.StatusOK},
+		{"Get Leaderboard", "GET", "/api/leaderboard", http.StatusOK},
+		{"Create League", "POST", "/api/leagues", http.StatusCreated},
+		{"List Leagues", "GET", "/api/leagues", http.StatusOK},
+		{"Update League Rules", "PUT", "/api/leagues/123/rules", http.StatusOK},
+		{"Generate League Fixtures", "POST", "/api/leagues/123/fixtures", http.StatusCreated},
+		{"List League Matchups", "GET", "/api/leagues/123/matchups", http.StatusOK},
+		{"Get League Ladder", "GET", "/api/leagues/123/ladder", http.StatusOK},
+		{"Create Survivor Pool", "POST", "/api/survivor-pools", http.StatusCreated},
+		{"List Survivor Pools", "GET", "/api/survivor-pools", http.StatusOK},
+		{"Make Survivor Pick", "POST", "/api/survivor-pools/123/picks", http.StatusCreated},
+		{"List Survivors", "GET", "/api/survivor-pools/123/survivors", http.StatusOK},
+		{"List Rounds", "GET", "/api/rounds", http.StatusOK},
+		{"Get Current Round", "GET", "/api/rounds/current", http.StatusOK},
+		{"Get Round", "GET", "/api/rounds/123", http.StatusOK},
+		{"Update Round", "PUT", "/api/rounds/123", http.StatusOK},
+		{"Get Round Matches", "GET", "/api/rounds/123/matches", http.StatusOK},
+		{"Get Scoring Rules", "GET", "/api/competitions/EPL/scoring", http.StatusOK},
+		{"Update Scoring Rules", "PUT", "/api/competitions/EPL/scoring", http.StatusOK},
 	}
 
 	for _, tc := range testCases {
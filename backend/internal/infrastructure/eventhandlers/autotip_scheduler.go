@@ -0,0 +1,188 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+// AutoTipScheduler makes tips for league members who have not tipped a match
+// once it locks, following each league's auto-tip policy
+type AutoTipScheduler struct {
+	eventStore        eventstore.EventStore
+	predictionHandler *PredictionEventHandler
+	lockCutoff        time.Duration
+	now               func() time.Time
+}
+
+// NewAutoTipScheduler creates a new auto-tip scheduler for matches that lock
+// the given time before kickoff
+func NewAutoTipScheduler(eventStore eventstore.EventStore, predictionRepo repository.PredictionRepository, lockCutoff time.Duration) *AutoTipScheduler {
+	return &AutoTipScheduler{
+		eventStore:        eventStore,
+		predictionHandler: NewPredictionEventHandler(predictionRepo),
+		lockCutoff:        lockCutoff,
+		now:               time.Now,
+	}
+}
+
+// Run makes due auto-tips every interval until the context is cancelled
+func (s *AutoTipScheduler) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.MakeDueTips(ctx); err != nil {
+			log.Printf("Failed to make auto-tips: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MakeDueTips makes the auto-tips for every scheduled or live match that has
+// locked for a league with an auto-tip policy, and returns how many it made.
+// A league with an earlier lock cutoff is tipped at its own lock, so the tips
+// count in it. Members with a tip of their own, or already auto-tipped for a
+// league, are skipped, so it is safe to run again.
+func (s *AutoTipScheduler) MakeDueTips(ctx context.Context) (int, error) {
+	leagues, err := LoadLeagues(ctx, s.eventStore)
+	if err != nil {
+		return 0, err
+	}
+	autoLeagues := make([]*domain.League, 0)
+	for _, league := range leagues {
+		if league.Rules.AutoTip != "" {
+			autoLeagues = append(autoLeagues, league)
+		}
+	}
+	if len(autoLeagues) == 0 {
+		return 0, nil
+	}
+
+	matches, err := LoadMatches(ctx, s.eventStore)
+	if err != nil {
+		return 0, err
+	}
+	roundEvents, err := LoadRoundEvents(ctx, s.eventStore)
+	if err != nil {
+		return 0, err
+	}
+	rounds, err := ReplayRounds(roundEvents)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	var catalog *CompetitionCatalog
+	var users []*domain.User
+	var predictions []*domain.Prediction
+	made := 0
+	for _, match := range matches {
+		if match.Status != domain.MatchStatusScheduled && match.Status != domain.MatchStatusLive {
+			continue
+		}
+		roundLocked := false
+		if round := FindRound(rounds, match.RoundID); round != nil {
+			roundLocked = round.IsLocked(now)
+		}
+		dueLeagues := make([]*domain.League, 0)
+		for _, league := range autoLeagues {
+			if league.Covers(match) && s.lockedFor(league, match, now, roundLocked) {
+				dueLeagues = append(dueLeagues, league)
+			}
+		}
+		if len(dueLeagues) == 0 {
+			continue
+		}
+
+		if catalog == nil {
+			if catalog, err = LoadCompetitionCatalog(ctx, s.eventStore); err != nil {
+				return made, err
+			}
+			if users, err = LoadUsers(ctx, s.eventStore); err != nil {
+				return made, err
+			}
+			predictionEvents, err := LoadPredictionEvents(ctx, s.eventStore)
+			if err != nil {
+				return made, err
+			}
+			if predictions, err = ReplayPredictions(predictionEvents, ""); err != nil {
+				return made, err
+			}
+		}
+
+		mode := domain.TippingModeExactScore
+		if competition := catalog.Competition(match.CompetitionID); match.CompetitionID != "" && competition != nil {
+			mode = competition.TippingMode
+		}
+
+		tips := domain.PlanAutoTips(domain.AutoTipInput{
+			Match:       match,
+			Mode:        mode,
+			Leagues:     dueLeagues,
+			Users:       users,
+			Predictions: predictions,
+			Matches:     matches,
+			At:          now,
+		}, utils.GenerateID)
+		for _, tip := range tips {
+			if err := s.save(ctx, tip); err != nil {
+				return made, err
+			}
+			predictions = append(predictions, tip)
+			made++
+		}
+		if len(tips) > 0 {
+			log.Printf("Made %d auto-tips for match %s", len(tips), match.ID)
+		}
+	}
+	return made, nil
+}
+
+// lockedFor returns true once tips for the match have locked for the league:
+// at the global cutoff, the round deadline or the league's own earlier cutoff
+func (s *AutoTipScheduler) lockedFor(league *domain.League, match *domain.Match, now time.Time, roundLocked bool) bool {
+	if roundLocked || match.IsLocked(now, s.lockCutoff) {
+		return true
+	}
+	cutoff := league.Rules.Cutoff()
+	return cutoff > s.lockCutoff && match.IsLocked(now, cutoff)
+}
+
+// save records an auto-tip and projects it to the predictions read model
+func (s *AutoTipScheduler) save(ctx context.Context, tip *domain.Prediction) error {
+	event := events.NewEvent("PredictionMade", events.PredictionMade{
+		ID:        tip.ID,
+		UserID:    tip.UserID,
+		MatchID:   tip.MatchID,
+		HomeGoals: tip.HomeGoals,
+		AwayGoals: tip.AwayGoals,
+		Winner:    string(tip.Winner),
+		Margin:    tip.Margin,
+		Auto:      true,
+		LeagueID:  tip.LeagueID,
+		CreatedAt: tip.CreatedAt,
+	})
+	if err := s.eventStore.SaveEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to save auto-tip for user %s on match %s: %w", tip.UserID, tip.MatchID, err)
+	}
+
+	// Process event to update read model
+	if err := s.predictionHandler.HandleEvent(ctx, event); err != nil {
+		log.Printf("Error processing auto-tip event: %v", err)
+		// Continue anyway since the event is saved
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// BadgeAwardHandler unlocks achievement badges as tips are made and scored
+type BadgeAwardHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewBadgeAwardHandler creates a new badge award handler
+func NewBadgeAwardHandler(eventStore eventstore.EventStore) *BadgeAwardHandler {
+	return &BadgeAwardHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent awards badges when a tip is made, when a match finishes or is
+// abandoned, and when a finished match's score is corrected
+func (h *BadgeAwardHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "PredictionMade", "MatchScoreCorrected":
+		return h.awardBadges(ctx)
+	case "MatchStatusChanged":
+		var statusChanged events.MatchStatusChanged
+		if err := decodeEventData(event, &statusChanged); err != nil {
+			return err
+		}
+
+		switch domain.MatchStatus(statusChanged.Status) {
+		case domain.MatchStatusFinished, domain.MatchStatusAbandoned:
+			return h.awardBadges(ctx)
+		default:
+			return nil
+		}
+	default:
+		return nil
+	}
+}
+
+// awardBadges emits a BadgeAwarded event for every achievement a user's
+// statistics now reach that they have not already been awarded. Badges are
+// never taken away.
+func (h *BadgeAwardHandler) awardBadges(ctx context.Context) error {
+	users, err := LoadAchievementStats(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+
+	badges, err := LoadBadges(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+	awarded := make(map[string]bool)
+	for _, badge := range badges {
+		awarded[badge.UserID+"/"+badge.BadgeID] = true
+	}
+
+	count := 0
+	for _, stats := range users {
+		for _, achievement := range stats.Unlocked() {
+			if awarded[stats.UserID+"/"+achievement.BadgeID] {
+				continue
+			}
+
+			event := events.NewEvent("BadgeAwarded", events.BadgeAwarded{
+				UserID:    stats.UserID,
+				BadgeID:   achievement.BadgeID,
+				Name:      achievement.Name,
+				Metric:    string(achievement.Metric),
+				Value:     stats.Value(achievement.Metric),
+				AwardedAt: time.Now(),
+			})
+			if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to award badge %s to user %s: %w", achievement.BadgeID, stats.UserID, err)
+			}
+			count++
+		}
+	}
+
+	if count > 0 {
+		log.Printf("Awarded %d badges", count)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// BracketScoringHandler awards bracket points as the knockout matches linked
+// to bracket ties finish
+type BracketScoringHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewBracketScoringHandler creates a new bracket scoring handler
+func NewBracketScoringHandler(eventStore eventstore.EventStore) *BracketScoringHandler {
+	return &BracketScoringHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent scores the bracket ties decided by a match when it finishes,
+// and rescores them when its score is corrected
+func (h *BracketScoringHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "MatchStatusChanged":
+		var statusChanged events.MatchStatusChanged
+		if err := decodeEventData(event, &statusChanged); err != nil {
+			return err
+		}
+		if domain.MatchStatus(statusChanged.Status) != domain.MatchStatusFinished {
+			return nil
+		}
+		return h.scoreMatch(ctx, statusChanged.MatchID, false)
+	case "MatchScoreCorrected":
+		var scoreCorrected events.MatchScoreCorrected
+		if err := decodeEventData(event, &scoreCorrected); err != nil {
+			return err
+		}
+		return h.scoreMatch(ctx, scoreCorrected.MatchID, true)
+	default:
+		return nil
+	}
+}
+
+// ScoreMatch emits a BracketPointsAwarded event for every entry in each
+// bracket with a tie decided by the finished match. Entries already scored
+// for the tie are skipped, so it is safe to call again, e.g. when a finished
+// match is linked to a tie after the fact.
+func (h *BracketScoringHandler) ScoreMatch(ctx context.Context, matchID string) error {
+	return h.scoreMatch(ctx, matchID, false)
+}
+
+// scoreMatch scores the ties the match decides. A correction also re-awards
+// the entries already scored whose points the corrected winner changes,
+// including taking the points away if the match no longer has a winner.
+func (h *BracketScoringHandler) scoreMatch(ctx context.Context, matchID string, correction bool) error {
+	brackets, err := LoadBrackets(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+
+	var match *domain.Match
+	for _, bracket := range brackets {
+		tie := bracket.TieForMatch(matchID)
+		if tie == nil {
+			continue
+		}
+
+		if match == nil {
+			matchEvents, err := h.eventStore.GetEvents(ctx, matchID)
+			if err != nil {
+				return fmt.Errorf("failed to get match events: %w", err)
+			}
+			if match, err = ReplayMatch(matchEvents); err != nil {
+				return err
+			}
+		}
+
+		winnerID := match.WinnerID()
+		if winnerID == "" && !correction {
+			log.Printf("No winner for match %s; skipping tie %s of bracket %s", matchID, tie.ID, bracket.ID)
+			continue
+		}
+
+		if err := h.scoreTie(ctx, bracket, tie, winnerID, correction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scoreTie awards each entry in the bracket the points it earned for the tie.
+// A correction re-awards scored entries whose points changed, with the
+// adjustment from their earlier award.
+func (h *BracketScoringHandler) scoreTie(ctx context.Context, bracket *domain.Bracket, tie *domain.BracketTie, winnerID string, correction bool) error {
+	entries, err := LoadBracketEntries(ctx, h.eventStore, bracket.ID)
+	if err != nil {
+		return err
+	}
+
+	scored := 0
+	for _, entry := range entries {
+		points := bracket.ScoreTie(entry, tie, winnerID)
+		rescored := entry.IsScored(tie.ID)
+		if rescored && (!correction || entry.Awards[tie.ID] == points) {
+			continue
+		}
+		if !rescored && winnerID == "" {
+			continue
+		}
+
+		pointsAwarded := events.BracketPointsAwarded{
+			BracketID: bracket.ID,
+			EntryID:   entry.ID,
+			UserID:    entry.UserID,
+			TieID:     tie.ID,
+			MatchID:   tie.MatchID,
+			WinnerID:  winnerID,
+			Points:    points,
+			AwardedAt: time.Now(),
+		}
+		if rescored {
+			pointsAwarded.Correction = true
+			pointsAwarded.Adjustment = points - entry.Awards[tie.ID]
+		}
+		event := events.NewEvent("BracketPointsAwarded", pointsAwarded)
+		if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to save bracket points for entry %s: %w", entry.ID, err)
+		}
+		scored++
+	}
+
+	log.Printf("Scored %d entries for tie %s of bracket %s", scored, tie.ID, bracket.ID)
+	return nil
+}
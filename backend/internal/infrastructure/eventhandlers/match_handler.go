@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/parkertr2/footy-tipping/internal/domain"
 	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
@@ -30,8 +31,16 @@ func (h *MatchEventHandler) HandleEvent(ctx context.Context, event *events.Event
 		return h.handleMatchCreated(ctx, event)
 	case "MatchScoreUpdated":
 		return h.handleMatchScoreUpdated(ctx, event)
+	case "MatchScoreCorrected":
+		return h.handleMatchScoreCorrected(ctx, event)
 	case "MatchStatusChanged":
 		return h.handleMatchStatusChanged(ctx, event)
+	case "MatchRescheduled":
+		return h.handleMatchRescheduled(ctx, event)
+	case "MatchOddsUpdated":
+		return h.handleMatchOddsUpdated(ctx, event)
+	case "GoalScored", "GoalDisallowed", "PeriodChanged":
+		return h.handleTimelineEvent(ctx, event)
 	default:
 		// Ignore unknown event types
 		return nil
@@ -59,6 +68,12 @@ func (h *MatchEventHandler) handleMatchCreated(ctx context.Context, event *event
 		matchCreated.Date,
 		matchCreated.Competition,
 	)
+	match.HomeTeamID = matchCreated.HomeTeamID
+	match.AwayTeamID = matchCreated.AwayTeamID
+	match.CompetitionID = matchCreated.CompetitionID
+	match.SeasonID = matchCreated.SeasonID
+	match.RoundID = matchCreated.RoundID
+	match.TimeZone = matchCreated.TimeZone
 
 	// Save to read model
 	if err := h.matchRepo.Create(ctx, match); err != nil {
@@ -89,8 +104,8 @@ func (h *MatchEventHandler) handleMatchScoreUpdated(ctx context.Context, event *
 		return fmt.Errorf("failed to get match from read model: %w", err)
 	}
 
-	// Update score
-	match.UpdateScore(scoreUpdated.HomeGoals, scoreUpdated.AwayGoals)
+	// Update score, including any extra time and shootout
+	match.SetScore(ScoreFromEvent(scoreUpdated))
 
 	// Save updated match to read model
 	if err := h.matchRepo.Update(ctx, match); err != nil {
@@ -102,6 +117,29 @@ func (h *MatchEventHandler) handleMatchScoreUpdated(ctx context.Context, event *
 	return nil
 }
 
+// handleMatchScoreCorrected processes MatchScoreCorrected events
+func (h *MatchEventHandler) handleMatchScoreCorrected(ctx context.Context, event *events.Event) error {
+	var scoreCorrected events.MatchScoreCorrected
+	if err := decodeEventData(event, &scoreCorrected); err != nil {
+		return fmt.Errorf("failed to unmarshal MatchScoreCorrected event: %w", err)
+	}
+
+	match, err := h.matchRepo.GetByID(ctx, scoreCorrected.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match from read model: %w", err)
+	}
+
+	match.SetScore(ScoreFromEvent(scoreCorrected.MatchScoreUpdated))
+
+	if err := h.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to update match in read model: %w", err)
+	}
+
+	log.Printf("Corrected match score in read model: %s %d-%d %s (%s)",
+		match.HomeTeam, scoreCorrected.HomeGoals, scoreCorrected.AwayGoals, match.AwayTeam, scoreCorrected.Reason)
+	return nil
+}
+
 // handleMatchStatusChanged processes MatchStatusChanged events
 func (h *MatchEventHandler) handleMatchStatusChanged(ctx context.Context, event *events.Event) error {
 	// Extract event data
@@ -133,3 +171,77 @@ func (h *MatchEventHandler) handleMatchStatusChanged(ctx context.Context, event
 		match.HomeTeam, match.AwayTeam, match.Status)
 	return nil
 }
+
+// handleMatchRescheduled processes MatchRescheduled events
+func (h *MatchEventHandler) handleMatchRescheduled(ctx context.Context, event *events.Event) error {
+	var rescheduled events.MatchRescheduled
+	if err := decodeEventData(event, &rescheduled); err != nil {
+		return fmt.Errorf("failed to unmarshal MatchRescheduled event: %w", err)
+	}
+
+	match, err := h.matchRepo.GetByID(ctx, rescheduled.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match from read model: %w", err)
+	}
+
+	match.Date = rescheduled.Date
+
+	if err := h.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to update match in read model: %w", err)
+	}
+
+	log.Printf("Rescheduled match in read model: %s vs %s to %s",
+		match.HomeTeam, match.AwayTeam, rescheduled.Date.Format(time.RFC3339))
+	return nil
+}
+
+// handleMatchOddsUpdated processes MatchOddsUpdated events
+func (h *MatchEventHandler) handleMatchOddsUpdated(ctx context.Context, event *events.Event) error {
+	var oddsUpdated events.MatchOddsUpdated
+	if err := decodeEventData(event, &oddsUpdated); err != nil {
+		return fmt.Errorf("failed to unmarshal MatchOddsUpdated event: %w", err)
+	}
+
+	match, err := h.matchRepo.GetByID(ctx, oddsUpdated.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match from read model: %w", err)
+	}
+
+	match.Odds = oddsFromEvent(oddsUpdated)
+
+	if err := h.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to update match in read model: %w", err)
+	}
+
+	log.Printf("Updated match odds in read model: %s vs %s", match.HomeTeam, match.AwayTeam)
+	return nil
+}
+
+// handleTimelineEvent processes in-play events that move the live score on
+func (h *MatchEventHandler) handleTimelineEvent(ctx context.Context, event *events.Event) error {
+	entry, err := TimelineEntryFromEvent(event)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", event.Type, err)
+	}
+
+	// Every in-play event names its match
+	var ref struct{ MatchID string }
+	if err := decodeEventData(event, &ref); err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", event.Type, err)
+	}
+
+	match, err := h.matchRepo.GetByID(ctx, ref.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match from read model: %w", err)
+	}
+
+	applyTimelineEntry(match, entry)
+
+	if err := h.matchRepo.Update(ctx, match); err != nil {
+		return fmt.Errorf("failed to update match in read model: %w", err)
+	}
+
+	log.Printf("Updated live score in read model: %s %d-%d %s (%s)",
+		match.HomeTeam, match.Score.HomeGoals, match.Score.AwayGoals, match.AwayTeam, event.Type)
+	return nil
+}
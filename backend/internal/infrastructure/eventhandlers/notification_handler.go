@@ -0,0 +1,94 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+	"github.com/parkertr2/footy-tipping/pkg/utils"
+)
+
+// kickoffFormat is how kickoffs are written in notification messages
+const kickoffFormat = "Mon 2 Jan 15:04 MST"
+
+// RescheduleNotificationHandler tells everyone who tipped a match when its
+// kickoff moves
+type RescheduleNotificationHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewRescheduleNotificationHandler creates a new reschedule notification handler
+func NewRescheduleNotificationHandler(eventStore eventstore.EventStore) *RescheduleNotificationHandler {
+	return &RescheduleNotificationHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent notifies a match's tippers when the match is rescheduled
+func (h *RescheduleNotificationHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	if event.Type != "MatchRescheduled" {
+		return nil
+	}
+
+	var rescheduled events.MatchRescheduled
+	if err := decodeEventData(event, &rescheduled); err != nil {
+		return err
+	}
+	return h.notifyTippers(ctx, rescheduled)
+}
+
+// notifyTippers emits a NotificationSent event for each user with a standing
+// tip on the match. Users with several tips on the match are told once.
+func (h *RescheduleNotificationHandler) notifyTippers(ctx context.Context, rescheduled events.MatchRescheduled) error {
+	matchEvents, err := h.eventStore.GetEvents(ctx, rescheduled.MatchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match events: %w", err)
+	}
+	match, err := ReplayMatch(matchEvents)
+	if err != nil {
+		return err
+	}
+
+	predictionEvents, err := LoadPredictionEvents(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+	predictions, err := ReplayPredictions(predictionEvents, rescheduled.MatchID)
+	if err != nil {
+		return err
+	}
+
+	message := fmt.Sprintf("%s v %s has moved from %s to %s",
+		match.HomeTeam, match.AwayTeam,
+		rescheduled.PreviousDate.UTC().Format(kickoffFormat), rescheduled.Date.UTC().Format(kickoffFormat))
+	if rescheduled.Reason != "" {
+		message += ": " + rescheduled.Reason
+	}
+
+	notified := make(map[string]bool)
+	for _, prediction := range predictions {
+		if prediction.IsWithdrawn() || notified[prediction.UserID] {
+			continue
+		}
+
+		event := events.NewEvent("NotificationSent", events.NotificationSent{
+			ID:      utils.GenerateID(),
+			UserID:  prediction.UserID,
+			Type:    string(domain.NotificationMatchRescheduled),
+			MatchID: match.ID,
+			Message: message,
+			SentAt:  time.Now(),
+		})
+		if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to notify user %s: %w", prediction.UserID, err)
+		}
+		notified[prediction.UserID] = true
+	}
+
+	log.Printf("Notified %d tippers that match %s was rescheduled", len(notified), match.ID)
+	return nil
+}
@@ -0,0 +1,81 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// OutrightSettlementHandler awards points for outright tips once their
+// market is settled
+type OutrightSettlementHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewOutrightSettlementHandler creates a new outright settlement handler
+func NewOutrightSettlementHandler(eventStore eventstore.EventStore) *OutrightSettlementHandler {
+	return &OutrightSettlementHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent scores a market's tips when it is settled
+func (h *OutrightSettlementHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	if event.Type != "OutrightMarketSettled" {
+		return nil
+	}
+
+	var marketSettled events.OutrightMarketSettled
+	if err := decodeEventData(event, &marketSettled); err != nil {
+		return err
+	}
+	return h.settleMarket(ctx, marketSettled)
+}
+
+// settleMarket emits an OutrightPointsAwarded event for every tip in the
+// market that has not been scored yet
+func (h *OutrightSettlementHandler) settleMarket(ctx context.Context, marketSettled events.OutrightMarketSettled) error {
+	markets, err := LoadOutrightMarkets(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+
+	market := FindOutrightMarket(markets, marketSettled.MarketID)
+	if market == nil {
+		return fmt.Errorf("outright market %s not found", marketSettled.MarketID)
+	}
+	market.Result = marketSettled.Result
+
+	tips, err := LoadOutrightTips(ctx, h.eventStore, market.ID)
+	if err != nil {
+		return err
+	}
+
+	scored := 0
+	for _, tip := range tips {
+		if tip.Settled {
+			continue
+		}
+
+		correct := market.CorrectPicks(tip.Picks)
+		event := events.NewEvent("OutrightPointsAwarded", events.OutrightPointsAwarded{
+			MarketID:  market.ID,
+			TipID:     tip.ID,
+			UserID:    tip.UserID,
+			Correct:   correct,
+			Points:    correct * market.PointsPerPick,
+			AwardedAt: time.Now(),
+		})
+		if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to save points for outright tip %s: %w", tip.ID, err)
+		}
+		scored++
+	}
+
+	log.Printf("Scored %d tips for outright market %s", scored, market.ID)
+	return nil
+}
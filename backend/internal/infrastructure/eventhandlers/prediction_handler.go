@@ -0,0 +1,117 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// PredictionEventHandler handles prediction-related events and updates the read model
+type PredictionEventHandler struct {
+	predictionRepo repository.PredictionRepository
+}
+
+// NewPredictionEventHandler creates a new prediction event handler
+func NewPredictionEventHandler(predictionRepo repository.PredictionRepository) *PredictionEventHandler {
+	return &PredictionEventHandler{
+		predictionRepo: predictionRepo,
+	}
+}
+
+// HandleEvent processes events and updates the read model accordingly
+func (h *PredictionEventHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "PredictionMade":
+		return h.handlePredictionMade(ctx, event)
+	case "PredictionAmended":
+		return h.handlePredictionAmended(ctx, event)
+	case "PredictionWithdrawn":
+		return h.handlePredictionWithdrawn(ctx, event)
+	default:
+		// Ignore unknown event types
+		return nil
+	}
+}
+
+// handlePredictionMade processes PredictionMade events
+func (h *PredictionEventHandler) handlePredictionMade(ctx context.Context, event *events.Event) error {
+	var predictionMade events.PredictionMade
+	if err := decodeEventData(event, &predictionMade); err != nil {
+		return err
+	}
+
+	prediction := newPrediction(predictionMade)
+
+	if err := h.predictionRepo.Create(ctx, prediction); err != nil {
+		return fmt.Errorf("failed to create prediction in read model: %w", err)
+	}
+
+	revision := prediction.CurrentRevision()
+	if err := h.predictionRepo.AddRevision(ctx, &revision); err != nil {
+		return fmt.Errorf("failed to record prediction revision: %w", err)
+	}
+
+	log.Printf("Created prediction in read model: %s for match %s", prediction.ID, prediction.MatchID)
+	return nil
+}
+
+// handlePredictionAmended processes PredictionAmended events
+func (h *PredictionEventHandler) handlePredictionAmended(ctx context.Context, event *events.Event) error {
+	var predictionAmended events.PredictionAmended
+	if err := decodeEventData(event, &predictionAmended); err != nil {
+		return err
+	}
+
+	prediction, err := h.predictionRepo.GetByID(ctx, predictionAmended.PredictionID)
+	if err != nil {
+		return fmt.Errorf("failed to get prediction from read model: %w", err)
+	}
+
+	amendPrediction(prediction, predictionAmended)
+	prediction.Revision = predictionAmended.Revision
+
+	if err := h.predictionRepo.Update(ctx, prediction); err != nil {
+		return fmt.Errorf("failed to update prediction in read model: %w", err)
+	}
+
+	revision := prediction.CurrentRevision()
+	if err := h.predictionRepo.AddRevision(ctx, &revision); err != nil {
+		return fmt.Errorf("failed to record prediction revision: %w", err)
+	}
+
+	log.Printf("Amended prediction in read model: %s (revision %d)", prediction.ID, prediction.Revision)
+	return nil
+}
+
+// handlePredictionWithdrawn processes PredictionWithdrawn events
+func (h *PredictionEventHandler) handlePredictionWithdrawn(ctx context.Context, event *events.Event) error {
+	var predictionWithdrawn events.PredictionWithdrawn
+	if err := decodeEventData(event, &predictionWithdrawn); err != nil {
+		return err
+	}
+
+	prediction, err := h.predictionRepo.GetByID(ctx, predictionWithdrawn.PredictionID)
+	if err != nil {
+		return fmt.Errorf("failed to get prediction from read model: %w", err)
+	}
+
+	if err := prediction.Withdraw(predictionWithdrawn.WithdrawnAt); err != nil {
+		return fmt.Errorf("failed to withdraw prediction %s: %w", prediction.ID, err)
+	}
+	prediction.Revision = predictionWithdrawn.Revision
+
+	if err := h.predictionRepo.Update(ctx, prediction); err != nil {
+		return fmt.Errorf("failed to update prediction in read model: %w", err)
+	}
+
+	revision := prediction.CurrentRevision()
+	if err := h.predictionRepo.AddRevision(ctx, &revision); err != nil {
+		return fmt.Errorf("failed to record prediction revision: %w", err)
+	}
+
+	log.Printf("Withdrew prediction in read model: %s for match %s", prediction.ID, prediction.MatchID)
+	return nil
+}
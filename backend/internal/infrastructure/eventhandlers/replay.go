@@ -0,0 +1,1541 @@
+package eventhandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// decodeEventData copies an event's payload into target. Events read from the
+// store carry typed payloads while freshly built ones may not, so the payload
+// is round-tripped through JSON either way.
+func decodeEventData(event *events.Event, target interface{}) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal %s event: %w", event.Type, err)
+	}
+	return nil
+}
+
+// ReplayMatch rebuilds a match from its event history. Events that do not
+// describe the match itself are ignored.
+func ReplayMatch(matchEvents []*events.Event) (*domain.Match, error) {
+	match := &domain.Match{}
+	for _, event := range matchEvents {
+		switch event.Type {
+		case "MatchCreated":
+			var matchCreated events.MatchCreated
+			if err := decodeEventData(event, &matchCreated); err != nil {
+				return nil, err
+			}
+			match = domain.NewMatch(
+				matchCreated.ID,
+				matchCreated.HomeTeam,
+				matchCreated.AwayTeam,
+				matchCreated.Date,
+				matchCreated.Competition,
+			)
+			match.HomeTeamID = matchCreated.HomeTeamID
+			match.AwayTeamID = matchCreated.AwayTeamID
+			match.CompetitionID = matchCreated.CompetitionID
+			match.SeasonID = matchCreated.SeasonID
+			match.RoundID = matchCreated.RoundID
+			match.TimeZone = matchCreated.TimeZone
+		case "MatchScoreUpdated":
+			var scoreUpdated events.MatchScoreUpdated
+			if err := decodeEventData(event, &scoreUpdated); err != nil {
+				return nil, err
+			}
+			match.SetScore(ScoreFromEvent(scoreUpdated))
+		case "MatchScoreCorrected":
+			var scoreCorrected events.MatchScoreCorrected
+			if err := decodeEventData(event, &scoreCorrected); err != nil {
+				return nil, err
+			}
+			match.SetScore(ScoreFromEvent(scoreCorrected.MatchScoreUpdated))
+		case "MatchStatusChanged":
+			var statusChanged events.MatchStatusChanged
+			if err := decodeEventData(event, &statusChanged); err != nil {
+				return nil, err
+			}
+			match.Status = domain.MatchStatus(statusChanged.Status)
+		case "MatchRescheduled":
+			var rescheduled events.MatchRescheduled
+			if err := decodeEventData(event, &rescheduled); err != nil {
+				return nil, err
+			}
+			match.Date = rescheduled.Date
+		case "MatchOddsUpdated":
+			var oddsUpdated events.MatchOddsUpdated
+			if err := decodeEventData(event, &oddsUpdated); err != nil {
+				return nil, err
+			}
+			match.Odds = oddsFromEvent(oddsUpdated)
+		case "GoalScored", "GoalDisallowed", "PeriodChanged":
+			entry, err := TimelineEntryFromEvent(event)
+			if err != nil {
+				return nil, err
+			}
+			applyTimelineEntry(match, entry)
+		}
+	}
+	return match, nil
+}
+
+// applyTimelineEntry moves a match's live score on for an in-play event.
+// Cards leave the score alone.
+func applyTimelineEntry(match *domain.Match, entry *domain.TimelineEntry) {
+	switch entry.Type {
+	case domain.TimelineGoal:
+		match.AddGoal(entry.Side, entry.Period)
+	case domain.TimelineGoalDisallowed:
+		match.RemoveGoal(entry.Side, entry.Period)
+	case domain.TimelinePeriod:
+		match.StartPeriod(entry.Period)
+	}
+}
+
+// TimelineEntryFromEvent builds the timeline entry for an in-play event. It
+// returns nil for events that are not in-play events.
+func TimelineEntryFromEvent(event *events.Event) (*domain.TimelineEntry, error) {
+	switch event.Type {
+	case "GoalScored":
+		var goalScored events.GoalScored
+		if err := decodeEventData(event, &goalScored); err != nil {
+			return nil, err
+		}
+		return &domain.TimelineEntry{
+			ID:         goalScored.ID,
+			Type:       domain.TimelineGoal,
+			Minute:     goalScored.Minute,
+			AddedTime:  goalScored.AddedTime,
+			Period:     domain.MatchPeriod(goalScored.Period),
+			Side:       domain.MatchSide(goalScored.Side),
+			Player:     goalScored.Player,
+			OwnGoal:    goalScored.OwnGoal,
+			Penalty:    goalScored.Penalty,
+			RecordedAt: goalScored.ScoredAt,
+		}, nil
+	case "GoalDisallowed":
+		var goalDisallowed events.GoalDisallowed
+		if err := decodeEventData(event, &goalDisallowed); err != nil {
+			return nil, err
+		}
+		return &domain.TimelineEntry{
+			ID:         goalDisallowed.ID,
+			Type:       domain.TimelineGoalDisallowed,
+			Minute:     goalDisallowed.Minute,
+			AddedTime:  goalDisallowed.AddedTime,
+			Period:     domain.MatchPeriod(goalDisallowed.Period),
+			Side:       domain.MatchSide(goalDisallowed.Side),
+			GoalID:     goalDisallowed.GoalID,
+			Reason:     goalDisallowed.Reason,
+			RecordedAt: goalDisallowed.DisallowedAt,
+		}, nil
+	case "CardShown":
+		var cardShown events.CardShown
+		if err := decodeEventData(event, &cardShown); err != nil {
+			return nil, err
+		}
+		return &domain.TimelineEntry{
+			ID:         cardShown.ID,
+			Type:       domain.TimelineCard,
+			Minute:     cardShown.Minute,
+			AddedTime:  cardShown.AddedTime,
+			Period:     domain.MatchPeriod(cardShown.Period),
+			Side:       domain.MatchSide(cardShown.Side),
+			Player:     cardShown.Player,
+			Card:       domain.CardType(cardShown.Card),
+			RecordedAt: cardShown.ShownAt,
+		}, nil
+	case "PeriodChanged":
+		var periodChanged events.PeriodChanged
+		if err := decodeEventData(event, &periodChanged); err != nil {
+			return nil, err
+		}
+		return &domain.TimelineEntry{
+			ID:         periodChanged.ID,
+			Type:       domain.TimelinePeriod,
+			Minute:     periodChanged.Minute,
+			Period:     domain.MatchPeriod(periodChanged.Period),
+			RecordedAt: periodChanged.ChangedAt,
+		}, nil
+	}
+	return nil, nil
+}
+
+// ReplayTimeline rebuilds a match's in-play timeline from its event history,
+// along with the match's status and live score
+func ReplayTimeline(matchEvents []*events.Event) (*domain.MatchTimeline, error) {
+	match, err := ReplayMatch(matchEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := domain.NewMatchTimeline(match)
+	for _, event := range matchEvents {
+		entry, err := TimelineEntryFromEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			timeline.Record(entry)
+		}
+	}
+	return timeline, nil
+}
+
+// ReplayScoreHistory returns every score recorded for a match, oldest first,
+// with corrections made after it finished marked as such
+func ReplayScoreHistory(matchEvents []*events.Event) ([]domain.ScoreRevision, error) {
+	history := make([]domain.ScoreRevision, 0)
+	for _, event := range matchEvents {
+		var scoreUpdated events.MatchScoreUpdated
+		revision := domain.ScoreRevision{Revision: len(history) + 1}
+		switch event.Type {
+		case "MatchScoreUpdated":
+			if err := decodeEventData(event, &scoreUpdated); err != nil {
+				return nil, err
+			}
+		case "MatchScoreCorrected":
+			var scoreCorrected events.MatchScoreCorrected
+			if err := decodeEventData(event, &scoreCorrected); err != nil {
+				return nil, err
+			}
+			scoreUpdated = scoreCorrected.MatchScoreUpdated
+			revision.Correction = true
+			revision.Reason = scoreCorrected.Reason
+		default:
+			continue
+		}
+		revision.MatchID = scoreUpdated.MatchID
+		revision.Score = ScoreFromEvent(scoreUpdated)
+		revision.RecordedAt = scoreUpdated.UpdatedAt
+		history = append(history, revision)
+	}
+	return history, nil
+}
+
+// LoadMarginMatch returns the match whose margin breaks ties in winner
+// tipping for the round the given match belongs to. The round's matches are
+// taken from their creation events and kicked off at their rescheduled
+// dates, with the given match as it stands now.
+func LoadMarginMatch(ctx context.Context, eventStore eventstore.EventStore, match *domain.Match) (*domain.Match, error) {
+	scheduled, err := LoadScheduledMatches(ctx, eventStore)
+	if err != nil {
+		return nil, err
+	}
+
+	roundMatches := []*domain.Match{match}
+	for _, other := range scheduled {
+		if other.ID != match.ID && other.RoundKey() == match.RoundKey() {
+			roundMatches = append(roundMatches, other)
+		}
+	}
+	return domain.MarginMatch(roundMatches), nil
+}
+
+// LoadScheduledMatches returns every match as created, kicking off at its
+// rescheduled date. Scores and statuses are not replayed.
+func LoadScheduledMatches(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.Match, error) {
+	scheduleEvents, err := loadEventsByTypes(ctx, eventStore, []string{"MatchCreated", "MatchRescheduled"})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*domain.Match
+	byID := make(map[string]*domain.Match)
+	for _, event := range scheduleEvents {
+		if event.Type == "MatchRescheduled" {
+			var rescheduled events.MatchRescheduled
+			if err := decodeEventData(event, &rescheduled); err != nil {
+				return nil, err
+			}
+			if match, ok := byID[rescheduled.MatchID]; ok {
+				match.Date = rescheduled.Date.UTC()
+			}
+			continue
+		}
+
+		match, err := ReplayMatch([]*events.Event{event})
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+		byID[match.ID] = match
+	}
+	return matches, nil
+}
+
+// oddsFromEvent builds the match odds recorded by an odds update
+func oddsFromEvent(oddsUpdated events.MatchOddsUpdated) *domain.MatchOdds {
+	return &domain.MatchOdds{
+		Home:      oddsUpdated.Home,
+		Draw:      oddsUpdated.Draw,
+		Away:      oddsUpdated.Away,
+		Source:    oddsUpdated.Source,
+		UpdatedAt: oddsUpdated.UpdatedAt,
+	}
+}
+
+// matchEventTypes are the event types that make up a match's schedule,
+// result and odds; in-play events are left out
+var matchEventTypes = []string{"MatchCreated", "MatchRescheduled", "MatchScoreUpdated", "MatchScoreCorrected", "MatchStatusChanged", "MatchOddsUpdated"}
+
+// LoadMatches returns every match with its current kickoff, status, score and
+// odds, in the order they were created. The live score of a match in play is
+// not replayed.
+func LoadMatches(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.Match, error) {
+	matchEvents, err := loadEventsByTypes(ctx, eventStore, matchEventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	var matchIDs []string
+	byMatch := make(map[string][]*events.Event)
+	for _, event := range matchEvents {
+		matchID, err := matchEventID(event)
+		if err != nil {
+			return nil, err
+		}
+		if event.Type == "MatchCreated" {
+			matchIDs = append(matchIDs, matchID)
+		}
+		byMatch[matchID] = append(byMatch[matchID], event)
+	}
+
+	matches := make([]*domain.Match, 0, len(matchIDs))
+	for _, matchID := range matchIDs {
+		match, err := ReplayMatch(byMatch[matchID])
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// matchEventID returns the ID of the match a match event is about
+func matchEventID(event *events.Event) (string, error) {
+	switch event.Type {
+	case "MatchCreated":
+		var matchCreated events.MatchCreated
+		err := decodeEventData(event, &matchCreated)
+		return matchCreated.ID, err
+	case "MatchScoreCorrected":
+		var scoreCorrected events.MatchScoreCorrected
+		err := decodeEventData(event, &scoreCorrected)
+		return scoreCorrected.MatchID, err
+	default:
+		var matchEvent struct{ MatchID string }
+		err := decodeEventData(event, &matchEvent)
+		return matchEvent.MatchID, err
+	}
+}
+
+// ScoreFromEvent builds the full match score recorded by a score update
+func ScoreFromEvent(scoreUpdated events.MatchScoreUpdated) domain.Score {
+	score := domain.Score{
+		HomeGoals: scoreUpdated.HomeGoals,
+		AwayGoals: scoreUpdated.AwayGoals,
+	}
+	if scoreUpdated.ExtraTime != nil {
+		score.ExtraTime = &domain.Scoreline{
+			HomeGoals: scoreUpdated.ExtraTime.HomeGoals,
+			AwayGoals: scoreUpdated.ExtraTime.AwayGoals,
+		}
+	}
+	if scoreUpdated.Penalties != nil {
+		score.Penalties = &domain.Scoreline{
+			HomeGoals: scoreUpdated.Penalties.HomeGoals,
+			AwayGoals: scoreUpdated.Penalties.AwayGoals,
+		}
+	}
+	return score
+}
+
+// competitionEventTypes are the event types that make up the competition catalog
+var competitionEventTypes = []string{"CompetitionCreated", "CompetitionResultBasisChanged", "CompetitionTippingModeChanged", "CompetitionTiebreakersChanged", "SeasonCreated", "SeasonStatusChanged"}
+
+// CompetitionCatalog holds every competition and season, rebuilt from events
+type CompetitionCatalog struct {
+	Competitions []*domain.Competition
+	Seasons      []*domain.Season
+}
+
+// LoadCompetitionCatalog retrieves and replays every competition and season event
+func LoadCompetitionCatalog(ctx context.Context, eventStore eventstore.EventStore) (*CompetitionCatalog, error) {
+	catalogEvents, err := loadEventsByTypes(ctx, eventStore, competitionEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayCompetitionCatalog(catalogEvents)
+}
+
+// ReplayCompetitionCatalog rebuilds the competitions and seasons from their events
+func ReplayCompetitionCatalog(catalogEvents []*events.Event) (*CompetitionCatalog, error) {
+	catalog := &CompetitionCatalog{
+		Competitions: make([]*domain.Competition, 0),
+		Seasons:      make([]*domain.Season, 0),
+	}
+	for _, event := range catalogEvents {
+		switch event.Type {
+		case "CompetitionCreated":
+			var competitionCreated events.CompetitionCreated
+			if err := decodeEventData(event, &competitionCreated); err != nil {
+				return nil, err
+			}
+			competition := domain.NewCompetition(
+				competitionCreated.ID,
+				competitionCreated.Name,
+				domain.Sport(competitionCreated.Sport),
+				competitionCreated.Aliases,
+			)
+			if competitionCreated.ResultBasis != "" {
+				competition.ResultBasis = domain.ResultBasis(competitionCreated.ResultBasis)
+			}
+			if competitionCreated.TippingMode != "" {
+				competition.TippingMode = domain.TippingMode(competitionCreated.TippingMode)
+			}
+			catalog.Competitions = append(catalog.Competitions, competition)
+		case "CompetitionResultBasisChanged":
+			var basisChanged events.CompetitionResultBasisChanged
+			if err := decodeEventData(event, &basisChanged); err != nil {
+				return nil, err
+			}
+			if competition := catalog.Competition(basisChanged.CompetitionID); competition != nil {
+				competition.ResultBasis = domain.ResultBasis(basisChanged.ResultBasis)
+			}
+		case "CompetitionTippingModeChanged":
+			var modeChanged events.CompetitionTippingModeChanged
+			if err := decodeEventData(event, &modeChanged); err != nil {
+				return nil, err
+			}
+			if competition := catalog.Competition(modeChanged.CompetitionID); competition != nil {
+				competition.TippingMode = domain.TippingMode(modeChanged.TippingMode)
+			}
+		case "CompetitionTiebreakersChanged":
+			var tiebreakersChanged events.CompetitionTiebreakersChanged
+			if err := decodeEventData(event, &tiebreakersChanged); err != nil {
+				return nil, err
+			}
+			if competition := catalog.Competition(tiebreakersChanged.CompetitionID); competition != nil {
+				competition.Tiebreakers = make([]domain.Tiebreaker, 0, len(tiebreakersChanged.Tiebreakers))
+				for _, tiebreaker := range tiebreakersChanged.Tiebreakers {
+					competition.Tiebreakers = append(competition.Tiebreakers, domain.Tiebreaker(tiebreaker))
+				}
+			}
+		case "SeasonCreated":
+			var seasonCreated events.SeasonCreated
+			if err := decodeEventData(event, &seasonCreated); err != nil {
+				return nil, err
+			}
+			catalog.Seasons = append(catalog.Seasons, domain.NewSeason(
+				seasonCreated.ID,
+				seasonCreated.CompetitionID,
+				seasonCreated.Name,
+				seasonCreated.StartDate,
+				seasonCreated.EndDate,
+			))
+		case "SeasonStatusChanged":
+			var statusChanged events.SeasonStatusChanged
+			if err := decodeEventData(event, &statusChanged); err != nil {
+				return nil, err
+			}
+			if season := catalog.Season(statusChanged.SeasonID); season != nil {
+				season.Status = domain.SeasonStatus(statusChanged.Status)
+			}
+		}
+	}
+	return catalog, nil
+}
+
+// Competition finds a competition by ID, name or alias, or returns nil
+func (c *CompetitionCatalog) Competition(ref string) *domain.Competition {
+	return domain.ResolveCompetition(c.Competitions, ref)
+}
+
+// Season finds a season by ID, or returns nil
+func (c *CompetitionCatalog) Season(seasonID string) *domain.Season {
+	for _, season := range c.Seasons {
+		if season.ID == seasonID {
+			return season
+		}
+	}
+	return nil
+}
+
+// SeasonsOf returns the seasons of a competition, in the order they were created
+func (c *CompetitionCatalog) SeasonsOf(competitionID string) []*domain.Season {
+	seasons := make([]*domain.Season, 0)
+	for _, season := range c.Seasons {
+		if season.CompetitionID == competitionID {
+			seasons = append(seasons, season)
+		}
+	}
+	return seasons
+}
+
+// teamEventTypes are the event types that make up the team registry
+var teamEventTypes = []string{"TeamCreated", "TeamUpdated"}
+
+// TeamRegistry holds every registered team, rebuilt from events
+type TeamRegistry struct {
+	Teams []*domain.Team
+}
+
+// LoadTeamRegistry retrieves and replays every team event
+func LoadTeamRegistry(ctx context.Context, eventStore eventstore.EventStore) (*TeamRegistry, error) {
+	teamEvents, err := loadEventsByTypes(ctx, eventStore, teamEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayTeamRegistry(teamEvents)
+}
+
+// ReplayTeamRegistry rebuilds the registered teams from their events
+func ReplayTeamRegistry(teamEvents []*events.Event) (*TeamRegistry, error) {
+	registry := &TeamRegistry{Teams: make([]*domain.Team, 0)}
+	for _, event := range teamEvents {
+		switch event.Type {
+		case "TeamCreated":
+			var teamCreated events.TeamCreated
+			if err := decodeEventData(event, &teamCreated); err != nil {
+				return nil, err
+			}
+			team := domain.NewTeam(
+				teamCreated.ID,
+				teamCreated.Name,
+				teamCreated.ShortCode,
+				teamCreated.Aliases,
+			)
+			team.TimeZone = teamCreated.TimeZone
+			registry.Teams = append(registry.Teams, team)
+		case "TeamUpdated":
+			var teamUpdated events.TeamUpdated
+			if err := decodeEventData(event, &teamUpdated); err != nil {
+				return nil, err
+			}
+			for i, team := range registry.Teams {
+				if team.ID == teamUpdated.TeamID {
+					registry.Teams[i] = domain.NewTeam(team.ID, teamUpdated.Name, teamUpdated.ShortCode, teamUpdated.Aliases)
+					registry.Teams[i].TimeZone = teamUpdated.TimeZone
+				}
+			}
+		}
+	}
+	return registry, nil
+}
+
+// Team finds a team by ID, name, short code or alias, or returns nil
+func (r *TeamRegistry) Team(ref string) *domain.Team {
+	return domain.ResolveTeam(r.Teams, ref)
+}
+
+// bracketEventTypes are the event types that make up the knockout brackets
+var bracketEventTypes = []string{"BracketCreated", "BracketTieMatchLinked"}
+
+// LoadBrackets retrieves and replays every bracket event
+func LoadBrackets(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.Bracket, error) {
+	bracketEvents, err := loadEventsByTypes(ctx, eventStore, bracketEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayBrackets(bracketEvents)
+}
+
+// ReplayBrackets rebuilds the knockout brackets from their events
+func ReplayBrackets(bracketEvents []*events.Event) ([]*domain.Bracket, error) {
+	brackets := make([]*domain.Bracket, 0)
+	for _, event := range bracketEvents {
+		switch event.Type {
+		case "BracketCreated":
+			var bracketCreated events.BracketCreated
+			if err := decodeEventData(event, &bracketCreated); err != nil {
+				return nil, err
+			}
+			ties := make([]*domain.BracketTie, 0, len(bracketCreated.Ties))
+			for _, tie := range bracketCreated.Ties {
+				ties = append(ties, &domain.BracketTie{
+					ID:      tie.ID,
+					Round:   tie.Round,
+					Home:    domain.TieSlot{TeamID: tie.HomeTeamID, WinnerOf: tie.HomeWinnerOf},
+					Away:    domain.TieSlot{TeamID: tie.AwayTeamID, WinnerOf: tie.AwayWinnerOf},
+					MatchID: tie.MatchID,
+				})
+			}
+			bracket := domain.NewBracket(
+				bracketCreated.ID,
+				bracketCreated.CompetitionID,
+				bracketCreated.Name,
+				bracketCreated.LockAt,
+				bracketCreated.RoundWeights,
+				ties,
+			)
+			bracket.SeasonID = bracketCreated.SeasonID
+			brackets = append(brackets, bracket)
+		case "BracketTieMatchLinked":
+			var tieMatchLinked events.BracketTieMatchLinked
+			if err := decodeEventData(event, &tieMatchLinked); err != nil {
+				return nil, err
+			}
+			if bracket := FindBracket(brackets, tieMatchLinked.BracketID); bracket != nil {
+				if tie := bracket.Tie(tieMatchLinked.TieID); tie != nil {
+					tie.MatchID = tieMatchLinked.MatchID
+				}
+			}
+		}
+	}
+	return brackets, nil
+}
+
+// FindBracket returns the bracket with the given ID, or nil if there is none
+func FindBracket(brackets []*domain.Bracket, bracketID string) *domain.Bracket {
+	for _, bracket := range brackets {
+		if bracket.ID == bracketID {
+			return bracket
+		}
+	}
+	return nil
+}
+
+// bracketEntryEventTypes are the event types that make up bracket entries and their points
+var bracketEntryEventTypes = []string{"BracketEntrySubmitted", "BracketPointsAwarded"}
+
+// LoadBracketEntries retrieves and replays the entries for a bracket
+func LoadBracketEntries(ctx context.Context, eventStore eventstore.EventStore, bracketID string) ([]*domain.BracketEntry, error) {
+	entryEvents, err := loadEventsByTypes(ctx, eventStore, bracketEntryEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayBracketEntries(entryEvents, bracketID)
+}
+
+// ReplayBracketEntries rebuilds a bracket's entries, with the points awarded
+// to them so far. A resubmitted entry keeps its ID and takes the new picks,
+// and a correction replaces the entry's earlier award for the tie.
+func ReplayBracketEntries(entryEvents []*events.Event, bracketID string) ([]*domain.BracketEntry, error) {
+	entries := make([]*domain.BracketEntry, 0)
+	byID := make(map[string]*domain.BracketEntry)
+	for _, event := range entryEvents {
+		switch event.Type {
+		case "BracketEntrySubmitted":
+			var entrySubmitted events.BracketEntrySubmitted
+			if err := decodeEventData(event, &entrySubmitted); err != nil {
+				return nil, err
+			}
+			if entrySubmitted.BracketID != bracketID {
+				continue
+			}
+			if entry, ok := byID[entrySubmitted.ID]; ok {
+				entry.Picks = entrySubmitted.Picks
+				entry.SubmittedAt = entrySubmitted.SubmittedAt
+				continue
+			}
+			entry := domain.NewBracketEntry(
+				entrySubmitted.ID,
+				entrySubmitted.BracketID,
+				entrySubmitted.UserID,
+				entrySubmitted.Picks,
+				entrySubmitted.SubmittedAt,
+			)
+			byID[entry.ID] = entry
+			entries = append(entries, entry)
+		case "BracketPointsAwarded":
+			var pointsAwarded events.BracketPointsAwarded
+			if err := decodeEventData(event, &pointsAwarded); err != nil {
+				return nil, err
+			}
+			if entry, ok := byID[pointsAwarded.EntryID]; ok && (pointsAwarded.Correction || !entry.IsScored(pointsAwarded.TieID)) {
+				entry.Award(pointsAwarded.TieID, pointsAwarded.Points)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// FindBracketEntry returns a user's entry in a bracket, or nil if they have none
+func FindBracketEntry(entries []*domain.BracketEntry, userID string) *domain.BracketEntry {
+	for _, entry := range entries {
+		if entry.UserID == userID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// outrightMarketEventTypes are the event types that make up the outright markets
+var outrightMarketEventTypes = []string{"OutrightMarketCreated", "OutrightMarketSettled"}
+
+// LoadOutrightMarkets retrieves and replays every outright market event
+func LoadOutrightMarkets(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.OutrightMarket, error) {
+	marketEvents, err := loadEventsByTypes(ctx, eventStore, outrightMarketEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayOutrightMarkets(marketEvents)
+}
+
+// ReplayOutrightMarkets rebuilds the outright markets from their events
+func ReplayOutrightMarkets(marketEvents []*events.Event) ([]*domain.OutrightMarket, error) {
+	markets := make([]*domain.OutrightMarket, 0)
+	for _, event := range marketEvents {
+		switch event.Type {
+		case "OutrightMarketCreated":
+			var marketCreated events.OutrightMarketCreated
+			if err := decodeEventData(event, &marketCreated); err != nil {
+				return nil, err
+			}
+			market := domain.NewOutrightMarket(
+				marketCreated.ID,
+				marketCreated.CompetitionID,
+				marketCreated.Name,
+				marketCreated.Selections,
+				marketCreated.PointsPerPick,
+				marketCreated.Deadline,
+			)
+			market.SeasonID = marketCreated.SeasonID
+			markets = append(markets, market)
+		case "OutrightMarketSettled":
+			var marketSettled events.OutrightMarketSettled
+			if err := decodeEventData(event, &marketSettled); err != nil {
+				return nil, err
+			}
+			if market := FindOutrightMarket(markets, marketSettled.MarketID); market != nil {
+				settledAt := marketSettled.SettledAt
+				market.Status = domain.MarketStatusSettled
+				market.Result = marketSettled.Result
+				market.SettledAt = &settledAt
+			}
+		}
+	}
+	return markets, nil
+}
+
+// FindOutrightMarket returns the market with the given ID, or nil if there is none
+func FindOutrightMarket(markets []*domain.OutrightMarket, marketID string) *domain.OutrightMarket {
+	for _, market := range markets {
+		if market.ID == marketID {
+			return market
+		}
+	}
+	return nil
+}
+
+// outrightTipEventTypes are the event types that make up outright tips and their points
+var outrightTipEventTypes = []string{"OutrightTipSubmitted", "OutrightPointsAwarded"}
+
+// LoadOutrightTips retrieves and replays the tips for an outright market
+func LoadOutrightTips(ctx context.Context, eventStore eventstore.EventStore, marketID string) ([]*domain.OutrightTip, error) {
+	tipEvents, err := loadEventsByTypes(ctx, eventStore, outrightTipEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayOutrightTips(tipEvents, marketID)
+}
+
+// ReplayOutrightTips rebuilds a market's tips, with their points once the
+// market is settled. A resubmitted tip keeps its ID and takes the new picks.
+func ReplayOutrightTips(tipEvents []*events.Event, marketID string) ([]*domain.OutrightTip, error) {
+	tips := make([]*domain.OutrightTip, 0)
+	byID := make(map[string]*domain.OutrightTip)
+	for _, event := range tipEvents {
+		switch event.Type {
+		case "OutrightTipSubmitted":
+			var tipSubmitted events.OutrightTipSubmitted
+			if err := decodeEventData(event, &tipSubmitted); err != nil {
+				return nil, err
+			}
+			if tipSubmitted.MarketID != marketID {
+				continue
+			}
+			if tip, ok := byID[tipSubmitted.ID]; ok {
+				tip.Picks = tipSubmitted.Picks
+				tip.SubmittedAt = tipSubmitted.SubmittedAt
+				continue
+			}
+			tip := domain.NewOutrightTip(
+				tipSubmitted.ID,
+				tipSubmitted.MarketID,
+				tipSubmitted.UserID,
+				tipSubmitted.Picks,
+				tipSubmitted.SubmittedAt,
+			)
+			byID[tip.ID] = tip
+			tips = append(tips, tip)
+		case "OutrightPointsAwarded":
+			var pointsAwarded events.OutrightPointsAwarded
+			if err := decodeEventData(event, &pointsAwarded); err != nil {
+				return nil, err
+			}
+			if tip, ok := byID[pointsAwarded.TipID]; ok {
+				tip.Points = pointsAwarded.Points
+				tip.Settled = true
+			}
+		}
+	}
+	return tips, nil
+}
+
+// FindOutrightTip returns a user's tip in a market, or nil if they have none
+func FindOutrightTip(tips []*domain.OutrightTip, userID string) *domain.OutrightTip {
+	for _, tip := range tips {
+		if tip.UserID == userID {
+			return tip
+		}
+	}
+	return nil
+}
+
+// survivorPoolEventTypes are the event types that make up the survivor pools
+var survivorPoolEventTypes = []string{"SurvivorPoolCreated"}
+
+// LoadSurvivorPools retrieves and replays every survivor pool event
+func LoadSurvivorPools(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.SurvivorPool, error) {
+	poolEvents, err := loadEventsByTypes(ctx, eventStore, survivorPoolEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplaySurvivorPools(poolEvents)
+}
+
+// ReplaySurvivorPools rebuilds the survivor pools from their events
+func ReplaySurvivorPools(poolEvents []*events.Event) ([]*domain.SurvivorPool, error) {
+	pools := make([]*domain.SurvivorPool, 0)
+	for _, event := range poolEvents {
+		if event.Type != "SurvivorPoolCreated" {
+			continue
+		}
+		var poolCreated events.SurvivorPoolCreated
+		if err := decodeEventData(event, &poolCreated); err != nil {
+			return nil, err
+		}
+		pool := domain.NewSurvivorPool(poolCreated.ID, poolCreated.Name, poolCreated.CompetitionID, poolCreated.CreatedAt)
+		pool.SeasonID = poolCreated.SeasonID
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
+// FindSurvivorPool returns the pool with the given ID, or nil if there is none
+func FindSurvivorPool(pools []*domain.SurvivorPool, poolID string) *domain.SurvivorPool {
+	for _, pool := range pools {
+		if pool.ID == poolID {
+			return pool
+		}
+	}
+	return nil
+}
+
+// survivorEntryEventTypes are the event types that make up survivor picks and their outcomes
+var survivorEntryEventTypes = []string{"SurvivorPickMade", "SurvivorPickSettled"}
+
+// LoadSurvivorEntries retrieves and replays the entries in a survivor pool
+func LoadSurvivorEntries(ctx context.Context, eventStore eventstore.EventStore, poolID string) ([]*domain.SurvivorEntry, error) {
+	entryEvents, err := loadEventsByTypes(ctx, eventStore, survivorEntryEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplaySurvivorEntries(entryEvents, poolID)
+}
+
+// ReplaySurvivorEntries rebuilds a pool's entries, one per player who has
+// picked, with each pick's outcome once its match is final. A changed pick
+// replaces the player's earlier pick for the round.
+func ReplaySurvivorEntries(entryEvents []*events.Event, poolID string) ([]*domain.SurvivorEntry, error) {
+	entries := make([]*domain.SurvivorEntry, 0)
+	for _, event := range entryEvents {
+		switch event.Type {
+		case "SurvivorPickMade":
+			var pickMade events.SurvivorPickMade
+			if err := decodeEventData(event, &pickMade); err != nil {
+				return nil, err
+			}
+			if pickMade.PoolID != poolID {
+				continue
+			}
+			entry := FindSurvivorEntry(entries, pickMade.UserID)
+			if entry == nil {
+				entry = domain.NewSurvivorEntry(pickMade.PoolID, pickMade.UserID)
+				entries = append(entries, entry)
+			}
+			entry.Pick(&domain.SurvivorPick{
+				ID:       pickMade.ID,
+				RoundID:  pickMade.RoundID,
+				MatchID:  pickMade.MatchID,
+				TeamID:   pickMade.TeamID,
+				PickedAt: pickMade.PickedAt,
+				Outcome:  domain.SurvivorOutcomePending,
+			})
+		case "SurvivorPickSettled":
+			var pickSettled events.SurvivorPickSettled
+			if err := decodeEventData(event, &pickSettled); err != nil {
+				return nil, err
+			}
+			if pickSettled.PoolID != poolID {
+				continue
+			}
+			if entry := FindSurvivorEntry(entries, pickSettled.UserID); entry != nil {
+				entry.Settle(pickSettled.PickID, domain.SurvivorOutcome(pickSettled.Outcome))
+			}
+		}
+	}
+	return entries, nil
+}
+
+// FindSurvivorEntry returns a player's entry in a pool, or nil if they have
+// not picked
+func FindSurvivorEntry(entries []*domain.SurvivorEntry, userID string) *domain.SurvivorEntry {
+	for _, entry := range entries {
+		if entry.UserID == userID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// leagueEventTypes are the event types that make up the private leagues
+var leagueEventTypes = []string{"LeagueCreated", "LeagueMemberJoined", "LeagueMemberLeft", "LeagueSettingsChanged", "LeagueRulesChanged", "LeagueFixturesGenerated"}
+
+// LoadLeagues retrieves and replays every league event
+func LoadLeagues(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.League, error) {
+	leagueEvents, err := loadEventsByTypes(ctx, eventStore, leagueEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayLeagues(leagueEvents)
+}
+
+// ReplayLeagues rebuilds the leagues and their members from their events
+func ReplayLeagues(leagueEvents []*events.Event) ([]*domain.League, error) {
+	leagues := make([]*domain.League, 0)
+	for _, event := range leagueEvents {
+		switch event.Type {
+		case "LeagueCreated":
+			var leagueCreated events.LeagueCreated
+			if err := decodeEventData(event, &leagueCreated); err != nil {
+				return nil, err
+			}
+			league := domain.NewLeague(
+				leagueCreated.ID,
+				leagueCreated.Name,
+				leagueCreated.OwnerID,
+				leagueCreated.InviteCode,
+				leagueCreated.CreatedAt,
+			)
+			league.CompetitionID = leagueCreated.CompetitionID
+			league.SeasonID = leagueCreated.SeasonID
+			if leagueCreated.Format != "" {
+				league.Format = domain.LeagueFormat(leagueCreated.Format)
+			}
+			leagues = append(leagues, league)
+		case "LeagueMemberJoined":
+			var memberJoined events.LeagueMemberJoined
+			if err := decodeEventData(event, &memberJoined); err != nil {
+				return nil, err
+			}
+			if league := FindLeague(leagues, memberJoined.LeagueID); league != nil {
+				_ = league.Join(memberJoined.UserID, memberJoined.JoinedAt)
+			}
+		case "LeagueMemberLeft":
+			var memberLeft events.LeagueMemberLeft
+			if err := decodeEventData(event, &memberLeft); err != nil {
+				return nil, err
+			}
+			if league := FindLeague(leagues, memberLeft.LeagueID); league != nil {
+				_ = league.Leave(memberLeft.UserID)
+			}
+		case "LeagueSettingsChanged":
+			var settingsChanged events.LeagueSettingsChanged
+			if err := decodeEventData(event, &settingsChanged); err != nil {
+				return nil, err
+			}
+			if league := FindLeague(leagues, settingsChanged.LeagueID); league != nil {
+				league.Name = settingsChanged.Name
+				league.CompetitionID = settingsChanged.CompetitionID
+				league.SeasonID = settingsChanged.SeasonID
+				if settingsChanged.InviteCode != "" {
+					league.InviteCode = settingsChanged.InviteCode
+				}
+				if settingsChanged.Format != "" {
+					league.Format = domain.LeagueFormat(settingsChanged.Format)
+				}
+			}
+		case "LeagueFixturesGenerated":
+			var fixturesGenerated events.LeagueFixturesGenerated
+			if err := decodeEventData(event, &fixturesGenerated); err != nil {
+				return nil, err
+			}
+			if league := FindLeague(leagues, fixturesGenerated.LeagueID); league != nil {
+				league.Fixtures = make([]*domain.Matchup, 0, len(fixturesGenerated.Fixtures))
+				for _, fixture := range fixturesGenerated.Fixtures {
+					league.Fixtures = append(league.Fixtures, domain.NewMatchup(fixture.RoundID, fixture.HomeUserID, fixture.AwayUserID))
+				}
+			}
+		case "LeagueRulesChanged":
+			var rulesChanged events.LeagueRulesChanged
+			if err := decodeEventData(event, &rulesChanged); err != nil {
+				return nil, err
+			}
+			if league := FindLeague(leagues, rulesChanged.LeagueID); league != nil {
+				league.Rules = domain.LeagueRules{
+					LockCutoff: rulesChanged.LockCutoff,
+					Jokers:     rulesChanged.Jokers,
+					RoundIDs:   rulesChanged.RoundIDs,
+					MatchIDs:   rulesChanged.MatchIDs,
+					AutoTip:    domain.AutoTipPolicy(rulesChanged.AutoTip),
+				}
+				if rulesChanged.Scoring != nil {
+					spec := scoringSpec(rulesChanged.Scoring)
+					league.Rules.Scoring = &spec
+				}
+			}
+		}
+	}
+	return leagues, nil
+}
+
+// FindLeague returns the league with the given ID, or nil if there is none
+func FindLeague(leagues []*domain.League, leagueID string) *domain.League {
+	for _, league := range leagues {
+		if league.ID == leagueID {
+			return league
+		}
+	}
+	return nil
+}
+
+// FindLeagueByInviteCode returns the league the invite code opens, or nil if
+// there is none
+func FindLeagueByInviteCode(leagues []*domain.League, code string) *domain.League {
+	for _, league := range leagues {
+		if league.HasInviteCode(code) {
+			return league
+		}
+	}
+	return nil
+}
+
+// awardEventTypes are the event types that award points towards the leaderboard
+var awardEventTypes = []string{"PointsAwarded", "BracketPointsAwarded", "OutrightPointsAwarded"}
+
+// LoadNotifications returns the notifications sent to a user, oldest first
+func LoadNotifications(ctx context.Context, eventStore eventstore.EventStore, userID string) ([]*domain.Notification, error) {
+	notificationEvents, err := eventStore.GetEventsByType(ctx, "NotificationSent")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NotificationSent events: %w", err)
+	}
+
+	notifications := make([]*domain.Notification, 0)
+	for _, event := range notificationEvents {
+		var notificationSent events.NotificationSent
+		if err := decodeEventData(event, &notificationSent); err != nil {
+			return nil, err
+		}
+		if notificationSent.UserID != userID {
+			continue
+		}
+		notifications = append(notifications, &domain.Notification{
+			ID:      notificationSent.ID,
+			UserID:  notificationSent.UserID,
+			Type:    domain.NotificationType(notificationSent.Type),
+			MatchID: notificationSent.MatchID,
+			Message: notificationSent.Message,
+			SentAt:  notificationSent.SentAt,
+		})
+	}
+	return notifications, nil
+}
+
+// userEventTypes are the event types that make up the registered users
+var userEventTypes = []string{"UserRegistered", "UserProfileUpdated", "UserDeactivated"}
+
+// LoadUsers retrieves and replays every user event
+func LoadUsers(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.User, error) {
+	userEvents, err := loadEventsByTypes(ctx, eventStore, userEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayUsers(userEvents)
+}
+
+// ReplayUsers rebuilds the registered users from their events
+func ReplayUsers(userEvents []*events.Event) ([]*domain.User, error) {
+	users := make([]*domain.User, 0)
+	for _, event := range userEvents {
+		switch event.Type {
+		case "UserRegistered":
+			var userRegistered events.UserRegistered
+			if err := decodeEventData(event, &userRegistered); err != nil {
+				return nil, err
+			}
+			user := domain.NewUser(userRegistered.ID, userRegistered.Username, userRegistered.Email)
+			user.JoinDate = userRegistered.RegisteredAt
+			users = append(users, user)
+		case "UserProfileUpdated":
+			var profileUpdated events.UserProfileUpdated
+			if err := decodeEventData(event, &profileUpdated); err != nil {
+				return nil, err
+			}
+			if user := FindUser(users, profileUpdated.UserID); user != nil {
+				user.Username = profileUpdated.Username
+				user.Email = profileUpdated.Email
+			}
+		case "UserDeactivated":
+			var userDeactivated events.UserDeactivated
+			if err := decodeEventData(event, &userDeactivated); err != nil {
+				return nil, err
+			}
+			if user := FindUser(users, userDeactivated.UserID); user != nil {
+				deactivatedAt := userDeactivated.DeactivatedAt
+				user.DeactivatedAt = &deactivatedAt
+			}
+		}
+	}
+	return users, nil
+}
+
+// FindUser returns the user with the given ID, or nil
+func FindUser(users []*domain.User, userID string) *domain.User {
+	for _, user := range users {
+		if user.ID == userID {
+			return user
+		}
+	}
+	return nil
+}
+
+// LoadUserPreferences returns a user's latest preferences, or the defaults if
+// they have never set any
+func LoadUserPreferences(ctx context.Context, eventStore eventstore.EventStore, userID string) (*domain.UserPreferences, error) {
+	preferenceEvents, err := eventStore.GetEventsByType(ctx, "UserPreferencesUpdated")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get UserPreferencesUpdated events: %w", err)
+	}
+
+	preferences := domain.NewUserPreferences(userID)
+	for _, event := range preferenceEvents {
+		var preferencesUpdated events.UserPreferencesUpdated
+		if err := decodeEventData(event, &preferencesUpdated); err != nil {
+			return nil, err
+		}
+		if preferencesUpdated.UserID != userID {
+			continue
+		}
+		updatedAt := preferencesUpdated.UpdatedAt
+		preferences.TimeZone = preferencesUpdated.TimeZone
+		preferences.UpdatedAt = &updatedAt
+	}
+	return preferences, nil
+}
+
+// LoadAchievementStats works out every user's achievement statistics from
+// the tips they made and the points awarded for them
+func LoadAchievementStats(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.AchievementStats, error) {
+	tipEvents, err := LoadPredictionEvents(ctx, eventStore)
+	if err != nil {
+		return nil, err
+	}
+	tips, err := ReplayPredictions(tipEvents, "")
+	if err != nil {
+		return nil, err
+	}
+
+	awardEvents, err := eventStore.GetEventsByType(ctx, "PointsAwarded")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PointsAwarded events: %w", err)
+	}
+	awards, err := ReplayPointsAwards(awardEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := LoadScheduledMatches(ctx, eventStore)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildAchievementStats(tips, awards, matches), nil
+}
+
+// FindAchievementStats returns the user's statistics, or empty statistics if
+// they have not tipped
+func FindAchievementStats(users []*domain.AchievementStats, userID string) *domain.AchievementStats {
+	for _, stats := range users {
+		if stats.UserID == userID {
+			return stats
+		}
+	}
+	return &domain.AchievementStats{UserID: userID}
+}
+
+// LoadBadges returns every badge awarded, oldest first
+func LoadBadges(ctx context.Context, eventStore eventstore.EventStore) ([]*domain.Badge, error) {
+	badgeEvents, err := eventStore.GetEventsByType(ctx, "BadgeAwarded")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BadgeAwarded events: %w", err)
+	}
+
+	badges := make([]*domain.Badge, 0, len(badgeEvents))
+	for _, event := range badgeEvents {
+		var badgeAwarded events.BadgeAwarded
+		if err := decodeEventData(event, &badgeAwarded); err != nil {
+			return nil, err
+		}
+		badge := &domain.Badge{
+			UserID:    badgeAwarded.UserID,
+			BadgeID:   badgeAwarded.BadgeID,
+			Name:      badgeAwarded.Name,
+			AwardedAt: badgeAwarded.AwardedAt,
+		}
+		if achievement := domain.FindAchievement(badgeAwarded.BadgeID); achievement != nil {
+			badge.Description = achievement.Description
+		}
+		badges = append(badges, badge)
+	}
+	return badges, nil
+}
+
+// LoadPointsAwards retrieves every points award, from match tips, bracket
+// ties and outright markets, in the order they were made
+func LoadPointsAwards(ctx context.Context, eventStore eventstore.EventStore) ([]domain.PointsAward, error) {
+	awardEvents, err := loadEventsByTypes(ctx, eventStore, awardEventTypes)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayPointsAwards(awardEvents)
+}
+
+// ReplayPointsAwards converts award events into the points awards the
+// leaderboard is built from
+func ReplayPointsAwards(awardEvents []*events.Event) ([]domain.PointsAward, error) {
+	awards := make([]domain.PointsAward, 0, len(awardEvents))
+	for _, event := range awardEvents {
+		switch event.Type {
+		case "PointsAwarded":
+			var pointsAwarded events.PointsAwarded
+			if err := decodeEventData(event, &pointsAwarded); err != nil {
+				return nil, err
+			}
+			awards = append(awards, matchAward(pointsAwarded))
+		case "BracketPointsAwarded":
+			var pointsAwarded events.BracketPointsAwarded
+			if err := decodeEventData(event, &pointsAwarded); err != nil {
+				return nil, err
+			}
+			awards = append(awards, domain.PointsAward{
+				UserID:   pointsAwarded.UserID,
+				Source:   domain.PointsSourceBracket,
+				SourceID: pointsAwarded.BracketID,
+				Key:      pointsAwarded.EntryID + "/" + pointsAwarded.TieID,
+				Points:   pointsAwarded.Points,
+			})
+		case "OutrightPointsAwarded":
+			var pointsAwarded events.OutrightPointsAwarded
+			if err := decodeEventData(event, &pointsAwarded); err != nil {
+				return nil, err
+			}
+			awards = append(awards, domain.PointsAward{
+				UserID:   pointsAwarded.UserID,
+				Source:   domain.PointsSourceOutright,
+				SourceID: pointsAwarded.MarketID,
+				Key:      pointsAwarded.TipID,
+				Points:   pointsAwarded.Points,
+			})
+		}
+	}
+	return awards, nil
+}
+
+// matchAward converts the points awarded for a match tip
+func matchAward(pointsAwarded events.PointsAwarded) domain.PointsAward {
+	award := domain.PointsAward{
+		UserID:      pointsAwarded.UserID,
+		Source:      domain.PointsSourceMatch,
+		SourceID:    pointsAwarded.MatchID,
+		Key:         pointsAwarded.PredictionID,
+		Points:      pointsAwarded.Points,
+		Void:        pointsAwarded.Void,
+		SubmittedAt: pointsAwarded.SubmittedAt,
+		Auto:        pointsAwarded.Auto,
+	}
+	for _, ruleAward := range pointsAwarded.Breakdown {
+		if ruleAward.Rule == domain.RuleExactScore {
+			award.ExactScore = true
+		}
+	}
+	if pointsAwarded.MarginError != nil {
+		award.MarginError = *pointsAwarded.MarginError
+	}
+	return award
+}
+
+// LoadLeaguePointsAwards retrieves the points awarded for match tips under a
+// league's own rules
+func LoadLeaguePointsAwards(ctx context.Context, eventStore eventstore.EventStore, leagueID string) ([]domain.PointsAward, error) {
+	awardEvents, err := loadEventsByTypes(ctx, eventStore, []string{"LeaguePointsAwarded"})
+	if err != nil {
+		return nil, err
+	}
+
+	awards := make([]domain.PointsAward, 0)
+	for _, event := range awardEvents {
+		var pointsAwarded events.LeaguePointsAwarded
+		if err := decodeEventData(event, &pointsAwarded); err != nil {
+			return nil, err
+		}
+		if pointsAwarded.LeagueID == leagueID {
+			awards = append(awards, matchAward(pointsAwarded.PointsAwarded))
+		}
+	}
+	return awards, nil
+}
+
+// roundEventTypes are the event types that make up a round's history
+var roundEventTypes = []string{"RoundCreated", "RoundUpdated"}
+
+// LoadRoundEvents retrieves every round event, in the order they occurred
+func LoadRoundEvents(ctx context.Context, eventStore eventstore.EventStore) ([]*events.Event, error) {
+	return loadEventsByTypes(ctx, eventStore, roundEventTypes)
+}
+
+// ReplayRounds rebuilds the current state of every round from its events
+func ReplayRounds(roundEvents []*events.Event) ([]*domain.Round, error) {
+	rounds := make([]*domain.Round, 0)
+	byID := make(map[string]*domain.Round)
+	for _, event := range roundEvents {
+		switch event.Type {
+		case "RoundCreated":
+			var roundCreated events.RoundCreated
+			if err := decodeEventData(event, &roundCreated); err != nil {
+				return nil, err
+			}
+			round := domain.NewRound(
+				roundCreated.ID,
+				roundCreated.CompetitionID,
+				roundCreated.Number,
+				roundCreated.Name,
+				roundCreated.StartDate,
+				roundCreated.EndDate,
+				roundCreated.Deadline,
+			)
+			round.SeasonID = roundCreated.SeasonID
+			rounds = append(rounds, round)
+			byID[round.ID] = round
+		case "RoundUpdated":
+			var roundUpdated events.RoundUpdated
+			if err := decodeEventData(event, &roundUpdated); err != nil {
+				return nil, err
+			}
+			round, ok := byID[roundUpdated.RoundID]
+			if !ok {
+				continue
+			}
+			round.Number = roundUpdated.Number
+			round.Name = roundUpdated.Name
+			round.SetDates(roundUpdated.StartDate, roundUpdated.EndDate, roundUpdated.Deadline)
+		}
+	}
+	return rounds, nil
+}
+
+// FindRound returns the round with the given ID, or nil if there is none
+func FindRound(rounds []*domain.Round, roundID string) *domain.Round {
+	for _, round := range rounds {
+		if round.ID == roundID {
+			return round
+		}
+	}
+	return nil
+}
+
+// predictionEventTypes are the event types that make up a prediction's history
+var predictionEventTypes = []string{"PredictionMade", "PredictionAmended", "PredictionWithdrawn"}
+
+// LoadPredictionEvents retrieves every prediction event, in the order they occurred
+func LoadPredictionEvents(ctx context.Context, eventStore eventstore.EventStore) ([]*events.Event, error) {
+	return loadEventsByTypes(ctx, eventStore, predictionEventTypes)
+}
+
+// loadEventsByTypes retrieves every event of the given types, in the order they occurred
+func loadEventsByTypes(ctx context.Context, eventStore eventstore.EventStore, eventTypes []string) ([]*events.Event, error) {
+	var result []*events.Event
+	for _, eventType := range eventTypes {
+		typeEvents, err := eventStore.GetEventsByType(ctx, eventType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s events: %w", eventType, err)
+		}
+		result = append(result, typeEvents...)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result, nil
+}
+
+// newPrediction builds a prediction from the event that made it
+func newPrediction(predictionMade events.PredictionMade) *domain.Prediction {
+	var prediction *domain.Prediction
+	if predictionMade.Winner != "" {
+		prediction = domain.NewWinnerPrediction(
+			predictionMade.ID,
+			predictionMade.UserID,
+			predictionMade.MatchID,
+			domain.MatchSide(predictionMade.Winner),
+			predictionMade.Margin,
+		)
+	} else {
+		prediction = domain.NewPrediction(
+			predictionMade.ID,
+			predictionMade.UserID,
+			predictionMade.MatchID,
+			predictionMade.HomeGoals,
+			predictionMade.AwayGoals,
+		)
+	}
+	prediction.Joker = predictionMade.Joker
+	prediction.Auto = predictionMade.Auto
+	prediction.LeagueID = predictionMade.LeagueID
+	prediction.CreatedAt = predictionMade.CreatedAt
+	prediction.UpdatedAt = predictionMade.CreatedAt
+	return prediction
+}
+
+// amendPrediction applies an amendment to a score or winner tip
+func amendPrediction(prediction *domain.Prediction, predictionAmended events.PredictionAmended) {
+	if predictionAmended.Winner != "" {
+		prediction.AmendWinner(domain.MatchSide(predictionAmended.Winner), predictionAmended.Margin, predictionAmended.Joker, predictionAmended.AmendedAt)
+		return
+	}
+	prediction.Amend(predictionAmended.HomeGoals, predictionAmended.AwayGoals, predictionAmended.Joker, predictionAmended.AmendedAt)
+}
+
+// ReplayPredictions rebuilds the current state of predictions from their
+// events, optionally keeping only those for a single match. Withdrawn
+// predictions are included and marked as such.
+func ReplayPredictions(predictionEvents []*events.Event, matchID string) ([]*domain.Prediction, error) {
+	predictions := make([]*domain.Prediction, 0)
+	byID := make(map[string]*domain.Prediction)
+	for _, event := range predictionEvents {
+		switch event.Type {
+		case "PredictionMade":
+			var predictionMade events.PredictionMade
+			if err := decodeEventData(event, &predictionMade); err != nil {
+				return nil, err
+			}
+			if matchID != "" && predictionMade.MatchID != matchID {
+				continue
+			}
+			prediction := newPrediction(predictionMade)
+			predictions = append(predictions, prediction)
+			byID[prediction.ID] = prediction
+		case "PredictionAmended":
+			var predictionAmended events.PredictionAmended
+			if err := decodeEventData(event, &predictionAmended); err != nil {
+				return nil, err
+			}
+			prediction, ok := byID[predictionAmended.PredictionID]
+			if !ok {
+				continue
+			}
+			amendPrediction(prediction, predictionAmended)
+		case "PredictionWithdrawn":
+			var predictionWithdrawn events.PredictionWithdrawn
+			if err := decodeEventData(event, &predictionWithdrawn); err != nil {
+				return nil, err
+			}
+			prediction, ok := byID[predictionWithdrawn.PredictionID]
+			if !ok {
+				continue
+			}
+			if err := prediction.Withdraw(predictionWithdrawn.WithdrawnAt); err != nil {
+				return nil, fmt.Errorf("failed to replay withdrawal of %s: %w", prediction.ID, err)
+			}
+		}
+	}
+	return predictions, nil
+}
+
+// ReplayPredictionHistory returns every revision of a prediction, oldest first
+func ReplayPredictionHistory(predictionEvents []*events.Event, predictionID string) ([]domain.PredictionRevision, error) {
+	var prediction *domain.Prediction
+	history := make([]domain.PredictionRevision, 0)
+	for _, event := range predictionEvents {
+		switch event.Type {
+		case "PredictionMade":
+			var predictionMade events.PredictionMade
+			if err := decodeEventData(event, &predictionMade); err != nil {
+				return nil, err
+			}
+			if predictionMade.ID != predictionID {
+				continue
+			}
+			prediction = newPrediction(predictionMade)
+			history = append(history, prediction.CurrentRevision())
+		case "PredictionAmended":
+			var predictionAmended events.PredictionAmended
+			if err := decodeEventData(event, &predictionAmended); err != nil {
+				return nil, err
+			}
+			if prediction == nil || predictionAmended.PredictionID != predictionID {
+				continue
+			}
+			amendPrediction(prediction, predictionAmended)
+			history = append(history, prediction.CurrentRevision())
+		case "PredictionWithdrawn":
+			var predictionWithdrawn events.PredictionWithdrawn
+			if err := decodeEventData(event, &predictionWithdrawn); err != nil {
+				return nil, err
+			}
+			if prediction == nil || predictionWithdrawn.PredictionID != predictionID {
+				continue
+			}
+			if err := prediction.Withdraw(predictionWithdrawn.WithdrawnAt); err != nil {
+				return nil, fmt.Errorf("failed to replay withdrawal of %s: %w", prediction.ID, err)
+			}
+			history = append(history, prediction.CurrentRevision())
+		}
+	}
+	return history, nil
+}
+
+// ReplayScoringSpec returns the most recent scoring spec configured for a
+// competition, or the default spec if none has been configured
+func ReplayScoringSpec(configEvents []*events.Event, competition string) (domain.ScoringSpec, error) {
+	spec := domain.DefaultScoringSpec()
+	for _, event := range configEvents {
+		if event.Type != "ScoringRulesConfigured" {
+			continue
+		}
+		var rulesConfigured events.ScoringRulesConfigured
+		if err := decodeEventData(event, &rulesConfigured); err != nil {
+			return domain.ScoringSpec{}, err
+		}
+		if rulesConfigured.Competition != competition {
+			continue
+		}
+		spec = scoringSpec(rulesConfigured.Rules)
+	}
+	return spec, nil
+}
+
+// scoringSpec converts configured scoring rules to a spec
+func scoringSpec(rules []events.ScoringRule) domain.ScoringSpec {
+	spec := domain.ScoringSpec{Rules: make([]domain.RuleSpec, 0, len(rules))}
+	for _, rule := range rules {
+		spec.Rules = append(spec.Rules, domain.RuleSpec{
+			Type:   rule.Type,
+			Points: rule.Points,
+			Final:  rule.Final,
+		})
+	}
+	return spec
+}
@@ -0,0 +1,86 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// RoundEventHandler handles round-related events and updates the read model
+type RoundEventHandler struct {
+	roundRepo repository.RoundRepository
+}
+
+// NewRoundEventHandler creates a new round event handler
+func NewRoundEventHandler(roundRepo repository.RoundRepository) *RoundEventHandler {
+	return &RoundEventHandler{
+		roundRepo: roundRepo,
+	}
+}
+
+// HandleEvent processes events and updates the read model accordingly
+func (h *RoundEventHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "RoundCreated":
+		return h.handleRoundCreated(ctx, event)
+	case "RoundUpdated":
+		return h.handleRoundUpdated(ctx, event)
+	default:
+		// Ignore unknown event types
+		return nil
+	}
+}
+
+// handleRoundCreated processes RoundCreated events
+func (h *RoundEventHandler) handleRoundCreated(ctx context.Context, event *events.Event) error {
+	var roundCreated events.RoundCreated
+	if err := decodeEventData(event, &roundCreated); err != nil {
+		return err
+	}
+
+	round := domain.NewRound(
+		roundCreated.ID,
+		roundCreated.CompetitionID,
+		roundCreated.Number,
+		roundCreated.Name,
+		roundCreated.StartDate,
+		roundCreated.EndDate,
+		roundCreated.Deadline,
+	)
+	round.SeasonID = roundCreated.SeasonID
+
+	if err := h.roundRepo.Create(ctx, round); err != nil {
+		return fmt.Errorf("failed to create round in read model: %w", err)
+	}
+
+	log.Printf("Created round in read model: %s (%s)", round.Name, round.CompetitionID)
+	return nil
+}
+
+// handleRoundUpdated processes RoundUpdated events
+func (h *RoundEventHandler) handleRoundUpdated(ctx context.Context, event *events.Event) error {
+	var roundUpdated events.RoundUpdated
+	if err := decodeEventData(event, &roundUpdated); err != nil {
+		return err
+	}
+
+	round, err := h.roundRepo.GetByID(ctx, roundUpdated.RoundID)
+	if err != nil {
+		return fmt.Errorf("failed to get round from read model: %w", err)
+	}
+
+	round.Number = roundUpdated.Number
+	round.Name = roundUpdated.Name
+	round.SetDates(roundUpdated.StartDate, roundUpdated.EndDate, roundUpdated.Deadline)
+
+	if err := h.roundRepo.Update(ctx, round); err != nil {
+		return fmt.Errorf("failed to update round in read model: %w", err)
+	}
+
+	log.Printf("Updated round in read model: %s (%s)", round.Name, round.CompetitionID)
+	return nil
+}
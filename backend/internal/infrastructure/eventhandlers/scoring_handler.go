@@ -0,0 +1,328 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// ScoringEventHandler awards points for predictions once a match result is final
+type ScoringEventHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewScoringEventHandler creates a new scoring event handler
+func NewScoringEventHandler(eventStore eventstore.EventStore) *ScoringEventHandler {
+	return &ScoringEventHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent scores predictions when a match finishes or is abandoned, and
+// rescores them when a finished match's score is corrected
+func (h *ScoringEventHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "MatchStatusChanged":
+		var statusChanged events.MatchStatusChanged
+		if err := decodeEventData(event, &statusChanged); err != nil {
+			return err
+		}
+
+		switch domain.MatchStatus(statusChanged.Status) {
+		case domain.MatchStatusFinished, domain.MatchStatusAbandoned:
+			return h.scoreMatch(ctx, statusChanged.MatchID, false)
+		default:
+			return nil
+		}
+	case "MatchScoreCorrected":
+		var scoreCorrected events.MatchScoreCorrected
+		if err := decodeEventData(event, &scoreCorrected); err != nil {
+			return err
+		}
+		return h.scoreMatch(ctx, scoreCorrected.MatchID, true)
+	default:
+		return nil
+	}
+}
+
+// scoreMatch emits a PointsAwarded event for every prediction on the match.
+// Predictions on an abandoned match are voided and awarded zero points, and
+// withdrawn predictions are skipped, as are auto-tips, which count only in
+// their league. In winner tipping, tips on the first
+// match of the round also record their margin error as a tiebreaker. The
+// same tips are then scored again for each league with its own rules.
+//
+// A correction rescores the match and re-awards only the predictions whose
+// award changed, recording the adjustment from the points awarded before.
+// The new award replaces the old one on the leaderboard.
+func (h *ScoringEventHandler) scoreMatch(ctx context.Context, matchID string, correction bool) error {
+	matchEvents, err := h.eventStore.GetEvents(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match events: %w", err)
+	}
+
+	match, err := ReplayMatch(matchEvents)
+	if err != nil {
+		return err
+	}
+
+	competition, err := h.competition(ctx, match)
+	if err != nil {
+		return err
+	}
+
+	scheme, err := h.scoringScheme(ctx, match, competition)
+	if err != nil {
+		return err
+	}
+
+	marginMatchID, err := h.marginMatchID(ctx, match, competition)
+	if err != nil {
+		return err
+	}
+
+	predictionEvents, err := LoadPredictionEvents(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+
+	predictions, err := ReplayPredictions(predictionEvents, matchID)
+	if err != nil {
+		return err
+	}
+
+	var previous map[string]domain.PointsAward
+	if correction {
+		awards, err := LoadPointsAwards(ctx, h.eventStore)
+		if err != nil {
+			return err
+		}
+		previous = latestAwards(awards, match.ID)
+	}
+
+	scored := 0
+	for _, prediction := range predictions {
+		if prediction.IsWithdrawn() || prediction.Auto {
+			continue
+		}
+
+		pointsAwarded := h.award(scheme, prediction, match, competition, marginMatchID)
+		if correction && !adjust(&pointsAwarded, previous) {
+			continue
+		}
+		event := events.NewEvent("PointsAwarded", pointsAwarded)
+		if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to save points for prediction %s: %w", prediction.ID, err)
+		}
+		scored++
+	}
+
+	log.Printf("Scored %d predictions for match %s (%s)", scored, match.ID, match.Status)
+	return h.scoreLeagues(ctx, match, competition, scheme, marginMatchID, predictionEvents, correction)
+}
+
+// scoreLeagues emits a LeaguePointsAwarded event for each member's tip in
+// every league that has its own rules and covers the match. A league with an
+// earlier lock scores each tip as it stood at that lock, leaving out tips
+// made after it but for its own auto-tips, which are made at the lock; a
+// league without jokers scores them as ordinary tips. Auto-tips made for
+// another league are skipped. A correction re-awards only the tips whose
+// league award changed.
+func (h *ScoringEventHandler) scoreLeagues(ctx context.Context, match *domain.Match, competition *domain.Competition, scheme *domain.ScoringScheme, marginMatchID string, predictionEvents []*events.Event, correction bool) error {
+	leagues, err := LoadLeagues(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+
+	for _, league := range leagues {
+		if !league.Rules.IsSet() || !league.Covers(match) {
+			continue
+		}
+
+		leagueScheme := scheme
+		if league.Rules.Scoring != nil {
+			built, err := league.Rules.Scoring.Build()
+			if err != nil {
+				return fmt.Errorf("failed to build scoring rules for league %s: %w", league.ID, err)
+			}
+			leagueScheme = built.WithBasis(competitionBasis(competition))
+		}
+
+		tipEvents := predictionEvents
+		if cutoff := league.Rules.Cutoff(); cutoff > 0 {
+			tipEvents = tipsAtLock(predictionEvents, match.LockTime(cutoff), league.ID)
+		}
+		predictions, err := ReplayPredictions(tipEvents, match.ID)
+		if err != nil {
+			return err
+		}
+
+		var previous map[string]domain.PointsAward
+		if correction {
+			awards, err := LoadLeaguePointsAwards(ctx, h.eventStore, league.ID)
+			if err != nil {
+				return err
+			}
+			previous = latestAwards(awards, match.ID)
+		}
+
+		for _, prediction := range predictions {
+			if prediction.IsWithdrawn() || !league.IsMember(prediction.UserID) ||
+				(prediction.Auto && prediction.LeagueID != league.ID) {
+				continue
+			}
+			if !league.Rules.JokersAllowed() {
+				tip := *prediction
+				tip.Joker = false
+				prediction = &tip
+			}
+
+			pointsAwarded := h.award(leagueScheme, prediction, match, competition, marginMatchID)
+			if correction && !adjust(&pointsAwarded, previous) {
+				continue
+			}
+			event := events.NewEvent("LeaguePointsAwarded", events.LeaguePointsAwarded{
+				LeagueID:      league.ID,
+				PointsAwarded: pointsAwarded,
+			})
+			if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to save league %s points for prediction %s: %w", league.ID, prediction.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// award scores a prediction, adding its margin error if it is a winner tip on
+// the round's tiebreaker match
+func (h *ScoringEventHandler) award(scheme *domain.ScoringScheme, prediction *domain.Prediction, match *domain.Match, competition *domain.Competition, marginMatchID string) events.PointsAwarded {
+	result := scheme.Evaluate(prediction, match)
+	if prediction.IsWinnerTip() && prediction.MatchID == marginMatchID {
+		marginError := prediction.MarginError(match.Score.Counted(competition.ResultBasis))
+		result.MarginError = &marginError
+	}
+	breakdown := make([]events.RuleAward, 0, len(result.Breakdown))
+	for _, award := range result.Breakdown {
+		breakdown = append(breakdown, events.RuleAward{Rule: award.Rule, Points: award.Points})
+	}
+
+	return events.PointsAwarded{
+		PredictionID: prediction.ID,
+		UserID:       prediction.UserID,
+		MatchID:      prediction.MatchID,
+		Points:       result.Points,
+		Breakdown:    breakdown,
+		MarginError:  result.MarginError,
+		Void:         match.IsAbandoned(),
+		SubmittedAt:  prediction.UpdatedAt,
+		Auto:         prediction.Auto,
+		AwardedAt:    time.Now(),
+	}
+}
+
+// latestAwards returns the latest award for each prediction on the match,
+// keyed by prediction ID
+func latestAwards(awards []domain.PointsAward, matchID string) map[string]domain.PointsAward {
+	latest := make(map[string]domain.PointsAward)
+	for _, award := range awards {
+		if award.Source == domain.PointsSourceMatch && award.SourceID == matchID {
+			latest[award.Key] = award
+		}
+	}
+	return latest
+}
+
+// adjust marks a rescored award as a correction of the prediction's previous
+// award, with the change in points. It returns false if the award is
+// unchanged and need not be made again.
+func adjust(pointsAwarded *events.PointsAwarded, previous map[string]domain.PointsAward) bool {
+	rescored := matchAward(*pointsAwarded)
+	before, ok := previous[pointsAwarded.PredictionID]
+	if ok && before.Points == rescored.Points && before.ExactScore == rescored.ExactScore &&
+		before.MarginError == rescored.MarginError && before.Void == rescored.Void {
+		return false
+	}
+	pointsAwarded.Correction = true
+	pointsAwarded.Adjustment = pointsAwarded.Points - before.Points
+	return true
+}
+
+// tipsAtLock returns the prediction events that were recorded before a
+// league's lock, along with the auto-tips made for the league at it
+func tipsAtLock(predictionEvents []*events.Event, lockAt time.Time, leagueID string) []*events.Event {
+	before := make([]*events.Event, 0, len(predictionEvents))
+	for _, event := range predictionEvents {
+		if event.Timestamp.Before(lockAt) {
+			before = append(before, event)
+			continue
+		}
+		var predictionMade events.PredictionMade
+		if event.Type == "PredictionMade" && decodeEventData(event, &predictionMade) == nil &&
+			predictionMade.Auto && predictionMade.LeagueID == leagueID {
+			before = append(before, event)
+		}
+	}
+	return before
+}
+
+// competition returns the managed competition the match is played in, or nil
+// for legacy matches
+func (h *ScoringEventHandler) competition(ctx context.Context, match *domain.Match) (*domain.Competition, error) {
+	catalog, err := LoadCompetitionCatalog(ctx, h.eventStore)
+	if err != nil {
+		return nil, err
+	}
+	return catalog.Competition(match.CompetitionKey()), nil
+}
+
+// scoringScheme loads the scoring scheme configured for the match's
+// competition, keyed by competition ID (or by name for legacy matches), and
+// applies the competition's result basis. Matches outside a managed
+// competition are scored on the regulation-time result.
+func (h *ScoringEventHandler) scoringScheme(ctx context.Context, match *domain.Match, competition *domain.Competition) (*domain.ScoringScheme, error) {
+	configEvents, err := h.eventStore.GetEventsByType(ctx, "ScoringRulesConfigured")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring rules: %w", err)
+	}
+
+	spec, err := ReplayScoringSpec(configEvents, match.CompetitionKey())
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, err := spec.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return scheme.WithBasis(competitionBasis(competition)), nil
+}
+
+// competitionBasis returns the result basis tips in the competition are
+// scored on, which is the regulation-time result outside managed competitions
+func competitionBasis(competition *domain.Competition) domain.ResultBasis {
+	if competition == nil {
+		return domain.ResultBasisRegulation
+	}
+	return competition.ResultBasis
+}
+
+// marginMatchID returns the ID of the round's tiebreaker match when the
+// competition uses winner tipping and the match has a result to measure
+// margins against. It is empty otherwise.
+func (h *ScoringEventHandler) marginMatchID(ctx context.Context, match *domain.Match, competition *domain.Competition) (string, error) {
+	if competition == nil || competition.TippingMode != domain.TippingModeWinnerMargin ||
+		match.Score == nil || match.IsAbandoned() {
+		return "", nil
+	}
+	marginMatch, err := LoadMarginMatch(ctx, h.eventStore, match)
+	if err != nil {
+		return "", err
+	}
+	return marginMatch.ID, nil
+}
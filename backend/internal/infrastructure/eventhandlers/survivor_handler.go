@@ -0,0 +1,125 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/eventstore"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// SurvivorEliminationHandler settles survivor picks as their matches finish,
+// knocking out players whose team lost or drew
+type SurvivorEliminationHandler struct {
+	eventStore eventstore.EventStore
+}
+
+// NewSurvivorEliminationHandler creates a new survivor elimination handler
+func NewSurvivorEliminationHandler(eventStore eventstore.EventStore) *SurvivorEliminationHandler {
+	return &SurvivorEliminationHandler{
+		eventStore: eventStore,
+	}
+}
+
+// HandleEvent settles survivor picks when a match finishes or is abandoned,
+// and resettles them when its score is corrected
+func (h *SurvivorEliminationHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "MatchStatusChanged":
+		var statusChanged events.MatchStatusChanged
+		if err := decodeEventData(event, &statusChanged); err != nil {
+			return err
+		}
+		switch domain.MatchStatus(statusChanged.Status) {
+		case domain.MatchStatusFinished, domain.MatchStatusAbandoned:
+			return h.settleMatch(ctx, statusChanged.MatchID, false)
+		default:
+			return nil
+		}
+	case "MatchScoreCorrected":
+		var scoreCorrected events.MatchScoreCorrected
+		if err := decodeEventData(event, &scoreCorrected); err != nil {
+			return err
+		}
+		return h.settleMatch(ctx, scoreCorrected.MatchID, true)
+	default:
+		return nil
+	}
+}
+
+// settleMatch emits a SurvivorPickSettled event for every unsettled pick on
+// the match in each pool that covers it. The result is read on the
+// competition's result basis, and picks on an abandoned match are void. A
+// correction also resettles the settled picks whose outcome it changes,
+// which can knock a player out or bring them back.
+func (h *SurvivorEliminationHandler) settleMatch(ctx context.Context, matchID string, correction bool) error {
+	pools, err := LoadSurvivorPools(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+	if len(pools) == 0 {
+		return nil
+	}
+
+	matchEvents, err := h.eventStore.GetEvents(ctx, matchID)
+	if err != nil {
+		return fmt.Errorf("failed to get match events: %w", err)
+	}
+	match, err := ReplayMatch(matchEvents)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := LoadCompetitionCatalog(ctx, h.eventStore)
+	if err != nil {
+		return err
+	}
+	basis := competitionBasis(catalog.Competition(match.CompetitionKey()))
+
+	for _, pool := range pools {
+		if !pool.Covers(match) {
+			continue
+		}
+
+		entries, err := LoadSurvivorEntries(ctx, h.eventStore, pool.ID)
+		if err != nil {
+			return err
+		}
+
+		settled := 0
+		for _, entry := range entries {
+			pick := entry.PickFor(match.RoundKey())
+			if pick == nil || pick.MatchID != match.ID {
+				continue
+			}
+			outcome := domain.SurvivorOutcomeFor(match, pick.TeamID, basis)
+			if pick.IsSettled() && (!correction || pick.Outcome == outcome) {
+				continue
+			}
+
+			pickSettled := events.SurvivorPickSettled{
+				PoolID:    pool.ID,
+				PickID:    pick.ID,
+				UserID:    entry.UserID,
+				MatchID:   match.ID,
+				Outcome:   string(outcome),
+				SettledAt: time.Now(),
+			}
+			if pick.IsSettled() {
+				pickSettled.Correction = true
+				pickSettled.PreviousOutcome = string(pick.Outcome)
+			}
+			event := events.NewEvent("SurvivorPickSettled", pickSettled)
+			if err := h.eventStore.SaveEvent(ctx, event); err != nil {
+				return fmt.Errorf("failed to settle survivor pick %s: %w", pick.ID, err)
+			}
+			settled++
+		}
+
+		log.Printf("Settled %d survivor picks for match %s in pool %s", settled, match.ID, pool.ID)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+	"github.com/parkertr2/footy-tipping/pkg/events"
+)
+
+// UserEventHandler handles user-related events and updates the read model
+type UserEventHandler struct {
+	userRepo repository.UserRepository
+}
+
+// NewUserEventHandler creates a new user event handler
+func NewUserEventHandler(userRepo repository.UserRepository) *UserEventHandler {
+	return &UserEventHandler{
+		userRepo: userRepo,
+	}
+}
+
+// HandleEvent processes events and updates the read model accordingly
+func (h *UserEventHandler) HandleEvent(ctx context.Context, event *events.Event) error {
+	switch event.Type {
+	case "UserRegistered":
+		return h.handleUserRegistered(ctx, event)
+	case "UserProfileUpdated":
+		return h.handleUserProfileUpdated(ctx, event)
+	case "UserDeactivated":
+		return h.handleUserDeactivated(ctx, event)
+	case "UserPreferencesUpdated":
+		return h.handleUserPreferencesUpdated(ctx, event)
+	default:
+		// Ignore unknown event types
+		return nil
+	}
+}
+
+// handleUserRegistered processes UserRegistered events
+func (h *UserEventHandler) handleUserRegistered(ctx context.Context, event *events.Event) error {
+	var userRegistered events.UserRegistered
+	if err := decodeEventData(event, &userRegistered); err != nil {
+		return err
+	}
+
+	user := domain.NewUser(userRegistered.ID, userRegistered.Username, userRegistered.Email)
+	user.JoinDate = userRegistered.RegisteredAt
+
+	if err := h.userRepo.Create(ctx, user); err != nil {
+		return fmt.Errorf("failed to create user in read model: %w", err)
+	}
+
+	log.Printf("Created user in read model: %s", user.Username)
+	return nil
+}
+
+// handleUserProfileUpdated processes UserProfileUpdated events
+func (h *UserEventHandler) handleUserProfileUpdated(ctx context.Context, event *events.Event) error {
+	var profileUpdated events.UserProfileUpdated
+	if err := decodeEventData(event, &profileUpdated); err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.GetByID(ctx, profileUpdated.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user from read model: %w", err)
+	}
+
+	user.Username = profileUpdated.Username
+	user.Email = profileUpdated.Email
+
+	if err := h.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user in read model: %w", err)
+	}
+
+	log.Printf("Updated user profile in read model: %s", user.Username)
+	return nil
+}
+
+// handleUserDeactivated processes UserDeactivated events
+func (h *UserEventHandler) handleUserDeactivated(ctx context.Context, event *events.Event) error {
+	var userDeactivated events.UserDeactivated
+	if err := decodeEventData(event, &userDeactivated); err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.GetByID(ctx, userDeactivated.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user from read model: %w", err)
+	}
+
+	deactivatedAt := userDeactivated.DeactivatedAt
+	user.DeactivatedAt = &deactivatedAt
+
+	if err := h.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user in read model: %w", err)
+	}
+
+	log.Printf("Deactivated user in read model: %s", user.Username)
+	return nil
+}
+
+// handleUserPreferencesUpdated processes UserPreferencesUpdated events.
+// Preferences may be set for users who were never registered, who have no
+// row to update.
+func (h *UserEventHandler) handleUserPreferencesUpdated(ctx context.Context, event *events.Event) error {
+	var preferencesUpdated events.UserPreferencesUpdated
+	if err := decodeEventData(event, &preferencesUpdated); err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.GetByID(ctx, preferencesUpdated.UserID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get user from read model: %w", err)
+	}
+
+	user.TimeZone = preferencesUpdated.TimeZone
+
+	if err := h.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user in read model: %w", err)
+	}
+
+	log.Printf("Updated user time zone in read model: %s", user.Username)
+	return nil
+}
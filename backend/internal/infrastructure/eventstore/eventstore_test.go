@@ -166,7 +166,7 @@ func TestGetEvents(t *testing.T) {
 
 		mock.ExpectQuery(`SELECT id, type, data, timestamp, version
                         FROM events
-                        WHERE \(data->>'ID' = \$1\) OR \(data->>'MatchID' = \$1\) OR \(data->>'UserID' = \$1\)
+                        WHERE \(data->>'ID' = \$1\) OR \(data->>'MatchID' = \$1\) OR \(data->>'UserID' = \$1\) OR \(data->>'PredictionID' = \$1\)
                         ORDER BY timestamp ASC`).
 			WithArgs(matchID).
 			WillReturnRows(rows)
@@ -254,7 +254,7 @@ func TestGetEvents(t *testing.T) {
 
 		mock.ExpectQuery(`SELECT id, type, data, timestamp, version
                         FROM events
-                        WHERE \(data->>'ID' = \$1\) OR \(data->>'MatchID' = \$1\) OR \(data->>'UserID' = \$1\)
+                        WHERE \(data->>'ID' = \$1\) OR \(data->>'MatchID' = \$1\) OR \(data->>'UserID' = \$1\) OR \(data->>'PredictionID' = \$1\)
                         ORDER BY timestamp ASC`).
 			WithArgs(userID).
 			WillReturnRows(rows)
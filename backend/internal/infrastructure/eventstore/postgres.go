@@ -57,7 +57,7 @@ func (s *PostgresEventStore) GetEvents(ctx context.Context, aggregateID string)
 	query := `
 		SELECT id, type, data, timestamp, version
 		FROM events
-		WHERE (data->>'ID' = $1) OR (data->>'MatchID' = $1) OR (data->>'UserID' = $1)
+		WHERE (data->>'ID' = $1) OR (data->>'MatchID' = $1) OR (data->>'UserID' = $1) OR (data->>'PredictionID' = $1)
 		ORDER BY timestamp ASC
 	`
 
@@ -80,32 +80,11 @@ func (s *PostgresEventStore) GetEvents(ctx context.Context, aggregateID string)
 		}
 
 		// Unmarshal the event data based on the event type
-		switch event.Type {
-		case "MatchCreated":
-			var matchCreated events.MatchCreated
-			if err := json.Unmarshal(data, &matchCreated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchCreated: %w", err)
-			}
-			event.Data = matchCreated
-		case "MatchScoreUpdated":
-			var scoreUpdated events.MatchScoreUpdated
-			if err := json.Unmarshal(data, &scoreUpdated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchScoreUpdated: %w", err)
-			}
-			event.Data = scoreUpdated
-		case "MatchStatusChanged":
-			var statusChanged events.MatchStatusChanged
-			if err := json.Unmarshal(data, &statusChanged); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchStatusChanged: %w", err)
-			}
-			event.Data = statusChanged
-		case "PredictionMade":
-			var predictionMade events.PredictionMade
-			if err := json.Unmarshal(data, &predictionMade); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal PredictionMade: %w", err)
-			}
-			event.Data = predictionMade
+		eventData, err := decodeEventData(event.Type, data)
+		if err != nil {
+			return nil, err
 		}
+		event.Data = eventData
 
 		result = append(result, &event)
 	}
@@ -141,32 +120,11 @@ func (s *PostgresEventStore) GetEventsByType(ctx context.Context, eventType stri
 		}
 
 		// Unmarshal the event data based on the event type
-		switch event.Type {
-		case "MatchCreated":
-			var matchCreated events.MatchCreated
-			if err := json.Unmarshal(data, &matchCreated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchCreated: %w", err)
-			}
-			event.Data = matchCreated
-		case "MatchScoreUpdated":
-			var scoreUpdated events.MatchScoreUpdated
-			if err := json.Unmarshal(data, &scoreUpdated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchScoreUpdated: %w", err)
-			}
-			event.Data = scoreUpdated
-		case "MatchStatusChanged":
-			var statusChanged events.MatchStatusChanged
-			if err := json.Unmarshal(data, &statusChanged); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchStatusChanged: %w", err)
-			}
-			event.Data = statusChanged
-		case "PredictionMade":
-			var predictionMade events.PredictionMade
-			if err := json.Unmarshal(data, &predictionMade); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal PredictionMade: %w", err)
-			}
-			event.Data = predictionMade
+		eventData, err := decodeEventData(event.Type, data)
+		if err != nil {
+			return nil, err
 		}
+		event.Data = eventData
 
 		result = append(result, &event)
 	}
@@ -202,35 +160,316 @@ func (s *PostgresEventStore) GetEventsByTimeRange(ctx context.Context, start, en
 		}
 
 		// Unmarshal the event data based on the event type
-		switch event.Type {
-		case "MatchCreated":
-			var matchCreated events.MatchCreated
-			if err := json.Unmarshal(data, &matchCreated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchCreated: %w", err)
-			}
-			event.Data = matchCreated
-		case "MatchScoreUpdated":
-			var scoreUpdated events.MatchScoreUpdated
-			if err := json.Unmarshal(data, &scoreUpdated); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchScoreUpdated: %w", err)
-			}
-			event.Data = scoreUpdated
-		case "MatchStatusChanged":
-			var statusChanged events.MatchStatusChanged
-			if err := json.Unmarshal(data, &statusChanged); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal MatchStatusChanged: %w", err)
-			}
-			event.Data = statusChanged
-		case "PredictionMade":
-			var predictionMade events.PredictionMade
-			if err := json.Unmarshal(data, &predictionMade); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal PredictionMade: %w", err)
-			}
-			event.Data = predictionMade
+		eventData, err := decodeEventData(event.Type, data)
+		if err != nil {
+			return nil, err
 		}
+		event.Data = eventData
 
 		result = append(result, &event)
 	}
 
 	return result, nil
 }
+
+// decodeEventData unmarshals stored event data into the struct for its event type.
+// Unknown event types are left undecoded.
+func decodeEventData(eventType string, data []byte) (interface{}, error) {
+	switch eventType {
+	case "MatchCreated":
+		var matchCreated events.MatchCreated
+		if err := json.Unmarshal(data, &matchCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchCreated: %w", err)
+		}
+		return matchCreated, nil
+	case "MatchScoreUpdated":
+		var scoreUpdated events.MatchScoreUpdated
+		if err := json.Unmarshal(data, &scoreUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchScoreUpdated: %w", err)
+		}
+		return scoreUpdated, nil
+	case "MatchScoreCorrected":
+		var scoreCorrected events.MatchScoreCorrected
+		if err := json.Unmarshal(data, &scoreCorrected); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchScoreCorrected: %w", err)
+		}
+		return scoreCorrected, nil
+	case "MatchStatusChanged":
+		var statusChanged events.MatchStatusChanged
+		if err := json.Unmarshal(data, &statusChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchStatusChanged: %w", err)
+		}
+		return statusChanged, nil
+	case "MatchRescheduled":
+		var matchRescheduled events.MatchRescheduled
+		if err := json.Unmarshal(data, &matchRescheduled); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchRescheduled: %w", err)
+		}
+		return matchRescheduled, nil
+	case "MatchOddsUpdated":
+		var oddsUpdated events.MatchOddsUpdated
+		if err := json.Unmarshal(data, &oddsUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal MatchOddsUpdated: %w", err)
+		}
+		return oddsUpdated, nil
+	case "GoalScored":
+		var goalScored events.GoalScored
+		if err := json.Unmarshal(data, &goalScored); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GoalScored: %w", err)
+		}
+		return goalScored, nil
+	case "GoalDisallowed":
+		var goalDisallowed events.GoalDisallowed
+		if err := json.Unmarshal(data, &goalDisallowed); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal GoalDisallowed: %w", err)
+		}
+		return goalDisallowed, nil
+	case "CardShown":
+		var cardShown events.CardShown
+		if err := json.Unmarshal(data, &cardShown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal CardShown: %w", err)
+		}
+		return cardShown, nil
+	case "PeriodChanged":
+		var periodChanged events.PeriodChanged
+		if err := json.Unmarshal(data, &periodChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PeriodChanged: %w", err)
+		}
+		return periodChanged, nil
+	case "TeamCreated":
+		var teamCreated events.TeamCreated
+		if err := json.Unmarshal(data, &teamCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TeamCreated: %w", err)
+		}
+		return teamCreated, nil
+	case "TeamUpdated":
+		var teamUpdated events.TeamUpdated
+		if err := json.Unmarshal(data, &teamUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal TeamUpdated: %w", err)
+		}
+		return teamUpdated, nil
+	case "CompetitionCreated":
+		var competitionCreated events.CompetitionCreated
+		if err := json.Unmarshal(data, &competitionCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal CompetitionCreated: %w", err)
+		}
+		return competitionCreated, nil
+	case "CompetitionResultBasisChanged":
+		var basisChanged events.CompetitionResultBasisChanged
+		if err := json.Unmarshal(data, &basisChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal CompetitionResultBasisChanged: %w", err)
+		}
+		return basisChanged, nil
+	case "CompetitionTippingModeChanged":
+		var modeChanged events.CompetitionTippingModeChanged
+		if err := json.Unmarshal(data, &modeChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal CompetitionTippingModeChanged: %w", err)
+		}
+		return modeChanged, nil
+	case "CompetitionTiebreakersChanged":
+		var tiebreakersChanged events.CompetitionTiebreakersChanged
+		if err := json.Unmarshal(data, &tiebreakersChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal CompetitionTiebreakersChanged: %w", err)
+		}
+		return tiebreakersChanged, nil
+	case "SeasonCreated":
+		var seasonCreated events.SeasonCreated
+		if err := json.Unmarshal(data, &seasonCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SeasonCreated: %w", err)
+		}
+		return seasonCreated, nil
+	case "SeasonStatusChanged":
+		var seasonStatusChanged events.SeasonStatusChanged
+		if err := json.Unmarshal(data, &seasonStatusChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SeasonStatusChanged: %w", err)
+		}
+		return seasonStatusChanged, nil
+	case "RoundCreated":
+		var roundCreated events.RoundCreated
+		if err := json.Unmarshal(data, &roundCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal RoundCreated: %w", err)
+		}
+		return roundCreated, nil
+	case "RoundUpdated":
+		var roundUpdated events.RoundUpdated
+		if err := json.Unmarshal(data, &roundUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal RoundUpdated: %w", err)
+		}
+		return roundUpdated, nil
+	case "PredictionMade":
+		var predictionMade events.PredictionMade
+		if err := json.Unmarshal(data, &predictionMade); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PredictionMade: %w", err)
+		}
+		return predictionMade, nil
+	case "PredictionAmended":
+		var predictionAmended events.PredictionAmended
+		if err := json.Unmarshal(data, &predictionAmended); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PredictionAmended: %w", err)
+		}
+		return predictionAmended, nil
+	case "PredictionWithdrawn":
+		var predictionWithdrawn events.PredictionWithdrawn
+		if err := json.Unmarshal(data, &predictionWithdrawn); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PredictionWithdrawn: %w", err)
+		}
+		return predictionWithdrawn, nil
+	case "PointsAwarded":
+		var pointsAwarded events.PointsAwarded
+		if err := json.Unmarshal(data, &pointsAwarded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal PointsAwarded: %w", err)
+		}
+		return pointsAwarded, nil
+	case "BracketCreated":
+		var bracketCreated events.BracketCreated
+		if err := json.Unmarshal(data, &bracketCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BracketCreated: %w", err)
+		}
+		return bracketCreated, nil
+	case "BracketTieMatchLinked":
+		var tieMatchLinked events.BracketTieMatchLinked
+		if err := json.Unmarshal(data, &tieMatchLinked); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BracketTieMatchLinked: %w", err)
+		}
+		return tieMatchLinked, nil
+	case "BracketEntrySubmitted":
+		var entrySubmitted events.BracketEntrySubmitted
+		if err := json.Unmarshal(data, &entrySubmitted); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BracketEntrySubmitted: %w", err)
+		}
+		return entrySubmitted, nil
+	case "BracketPointsAwarded":
+		var bracketPointsAwarded events.BracketPointsAwarded
+		if err := json.Unmarshal(data, &bracketPointsAwarded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BracketPointsAwarded: %w", err)
+		}
+		return bracketPointsAwarded, nil
+	case "OutrightMarketCreated":
+		var marketCreated events.OutrightMarketCreated
+		if err := json.Unmarshal(data, &marketCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal OutrightMarketCreated: %w", err)
+		}
+		return marketCreated, nil
+	case "OutrightTipSubmitted":
+		var tipSubmitted events.OutrightTipSubmitted
+		if err := json.Unmarshal(data, &tipSubmitted); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal OutrightTipSubmitted: %w", err)
+		}
+		return tipSubmitted, nil
+	case "OutrightMarketSettled":
+		var marketSettled events.OutrightMarketSettled
+		if err := json.Unmarshal(data, &marketSettled); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal OutrightMarketSettled: %w", err)
+		}
+		return marketSettled, nil
+	case "OutrightPointsAwarded":
+		var outrightPointsAwarded events.OutrightPointsAwarded
+		if err := json.Unmarshal(data, &outrightPointsAwarded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal OutrightPointsAwarded: %w", err)
+		}
+		return outrightPointsAwarded, nil
+	case "LeagueCreated":
+		var leagueCreated events.LeagueCreated
+		if err := json.Unmarshal(data, &leagueCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueCreated: %w", err)
+		}
+		return leagueCreated, nil
+	case "LeagueMemberJoined":
+		var memberJoined events.LeagueMemberJoined
+		if err := json.Unmarshal(data, &memberJoined); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueMemberJoined: %w", err)
+		}
+		return memberJoined, nil
+	case "LeagueMemberLeft":
+		var memberLeft events.LeagueMemberLeft
+		if err := json.Unmarshal(data, &memberLeft); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueMemberLeft: %w", err)
+		}
+		return memberLeft, nil
+	case "LeagueSettingsChanged":
+		var settingsChanged events.LeagueSettingsChanged
+		if err := json.Unmarshal(data, &settingsChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueSettingsChanged: %w", err)
+		}
+		return settingsChanged, nil
+	case "LeagueRulesChanged":
+		var rulesChanged events.LeagueRulesChanged
+		if err := json.Unmarshal(data, &rulesChanged); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueRulesChanged: %w", err)
+		}
+		return rulesChanged, nil
+	case "LeaguePointsAwarded":
+		var leaguePointsAwarded events.LeaguePointsAwarded
+		if err := json.Unmarshal(data, &leaguePointsAwarded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeaguePointsAwarded: %w", err)
+		}
+		return leaguePointsAwarded, nil
+	case "LeagueFixturesGenerated":
+		var fixturesGenerated events.LeagueFixturesGenerated
+		if err := json.Unmarshal(data, &fixturesGenerated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal LeagueFixturesGenerated: %w", err)
+		}
+		return fixturesGenerated, nil
+	case "SurvivorPoolCreated":
+		var poolCreated events.SurvivorPoolCreated
+		if err := json.Unmarshal(data, &poolCreated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SurvivorPoolCreated: %w", err)
+		}
+		return poolCreated, nil
+	case "SurvivorPickMade":
+		var pickMade events.SurvivorPickMade
+		if err := json.Unmarshal(data, &pickMade); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SurvivorPickMade: %w", err)
+		}
+		return pickMade, nil
+	case "SurvivorPickSettled":
+		var pickSettled events.SurvivorPickSettled
+		if err := json.Unmarshal(data, &pickSettled); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal SurvivorPickSettled: %w", err)
+		}
+		return pickSettled, nil
+	case "NotificationSent":
+		var notificationSent events.NotificationSent
+		if err := json.Unmarshal(data, &notificationSent); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal NotificationSent: %w", err)
+		}
+		return notificationSent, nil
+	case "UserRegistered":
+		var userRegistered events.UserRegistered
+		if err := json.Unmarshal(data, &userRegistered); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UserRegistered: %w", err)
+		}
+		return userRegistered, nil
+	case "UserProfileUpdated":
+		var profileUpdated events.UserProfileUpdated
+		if err := json.Unmarshal(data, &profileUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UserProfileUpdated: %w", err)
+		}
+		return profileUpdated, nil
+	case "UserDeactivated":
+		var userDeactivated events.UserDeactivated
+		if err := json.Unmarshal(data, &userDeactivated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UserDeactivated: %w", err)
+		}
+		return userDeactivated, nil
+	case "UserPreferencesUpdated":
+		var preferencesUpdated events.UserPreferencesUpdated
+		if err := json.Unmarshal(data, &preferencesUpdated); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal UserPreferencesUpdated: %w", err)
+		}
+		return preferencesUpdated, nil
+	case "BadgeAwarded":
+		var badgeAwarded events.BadgeAwarded
+		if err := json.Unmarshal(data, &badgeAwarded); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal BadgeAwarded: %w", err)
+		}
+		return badgeAwarded, nil
+	case "ScoringRulesConfigured":
+		var rulesConfigured events.ScoringRulesConfigured
+		if err := json.Unmarshal(data, &rulesConfigured); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ScoringRulesConfigured: %w", err)
+		}
+		return rulesConfigured, nil
+	}
+	return nil, nil
+}
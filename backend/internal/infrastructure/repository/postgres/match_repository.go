@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -19,27 +20,40 @@ func NewMatchRepository(db *sql.DB) *MatchRepository {
 }
 
 func (r *MatchRepository) Create(ctx context.Context, match *domain.Match) error {
-	var homeGoals, awayGoals sql.NullInt32
+	var homeGoals, awayGoals, extraTimeHome, extraTimeAway, penaltyHome, penaltyAway sql.NullInt32
 	if match.Score != nil {
 		homeGoals = sql.NullInt32{Int32: int32(match.Score.HomeGoals), Valid: true}
 		awayGoals = sql.NullInt32{Int32: int32(match.Score.AwayGoals), Valid: true}
+		extraTimeHome, extraTimeAway = nullScoreline(match.Score.ExtraTime)
+		penaltyHome, penaltyAway = nullScoreline(match.Score.Penalties)
 	}
 
 	query := `
 		INSERT INTO matches_view (
-			id, home_team, away_team, match_date, competition, status, home_goals, away_goals
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			id, home_team, away_team, home_team_id, away_team_id, match_date, competition, competition_id, season_id, round_id, status, home_goals, away_goals,
+			extra_time_home_goals, extra_time_away_goals, penalty_home_goals, penalty_away_goals, venue_time_zone
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		match.ID,
 		match.HomeTeam,
 		match.AwayTeam,
-		match.Date,
+		nullString(match.HomeTeamID),
+		nullString(match.AwayTeamID),
+		match.Date.UTC(),
 		match.Competition,
+		nullString(match.CompetitionID),
+		nullString(match.SeasonID),
+		nullString(match.RoundID),
 		match.Status,
 		homeGoals,
 		awayGoals,
+		extraTimeHome,
+		extraTimeAway,
+		penaltyHome,
+		penaltyAway,
+		nullString(match.TimeZone),
 	)
 
 	if err != nil {
@@ -50,33 +64,62 @@ func (r *MatchRepository) Create(ctx context.Context, match *domain.Match) error
 }
 
 func (r *MatchRepository) Update(ctx context.Context, match *domain.Match) error {
-	var homeGoals, awayGoals sql.NullInt32
+	var homeGoals, awayGoals, extraTimeHome, extraTimeAway, penaltyHome, penaltyAway sql.NullInt32
 	if match.Score != nil {
 		homeGoals = sql.NullInt32{Int32: int32(match.Score.HomeGoals), Valid: true}
 		awayGoals = sql.NullInt32{Int32: int32(match.Score.AwayGoals), Valid: true}
+		extraTimeHome, extraTimeAway = nullScoreline(match.Score.ExtraTime)
+		penaltyHome, penaltyAway = nullScoreline(match.Score.Penalties)
 	}
 
 	query := `
 		UPDATE matches_view
 		SET home_team = $1,
 			away_team = $2,
-			match_date = $3,
-			competition = $4,
-			status = $5,
-			home_goals = $6,
-			away_goals = $7,
+			home_team_id = $3,
+			away_team_id = $4,
+			match_date = $5,
+			competition = $6,
+			competition_id = $7,
+			season_id = $8,
+			round_id = $9,
+			status = $10,
+			home_goals = $11,
+			away_goals = $12,
+			extra_time_home_goals = $13,
+			extra_time_away_goals = $14,
+			penalty_home_goals = $15,
+			penalty_away_goals = $16,
+			venue_time_zone = $17,
+			odds = $18,
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $8
+		WHERE id = $19
 	`
 
+	odds, err := nullOdds(match.Odds)
+	if err != nil {
+		return err
+	}
+
 	result, err := r.db.ExecContext(ctx, query,
 		match.HomeTeam,
 		match.AwayTeam,
-		match.Date,
+		nullString(match.HomeTeamID),
+		nullString(match.AwayTeamID),
+		match.Date.UTC(),
 		match.Competition,
+		nullString(match.CompetitionID),
+		nullString(match.SeasonID),
+		nullString(match.RoundID),
 		match.Status,
 		homeGoals,
 		awayGoals,
+		extraTimeHome,
+		extraTimeAway,
+		penaltyHome,
+		penaltyAway,
+		nullString(match.TimeZone),
+		odds,
 		match.ID,
 	)
 
@@ -98,22 +141,35 @@ func (r *MatchRepository) Update(ctx context.Context, match *domain.Match) error
 
 func (r *MatchRepository) GetByID(ctx context.Context, id string) (*domain.Match, error) {
 	query := `
-		SELECT id, home_team, away_team, match_date, competition, status, home_goals, away_goals
+		SELECT id, home_team, away_team, home_team_id, away_team_id, match_date, competition, competition_id, season_id, round_id, status, home_goals, away_goals,
+			extra_time_home_goals, extra_time_away_goals, penalty_home_goals, penalty_away_goals, venue_time_zone, odds
 		FROM matches_view
 		WHERE id = $1
 	`
 
-	var homeGoals, awayGoals sql.NullInt32
+	var homeGoals, awayGoals, extraTimeHome, extraTimeAway, penaltyHome, penaltyAway sql.NullInt32
+	var homeTeamID, awayTeamID, competitionID, seasonID, roundID, timeZone, odds sql.NullString
 	match := &domain.Match{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&match.ID,
 		&match.HomeTeam,
 		&match.AwayTeam,
+		&homeTeamID,
+		&awayTeamID,
 		&match.Date,
 		&match.Competition,
+		&competitionID,
+		&seasonID,
+		&roundID,
 		&match.Status,
 		&homeGoals,
 		&awayGoals,
+		&extraTimeHome,
+		&extraTimeAway,
+		&penaltyHome,
+		&penaltyAway,
+		&timeZone,
+		&odds,
 	)
 
 	if err == sql.ErrNoRows {
@@ -124,10 +180,23 @@ func (r *MatchRepository) GetByID(ctx context.Context, id string) (*domain.Match
 		return nil, fmt.Errorf("failed to get match: %w", err)
 	}
 
+	match.HomeTeamID = homeTeamID.String
+	match.AwayTeamID = awayTeamID.String
+	match.CompetitionID = competitionID.String
+	match.SeasonID = seasonID.String
+	match.RoundID = roundID.String
+	match.TimeZone = timeZone.String
+	match.Date = match.Date.UTC()
+	if match.Odds, err = oddsFromColumn(odds); err != nil {
+		return nil, err
+	}
+
 	if homeGoals.Valid && awayGoals.Valid {
 		match.Score = &domain.Score{
 			HomeGoals: int(homeGoals.Int32),
 			AwayGoals: int(awayGoals.Int32),
+			ExtraTime: scorelineFromColumns(extraTimeHome, extraTimeAway),
+			Penalties: scorelineFromColumns(penaltyHome, penaltyAway),
 		}
 	}
 
@@ -160,10 +229,35 @@ func (r *MatchRepository) List(ctx context.Context, filters repository.MatchFilt
 	if filters.Status != nil {
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argPos))
 		args = append(args, *filters.Status)
+		argPos++
+	}
+
+	if filters.TeamID != nil {
+		conditions = append(conditions, fmt.Sprintf("(home_team_id = $%d OR away_team_id = $%d)", argPos, argPos))
+		args = append(args, *filters.TeamID)
+		argPos++
+	}
+
+	if filters.CompetitionID != nil {
+		conditions = append(conditions, fmt.Sprintf("competition_id = $%d", argPos))
+		args = append(args, *filters.CompetitionID)
+		argPos++
+	}
+
+	if filters.SeasonID != nil {
+		conditions = append(conditions, fmt.Sprintf("season_id = $%d", argPos))
+		args = append(args, *filters.SeasonID)
+		argPos++
+	}
+
+	if filters.RoundID != nil {
+		conditions = append(conditions, fmt.Sprintf("round_id = $%d", argPos))
+		args = append(args, *filters.RoundID)
 	}
 
 	query := `
-		SELECT id, home_team, away_team, match_date, competition, status, home_goals, away_goals
+		SELECT id, home_team, away_team, home_team_id, away_team_id, match_date, competition, competition_id, season_id, round_id, status, home_goals, away_goals,
+			extra_time_home_goals, extra_time_away_goals, penalty_home_goals, penalty_away_goals, venue_time_zone, odds
 		FROM matches_view
 	`
 
@@ -185,26 +279,51 @@ func (r *MatchRepository) List(ctx context.Context, filters repository.MatchFilt
 
 	var matches []*domain.Match
 	for rows.Next() {
-		var homeGoals, awayGoals sql.NullInt32
+		var homeGoals, awayGoals, extraTimeHome, extraTimeAway, penaltyHome, penaltyAway sql.NullInt32
+		var homeTeamID, awayTeamID, competitionID, seasonID, roundID, timeZone, odds sql.NullString
 		match := &domain.Match{}
 		err := rows.Scan(
 			&match.ID,
 			&match.HomeTeam,
 			&match.AwayTeam,
+			&homeTeamID,
+			&awayTeamID,
 			&match.Date,
 			&match.Competition,
+			&competitionID,
+			&seasonID,
+			&roundID,
 			&match.Status,
 			&homeGoals,
 			&awayGoals,
+			&extraTimeHome,
+			&extraTimeAway,
+			&penaltyHome,
+			&penaltyAway,
+			&timeZone,
+			&odds,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan match: %w", err)
 		}
 
+		match.HomeTeamID = homeTeamID.String
+		match.AwayTeamID = awayTeamID.String
+		match.CompetitionID = competitionID.String
+		match.SeasonID = seasonID.String
+		match.RoundID = roundID.String
+		match.TimeZone = timeZone.String
+		match.Date = match.Date.UTC()
+		if match.Odds, err = oddsFromColumn(odds); err != nil {
+			return nil, err
+		}
+
 		if homeGoals.Valid && awayGoals.Valid {
 			match.Score = &domain.Score{
 				HomeGoals: int(homeGoals.Int32),
 				AwayGoals: int(awayGoals.Int32),
+				ExtraTime: scorelineFromColumns(extraTimeHome, extraTimeAway),
+				Penalties: scorelineFromColumns(penaltyHome, penaltyAway),
 			}
 		}
 
@@ -217,3 +336,49 @@ func (r *MatchRepository) List(ctx context.Context, filters repository.MatchFilt
 
 	return matches, nil
 }
+
+// nullString stores an empty string as NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullScoreline stores a missing score phase as a pair of NULLs
+func nullScoreline(line *domain.Scoreline) (sql.NullInt32, sql.NullInt32) {
+	if line == nil {
+		return sql.NullInt32{}, sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(line.HomeGoals), Valid: true},
+		sql.NullInt32{Int32: int32(line.AwayGoals), Valid: true}
+}
+
+// scorelineFromColumns reads back a score phase stored by nullScoreline
+func scorelineFromColumns(home, away sql.NullInt32) *domain.Scoreline {
+	if !home.Valid || !away.Valid {
+		return nil
+	}
+	return &domain.Scoreline{HomeGoals: int(home.Int32), AwayGoals: int(away.Int32)}
+}
+
+// nullOdds stores a match's odds as JSON, or NULL if it has none
+func nullOdds(odds *domain.MatchOdds) (sql.NullString, error) {
+	if odds == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(odds)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to marshal match odds: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// oddsFromColumn reads odds stored as JSON, or nil if the column is NULL
+func oddsFromColumn(value sql.NullString) (*domain.MatchOdds, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	var odds domain.MatchOdds
+	if err := json.Unmarshal([]byte(value.String), &odds); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal match odds: %w", err)
+	}
+	return &odds, nil
+}
@@ -19,8 +19,8 @@ func NewPredictionRepository(db *sql.DB) *PredictionRepository {
 func (r *PredictionRepository) Create(ctx context.Context, prediction *domain.Prediction) error {
 	query := `
 		INSERT INTO predictions_view (
-			id, user_id, match_id, home_goals, away_goals
-		) VALUES ($1, $2, $3, $4, $5)
+			id, user_id, match_id, home_goals, away_goals, winner, margin, joker, auto, league_id, revision
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -29,6 +29,12 @@ func (r *PredictionRepository) Create(ctx context.Context, prediction *domain.Pr
 		prediction.MatchID,
 		prediction.HomeGoals,
 		prediction.AwayGoals,
+		nullString(string(prediction.Winner)),
+		nullInt(prediction.Margin),
+		prediction.Joker,
+		prediction.Auto,
+		nullString(prediction.LeagueID),
+		prediction.Revision,
 	)
 
 	if err != nil {
@@ -43,15 +49,29 @@ func (r *PredictionRepository) Update(ctx context.Context, prediction *domain.Pr
 		UPDATE predictions_view
 		SET home_goals = $1,
 			away_goals = $2,
-			points = $3,
+			winner = $3,
+			margin = $4,
+			joker = $5,
+			auto = $6,
+			league_id = $7,
+			revision = $8,
+			points = $9,
+			withdrawn_at = $10,
 			updated_at = CURRENT_TIMESTAMP
-		WHERE id = $4
+		WHERE id = $11
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
 		prediction.HomeGoals,
 		prediction.AwayGoals,
+		nullString(string(prediction.Winner)),
+		nullInt(prediction.Margin),
+		prediction.Joker,
+		prediction.Auto,
+		nullString(prediction.LeagueID),
+		prediction.Revision,
 		prediction.Points,
+		prediction.WithdrawnAt,
 		prediction.ID,
 	)
 
@@ -73,11 +93,13 @@ func (r *PredictionRepository) Update(ctx context.Context, prediction *domain.Pr
 
 func (r *PredictionRepository) GetByID(ctx context.Context, id string) (*domain.Prediction, error) {
 	query := `
-		SELECT id, user_id, match_id, home_goals, away_goals, points
+		SELECT id, user_id, match_id, home_goals, away_goals, winner, margin, joker, auto, league_id, revision, points, withdrawn_at
 		FROM predictions_view
 		WHERE id = $1
 	`
 
+	var winner, leagueID sql.NullString
+	var margin sql.NullInt32
 	prediction := &domain.Prediction{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&prediction.ID,
@@ -85,7 +107,14 @@ func (r *PredictionRepository) GetByID(ctx context.Context, id string) (*domain.
 		&prediction.MatchID,
 		&prediction.HomeGoals,
 		&prediction.AwayGoals,
+		&winner,
+		&margin,
+		&prediction.Joker,
+		&prediction.Auto,
+		&leagueID,
+		&prediction.Revision,
 		&prediction.Points,
+		&prediction.WithdrawnAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -96,16 +125,23 @@ func (r *PredictionRepository) GetByID(ctx context.Context, id string) (*domain.
 		return nil, fmt.Errorf("failed to get prediction: %w", err)
 	}
 
+	prediction.Winner = domain.MatchSide(winner.String)
+	prediction.LeagueID = leagueID.String
+	prediction.Margin = intFromColumn(margin)
 	return prediction, nil
 }
 
 func (r *PredictionRepository) GetByUserAndMatch(ctx context.Context, userID, matchID string) (*domain.Prediction, error) {
 	query := `
-		SELECT id, user_id, match_id, home_goals, away_goals, points
+		SELECT id, user_id, match_id, home_goals, away_goals, winner, margin, joker, auto, league_id, revision, points, withdrawn_at
 		FROM predictions_view
-		WHERE user_id = $1 AND match_id = $2
+		WHERE user_id = $1 AND match_id = $2 AND withdrawn_at IS NULL
+		ORDER BY auto, created_at
+		LIMIT 1
 	`
 
+	var winner, leagueID sql.NullString
+	var margin sql.NullInt32
 	prediction := &domain.Prediction{}
 	err := r.db.QueryRowContext(ctx, query, userID, matchID).Scan(
 		&prediction.ID,
@@ -113,7 +149,14 @@ func (r *PredictionRepository) GetByUserAndMatch(ctx context.Context, userID, ma
 		&prediction.MatchID,
 		&prediction.HomeGoals,
 		&prediction.AwayGoals,
+		&winner,
+		&margin,
+		&prediction.Joker,
+		&prediction.Auto,
+		&leagueID,
+		&prediction.Revision,
 		&prediction.Points,
+		&prediction.WithdrawnAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -124,12 +167,15 @@ func (r *PredictionRepository) GetByUserAndMatch(ctx context.Context, userID, ma
 		return nil, fmt.Errorf("failed to get prediction: %w", err)
 	}
 
+	prediction.Winner = domain.MatchSide(winner.String)
+	prediction.LeagueID = leagueID.String
+	prediction.Margin = intFromColumn(margin)
 	return prediction, nil
 }
 
 func (r *PredictionRepository) ListByUser(ctx context.Context, userID string) ([]*domain.Prediction, error) {
 	query := `
-		SELECT id, user_id, match_id, home_goals, away_goals, points
+		SELECT id, user_id, match_id, home_goals, away_goals, winner, margin, joker, auto, league_id, revision, points, withdrawn_at
 		FROM predictions_view
 		WHERE user_id = $1
 		ORDER BY created_at DESC
@@ -147,6 +193,8 @@ func (r *PredictionRepository) ListByUser(ctx context.Context, userID string) ([
 
 	var predictions []*domain.Prediction
 	for rows.Next() {
+		var winner, leagueID sql.NullString
+		var margin sql.NullInt32
 		prediction := &domain.Prediction{}
 		err := rows.Scan(
 			&prediction.ID,
@@ -154,11 +202,21 @@ func (r *PredictionRepository) ListByUser(ctx context.Context, userID string) ([
 			&prediction.MatchID,
 			&prediction.HomeGoals,
 			&prediction.AwayGoals,
+			&winner,
+			&margin,
+			&prediction.Joker,
+			&prediction.Auto,
+			&leagueID,
+			&prediction.Revision,
 			&prediction.Points,
+			&prediction.WithdrawnAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan prediction: %w", err)
 		}
+		prediction.Winner = domain.MatchSide(winner.String)
+		prediction.LeagueID = leagueID.String
+		prediction.Margin = intFromColumn(margin)
 		predictions = append(predictions, prediction)
 	}
 
@@ -171,7 +229,7 @@ func (r *PredictionRepository) ListByUser(ctx context.Context, userID string) ([
 
 func (r *PredictionRepository) ListByMatch(ctx context.Context, matchID string) ([]*domain.Prediction, error) {
 	query := `
-		SELECT id, user_id, match_id, home_goals, away_goals, points
+		SELECT id, user_id, match_id, home_goals, away_goals, winner, margin, joker, auto, league_id, revision, points, withdrawn_at
 		FROM predictions_view
 		WHERE match_id = $1
 		ORDER BY created_at DESC
@@ -189,6 +247,8 @@ func (r *PredictionRepository) ListByMatch(ctx context.Context, matchID string)
 
 	var predictions []*domain.Prediction
 	for rows.Next() {
+		var winner, leagueID sql.NullString
+		var margin sql.NullInt32
 		prediction := &domain.Prediction{}
 		err := rows.Scan(
 			&prediction.ID,
@@ -196,11 +256,21 @@ func (r *PredictionRepository) ListByMatch(ctx context.Context, matchID string)
 			&prediction.MatchID,
 			&prediction.HomeGoals,
 			&prediction.AwayGoals,
+			&winner,
+			&margin,
+			&prediction.Joker,
+			&prediction.Auto,
+			&leagueID,
+			&prediction.Revision,
 			&prediction.Points,
+			&prediction.WithdrawnAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan prediction: %w", err)
 		}
+		prediction.Winner = domain.MatchSide(winner.String)
+		prediction.LeagueID = leagueID.String
+		prediction.Margin = intFromColumn(margin)
 		predictions = append(predictions, prediction)
 	}
 
@@ -210,3 +280,46 @@ func (r *PredictionRepository) ListByMatch(ctx context.Context, matchID string)
 
 	return predictions, nil
 }
+
+func (r *PredictionRepository) AddRevision(ctx context.Context, revision *domain.PredictionRevision) error {
+	query := `
+		INSERT INTO prediction_revisions_view (
+			prediction_id, revision, home_goals, away_goals, winner, margin, joker, withdrawn, recorded_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		revision.PredictionID,
+		revision.Revision,
+		revision.HomeGoals,
+		revision.AwayGoals,
+		nullString(string(revision.Winner)),
+		nullInt(revision.Margin),
+		revision.Joker,
+		revision.Withdrawn,
+		revision.RecordedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to add prediction revision to read model: %w", err)
+	}
+
+	return nil
+}
+
+// nullInt stores a missing number as NULL
+func nullInt(value *int) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*value), Valid: true}
+}
+
+// intFromColumn reads back a number stored by nullInt
+func intFromColumn(value sql.NullInt32) *int {
+	if !value.Valid {
+		return nil
+	}
+	n := int(value.Int32)
+	return &n
+}
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+)
+
+type RoundRepository struct {
+	db *sql.DB
+}
+
+func NewRoundRepository(db *sql.DB) *RoundRepository {
+	return &RoundRepository{db: db}
+}
+
+func (r *RoundRepository) Create(ctx context.Context, round *domain.Round) error {
+	query := `
+		INSERT INTO rounds_view (
+			id, competition_id, season_id, number, name, start_date, end_date, deadline
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		round.ID,
+		round.CompetitionID,
+		nullString(round.SeasonID),
+		round.Number,
+		round.Name,
+		round.StartDate,
+		round.EndDate,
+		round.Deadline,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create round in read model: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RoundRepository) Update(ctx context.Context, round *domain.Round) error {
+	query := `
+		UPDATE rounds_view
+		SET number = $1,
+			name = $2,
+			start_date = $3,
+			end_date = $4,
+			deadline = $5,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		round.Number,
+		round.Name,
+		round.StartDate,
+		round.EndDate,
+		round.Deadline,
+		round.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update round in read model: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("round not found: %s", round.ID)
+	}
+
+	return nil
+}
+
+func (r *RoundRepository) GetByID(ctx context.Context, id string) (*domain.Round, error) {
+	query := `
+		SELECT id, competition_id, season_id, number, name, start_date, end_date, deadline
+		FROM rounds_view
+		WHERE id = $1
+	`
+
+	var seasonID sql.NullString
+	round := &domain.Round{}
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&round.ID,
+		&round.CompetitionID,
+		&seasonID,
+		&round.Number,
+		&round.Name,
+		&round.StartDate,
+		&round.EndDate,
+		&round.Deadline,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: round %s", repository.ErrNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get round: %w", err)
+	}
+
+	round.SeasonID = seasonID.String
+	round.SetDates(round.StartDate, round.EndDate, round.Deadline)
+
+	return round, nil
+}
+
+func (r *RoundRepository) List(ctx context.Context, filters repository.RoundFilters) ([]*domain.Round, error) {
+	query := `
+		SELECT id, competition_id, season_id, number, name, start_date, end_date, deadline
+		FROM rounds_view
+	`
+
+	var conditions []string
+	var args []interface{}
+	argPos := 1
+
+	if filters.CompetitionID != nil {
+		conditions = append(conditions, fmt.Sprintf("competition_id = $%d", argPos))
+		args = append(args, *filters.CompetitionID)
+		argPos++
+	}
+
+	if filters.SeasonID != nil {
+		conditions = append(conditions, fmt.Sprintf("season_id = $%d", argPos))
+		args = append(args, *filters.SeasonID)
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY start_date ASC, number ASC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rounds: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("error closing rows: %v\n", err)
+		}
+	}()
+
+	var rounds []*domain.Round
+	for rows.Next() {
+		var seasonID sql.NullString
+		round := &domain.Round{}
+		err := rows.Scan(
+			&round.ID,
+			&round.CompetitionID,
+			&seasonID,
+			&round.Number,
+			&round.Name,
+			&round.StartDate,
+			&round.EndDate,
+			&round.Deadline,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan round: %w", err)
+		}
+
+		round.SeasonID = seasonID.String
+		round.SetDates(round.StartDate, round.EndDate, round.Deadline)
+		rounds = append(rounds, round)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rounds: %w", err)
+	}
+
+	return rounds, nil
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/parkertr2/footy-tipping/internal/domain"
+	"github.com/parkertr2/footy-tipping/internal/infrastructure/repository"
+)
+
+type UserRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	query := `
+		INSERT INTO users (
+			id, username, email, time_zone, join_date, deactivated_at
+		) VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		user.ID,
+		user.Username,
+		user.Email,
+		user.TimeZone,
+		user.JoinDate.UTC(),
+		nullTime(user.DeactivatedAt),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to create user in read model: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	query := `
+		UPDATE users
+		SET username = $1,
+			email = $2,
+			time_zone = $3,
+			deactivated_at = $4,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $5
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		user.Username,
+		user.Email,
+		user.TimeZone,
+		nullTime(user.DeactivatedAt),
+		user.ID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update user in read model: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("%w: user %s", repository.ErrNotFound, user.ID)
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	query := `
+		SELECT id, username, email, time_zone, join_date, deactivated_at
+		FROM users
+		WHERE id = $1
+	`
+
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: user %s", repository.ErrNotFound, id)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (r *UserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	query := `
+		SELECT id, username, email, time_zone, join_date, deactivated_at
+		FROM users
+		ORDER BY username ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			fmt.Printf("error closing rows: %v\n", err)
+		}
+	}()
+
+	var users []*domain.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating users: %w", err)
+	}
+
+	return users, nil
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanUser reads a user from a row selected with the user columns
+func scanUser(row rowScanner) (*domain.User, error) {
+	var deactivatedAt sql.NullTime
+	user := &domain.User{}
+	err := row.Scan(
+		&user.ID,
+		&user.Username,
+		&user.Email,
+		&user.TimeZone,
+		&user.JoinDate,
+		&deactivatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	user.JoinDate = user.JoinDate.UTC()
+	if deactivatedAt.Valid {
+		at := deactivatedAt.Time.UTC()
+		user.DeactivatedAt = &at
+	}
+	return user, nil
+}
+
+// nullTime stores a missing time as NULL
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: value.UTC(), Valid: true}
+}
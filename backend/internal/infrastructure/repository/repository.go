@@ -39,7 +39,8 @@ type PredictionRepository interface {
 	// GetByID retrieves a prediction by its ID
 	GetByID(ctx context.Context, id string) (*domain.Prediction, error)
 
-	// GetByUserAndMatch retrieves a prediction by user ID and match ID
+	// GetByUserAndMatch retrieves a user's active (not withdrawn) prediction for a match,
+	// preferring their own tip to any league's auto-tip
 	GetByUserAndMatch(ctx context.Context, userID, matchID string) (*domain.Prediction, error)
 
 	// ListByUser retrieves all predictions for a user
@@ -47,12 +48,55 @@ type PredictionRepository interface {
 
 	// ListByMatch retrieves all predictions for a match
 	ListByMatch(ctx context.Context, matchID string) ([]*domain.Prediction, error)
+
+	// AddRevision records a revision in a prediction's history
+	AddRevision(ctx context.Context, revision *domain.PredictionRevision) error
+}
+
+// RoundRepository defines the interface for round read model operations
+type RoundRepository interface {
+	// Create creates a new round in the read model
+	Create(ctx context.Context, round *domain.Round) error
+
+	// Update updates an existing round in the read model
+	Update(ctx context.Context, round *domain.Round) error
+
+	// GetByID retrieves a round by its ID
+	GetByID(ctx context.Context, id string) (*domain.Round, error)
+
+	// List retrieves all rounds with optional filters
+	List(ctx context.Context, filters RoundFilters) ([]*domain.Round, error)
+}
+
+// UserRepository defines the interface for user read model operations
+type UserRepository interface {
+	// Create creates a new user in the read model
+	Create(ctx context.Context, user *domain.User) error
+
+	// Update updates an existing user in the read model
+	Update(ctx context.Context, user *domain.User) error
+
+	// GetByID retrieves a user by their ID
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+
+	// List retrieves every user, ordered by username
+	List(ctx context.Context) ([]*domain.User, error)
 }
 
 // MatchFilters defines the available filters for listing matches
 type MatchFilters struct {
-	Competition *string    // Filter by competition
-	StartDate   *time.Time // Filter by start date
-	EndDate     *time.Time // Filter by end date
-	Status      *string    // Filter by match status
+	Competition   *string    // Filter by competition name
+	TeamID        *string    // Filter by team, home or away
+	CompetitionID *string    // Filter by competition
+	SeasonID      *string    // Filter by season
+	RoundID       *string    // Filter by round
+	StartDate     *time.Time // Filter by start date
+	EndDate       *time.Time // Filter by end date
+	Status        *string    // Filter by match status
+}
+
+// RoundFilters defines the available filters for listing rounds
+type RoundFilters struct {
+	CompetitionID *string // Filter by competition
+	SeasonID      *string // Filter by season
 }
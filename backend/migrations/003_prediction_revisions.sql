@@ -0,0 +1,14 @@
+-- Track jokers and amendments on the predictions read model
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS joker BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 1;
+
+-- Create prediction revisions read model table
+CREATE TABLE IF NOT EXISTS prediction_revisions_view (
+    prediction_id VARCHAR(255) NOT NULL REFERENCES predictions_view(id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    home_goals INT NOT NULL,
+    away_goals INT NOT NULL,
+    joker BOOLEAN NOT NULL DEFAULT FALSE,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (prediction_id, revision)
+);
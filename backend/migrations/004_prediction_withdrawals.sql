@@ -0,0 +1,8 @@
+-- Track withdrawn predictions on the predictions read model
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS withdrawn_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE prediction_revisions_view ADD COLUMN IF NOT EXISTS withdrawn BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A user may tip a match again after withdrawing, so only active tips are unique
+ALTER TABLE predictions_view DROP CONSTRAINT IF EXISTS predictions_view_user_id_match_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_predictions_view_active_user_match
+    ON predictions_view(user_id, match_id) WHERE withdrawn_at IS NULL;
@@ -0,0 +1,19 @@
+-- Create rounds read model table
+CREATE TABLE IF NOT EXISTS rounds_view (
+    id VARCHAR(255) PRIMARY KEY,
+    competition VARCHAR(255) NOT NULL,
+    number INT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    start_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    deadline TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_rounds_view_competition ON rounds_view(competition);
+CREATE INDEX IF NOT EXISTS idx_rounds_view_start_date ON rounds_view(start_date);
+
+-- Matches belong to a round
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS round_id VARCHAR(255) REFERENCES rounds_view(id);
+CREATE INDEX IF NOT EXISTS idx_matches_view_round_id ON matches_view(round_id);
@@ -0,0 +1,13 @@
+-- Matches reference managed competitions and seasons by ID. The competition
+-- name is kept for display and for matches created before competitions existed.
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS competition_id VARCHAR(255);
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS season_id VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_matches_view_competition_id ON matches_view(competition_id);
+CREATE INDEX IF NOT EXISTS idx_matches_view_season_id ON matches_view(season_id);
+
+-- Rounds belong to a competition by ID, and optionally to a season
+ALTER TABLE rounds_view RENAME COLUMN competition TO competition_id;
+ALTER TABLE rounds_view ADD COLUMN IF NOT EXISTS season_id VARCHAR(255);
+DROP INDEX IF EXISTS idx_rounds_view_competition;
+CREATE INDEX IF NOT EXISTS idx_rounds_view_competition_id ON rounds_view(competition_id);
+CREATE INDEX IF NOT EXISTS idx_rounds_view_season_id ON rounds_view(season_id);
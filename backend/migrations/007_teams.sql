@@ -0,0 +1,6 @@
+-- Matches reference registered teams by ID. The team names are kept for
+-- display and for matches created before the team registry existed.
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS home_team_id VARCHAR(255);
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS away_team_id VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_matches_view_home_team_id ON matches_view(home_team_id);
+CREATE INDEX IF NOT EXISTS idx_matches_view_away_team_id ON matches_view(away_team_id);
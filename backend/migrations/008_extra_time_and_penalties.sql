@@ -0,0 +1,6 @@
+-- Matches record the score after extra time and the penalty shootout
+-- separately from the regulation-time score held in home_goals/away_goals
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS extra_time_home_goals INTEGER;
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS extra_time_away_goals INTEGER;
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS penalty_home_goals INTEGER;
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS penalty_away_goals INTEGER;
@@ -0,0 +1,6 @@
+-- Winner tipping: a prediction may pick a winner (HOME or AWAY) and an
+-- optional winning margin instead of an exact score
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS winner VARCHAR(4);
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS margin INTEGER;
+ALTER TABLE prediction_revisions_view ADD COLUMN IF NOT EXISTS winner VARCHAR(4);
+ALTER TABLE prediction_revisions_view ADD COLUMN IF NOT EXISTS margin INTEGER;
@@ -0,0 +1,19 @@
+-- Kickoffs are instants: store them with a time zone, interpreting existing
+-- values as the UTC they were written in, and record the venue's time zone
+-- so kickoffs can also be shown in local time at the ground
+ALTER TABLE matches_view
+    ALTER COLUMN match_date TYPE TIMESTAMP WITH TIME ZONE USING match_date AT TIME ZONE 'UTC',
+    ALTER COLUMN created_at TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMP WITH TIME ZONE USING updated_at AT TIME ZONE 'UTC';
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS venue_time_zone VARCHAR(64);
+
+ALTER TABLE predictions_view
+    ALTER COLUMN created_at TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMP WITH TIME ZONE USING updated_at AT TIME ZONE 'UTC';
+
+ALTER TABLE rounds_view
+    ALTER COLUMN created_at TYPE TIMESTAMP WITH TIME ZONE USING created_at AT TIME ZONE 'UTC',
+    ALTER COLUMN updated_at TYPE TIMESTAMP WITH TIME ZONE USING updated_at AT TIME ZONE 'UTC';
+
+-- Each user sees kickoffs in their preferred time zone, UTC by default
+ALTER TABLE users ADD COLUMN IF NOT EXISTS time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
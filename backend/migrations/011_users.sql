@@ -0,0 +1,3 @@
+-- Users are event sourced: the users table is their read model, and a
+-- deactivated user keeps their row with the time they were deactivated
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,6 @@
+-- Auto-tips: a league's auto-tip policy tips for members who miss a match's
+-- lock, and those predictions are marked so leaderboards can count them
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS auto BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The latest bookmaker odds for a match, used by the FAVOURITE_ODDS policy
+ALTER TABLE matches_view ADD COLUMN IF NOT EXISTS odds JSONB;
@@ -0,0 +1,9 @@
+-- Auto-tips are made per league and count only in the league whose policy
+-- made them
+ALTER TABLE predictions_view ADD COLUMN IF NOT EXISTS league_id VARCHAR(255);
+
+-- A member may hold an auto-tip from each league alongside their own tip, so
+-- active tips are unique per league, with a user's own tips having none
+DROP INDEX IF EXISTS idx_predictions_view_active_user_match;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_predictions_view_active_user_match
+    ON predictions_view(user_id, match_id, COALESCE(league_id, '')) WHERE withdrawn_at IS NULL;
@@ -15,19 +15,41 @@ type Event struct {
 
 // MatchCreated represents a match creation event
 type MatchCreated struct {
-	ID          string
-	HomeTeam    string
-	AwayTeam    string
-	Date        time.Time
-	Competition string
+	ID            string
+	HomeTeam      string
+	AwayTeam      string
+	HomeTeamID    string
+	AwayTeamID    string
+	Date          time.Time
+	Competition   string
+	CompetitionID string
+	SeasonID      string
+	RoundID       string
+	TimeZone      string // IANA time zone of the venue
 }
 
 // MatchScoreUpdated represents a match score update event
 type MatchScoreUpdated struct {
 	MatchID   string
+	HomeGoals int        // after regulation time
+	AwayGoals int        // after regulation time
+	ExtraTime *Scoreline // score at the end of extra time, if played
+	Penalties *Scoreline // shootout tally, if held
+	UpdatedAt time.Time
+}
+
+// Scoreline is a pair of goal tallies for one phase of a match
+type Scoreline struct {
 	HomeGoals int
 	AwayGoals int
-	UpdatedAt time.Time
+}
+
+// MatchScoreCorrected represents the score of a finished match being fixed
+// after it was recorded wrongly. Points already awarded for the match are
+// recalculated.
+type MatchScoreCorrected struct {
+	MatchScoreUpdated
+	Reason string
 }
 
 // MatchStatusChanged represents a match status change event
@@ -37,6 +59,172 @@ type MatchStatusChanged struct {
 	ChangedAt time.Time
 }
 
+// MatchRescheduled represents a match's kickoff moving to a new date. Tips
+// lock relative to the new kickoff.
+type MatchRescheduled struct {
+	MatchID       string
+	PreviousDate  time.Time
+	Date          time.Time
+	Reason        string
+	RescheduledAt time.Time
+}
+
+// MatchOddsUpdated represents new bookmaker prices being recorded for a
+// match, replacing any earlier prices
+type MatchOddsUpdated struct {
+	MatchID   string
+	Home      float64
+	Draw      float64 // zero for sports without draws
+	Away      float64
+	Source    string
+	UpdatedAt time.Time
+}
+
+// GoalScored represents a goal in a live match. Side is the team credited
+// with the goal, so for an own goal the player is on the other side.
+type GoalScored struct {
+	ID        string
+	MatchID   string
+	Side      string // HOME or AWAY
+	Player    string
+	Minute    int
+	AddedTime int    // stoppage-time minutes past Minute
+	Period    string // the period the goal was scored in
+	OwnGoal   bool
+	Penalty   bool
+	ScoredAt  time.Time
+}
+
+// GoalDisallowed represents a goal being ruled out after it was recorded.
+// The goal's side and period are carried so it can be taken off the
+// score.
+type GoalDisallowed struct {
+	ID           string
+	MatchID      string
+	GoalID       string
+	Side         string
+	Period       string
+	Minute       int
+	AddedTime    int
+	Reason       string
+	DisallowedAt time.Time
+}
+
+// CardShown represents a yellow or red card shown in a live match
+type CardShown struct {
+	ID        string
+	MatchID   string
+	Side      string
+	Player    string
+	Card      string // YELLOW, SECOND_YELLOW or RED
+	Minute    int
+	AddedTime int
+	Period    string
+	ShownAt   time.Time
+}
+
+// PeriodChanged represents a live match moving into a new period of play,
+// e.g. kickoff, half time or a penalty shootout
+type PeriodChanged struct {
+	ID        string
+	MatchID   string
+	Period    string
+	Minute    int
+	ChangedAt time.Time
+}
+
+// TeamCreated represents a team registration event
+type TeamCreated struct {
+	ID        string
+	Name      string
+	ShortCode string
+	Aliases   []string // alternative names, e.g. "Man United" for "Manchester United"
+	TimeZone  string   // IANA time zone of the home ground, if known
+}
+
+// TeamUpdated represents a change to a team's name, short code or aliases
+type TeamUpdated struct {
+	TeamID    string
+	Name      string
+	ShortCode string
+	Aliases   []string
+	TimeZone  string
+	UpdatedAt time.Time
+}
+
+// CompetitionCreated represents a competition creation event
+type CompetitionCreated struct {
+	ID          string
+	Name        string
+	Sport       string
+	Aliases     []string // alternative names, e.g. "EPL" for "Premier League"
+	ResultBasis string   // which part of the score counts for tipping; empty means regulation
+	TippingMode string   // EXACT_SCORE or WINNER_MARGIN; empty means the sport's default
+}
+
+// CompetitionResultBasisChanged represents a change to which part of the
+// score counts for tipping in a competition
+type CompetitionResultBasisChanged struct {
+	CompetitionID string
+	ResultBasis   string
+	ChangedAt     time.Time
+}
+
+// CompetitionTippingModeChanged represents a change to what users tip for
+// each match in a competition
+type CompetitionTippingModeChanged struct {
+	CompetitionID string
+	TippingMode   string
+	ChangedAt     time.Time
+}
+
+// CompetitionTiebreakersChanged represents a change to the ordered
+// tiebreakers that split users level on points in a competition
+type CompetitionTiebreakersChanged struct {
+	CompetitionID string
+	Tiebreakers   []string
+	ChangedAt     time.Time
+}
+
+// SeasonCreated represents a season creation event
+type SeasonCreated struct {
+	ID            string
+	CompetitionID string
+	Name          string
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+// SeasonStatusChanged represents a season moving through its lifecycle
+type SeasonStatusChanged struct {
+	SeasonID  string
+	Status    string
+	ChangedAt time.Time
+}
+
+// RoundCreated represents a round creation event
+type RoundCreated struct {
+	ID            string
+	CompetitionID string
+	SeasonID      string
+	Number        int
+	Name          string
+	StartDate     time.Time
+	EndDate       time.Time
+	Deadline      time.Time
+}
+
+// RoundUpdated represents a change to a round's details
+type RoundUpdated struct {
+	RoundID   string
+	Number    int
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+	Deadline  time.Time
+	UpdatedAt time.Time
+}
+
 // PredictionMade represents a prediction creation event
 type PredictionMade struct {
 	ID        string
@@ -44,15 +232,331 @@ type PredictionMade struct {
 	MatchID   string
 	HomeGoals int
 	AwayGoals int
+	Winner    string // HOME or AWAY for a winner tip; empty for an exact-score tip
+	Margin    *int   // optional winning margin for a winner tip
+	Joker     bool   // doubles the points earned; one per user per round
+	Auto      bool   // made by a league's auto-tip policy after the user missed the lock
+	LeagueID  string // the league an auto-tip was made for; it counts in no other
 	CreatedAt time.Time
 }
 
+// PredictionAmended represents a change to an existing prediction before kickoff
+type PredictionAmended struct {
+	PredictionID string
+	UserID       string
+	MatchID      string
+	HomeGoals    int
+	AwayGoals    int
+	Winner       string
+	Margin       *int
+	Joker        bool
+	Revision     int
+	AmendedAt    time.Time
+}
+
+// PredictionWithdrawn represents a prediction being pulled before kickoff
+type PredictionWithdrawn struct {
+	PredictionID string
+	UserID       string
+	MatchID      string
+	Revision     int
+	WithdrawnAt  time.Time
+}
+
 // PointsAwarded represents points being awarded for a prediction
 type PointsAwarded struct {
+	PredictionID string
+	UserID       string
+	MatchID      string
+	Points       int
+	Breakdown    []RuleAward // scoring rules that contributed to Points
+	MarginError  *int        // set for the winner tip on the first match of a round
+	Void         bool        // true when the match was abandoned and the prediction voided
+	SubmittedAt  time.Time   // when the prediction was last made or amended
+	Correction   bool        // true when re-awarded after a score correction
+	Adjustment   int         // change from the points previously awarded, on a correction
+	Auto         bool        // true when the prediction was an auto-tip
+	AwardedAt    time.Time
+}
+
+// RuleAward records the points a single scoring rule contributed
+type RuleAward struct {
+	Rule   string
+	Points int
+}
+
+// BracketCreated represents a knockout bracket being set up for a tournament
+type BracketCreated struct {
+	ID            string
+	CompetitionID string
+	SeasonID      string
+	Name          string
+	LockAt        time.Time
+	RoundWeights  []int // points per correct pick, by round; rounds without a weight are worth 1
+	Ties          []BracketTie
+}
+
+// BracketTie is one knockout tie within BracketCreated. Each side is either a
+// team ID or the ID of the earlier tie whose winner fills it.
+type BracketTie struct {
+	ID           string
+	Round        int
+	HomeTeamID   string
+	HomeWinnerOf string
+	AwayTeamID   string
+	AwayWinnerOf string
+	MatchID      string
+}
+
+// BracketTieMatchLinked represents the match that decides a bracket tie being set
+type BracketTieMatchLinked struct {
+	BracketID string
+	TieID     string
+	MatchID   string
+	LinkedAt  time.Time
+}
+
+// BracketEntrySubmitted represents a user submitting, or resubmitting before
+// the lock, their picks for a bracket
+type BracketEntrySubmitted struct {
+	ID          string
+	BracketID   string
+	UserID      string
+	Picks       map[string]string // tie ID to the team ID picked to advance
+	SubmittedAt time.Time
+}
+
+// BracketPointsAwarded represents the points a bracket entry earned for a
+// decided tie
+type BracketPointsAwarded struct {
+	BracketID string
+	EntryID   string
 	UserID    string
+	TieID     string
 	MatchID   string
+	WinnerID  string
 	Points    int
 	AwardedAt time.Time
+
+	// Correction is set when a score correction changed the entry's points
+	// for the tie; Adjustment is the change from the points awarded before
+	Correction bool
+	Adjustment int
+}
+
+// OutrightMarketCreated represents a season-long outright market being opened
+type OutrightMarketCreated struct {
+	ID            string
+	CompetitionID string
+	SeasonID      string
+	Name          string
+	Selections    int // how many teams each tip picks, e.g. 3 for the relegated teams
+	PointsPerPick int
+	Deadline      time.Time
+}
+
+// OutrightTipSubmitted represents a user submitting, or resubmitting before
+// the deadline, their picks for an outright market
+type OutrightTipSubmitted struct {
+	ID          string
+	MarketID    string
+	UserID      string
+	Picks       []string // team IDs
+	SubmittedAt time.Time
+}
+
+// OutrightMarketSettled represents an outright market's result being recorded
+type OutrightMarketSettled struct {
+	MarketID  string
+	Result    []string // team IDs
+	SettledAt time.Time
+}
+
+// OutrightPointsAwarded represents points being awarded for an outright tip
+// once its market is settled
+type OutrightPointsAwarded struct {
+	MarketID  string
+	TipID     string
+	UserID    string
+	Correct   int // picks that were in the result
+	Points    int
+	AwardedAt time.Time
+}
+
+// LeagueCreated represents a private league being created by its owner
+type LeagueCreated struct {
+	ID            string
+	Name          string
+	OwnerID       string
+	InviteCode    string
+	CompetitionID string
+	SeasonID      string
+	Format        string // CLASSIC or HEAD_TO_HEAD; empty means CLASSIC
+	CreatedAt     time.Time
+}
+
+// LeagueMemberJoined represents a user joining a league with its invite code
+type LeagueMemberJoined struct {
+	LeagueID string
+	UserID   string
+	JoinedAt time.Time
+}
+
+// LeagueMemberLeft represents a member leaving a league
+type LeagueMemberLeft struct {
+	LeagueID string
+	UserID   string
+	LeftAt   time.Time
+}
+
+// LeagueSettingsChanged represents the owner changing a league's settings.
+// InviteCode is only set when the code was regenerated.
+type LeagueSettingsChanged struct {
+	LeagueID      string
+	Name          string
+	CompetitionID string
+	SeasonID      string
+	InviteCode    string
+	Format        string // empty leaves the format unchanged
+	ChangedAt     time.Time
+}
+
+// LeagueRulesChanged represents the owner setting a league's own rules.
+// Empty fields fall back to the competition's rules.
+type LeagueRulesChanged struct {
+	LeagueID   string
+	Scoring    []ScoringRule // nil uses the competition's scoring rules
+	LockCutoff string
+	Jokers     *bool
+	RoundIDs   []string
+	MatchIDs   []string
+	AutoTip    string // auto-tip policy for members who miss the lock; empty for none
+	ChangedAt  time.Time
+}
+
+// LeaguePointsAwarded represents points being awarded for a prediction under
+// a league's own rules, alongside the global PointsAwarded
+type LeaguePointsAwarded struct {
+	LeagueID string
+	PointsAwarded
+}
+
+// LeagueFixturesGenerated represents a head-to-head league's round-robin
+// fixtures being generated, replacing any earlier fixtures
+type LeagueFixturesGenerated struct {
+	LeagueID    string
+	Fixtures    []LeagueFixture
+	GeneratedAt time.Time
+}
+
+// LeagueFixture is one matchup within LeagueFixturesGenerated
+type LeagueFixture struct {
+	RoundID    string
+	HomeUserID string
+	AwayUserID string
+}
+
+// SurvivorPoolCreated represents a last-man-standing pool being opened over
+// a competition's matches
+type SurvivorPoolCreated struct {
+	ID            string
+	Name          string
+	CompetitionID string
+	SeasonID      string
+	CreatedAt     time.Time
+}
+
+// SurvivorPickMade represents a player picking a team to win in a survivor
+// pool, or changing their pick for the round before it locks
+type SurvivorPickMade struct {
+	ID       string
+	PoolID   string
+	UserID   string
+	RoundID  string // the match's round key
+	MatchID  string
+	TeamID   string
+	PickedAt time.Time
+}
+
+// SurvivorPickSettled represents a survivor pick's match result being final.
+// A LOST or DRAWN outcome eliminates the player.
+type SurvivorPickSettled struct {
+	PoolID    string
+	PickID    string
+	UserID    string
+	MatchID   string
+	Outcome   string
+	SettledAt time.Time
+
+	// Correction is set when a score correction changed a settled pick's
+	// outcome, from PreviousOutcome
+	Correction      bool
+	PreviousOutcome string
+}
+
+// ScoringRulesConfigured represents a competition's scoring rules being set
+type ScoringRulesConfigured struct {
+	Competition  string
+	Rules        []ScoringRule
+	ConfiguredAt time.Time
+}
+
+// ScoringRule is one configured rule within ScoringRulesConfigured
+type ScoringRule struct {
+	Type   string
+	Points int
+	Final  bool
+}
+
+// NotificationSent represents a message sent to a user about something that
+// affects their tips
+type NotificationSent struct {
+	ID      string
+	UserID  string
+	Type    string
+	MatchID string
+	Message string
+	SentAt  time.Time
+}
+
+// UserRegistered represents a new user signing up
+type UserRegistered struct {
+	ID           string
+	Username     string
+	Email        string
+	RegisteredAt time.Time
+}
+
+// UserProfileUpdated represents a user changing their username or email
+type UserProfileUpdated struct {
+	UserID    string
+	Username  string
+	Email     string
+	UpdatedAt time.Time
+}
+
+// UserDeactivated represents a user's account being closed
+type UserDeactivated struct {
+	UserID        string
+	Reason        string
+	DeactivatedAt time.Time
+}
+
+// UserPreferencesUpdated represents a user changing their display preferences
+type UserPreferencesUpdated struct {
+	UserID    string
+	TimeZone  string // IANA time zone kickoffs are shown in
+	UpdatedAt time.Time
+}
+
+// BadgeAwarded represents a user unlocking an achievement badge
+type BadgeAwarded struct {
+	UserID    string
+	BadgeID   string
+	Name      string
+	Metric    string // the statistic the badge is measured on
+	Value     int    // the user's statistic when the badge was unlocked
+	AwardedAt time.Time
 }
 
 // NewEvent creates a new event instance
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// inviteCodeAlphabet leaves out characters that are easily misread, such as
+// 0 and O or 1 and I
+const inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// inviteCodeLength is the number of characters in an invite code
+const inviteCodeLength = 8
+
+// GenerateInviteCode generates a random code for joining a private league
+func GenerateInviteCode() string {
+	code := make([]byte, inviteCodeLength)
+	max := big.NewInt(int64(len(inviteCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic("failed to generate invite code: " + err.Error())
+		}
+		code[i] = inviteCodeAlphabet[n.Int64()]
+	}
+	return string(code)
+}
@@ -8,6 +8,7 @@ interface LeaderboardEntry {
   points: number
   correctPredictions: number
   totalPredictions: number
+  autoTips: number
 }
 
 const Leaderboard = () => {
@@ -34,12 +35,13 @@ const Leaderboard = () => {
               <TableCell align="right">Points</TableCell>
               <TableCell align="right">Correct Predictions</TableCell>
               <TableCell align="right">Success Rate</TableCell>
+              <TableCell align="right">Auto-tips</TableCell>
             </TableRow>
           </TableHead>
           <TableBody>
             {isLoading ? (
               <TableRow>
-                <TableCell colSpan={5} align="center">
+                <TableCell colSpan={6} align="center">
                   Loading leaderboard...
                 </TableCell>
               </TableRow>
@@ -53,6 +55,7 @@ const Leaderboard = () => {
                   <TableCell align="right">
                     {((entry.correctPredictions / entry.totalPredictions) * 100).toFixed(1)}%
                   </TableCell>
+                  <TableCell align="right">{entry.autoTips}</TableCell>
                 </TableRow>
               ))
             )}
@@ -26,8 +26,9 @@ const Matches: React.FC = () => {
   const [prediction, setPrediction] = useState('')
   const [userPredictions, setUserPredictions] = useState<Record<string, Prediction>>({})
 
+  // Set by the Profile page when the user registers
   // TODO: Replace with actual user ID from authentication
-  const currentUserId = 'user123'
+  const currentUserId = localStorage.getItem('userId')
 
   const { data: matches, isLoading } = useQuery<Match[]>({
     queryKey: ['matches'],
@@ -40,7 +41,7 @@ const Matches: React.FC = () => {
   // Fetch user predictions for all matches
   useEffect(() => {
     const fetchPredictions = async () => {
-      if (!matches) return
+      if (!matches || !currentUserId) return
 
       const predictions: Record<string, Prediction> = {}
 
@@ -61,6 +62,10 @@ const Matches: React.FC = () => {
 
   const submitPrediction = useMutation({
     mutationFn: async ({ matchId, prediction }: { matchId: string; prediction: string }) => {
+      if (!currentUserId) {
+        throw new Error('Register on the Profile page before making predictions')
+      }
+
       // Parse prediction format "2-1" into homeGoals and awayGoals
       const scoreParts = prediction.split('-')
       if (scoreParts.length !== 2) {
@@ -94,7 +99,8 @@ const Matches: React.FC = () => {
     },
     onError: (error: any) => {
       console.error('Failed to submit prediction:', error)
-      alert(error.message || 'Failed to submit prediction')
+      // API errors are problem details; prefer their detail over axios's message
+      alert(error.response?.data?.detail || error.message || 'Failed to submit prediction')
     },
   })
 
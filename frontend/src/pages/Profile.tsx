@@ -4,6 +4,7 @@ import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import axios from 'axios'
 
 interface UserProfile {
+  id: string
   username: string
   email: string
   joinDate: string
@@ -13,7 +14,7 @@ interface UserProfile {
     totalPredictions: number
     currentRank: number
   }
-  recentPredictions: {
+  recentPredictions?: {
     matchId: string
     homeTeam: string
     awayTeam: string
@@ -26,26 +27,48 @@ interface UserProfile {
 const Profile = () => {
   const [isEditing, setIsEditing] = useState(false)
   const [email, setEmail] = useState('')
+  const [username, setUsername] = useState('')
+  // TODO: Replace with actual user ID from authentication
+  const [userId, setUserId] = useState(localStorage.getItem('userId'))
   const queryClient = useQueryClient()
 
   const { data: profile, isLoading } = useQuery<UserProfile>({
-    queryKey: ['profile'],
+    queryKey: ['profile', userId],
     queryFn: async () => {
-      const response = await axios.get('/api/profile')
+      const response = await axios.get(`/api/users/${userId}`)
       setEmail(response.data.email)
       return response.data
     },
+    enabled: !!userId,
+  })
+
+  const registerUser = useMutation({
+    mutationFn: async () => {
+      const response = await axios.post('/api/users', { username, email })
+      return response.data
+    },
+    onSuccess: (data: UserProfile) => {
+      localStorage.setItem('userId', data.id)
+      setUserId(data.id)
+    },
+    onError: (error: any) => {
+      // API errors are problem details; prefer their detail over axios's message
+      alert(error.response?.data?.detail || error.message || 'Failed to register')
+    },
   })
 
   const updateProfile = useMutation({
     mutationFn: async (newEmail: string) => {
-      const response = await axios.put('/api/profile', { email: newEmail })
+      const response = await axios.put(`/api/users/${userId}`, { email: newEmail })
       return response.data
     },
     onSuccess: () => {
-      queryClient.invalidateQueries({ queryKey: ['profile'] })
+      queryClient.invalidateQueries({ queryKey: ['profile', userId] })
       setIsEditing(false)
     },
+    onError: (error: any) => {
+      alert(error.response?.data?.detail || error.message || 'Failed to update profile')
+    },
   })
 
   const handleSubmit = (e: React.FormEvent) => {
@@ -53,6 +76,42 @@ const Profile = () => {
     updateProfile.mutate(email)
   }
 
+  const handleRegister = (e: React.FormEvent) => {
+    e.preventDefault()
+    registerUser.mutate()
+  }
+
+  if (!userId) {
+    return (
+      <Container maxWidth="sm">
+        <Typography variant="h4" component="h1" gutterBottom>
+          Register
+        </Typography>
+        <Paper sx={{ p: 3 }}>
+          <form onSubmit={handleRegister}>
+            <TextField
+              fullWidth
+              label="Username"
+              value={username}
+              onChange={(e) => setUsername(e.target.value)}
+              margin="normal"
+            />
+            <TextField
+              fullWidth
+              label="Email"
+              value={email}
+              onChange={(e) => setEmail(e.target.value)}
+              margin="normal"
+            />
+            <Button type="submit" variant="contained" sx={{ mt: 2 }} disabled={registerUser.isPending}>
+              Register
+            </Button>
+          </form>
+        </Paper>
+      </Container>
+    )
+  }
+
   if (isLoading) {
     return <Typography>Loading profile...</Typography>
   }
@@ -117,7 +176,7 @@ const Profile = () => {
               Recent Predictions
             </Typography>
             <List>
-              {profile?.recentPredictions.map((prediction) => (
+              {profile?.recentPredictions?.map((prediction) => (
                 <ListItem key={prediction.matchId}>
                   <ListItemText
                     primary={`${prediction.homeTeam} vs ${prediction.awayTeam}`}